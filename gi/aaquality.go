@@ -0,0 +1,104 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/goki/ki/kit"
+	"github.com/srwiley/scanx"
+)
+
+// AAQualities are the anti-aliasing quality levels for path rasterization
+// -- see RenderState.AAQual and the AAQuality default
+type AAQualities int32
+
+const (
+	// AANone disables edge anti-aliasing: partial edge coverage is
+	// thresholded to fully covered or not covered at all -- crisp but
+	// jagged edges, and the fastest blending -- for apps rasterizing
+	// thousands of small shapes (e.g., icon grids) where smooth edges are
+	// not worth the cost
+	AANone AAQualities = iota
+
+	// AAFast is the standard single-sample anti-aliasing of the scanx
+	// rasterizer (the default)
+	AAFast
+
+	// AAHigh applies perceptual (gamma-corrected) weighting to the edge
+	// coverage, which renders thin diagonal lines and small text crisper
+	// and more evenly weighted -- slightly slower than AAFast -- for
+	// plotting and other fine-line rendering
+	AAHigh
+
+	AAQualitiesN
+)
+
+//go:generate stringer -type=AAQualities
+
+var KiT_AAQualities = kit.Enums.AddEnumAltLower(AAQualitiesN, kit.NotBitFlag, StylePropProps, "AA")
+
+func (ev AAQualities) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *AAQualities) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// AAQuality is the default anti-aliasing quality for new RenderStates --
+// set at app init -- individual render states can override with
+// SetAAQuality
+var AAQuality = AAFast
+
+// aaHighGamma is the coverage gamma for AAHigh -- coverage is raised to
+// 1/aaHighGamma, thickening partial coverage perceptually
+const aaHighGamma = 1.5
+
+// aaHighLut maps the high byte of linear edge coverage to gamma-corrected
+// 16-bit coverage -- built on first use of AAHigh
+var aaHighLut [256]uint32
+var aaHighLutOk bool
+
+func aaHighLutInit() {
+	if aaHighLutOk {
+		return
+	}
+	for i := 0; i < 256; i++ {
+		v := math32.Pow(float32(i)/255, 1/aaHighGamma)
+		aaHighLut[i] = uint32(v*0xffff + 0.5)
+	}
+	aaHighLutOk = true
+}
+
+// aaSpanner wraps the image spanner, adjusting edge coverage according to
+// the anti-aliasing quality before the spans are blended -- see AAQualities
+type aaSpanner struct {
+	sp   *scanx.ImgSpanner
+	qual *AAQualities // points at the owning RenderState's AAQual
+}
+
+func (x *aaSpanner) SetColor(c interface{}) {
+	x.sp.SetColor(c)
+}
+
+func (x *aaSpanner) GetSpanFunc() scanx.SpanFunc {
+	fn := x.sp.GetSpanFunc()
+	switch *x.qual {
+	case AANone:
+		return func(yi, xi0, xi1 int, ma uint32) {
+			if ma < 0x8000 { // threshold edge coverage to on / off
+				return
+			}
+			fn(yi, xi0, xi1, 0xffff)
+		}
+	case AAHigh:
+		aaHighLutInit()
+		return func(yi, xi0, xi1 int, ma uint32) {
+			fn(yi, xi0, xi1, aaHighLut[ma>>8])
+		}
+	}
+	return fn
+}
+
+// SetAAQuality sets the anti-aliasing quality for this render state --
+// takes effect for all subsequent fill / stroke operations
+func (rs *RenderState) SetAAQuality(q AAQualities) {
+	rs.AAQual = q
+}
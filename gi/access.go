@@ -0,0 +1,72 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image/color"
+
+	"github.com/goki/gi/oswin"
+)
+
+// Accessibility modes: high contrast and reduced motion can be requested
+// in Prefs, or detected from the OS accessibility settings where the
+// platform exposes them (see oswin.App PrefersHighContrast /
+// PrefersReducedMotion) -- either source turns the mode on.  High
+// contrast switches the active color preferences to maximum-contrast
+// variants (black on white, full-strength focus / selection colors), and
+// reduced motion disables the non-essential animations (collapse, pager
+// slide, icon state transitions).  Both are applied in Preferences.Apply
+// so they take effect at startup and when prefs are edited.
+
+// HighContrast is the active high-contrast state -- set from Prefs and OS
+// accessibility settings in Preferences.Apply -- the active color
+// preferences are switched to high-contrast variants when on
+var HighContrast = false
+
+// ReducedMotion is the active reduced-motion state -- set from Prefs and
+// OS accessibility settings in Preferences.Apply -- non-essential
+// animations are disabled when on
+var ReducedMotion = false
+
+// hcSavedColors holds the user's color preferences while the
+// high-contrast variants are in effect, so they are restored (and saved
+// to disk) intact when high contrast is turned off
+var hcSavedColors *ColorPrefs
+
+// ApplyAccess applies the accessibility preferences, combining the Prefs
+// toggles with the OS accessibility settings -- called from Apply
+func (pf *Preferences) ApplyAccess() {
+	hc := pf.HighContrast
+	rm := pf.ReducedMotion
+	if oswin.TheApp != nil {
+		hc = hc || oswin.TheApp.PrefersHighContrast()
+		rm = rm || oswin.TheApp.PrefersReducedMotion()
+	}
+	ReducedMotion = rm
+	if hc && !HighContrast {
+		sv := pf.Colors
+		hcSavedColors = &sv
+		pf.Colors.ApplyHighContrast()
+	} else if !hc && HighContrast && hcSavedColors != nil {
+		pf.Colors = *hcSavedColors
+		hcSavedColors = nil
+	}
+	HighContrast = hc
+}
+
+// ApplyHighContrast sets the colors to maximum-contrast variants: black
+// on white, with full-strength border (focus ring), selection and
+// highlight colors
+func (pf *ColorPrefs) ApplyHighContrast() {
+	pf.Font.SetColor(color.Black)
+	pf.Border.SetColor(color.Black)
+	pf.Background.SetColor(color.White)
+	pf.Shadow.SetString("darker-30", &pf.Background)
+	pf.Control.SetColor(color.White)
+	pf.Icon.SetColor(color.Black)
+	pf.Select.SetString("#6F6", nil)
+	pf.Highlight.SetString("#FF0", nil)
+	pf.Link.SetString("#00F", nil)
+}
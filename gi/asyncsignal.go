@@ -0,0 +1,133 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"sync"
+
+	"github.com/goki/gi/oswin"
+	"github.com/goki/ki/ki"
+)
+
+// Asynchronous signal delivery: ki.Signal emits are normally synchronous,
+// running all receiver functions inline before Emit returns.  For
+// interconnected value views that update each other on change, this can
+// produce re-entrant update cycles.  EmitSignalAsync instead queues the
+// emission and delivers it through the window's event loop, after the
+// current event (and thus the current update call chain) has completed.
+//
+// Ordering guarantees:
+//   - Queued emissions are delivered in FIFO enqueue order, on the event
+//     loop goroutine, after all currently-pending events are processed.
+//   - Re-emitting the same signal from the same sender while it is still
+//     queued is coalesced: the emission keeps its original queue position
+//     and only the most recent data is delivered.
+//   - An EmitSignalAsync called from within a delivery (re-entrant case) is
+//     queued for a subsequent delivery pass -- it never runs inline, so
+//     cycles settle one pass at a time instead of recursing.
+//   - Emissions queued on a window that closes before delivery are dropped.
+
+// asyncSigEmit is one queued signal emission
+type asyncSigEmit struct {
+	sig    *ki.Signal
+	sender ki.Ki
+	sigIdx int64
+	data   interface{}
+}
+
+// asyncSigKey identifies an emission for coalescing
+type asyncSigKey struct {
+	sig    *ki.Signal
+	sender ki.Ki
+	sigIdx int64
+}
+
+// asyncSigFlush is the custom event marker that triggers a delivery pass
+type asyncSigFlush struct{}
+
+// asyncSigQueue is the per-window queue of pending async emissions
+type asyncSigQueue struct {
+	mu      sync.Mutex
+	emits   []asyncSigEmit
+	idx     map[asyncSigKey]int
+	flushed bool // flush event already sent for current queue contents
+}
+
+// add queues given emission, coalescing with any still-queued emission of
+// the same signal from the same sender -- returns true if a flush event
+// needs to be sent
+func (aq *asyncSigQueue) add(sig *ki.Signal, sender ki.Ki, sigIdx int64, data interface{}) bool {
+	aq.mu.Lock()
+	defer aq.mu.Unlock()
+	key := asyncSigKey{sig: sig, sender: sender, sigIdx: sigIdx}
+	if aq.idx == nil {
+		aq.idx = make(map[asyncSigKey]int)
+	}
+	if i, has := aq.idx[key]; has {
+		aq.emits[i].data = data // coalesce: keep position, latest data
+		return false
+	}
+	aq.idx[key] = len(aq.emits)
+	aq.emits = append(aq.emits, asyncSigEmit{sig: sig, sender: sender, sigIdx: sigIdx, data: data})
+	if aq.flushed {
+		return false
+	}
+	aq.flushed = true
+	return true
+}
+
+// take removes and returns the currently-queued emissions -- emissions
+// added during subsequent delivery go into a fresh queue
+func (aq *asyncSigQueue) take() []asyncSigEmit {
+	aq.mu.Lock()
+	defer aq.mu.Unlock()
+	emits := aq.emits
+	aq.emits = nil
+	aq.idx = nil
+	aq.flushed = false
+	return emits
+}
+
+// EmitSignalAsync queues an emission of given signal for asynchronous,
+// coalesced delivery via this window's event loop, instead of the normal
+// synchronous inline delivery of ki.Signal.Emit -- see the ordering
+// guarantees in this file's documentation.  Safe to call from any
+// goroutine.
+func (w *Window) EmitSignalAsync(sig *ki.Signal, sender ki.Ki, sigIdx int64, data interface{}) {
+	if w.IsClosing() || w.IsClosed() {
+		return
+	}
+	if w.asyncSigs.add(sig, sender, sigIdx, data) {
+		w.SendCustomEvent(asyncSigFlush{})
+	}
+}
+
+// ProcessAsyncSigEvent delivers queued async signal emissions when the
+// flush marker event arrives -- returns true if the event was ours and has
+// been handled
+func (w *Window) ProcessAsyncSigEvent(evi oswin.Event) bool {
+	ce, ok := evi.(*oswin.CustomEvent)
+	if !ok {
+		return false
+	}
+	if _, ok := ce.Data.(asyncSigFlush); !ok {
+		return false
+	}
+	ce.SetProcessed()
+	w.processAsyncSigFlush()
+	return true
+}
+
+// processAsyncSigFlush runs one delivery pass over the queued emissions
+func (w *Window) processAsyncSigFlush() {
+	emits := w.asyncSigs.take()
+	for i := range emits {
+		ae := &emits[i]
+		if ae.sender != nil && ae.sender.IsDestroyed() {
+			continue
+		}
+		ae.sig.Emit(ae.sender, ae.sigIdx, ae.data)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
+)
+
+// AvatarSizes are standard size presets for the Avatar widget
+type AvatarSizes int32
+
+const (
+	AvatarSmall AvatarSizes = iota
+	AvatarMedium
+	AvatarLarge
+	AvatarXLarge
+	AvatarSizesN
+)
+
+//go:generate stringer -type=AvatarSizes
+
+var KiT_AvatarSizes = kit.Enums.AddEnumAltLower(AvatarSizesN, kit.NotBitFlag, StylePropProps, "Avatar")
+
+func (ev AvatarSizes) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *AvatarSizes) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// AvatarSizePx has the avatar diameter in pixels for each size preset
+var AvatarSizePx = map[AvatarSizes]float32{
+	AvatarSmall:  24,
+	AvatarMedium: 32,
+	AvatarLarge:  48,
+	AvatarXLarge: 64,
+}
+
+// AvatarColors is the palette of background colors used for the initials
+// fallback -- a given user name always hashes to the same color
+var AvatarColors = []string{
+	"#D32F2F", "#7B1FA2", "#303F9F", "#0288D1",
+	"#00796B", "#689F38", "#F57C00", "#5D4037",
+}
+
+// Avatar shows a circularly clipped image of a user, falling back to their
+// initials on a colored disc generated from UserName when no image is set.
+// An optional status dot (e.g., online / busy) is drawn in the lower right.
+// Commonly used in chat and user-list UIs -- see also ListItem.
+type Avatar struct {
+	WidgetBase
+	UserName string      `xml:"user-name" desc:"name of the user -- drives the initials and fallback color, and is the default tooltip"`
+	Image    image.Image `copy:"-" json:"-" xml:"-" view:"-" desc:"image of the user, shown circularly clipped -- nil falls back to initials"`
+	Size     AvatarSizes `desc:"size preset -- see AvatarSizePx for the pixel sizes, which can be overridden by width / height props"`
+	Status   Color       `desc:"color of the status dot drawn in the lower right -- nil (zero) color means no status dot"`
+	rendImg  *image.RGBA // cached scaled, circularly-masked copy of Image
+}
+
+var KiT_Avatar = kit.Types.AddType(&Avatar{}, AvatarProps)
+
+// AddNewAvatar adds a new avatar to given parent node, with given name and
+// user name -- defaults to AvatarMedium size
+func AddNewAvatar(parent ki.Ki, name, userName string) *Avatar {
+	av := parent.AddNewChild(KiT_Avatar, name).(*Avatar)
+	av.UserName = userName
+	av.Size = AvatarMedium
+	return av
+}
+
+func (nb *Avatar) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*Avatar)
+	nb.WidgetBase.CopyFieldsFrom(&fr.WidgetBase)
+	nb.UserName = fr.UserName
+	nb.Image = fr.Image
+	nb.Size = fr.Size
+	nb.Status = fr.Status
+}
+
+var AvatarProps = ki.Props{
+	"EnumType:Flag": KiT_NodeFlags,
+	"margin":        units.NewPx(2),
+}
+
+// SetUserName sets the user name and updates the display -- also clears any
+// cached rendering of the fallback initials
+func (av *Avatar) SetUserName(userName string) {
+	if av.UserName == userName {
+		return
+	}
+	av.UserName = userName
+	av.UpdateSig()
+}
+
+// SetImage sets the image shown in the avatar -- nil reverts to the
+// initials fallback
+func (av *Avatar) SetImage(img image.Image) {
+	av.Image = img
+	av.rendImg = nil
+	av.UpdateSig()
+}
+
+// SetStatus sets the status dot color and updates the display -- pass a nil
+// (zero) color to remove the dot
+func (av *Avatar) SetStatus(clr Color) {
+	av.Status = clr
+	av.UpdateSig()
+}
+
+// SetSize sets the size preset and updates the display
+func (av *Avatar) SetSize(sz AvatarSizes) {
+	if av.Size == sz {
+		return
+	}
+	av.Size = sz
+	av.rendImg = nil
+	av.SetFullReRender()
+	av.UpdateSig()
+}
+
+func (av *Avatar) Style2D() {
+	px := AvatarSizePx[av.Size]
+	av.SetMinPrefWidth(units.NewPx(px))
+	av.SetMinPrefHeight(units.NewPx(px))
+	if av.Tooltip == "" {
+		av.Tooltip = av.UserName
+	}
+	av.Style2DWidget()
+	av.LayData.SetFromStyle(&av.Sty.Layout) // also does reset
+}
+
+// Initials returns the initials shown in the fallback rendering: the first
+// letters of the first and last words of UserName, upper-cased
+func (av *Avatar) Initials() string {
+	flds := strings.Fields(av.UserName)
+	if len(flds) == 0 {
+		return ""
+	}
+	ini := string([]rune(flds[0])[0])
+	if len(flds) > 1 {
+		ini += string([]rune(flds[len(flds)-1])[0])
+	}
+	return strings.ToUpper(ini)
+}
+
+// FallbackColor returns the background color for the initials fallback,
+// picked from AvatarColors by a hash of UserName
+func (av *Avatar) FallbackColor() Color {
+	hash := 0
+	for _, b := range []byte(av.UserName) {
+		hash += int(b)
+	}
+	var clr Color
+	clr.SetString(AvatarColors[hash%len(AvatarColors)], nil)
+	return clr
+}
+
+// RendImage returns the scaled, circularly-masked version of Image at given
+// pixel diameter, computing and caching it if needed
+func (av *Avatar) RendImage(sz int) *image.RGBA {
+	if av.rendImg != nil && av.rendImg.Bounds().Dx() == sz {
+		return av.rendImg
+	}
+	isz := av.Image.Bounds().Size()
+	scx := float32(sz) / float32(isz.X)
+	scy := float32(sz) / float32(isz.Y)
+	scaled := image.NewRGBA(image.Rect(0, 0, sz, sz))
+	m := mat32.Scale2D(scx, scy)
+	s2d := f64.Aff3{float64(m.XX), float64(m.XY), float64(m.X0), float64(m.YX), float64(m.YY), float64(m.Y0)}
+	draw.BiLinear.Transform(scaled, s2d, av.Image, av.Image.Bounds(), draw.Src, nil)
+
+	// circular alpha mask with a 1 pixel anti-aliased edge
+	mask := image.NewAlpha(image.Rect(0, 0, sz, sz))
+	rad := .5 * float32(sz)
+	for y := 0; y < sz; y++ {
+		for x := 0; x < sz; x++ {
+			dx := float32(x) + .5 - rad
+			dy := float32(y) + .5 - rad
+			d := math32.Sqrt(dx*dx + dy*dy)
+			a := uint8(0)
+			switch {
+			case d <= rad-1:
+				a = 255
+			case d < rad:
+				a = uint8(255 * (rad - d))
+			}
+			mask.SetAlpha(x, y, color.Alpha{a})
+		}
+	}
+	av.rendImg = image.NewRGBA(image.Rect(0, 0, sz, sz))
+	draw.DrawMask(av.rendImg, av.rendImg.Bounds(), scaled, image.ZP, mask, image.ZP, draw.Src)
+	return av.rendImg
+}
+
+func (av *Avatar) RenderAvatar() {
+	rs := &av.Viewport.Render
+	rs.Lock()
+	pc := &rs.Paint
+	sty := &av.Sty
+	pos := av.LayData.AllocPos
+	sz := av.LayData.AllocSize
+	ctr := pos.Add(sz.MulScalar(.5))
+	rad := .5 * math32.Min(sz.X, sz.Y)
+
+	fallback := av.Image == nil
+	if !fallback {
+		img := av.RendImage(int(2*rad + .5))
+		pc.DrawImage(rs, img, int(ctr.X-rad), int(ctr.Y-rad))
+	} else {
+		pc.FillStyle.SetColor(av.FallbackColor())
+		pc.DrawCircle(rs, ctr.X, ctr.Y, rad)
+		pc.Fill(rs)
+	}
+
+	if !av.Status.IsNil() {
+		dctr := ctr.Add(mat32.Vec2{rad, rad}.MulScalar(.7071))
+		drad := math32.Max(rad*.18, 2)
+		pc.FillStyle.SetColor(av.Status)
+		pc.StrokeStyle.SetColor(&Prefs.Colors.Background)
+		pc.StrokeStyle.Width.Dots = math32.Max(drad*.3, 1)
+		pc.DrawCircle(rs, dctr.X, dctr.Y, drad)
+		pc.FillStrokeClear(rs)
+	}
+	rs.Unlock()
+
+	if fallback {
+		ini := av.Initials()
+		if ini != "" {
+			bg := av.FallbackColor()
+			fst := sty.Font
+			fst.BgColor.SetColor(nil)
+			_, _, l, _ := bg.ToHSLA()
+			if l > .6 {
+				fst.Color.SetUInt8(0, 0, 0, 255)
+			} else {
+				fst.Color.SetUInt8(255, 255, 255, 255)
+			}
+			var tr TextRender
+			tr.SetString(ini, &fst, &sty.UnContext, &sty.Text, true, 0, 0)
+			tr.Render(rs, mat32.Vec2{ctr.X - .5*tr.Size.X, ctr.Y - .5*tr.Size.Y})
+		}
+	}
+}
+
+func (av *Avatar) Render2D() {
+	if av.FullReRenderIfNeeded() {
+		return
+	}
+	if av.PushBounds() {
+		av.This().(Node2D).ConnectEvents2D()
+		av.RenderAvatar()
+		av.Render2DChildren()
+		av.PopBounds()
+	} else {
+		av.DisconnectAllEvents(RegPri)
+	}
+}
+
+func (av *Avatar) ConnectEvents2D() {
+	av.HoverTooltipEvent()
+}
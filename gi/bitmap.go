@@ -5,10 +5,12 @@
 package gi
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"image/jpeg"
 	"image/png"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -31,9 +33,10 @@ import (
 // can grab the image of another vp and show that
 type Bitmap struct {
 	WidgetBase
-	Filename FileName    `desc:"file name of image loaded -- set by OpenImage"`
-	Size     image.Point `desc:"size of the image"`
-	Pixels   *image.RGBA `copy:"-", view:"-", xml:"-" json:"-" desc:"the bitmap image"`
+	Filename FileName      `desc:"file name of image loaded -- set by OpenImage"`
+	Size     image.Point   `desc:"size of the image"`
+	Pixels   *image.RGBA   `copy:"-", view:"-", xml:"-" json:"-" desc:"the bitmap image"`
+	Provider ImageProvider `copy:"-" view:"-" xml:"-" json:"-" desc:"external image source we are bound to, if set -- see SetImageProvider"`
 }
 
 var KiT_Bitmap = kit.Types.AddType(&Bitmap{}, BitmapProps)
@@ -114,6 +117,55 @@ func (bm *Bitmap) SetImage(img image.Image, width, height float32) {
 	}
 }
 
+// SetImageProvider binds the bitmap to given external image source: shows
+// its current image and refreshes whenever it signals a change.  A non-empty
+// image.Rectangle in the signal data restricts the update to that region of
+// the image.  Pass nil to unbind.
+func (bm *Bitmap) SetImageProvider(ip ImageProvider) {
+	if bm.Provider != nil {
+		bm.Provider.ImageSig().Disconnect(bm.This())
+	}
+	bm.Provider = ip
+	if ip == nil {
+		return
+	}
+	ip.ImageSig().Connect(bm.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		bmm := recv.Embed(KiT_Bitmap).(*Bitmap)
+		bmm.ProviderUpdate(data)
+	})
+	if img := ip.Image(); img != nil {
+		bm.SetImage(img, 0, 0)
+	}
+}
+
+// ProviderUpdate refreshes the bitmap from its bound ImageProvider -- if
+// data is a non-empty image.Rectangle, only that region is copied, for
+// efficient updates of e.g., streaming camera frames where only part of the
+// frame changed
+func (bm *Bitmap) ProviderUpdate(data interface{}) {
+	if bm.Provider == nil {
+		return
+	}
+	img := bm.Provider.Image()
+	if img == nil {
+		return
+	}
+	sz := img.Bounds().Size()
+	if bm.Pixels == nil || bm.Size != sz {
+		bm.SetImage(img, 0, 0) // does UpdateStart / End
+		return
+	}
+	updt := bm.UpdateStart()
+	if r, ok := data.(image.Rectangle); ok && !r.Empty() {
+		r = r.Intersect(img.Bounds())
+		dr := r.Sub(img.Bounds().Min) // Pixels is zero-based
+		draw.Draw(bm.Pixels, dr, img, r.Min, draw.Src)
+	} else {
+		draw.Draw(bm.Pixels, bm.Pixels.Bounds(), img, img.Bounds().Min, draw.Src)
+	}
+	bm.UpdateEnd(updt)
+}
+
 // GrabRenderFrom grabs the rendered image from given node
 func (bm *Bitmap) GrabRenderFrom(nii Node2D) {
 	img := GrabRenderFrom(nii)
@@ -142,7 +194,7 @@ func (bm *Bitmap) DrawIntoViewport(parVp *Viewport2D) {
 		}
 		r = nr
 	}
-	draw.Draw(parVp.Pixels, r, bm.Pixels, sp, draw.Over)
+	CompositeDraw(parVp.Pixels, r, bm.Pixels, sp, draw.Over)
 }
 
 func (bm *Bitmap) Render2D() {
@@ -152,6 +204,67 @@ func (bm *Bitmap) Render2D() {
 	}
 }
 
+//////////////////////////////////////////////////////////////////////////////////
+//  ImageProvider
+
+// ImageProvider is the interface for external sources of dynamically
+// generated images (plots from other libraries, camera frames, etc) that a
+// Bitmap can bind to via SetImageProvider, so the display refreshes
+// automatically when the source changes
+type ImageProvider interface {
+	// Image returns the current image -- must remain valid to read until
+	// the next change signal
+	Image() image.Image
+
+	// ImageSig returns the signal emitted when the image contents change --
+	// signal data can be an image.Rectangle bounding the changed region
+	// (in the image's own coordinates), or nil / image.ZR if the whole
+	// image changed
+	ImageSig() *ki.Signal
+}
+
+// ImageSource is a basic ImageProvider for use by non-Ki image generators:
+// set the image with SetImage, or write directly into Img and call
+// UpdateRegion / UpdateAll.  Must be created with NewImageSource (signals
+// from an uninitialized source are dropped).
+type ImageSource struct {
+	ki.Node
+	Img image.Image `desc:"the current image"`
+	Sig ki.Signal   `desc:"signal emitted when the image changes"`
+}
+
+// NewImageSource returns a new initialized ImageSource with given name
+func NewImageSource(name string) *ImageSource {
+	is := &ImageSource{}
+	is.InitName(is, name)
+	return is
+}
+
+func (is *ImageSource) Image() image.Image {
+	return is.Img
+}
+
+func (is *ImageSource) ImageSig() *ki.Signal {
+	return &is.Sig
+}
+
+// SetImage sets a new image and signals a full change
+func (is *ImageSource) SetImage(img image.Image) {
+	is.Img = img
+	is.UpdateAll()
+}
+
+// UpdateAll signals that the entire image has changed
+func (is *ImageSource) UpdateAll() {
+	is.Sig.Emit(is.This(), 0, image.ZR)
+}
+
+// UpdateRegion signals that given region of the image has changed --
+// bound displays only re-copy that region
+func (is *ImageSource) UpdateRegion(r image.Rectangle) {
+	is.Sig.Emit(is.This(), 0, r)
+}
+
 //////////////////////////////////////////////////////////////////////////////////
 //  Image IO
 
@@ -180,6 +293,39 @@ func GrabRenderFrom(nii Node2D) *image.RGBA {
 	return img
 }
 
+// RenderToImage renders this node's subtree into a fresh image.RGBA of its
+// VpBBox size, independent of the window contents -- unlike GrabRenderFrom,
+// which copies whatever was last rendered, this forces a fresh render of
+// just this subtree, so it works even if the node has since been drawn over
+// (e.g., by a popup) -- for docs, tests, and drag images.  Returns nil if
+// the node is not currently on screen (empty VpBBox).
+func (nb *Node2DBase) RenderToImage() *image.RGBA {
+	nii, ok := nb.This().(Node2D)
+	if !ok {
+		return nil
+	}
+	vp := nb.Viewport
+	if vp == nil || vp.Pixels == nil || nb.VpBBox.Empty() {
+		return nil
+	}
+	vp.UpdtMu.Lock()
+	savePix := vp.Pixels
+	// offset-bounds image: the node renders at its usual VpBBox coords,
+	// which land directly in this buffer (PixOffset honors Rect.Min)
+	img := image.NewRGBA(nb.VpBBox)
+	vp.Pixels = img
+	vp.Backend().InitState(&vp.Render, nb.VpBBox.Max.X, nb.VpBBox.Max.Y, vp.Pixels)
+	nii.Render2D()
+	vp.Backend().Flush(&vp.Render)
+	vp.Pixels = savePix
+	vp.Backend().InitState(&vp.Render, savePix.Bounds().Max.X, savePix.Bounds().Max.Y, savePix)
+	vp.UpdtMu.Unlock()
+	// translate to a standard zero-origin image
+	out := image.NewRGBA(image.Rectangle{Max: nb.VpBBox.Size()})
+	draw.Draw(out, out.Bounds(), img, nb.VpBBox.Min, draw.Src)
+	return out
+}
+
 // OpenImage opens an image from given path filename -- format is inferred automatically.
 func OpenImage(path string) (image.Image, error) {
 	file, err := os.Open(path)
@@ -229,6 +375,54 @@ func SavePNG(path string, im image.Image) error {
 	return png.Encode(file, im)
 }
 
+// OpenJPEG opens an image encoded in the JPEG format
+func OpenJPEG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return jpeg.Decode(file)
+}
+
+// SaveJPEG saves an image encoded in the JPEG format at given quality
+// (1..100, e.g., 90)
+func SaveJPEG(path string, im image.Image, quality int) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return jpeg.Encode(file, im, &jpeg.Options{Quality: quality})
+}
+
+// EncodeWebPFunc is the pluggable WebP encoder: the Go standard library
+// and x/image provide WebP decoding only, so apps wanting WebP export must
+// install an encoder here (e.g., wrapping github.com/chai2010/webp) --
+// quality is 1..100 with 100 meaning lossless
+var EncodeWebPFunc func(w io.Writer, im image.Image, quality int) error
+
+// EncodeWebP encodes an image in the WebP format at given quality using
+// the registered EncodeWebPFunc -- returns an error if no encoder is
+// registered
+func EncodeWebP(w io.Writer, im image.Image, quality int) error {
+	if EncodeWebPFunc == nil {
+		return errors.New("gi.EncodeWebP: no WebP encoder registered -- set gi.EncodeWebPFunc")
+	}
+	return EncodeWebPFunc(w, im, quality)
+}
+
+// SaveWebP saves an image encoded in the WebP format at given quality --
+// requires a registered encoder, see EncodeWebPFunc
+func SaveWebP(path string, im image.Image, quality int) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return EncodeWebP(file, im, quality)
+}
+
 //////////////////////////////////////////////////////////////////////////////////
 //  Image Manipulations
 
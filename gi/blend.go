@@ -0,0 +1,82 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"image/draw"
+)
+
+// BlendRGBAOver composites src over dst in given rectangle, with sp as the
+// start point in src -- equivalent to draw.Draw with draw.Over but
+// operating directly on the Pix slices, skipping runs of fully-transparent
+// source pixels and copying runs of fully-opaque ones, which is much
+// faster for the typical popup / sub-viewport case where most of the image
+// is one or the other.  Architectures can install a vectorized
+// implementation by setting blendRGBAOverImpl.
+func BlendRGBAOver(dst *image.RGBA, r image.Rectangle, src *image.RGBA, sp image.Point) {
+	// clip exactly as draw.Draw does: restrict r to dst bounds and to the
+	// src bounds translated into dst space, then shift sp by however much
+	// r.Min moved, so src rows stay aligned with dst rows
+	orig := r.Min
+	r = r.Intersect(dst.Bounds())
+	r = r.Intersect(src.Bounds().Add(orig.Sub(sp)))
+	if r.Empty() {
+		return
+	}
+	sp = sp.Add(r.Min.Sub(orig))
+	blendRGBAOverImpl(dst, r, src, sp)
+}
+
+// blendRGBAOverImpl is the active row-blending implementation --
+// replaceable with an arch-specific vectorized version
+var blendRGBAOverImpl = blendRGBAOverGo
+
+// blendRGBAOverGo is the portable implementation of BlendRGBAOver
+func blendRGBAOverGo(dst *image.RGBA, r image.Rectangle, src *image.RGBA, sp image.Point) {
+	w := r.Dx()
+	for y := 0; y < r.Dy(); y++ {
+		di := dst.PixOffset(r.Min.X, r.Min.Y+y)
+		si := src.PixOffset(sp.X, sp.Y+y)
+		dpix := dst.Pix[di : di+w*4 : di+w*4]
+		spix := src.Pix[si : si+w*4 : si+w*4]
+		for x := 0; x < w*4; {
+			sa := spix[x+3]
+			switch sa {
+			case 0: // skip transparent run
+				x += 4
+				for x < w*4 && spix[x+3] == 0 {
+					x += 4
+				}
+			case 0xff: // copy opaque run
+				st := x
+				x += 4
+				for x < w*4 && spix[x+3] == 0xff {
+					x += 4
+				}
+				copy(dpix[st:x], spix[st:x])
+			default: // standard premultiplied over blend
+				a := 0xff - uint32(sa)
+				dpix[x] = uint8((uint32(spix[x])*0xff + uint32(dpix[x])*a) / 0xff)
+				dpix[x+1] = uint8((uint32(spix[x+1])*0xff + uint32(dpix[x+1])*a) / 0xff)
+				dpix[x+2] = uint8((uint32(spix[x+2])*0xff + uint32(dpix[x+2])*a) / 0xff)
+				dpix[x+3] = uint8((uint32(sa)*0xff + uint32(dpix[x+3])*a) / 0xff)
+				x += 4
+			}
+		}
+	}
+}
+
+// blendDraw is the standard compositing entry used by CompositeDraw --
+// uses the fast RGBA path when both images are *image.RGBA
+func blendDraw(dst *image.RGBA, r image.Rectangle, src image.Image, sp image.Point, op draw.Op) {
+	if op == draw.Over {
+		if simg, ok := src.(*image.RGBA); ok {
+			BlendRGBAOver(dst, r, simg, sp)
+			return
+		}
+	}
+	draw.Draw(dst, r, src, sp, op)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/goki/ki/kit"
+)
+
+// BlendModes are the ways a sub-viewport image can be composited into its
+// parent -- see Viewport2D.BlendMode and Viewport2D.Opacity
+type BlendModes int32
+
+const (
+	// BlendOver is standard alpha compositing (the default)
+	BlendOver BlendModes = iota
+
+	// BlendMultiply multiplies source and destination colors, darkening
+	BlendMultiply
+
+	// BlendScreen inverts, multiplies, and inverts again, lightening
+	BlendScreen
+
+	BlendModesN
+)
+
+//go:generate stringer -type=BlendModes
+
+var KiT_BlendModes = kit.Enums.AddEnumAltLower(BlendModesN, kit.NotBitFlag, StylePropProps, "Blend")
+
+func (ev BlendModes) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *BlendModes) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// BlendImage composites src into dst in given rectangle with sp as the
+// start point in src, using given blend mode and opacity (0 or 1 = fully
+// opaque) -- used by DrawIntoParent for translucent / blended
+// sub-viewports.  Blending follows the W3C compositing model: the blend
+// function mixes source color with backdrop color by backdrop alpha, then
+// standard over compositing applies.
+func BlendImage(dst *image.RGBA, r image.Rectangle, src *image.RGBA, sp image.Point, mode BlendModes, opacity float32) {
+	if opacity <= 0 || opacity > 1 {
+		opacity = 1
+	}
+	if mode == BlendOver && opacity == 1 {
+		CompositeDraw(dst, r, src, sp, draw.Over)
+		return
+	}
+	// clip as draw.Draw does: restrict r to dst bounds and the translated
+	// src bounds, shifting sp by however much r.Min moved
+	orig := r.Min
+	r = r.Intersect(dst.Bounds())
+	r = r.Intersect(src.Bounds().Add(orig.Sub(sp)))
+	if r.Empty() {
+		return
+	}
+	sp = sp.Add(r.Min.Sub(orig))
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		si := src.PixOffset(sp.X, sp.Y+y-r.Min.Y)
+		di := dst.PixOffset(r.Min.X, y)
+		for x := r.Min.X; x < r.Max.X; x++ {
+			sa := float32(src.Pix[si+3]) / 255 * opacity
+			if sa > 0 {
+				da := float32(dst.Pix[di+3]) / 255
+				oa := sa + da*(1-sa)
+				for c := 0; c < 3; c++ {
+					cs := unPremul(src.Pix[si+c], src.Pix[si+3])
+					cb := unPremul(dst.Pix[di+c], dst.Pix[di+3])
+					bl := blendFunc(mode, cs, cb)
+					// mix blend result in by backdrop alpha, then over
+					cr := (1-da)*cs + da*bl
+					ov := cr*sa + cb*da*(1-sa) // premultiplied by oa
+					dst.Pix[di+c] = uint8(ov*255 + 0.5)
+				}
+				dst.Pix[di+3] = uint8(oa*255 + 0.5)
+			}
+			si += 4
+			di += 4
+		}
+	}
+}
+
+// unPremul returns the unpremultiplied [0-1] value of given channel
+func unPremul(c, a uint8) float32 {
+	if a == 0 {
+		return 0
+	}
+	return float32(c) / float32(a)
+}
+
+// blendFunc applies the separable blend function for given mode to
+// unpremultiplied source and backdrop values
+func blendFunc(mode BlendModes, cs, cb float32) float32 {
+	switch mode {
+	case BlendMultiply:
+		return cs * cb
+	case BlendScreen:
+		return cs + cb - cs*cb
+	}
+	return cs
+}
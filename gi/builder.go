@@ -0,0 +1,403 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"github.com/goki/ki/ki"
+)
+
+// Fluent builders: a declarative construction layer over the AddNew*
+// constructors, for compactly building widget trees without the
+// AddNewChild / SetProp / Connect boilerplate:
+//
+//	gi.NewButtonB(row).Text("OK").Icon("check").OnClick(func() {
+//		...
+//	})
+//
+// Each NewXB function adds a new widget of that type to parent (name is
+// optional -- auto-uniquified if empty) and returns a small builder whose
+// methods set the common configuration options, returning the builder for
+// chaining.  The built widget is in the W field for anything beyond what
+// the builder covers.  Builders are just a construction convenience --
+// the result is an ordinary widget in the Ki tree, styled and updated as
+// always.
+
+func builderName(name []string) string {
+	if len(name) > 0 {
+		return name[0]
+	}
+	return ""
+}
+
+////////////////////////////////////////////////////////////////////////
+//  Label
+
+// LabelB is a fluent builder for Label -- see NewLabelB
+type LabelB struct {
+	W *Label
+}
+
+// NewLabelB adds a new Label to parent and returns its builder
+func NewLabelB(parent ki.Ki, name ...string) LabelB {
+	return LabelB{W: AddNewLabel(parent, builderName(name), "")}
+}
+
+// Text sets the label text
+func (b LabelB) Text(text string) LabelB {
+	b.W.SetText(text)
+	return b
+}
+
+// Tooltip sets the tooltip
+func (b LabelB) Tooltip(tip string) LabelB {
+	b.W.Tooltip = tip
+	return b
+}
+
+// Prop sets given styling property
+func (b LabelB) Prop(key string, val interface{}) LabelB {
+	b.W.SetProp(key, val)
+	return b
+}
+
+////////////////////////////////////////////////////////////////////////
+//  Button
+
+// ButtonB is a fluent builder for Button -- see NewButtonB
+type ButtonB struct {
+	W *Button
+}
+
+// NewButtonB adds a new Button to parent and returns its builder
+func NewButtonB(parent ki.Ki, name ...string) ButtonB {
+	return ButtonB{W: AddNewButton(parent, builderName(name))}
+}
+
+// Text sets the button text
+func (b ButtonB) Text(text string) ButtonB {
+	b.W.SetText(text)
+	return b
+}
+
+// Icon sets the button icon by name
+func (b ButtonB) Icon(icon string) ButtonB {
+	b.W.SetIcon(icon)
+	return b
+}
+
+// Tooltip sets the tooltip
+func (b ButtonB) Tooltip(tip string) ButtonB {
+	b.W.Tooltip = tip
+	return b
+}
+
+// Prop sets given styling property
+func (b ButtonB) Prop(key string, val interface{}) ButtonB {
+	b.W.SetProp(key, val)
+	return b
+}
+
+// OnClick connects fun to the ButtonClicked signal
+func (b ButtonB) OnClick(fun func()) ButtonB {
+	b.W.ButtonSig.Connect(b.W.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(ButtonClicked) {
+			fun()
+		}
+	})
+	return b
+}
+
+////////////////////////////////////////////////////////////////////////
+//  CheckBox
+
+// CheckBoxB is a fluent builder for CheckBox -- see NewCheckBoxB
+type CheckBoxB struct {
+	W *CheckBox
+}
+
+// NewCheckBoxB adds a new CheckBox to parent and returns its builder
+func NewCheckBoxB(parent ki.Ki, name ...string) CheckBoxB {
+	return CheckBoxB{W: AddNewCheckBox(parent, builderName(name))}
+}
+
+// Text sets the checkbox label text
+func (b CheckBoxB) Text(text string) CheckBoxB {
+	b.W.SetText(text)
+	return b
+}
+
+// Checked sets the initial checked state
+func (b CheckBoxB) Checked(chk bool) CheckBoxB {
+	b.W.SetChecked(chk)
+	return b
+}
+
+// Tooltip sets the tooltip
+func (b CheckBoxB) Tooltip(tip string) CheckBoxB {
+	b.W.Tooltip = tip
+	return b
+}
+
+// Prop sets given styling property
+func (b CheckBoxB) Prop(key string, val interface{}) CheckBoxB {
+	b.W.SetProp(key, val)
+	return b
+}
+
+// OnToggle connects fun to the ButtonToggled signal, called with the new
+// checked state
+func (b CheckBoxB) OnToggle(fun func(on bool)) CheckBoxB {
+	cb := b.W
+	cb.ButtonSig.Connect(cb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(ButtonToggled) {
+			fun(cb.IsChecked())
+		}
+	})
+	return b
+}
+
+////////////////////////////////////////////////////////////////////////
+//  TextField
+
+// TextFieldB is a fluent builder for TextField -- see NewTextFieldB
+type TextFieldB struct {
+	W *TextField
+}
+
+// NewTextFieldB adds a new TextField to parent and returns its builder
+func NewTextFieldB(parent ki.Ki, name ...string) TextFieldB {
+	return TextFieldB{W: AddNewTextField(parent, builderName(name))}
+}
+
+// Text sets the initial text
+func (b TextFieldB) Text(text string) TextFieldB {
+	b.W.SetText(text)
+	return b
+}
+
+// Placeholder sets the placeholder text shown when the field is empty
+func (b TextFieldB) Placeholder(text string) TextFieldB {
+	b.W.Placeholder = text
+	return b
+}
+
+// Tooltip sets the tooltip
+func (b TextFieldB) Tooltip(tip string) TextFieldB {
+	b.W.Tooltip = tip
+	return b
+}
+
+// Prop sets given styling property
+func (b TextFieldB) Prop(key string, val interface{}) TextFieldB {
+	b.W.SetProp(key, val)
+	return b
+}
+
+// OnDone connects fun to the TextFieldDone signal, called with the edited
+// text when editing is completed
+func (b TextFieldB) OnDone(fun func(text string)) TextFieldB {
+	b.W.TextFieldSig.Connect(b.W.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(TextFieldDone) {
+			fun(data.(string))
+		}
+	})
+	return b
+}
+
+////////////////////////////////////////////////////////////////////////
+//  Slider
+
+// SliderB is a fluent builder for Slider -- see NewSliderB
+type SliderB struct {
+	W *Slider
+}
+
+// NewSliderB adds a new Slider to parent and returns its builder
+func NewSliderB(parent ki.Ki, name ...string) SliderB {
+	return SliderB{W: AddNewSlider(parent, builderName(name))}
+}
+
+// MinMax sets the value range
+func (b SliderB) MinMax(min, max float32) SliderB {
+	b.W.Min = min
+	b.W.Max = max
+	return b
+}
+
+// Value sets the initial value
+func (b SliderB) Value(val float32) SliderB {
+	b.W.SetValue(val)
+	return b
+}
+
+// Tooltip sets the tooltip
+func (b SliderB) Tooltip(tip string) SliderB {
+	b.W.Tooltip = tip
+	return b
+}
+
+// Prop sets given styling property
+func (b SliderB) Prop(key string, val interface{}) SliderB {
+	b.W.SetProp(key, val)
+	return b
+}
+
+// OnChange connects fun to the SliderValueChanged signal, called with the
+// new value
+func (b SliderB) OnChange(fun func(val float32)) SliderB {
+	b.W.SliderSig.Connect(b.W.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(SliderValueChanged) {
+			fun(data.(float32))
+		}
+	})
+	return b
+}
+
+////////////////////////////////////////////////////////////////////////
+//  SpinBox
+
+// SpinBoxB is a fluent builder for SpinBox -- see NewSpinBoxB
+type SpinBoxB struct {
+	W *SpinBox
+}
+
+// NewSpinBoxB adds a new SpinBox to parent and returns its builder
+func NewSpinBoxB(parent ki.Ki, name ...string) SpinBoxB {
+	return SpinBoxB{W: AddNewSpinBox(parent, builderName(name))}
+}
+
+// MinMax sets the value range
+func (b SpinBoxB) MinMax(min, max float32) SpinBoxB {
+	b.W.SetMinMax(true, min, true, max)
+	return b
+}
+
+// Step sets the increment step size
+func (b SpinBoxB) Step(step float32) SpinBoxB {
+	b.W.Step = step
+	return b
+}
+
+// Value sets the initial value
+func (b SpinBoxB) Value(val float32) SpinBoxB {
+	b.W.SetValue(val)
+	return b
+}
+
+// Tooltip sets the tooltip
+func (b SpinBoxB) Tooltip(tip string) SpinBoxB {
+	b.W.Tooltip = tip
+	return b
+}
+
+// Prop sets given styling property
+func (b SpinBoxB) Prop(key string, val interface{}) SpinBoxB {
+	b.W.SetProp(key, val)
+	return b
+}
+
+// OnChange connects fun to the SpinBoxSig signal, called with the new
+// value
+func (b SpinBoxB) OnChange(fun func(val float32)) SpinBoxB {
+	b.W.SpinBoxSig.Connect(b.W.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		fun(data.(float32))
+	})
+	return b
+}
+
+////////////////////////////////////////////////////////////////////////
+//  ComboBox
+
+// ComboBoxB is a fluent builder for ComboBox -- see NewComboBoxB
+type ComboBoxB struct {
+	W *ComboBox
+}
+
+// NewComboBoxB adds a new ComboBox to parent and returns its builder
+func NewComboBoxB(parent ki.Ki, name ...string) ComboBoxB {
+	return ComboBoxB{W: AddNewComboBox(parent, builderName(name))}
+}
+
+// Items sets the items from a list of string values, selecting the first
+func (b ComboBoxB) Items(items []string) ComboBoxB {
+	b.W.ItemsFromStringList(items, true, 0)
+	return b
+}
+
+// CurIndex sets the initially-selected item by index
+func (b ComboBoxB) CurIndex(idx int) ComboBoxB {
+	b.W.SetCurIndex(idx)
+	return b
+}
+
+// Tooltip sets the tooltip
+func (b ComboBoxB) Tooltip(tip string) ComboBoxB {
+	b.W.Tooltip = tip
+	return b
+}
+
+// Prop sets given styling property
+func (b ComboBoxB) Prop(key string, val interface{}) ComboBoxB {
+	b.W.SetProp(key, val)
+	return b
+}
+
+// OnSelected connects fun to the ComboSig signal, called with the index
+// and value of the newly-selected item
+func (b ComboBoxB) OnSelected(fun func(idx int, val interface{})) ComboBoxB {
+	b.W.ComboSig.Connect(b.W.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		fun(int(sig), data)
+	})
+	return b
+}
+
+////////////////////////////////////////////////////////////////////////
+//  Layout, Frame
+
+// LayoutB is a fluent builder for Layout -- see NewLayoutB
+type LayoutB struct {
+	W *Layout
+}
+
+// NewLayoutB adds a new Layout with given layout style to parent and
+// returns its builder
+func NewLayoutB(parent ki.Ki, lay Layouts, name ...string) LayoutB {
+	return LayoutB{W: AddNewLayout(parent, builderName(name), lay)}
+}
+
+// Prop sets given styling property
+func (b LayoutB) Prop(key string, val interface{}) LayoutB {
+	b.W.SetProp(key, val)
+	return b
+}
+
+// Spacing sets the spacing between elements, in given units
+func (b LayoutB) Spacing(val interface{}) LayoutB {
+	b.W.SetProp("spacing", val)
+	return b
+}
+
+// FrameB is a fluent builder for Frame -- see NewFrameB
+type FrameB struct {
+	W *Frame
+}
+
+// NewFrameB adds a new Frame with given layout style to parent and
+// returns its builder
+func NewFrameB(parent ki.Ki, lay Layouts, name ...string) FrameB {
+	return FrameB{W: AddNewFrame(parent, builderName(name), lay)}
+}
+
+// Prop sets given styling property
+func (b FrameB) Prop(key string, val interface{}) FrameB {
+	b.W.SetProp(key, val)
+	return b
+}
+
+// Spacing sets the spacing between elements, in given units
+func (b FrameB) Spacing(val interface{}) FrameB {
+	b.W.SetProp("spacing", val)
+	return b
+}
@@ -13,20 +13,21 @@ func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the stringer command to generate them again.
 	var x [1]struct{}
-	_ = x[ButtonFlagCheckable-29]
-	_ = x[ButtonFlagChecked-30]
-	_ = x[ButtonFlagMenu-31]
-	_ = x[ButtonFlagsN-32]
+	_ = x[ButtonFlagCheckable-30]
+	_ = x[ButtonFlagChecked-31]
+	_ = x[ButtonFlagMenu-32]
+	_ = x[ButtonFlagIndeterminate-33]
+	_ = x[ButtonFlagsN-34]
 }
 
-const _ButtonFlags_name = "ButtonFlagCheckableButtonFlagCheckedButtonFlagMenuButtonFlagsN"
+const _ButtonFlags_name = "ButtonFlagCheckableButtonFlagCheckedButtonFlagMenuButtonFlagIndeterminateButtonFlagsN"
 
-var _ButtonFlags_index = [...]uint8{0, 19, 36, 50, 62}
+var _ButtonFlags_index = [...]uint8{0, 19, 36, 50, 73, 85}
 
 func (i ButtonFlags) String() string {
-	i -= 29
+	i -= 30
 	if i < 0 || i >= ButtonFlags(len(_ButtonFlags_index)-1) {
-		return "ButtonFlags(" + strconv.FormatInt(int64(i+29), 10) + ")"
+		return "ButtonFlags(" + strconv.FormatInt(int64(i+30), 10) + ")"
 	}
 	return _ButtonFlags_name[_ButtonFlags_index[i]:_ButtonFlags_index[i+1]]
 }
@@ -34,7 +35,7 @@ func (i ButtonFlags) String() string {
 func StringToButtonFlags(s string) (ButtonFlags, error) {
 	for i := 0; i < len(_ButtonFlags_index)-1; i++ {
 		if s == _ButtonFlags_name[_ButtonFlags_index[i]:_ButtonFlags_index[i+1]] {
-			return ButtonFlags(i + 29), nil
+			return ButtonFlags(i + 30), nil
 		}
 	}
 	return 0, errors.New("String: " + s + " is not a valid option for type: ButtonFlags")
@@ -80,6 +80,10 @@ const (
 	// Menu flag means that the button is a menu item
 	ButtonFlagMenu
 
+	// button is in an indeterminate (mixed / partial) checked state -- e.g.,
+	// a group-header checkbox when only some of the group is checked
+	ButtonFlagIndeterminate
+
 	ButtonFlagsN
 )
 
@@ -172,12 +176,42 @@ func (bb *ButtonBase) SetChecked(chk bool) {
 	bb.SetFlagState(chk, int(ButtonFlagChecked))
 }
 
-// ToggleChecked toggles the checked state of this button -- does not emit
-// signal or update
+// IsIndeterminate checks if button is in the indeterminate (mixed) state
+func (bb *ButtonBase) IsIndeterminate() bool {
+	return bb.HasFlag(int(ButtonFlagIndeterminate))
+}
+
+// SetIndeterminate sets the indeterminate (mixed) state of this button --
+// e.g., for a group-header checkbox when only some of the group is checked
+// -- cleared by any user toggle -- does not emit signal or update
+func (bb *ButtonBase) SetIndeterminate(ind bool) {
+	bb.SetFlagState(ind, int(ButtonFlagIndeterminate))
+}
+
+// ToggleChecked toggles the checked state of this button -- an indeterminate
+// state resolves to checked -- does not emit signal or update
 func (bb *ButtonBase) ToggleChecked() {
+	if bb.IsIndeterminate() {
+		bb.SetIndeterminate(false)
+		bb.SetChecked(true)
+		return
+	}
 	bb.SetChecked(!bb.IsChecked())
 }
 
+// CheckedState returns the checked state encoded as an int: 1 = checked,
+// 0 = unchecked, -1 = indeterminate -- sent as the data in ButtonToggled
+// signals
+func (bb *ButtonBase) CheckedState() int {
+	switch {
+	case bb.IsIndeterminate():
+		return -1
+	case bb.IsChecked():
+		return 1
+	}
+	return 0
+}
+
 // SetAsMenu ensures that this functions as a menu even before menu items are added
 func (bb *ButtonBase) SetAsMenu() {
 	bb.SetFlag(int(ButtonFlagMenu))
@@ -324,7 +358,7 @@ func (bb *ButtonBase) ButtonReleased() {
 
 		if bb.IsCheckable() {
 			bb.ToggleChecked()
-			bb.ButtonSig.Emit(bb.This(), int64(ButtonToggled), nil)
+			bb.ButtonSig.Emit(bb.This(), int64(ButtonToggled), bb.CheckedState())
 		}
 	}
 	bb.UpdateEnd(updt)
@@ -787,10 +821,14 @@ var ButtonProps = ki.Props{
 ///////////////////////////////////////////////////////////
 // CheckBox
 
-// CheckBox toggles between a checked and unchecked state
+// CheckBox toggles between a checked and unchecked state, with an optional
+// third indeterminate (mixed) state for e.g., group headers -- see
+// SetIndeterminate.  Set the "variant" property to "switch" for a sliding
+// toggle switch appearance instead of the default box and checkmark.
 type CheckBox struct {
 	ButtonBase
 	IconOff IconName `xml:"icon-off" view:"show-name" desc:"icon to use for the off, unchecked state of the icon -- plain Icon holds the On state -- can be set with icon-off property"`
+	IconInd IconName `xml:"icon-ind" view:"show-name" desc:"icon to use for the indeterminate (mixed) state -- can be set with icon-ind property"`
 }
 
 var KiT_CheckBox = kit.Types.AddType(&CheckBox{}, CheckBoxProps)
@@ -804,12 +842,14 @@ func (nb *CheckBox) CopyFieldsFrom(frm interface{}) {
 	fr := frm.(*CheckBox)
 	nb.ButtonBase.CopyFieldsFrom(&fr.ButtonBase)
 	nb.IconOff = fr.IconOff
+	nb.IconInd = fr.IconInd
 }
 
 var CheckBoxProps = ki.Props{
 	"EnumType:Flag":    KiT_ButtonFlags,
 	"icon":             "checked-box",
 	"icon-off":         "unchecked-box",
+	"icon-ind":         "indeterminate-box",
 	"text-align":       AlignLeft,
 	"color":            &Prefs.Colors.Font,
 	"background-color": &Prefs.Colors.Control,
@@ -834,6 +874,15 @@ var CheckBoxProps = ki.Props{
 		"fill":             &Prefs.Colors.Control,
 		"stroke":           &Prefs.Colors.Font,
 	},
+	"#icon2": ki.Props{
+		"width":            units.NewEm(1),
+		"height":           units.NewEm(1),
+		"margin":           units.NewPx(0),
+		"padding":          units.NewPx(0),
+		"background-color": color.Transparent,
+		"fill":             &Prefs.Colors.Control,
+		"stroke":           &Prefs.Colors.Font,
+	},
 	"#space": ki.Props{
 		"width": units.NewCh(0.1),
 	},
@@ -907,16 +956,55 @@ func (cb *CheckBox) StyleParts() {
 		pvs := kit.ToString(pv)
 		cb.IconOff = IconName(pvs)
 	}
+	if pv, ok := cb.PropInherit("icon-ind", false, true); ok {
+		pvs := kit.ToString(pv)
+		cb.IconInd = IconName(pvs)
+	}
+}
+
+// IsSwitch returns whether the "variant" property is set to "switch", for
+// the sliding toggle switch appearance
+func (cb *CheckBox) IsSwitch() bool {
+	pv, ok := cb.PropInherit("variant", false, true) // no inh, yes type
+	return ok && kit.ToString(pv) == "switch"
+}
+
+// SetAsSwitch sets the "variant" property to "switch", selecting the sliding
+// toggle switch appearance -- call before the checkbox is first configured
+func (cb *CheckBox) SetAsSwitch() {
+	cb.SetProp("variant", "switch")
+}
+
+// StateStackTop returns the icon stack index for the current check state
+func (cb *CheckBox) StateStackTop() int {
+	switch {
+	case cb.IsIndeterminate():
+		return 2
+	case cb.IsChecked():
+		return 0
+	}
+	return 1
 }
 
 func (cb *CheckBox) ConfigParts() {
 	cb.SetCheckable(true)
+	if cb.IsSwitch() {
+		if !cb.Icon.IsValid() || cb.Icon == "checked-box" {
+			cb.Icon = "switch-on"
+		}
+		if !cb.IconOff.IsValid() || cb.IconOff == "unchecked-box" {
+			cb.IconOff = "switch-off"
+		}
+	}
 	if !cb.Icon.IsValid() {
 		cb.Icon = "checked-box" // fallback
 	}
 	if !cb.IconOff.IsValid() {
 		cb.IconOff = "unchecked-box"
 	}
+	if !cb.IconInd.IsValid() {
+		cb.IconInd = "indeterminate-box"
+	}
 	config := kit.TypeAndNameList{}
 	icIdx := 0 // always there
 	lbIdx := -1
@@ -930,7 +1018,7 @@ func (cb *CheckBox) ConfigParts() {
 	ist := cb.Parts.Child(icIdx).(*Layout)
 	if mods {
 		ist.Lay = LayoutStacked
-		ist.SetNChildren(2, KiT_Icon, "icon") // covered by above config update
+		ist.SetNChildren(3, KiT_Icon, "icon") // covered by above config update
 		icon := ist.Child(0).(*Icon)
 		if set, _ := cb.Icon.SetIcon(icon); set {
 			cb.StylePart(Node2D(icon))
@@ -939,12 +1027,12 @@ func (cb *CheckBox) ConfigParts() {
 		if set, _ := cb.IconOff.SetIcon(icoff); set {
 			cb.StylePart(Node2D(icoff))
 		}
+		icind := ist.Child(2).(*Icon)
+		if set, _ := cb.IconInd.SetIcon(icind); set {
+			cb.StylePart(Node2D(icind))
+		}
 	}
-	if cb.IsChecked() {
-		ist.StackTop = 0
-	} else {
-		ist.StackTop = 1
-	}
+	ist.StackTop = cb.StateStackTop()
 	if lbIdx >= 0 {
 		lbl := cb.Parts.Child(lbIdx).(*Label)
 		if lbl.Text != cb.Text {
@@ -980,9 +1068,13 @@ func (cb *CheckBox) ConfigPartsIfNeeded() {
 			}
 		}
 	}
-	if cb.IsChecked() {
-		ist.StackTop = 0
-	} else {
-		ist.StackTop = 1
+	if cb.IconInd.IsValid() && ist.NumChildren() > 2 {
+		icind := ist.Child(2).(*Icon)
+		if !icind.HasChildren() || icind.UniqueNm != string(cb.IconInd) || cb.NeedsFullReRender() {
+			if set, _ := cb.IconInd.SetIcon(icind); set {
+				cb.StylePart(Node2D(icind))
+			}
+		}
 	}
+	ist.StackTop = cb.StateStackTop()
 }
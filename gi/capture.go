@@ -0,0 +1,104 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"os"
+	"sync"
+	"time"
+)
+
+// VpCapture records successive frames of a viewport's Pixels as they are
+// uploaded to the window, for encoding as an animated GIF -- the animated
+// format supported by the standard library -- so widget behavior can be
+// recorded for bug reports and docs directly from the toolkit.  See
+// Viewport2D.StartCapture / StopCapture.
+type VpCapture struct {
+	Frames       []*image.Paletted `desc:"captured frames, quantized to 256 colors"`
+	Delays       []int             `desc:"per-frame display durations, in 100ths of a second"`
+	MinFrameMSec int               `desc:"minimum interval between captured frames in msec -- uploads arriving faster are skipped"`
+	last         time.Time         // time of last captured frame
+	Mu           sync.Mutex        `copy:"-" json:"-" xml:"-" view:"-" desc:"protects frame list -- uploads can come from multiple paths"`
+}
+
+// CaptureFrame quantizes given image and appends it as a frame, updating
+// the delay of the previous frame from the elapsed time -- frames arriving
+// within MinFrameMSec of the previous one are skipped
+func (vc *VpCapture) CaptureFrame(img *image.RGBA) {
+	vc.Mu.Lock()
+	defer vc.Mu.Unlock()
+	now := time.Now()
+	elapsed := now.Sub(vc.last)
+	if len(vc.Frames) > 0 {
+		if elapsed < time.Duration(vc.MinFrameMSec)*time.Millisecond {
+			return
+		}
+		vc.Delays[len(vc.Delays)-1] = int(elapsed / (10 * time.Millisecond))
+	}
+	b := img.Bounds()
+	pal := image.NewPaletted(image.Rectangle{Max: b.Size()}, palette.Plan9)
+	draw.FloydSteinberg.Draw(pal, pal.Bounds(), img, b.Min)
+	vc.Frames = append(vc.Frames, pal)
+	vc.Delays = append(vc.Delays, vc.MinFrameMSec/10)
+	vc.last = now
+}
+
+// GIF returns the captured frames assembled as an animated GIF
+func (vc *VpCapture) GIF() *gif.GIF {
+	vc.Mu.Lock()
+	defer vc.Mu.Unlock()
+	return &gif.GIF{Image: vc.Frames, Delay: vc.Delays, LoopCount: 0}
+}
+
+// StartCapture begins recording this viewport: after each upload to the
+// window, the current Pixels image is grabbed as a frame, with at least
+// minFrameMSec msec between frames (faster uploads are skipped) -- stop and
+// encode with StopCapture or StopCaptureSave -- any prior capture in
+// progress is discarded
+func (vp *Viewport2D) StartCapture(minFrameMSec int) {
+	if minFrameMSec <= 0 {
+		minFrameMSec = 50 // 20 fps max by default
+	}
+	vp.Capture = &VpCapture{MinFrameMSec: minFrameMSec}
+}
+
+// StopCapture stops recording this viewport and returns the captured
+// animation -- nil if no capture was in progress or no frames were grabbed
+func (vp *Viewport2D) StopCapture() *gif.GIF {
+	vc := vp.Capture
+	vp.Capture = nil
+	if vc == nil || len(vc.Frames) == 0 {
+		return nil
+	}
+	return vc.GIF()
+}
+
+// StopCaptureSave stops recording this viewport and encodes the captured
+// animation as an animated GIF at given path
+func (vp *Viewport2D) StopCaptureSave(path string) error {
+	g := vp.StopCapture()
+	if g == nil {
+		return fmt.Errorf("gi.Viewport2D.StopCaptureSave: no frames captured")
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return gif.EncodeAll(f, g)
+}
+
+// captureUpload grabs the current Pixels as a capture frame if a capture is
+// in progress -- called from the VpUpload* methods after the render flush
+func (vp *Viewport2D) captureUpload() {
+	if vc := vp.Capture; vc != nil && vp.Pixels != nil {
+		vc.CaptureFrame(vp.Pixels)
+	}
+}
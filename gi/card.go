@@ -0,0 +1,299 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// This file provides composite convenience widgets for building
+// modern-looking list and dashboard UIs: Card with standard header, content,
+// and action areas, and ListItem with icon, primary / secondary text, and a
+// trailing widget area.
+
+// Card is an elevated frame with a standard arrangement of a header title,
+// a content area, and a row of action buttons at the bottom.  Add content to
+// the Content() frame and actions via AddAction.
+type Card struct {
+	Frame
+	Title string `xml:"title" desc:"title shown in the card header -- header is omitted if empty"`
+}
+
+var KiT_Card = kit.Types.AddType(&Card{}, CardProps)
+
+// AddNewCard adds a new card to given parent node, with given name and title.
+func AddNewCard(parent ki.Ki, name, title string) *Card {
+	cd := parent.AddNewChild(KiT_Card, name).(*Card)
+	cd.Title = title
+	return cd
+}
+
+func (nb *Card) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*Card)
+	nb.Frame.CopyFieldsFrom(&fr.Frame)
+	nb.Title = fr.Title
+}
+
+var CardProps = ki.Props{
+	"EnumType:Flag":       KiT_NodeFlags,
+	"border-width":        units.NewPx(1),
+	"border-radius":       units.NewPx(4),
+	"border-color":        &Prefs.Colors.Border,
+	"background-color":    &Prefs.Colors.Background,
+	"color":               &Prefs.Colors.Font,
+	"margin":              units.NewPx(4),
+	"padding":             units.NewPx(8),
+	"box-shadow.h-offset": units.NewPx(2),
+	"box-shadow.v-offset": units.NewPx(2),
+	"box-shadow.blur":     units.NewPx(4),
+	"box-shadow.color":    &Prefs.Colors.Shadow,
+	"#title": ki.Props{
+		"font-weight": WeightBold,
+		"text-align":  AlignLeft,
+	},
+}
+
+func (cd *Card) Init2D() {
+	cd.Lay = LayoutVert
+	cd.Frame.Init2D()
+	cd.ConfigCard()
+}
+
+func (cd *Card) Style2D() {
+	cd.ConfigCard()
+	cd.Frame.Style2D()
+}
+
+// TitleLabel returns the header title label, or nil if no title
+func (cd *Card) TitleLabel() *Label {
+	ti := cd.ChildByName("title", 0)
+	if ti == nil {
+		return nil
+	}
+	return ti.(*Label)
+}
+
+// Content returns the content frame -- add the card content here
+func (cd *Card) Content() *Frame {
+	ci := cd.ChildByName("content", 0)
+	if ci == nil {
+		return nil
+	}
+	return ci.(*Frame)
+}
+
+// Actions returns the bottom action row, or nil if no actions added yet --
+// see AddAction
+func (cd *Card) Actions() *Layout {
+	ai := cd.ChildByName("actions", 1)
+	if ai == nil {
+		return nil
+	}
+	return ai.(*Layout)
+}
+
+// ConfigCard configures the standard card areas if not already done, and
+// syncs the title
+func (cd *Card) ConfigCard() {
+	config := kit.TypeAndNameList{}
+	if cd.Title != "" {
+		config.Add(KiT_Label, "title")
+	}
+	config.Add(KiT_Frame, "content")
+	if cd.Actions() != nil {
+		config.Add(KiT_Layout, "actions")
+	}
+	mods, updt := cd.ConfigChildren(config, false)
+	if tl := cd.TitleLabel(); tl != nil {
+		if tl.Text != cd.Title {
+			tl.SetText(cd.Title)
+		}
+		tl.SetProp("font-weight", WeightBold)
+	}
+	if mods {
+		ct := cd.Content()
+		ct.Lay = LayoutVert
+		cd.UpdateEnd(updt)
+	}
+}
+
+// AddAction adds an action button with given name and label to the bottom
+// action row, creating the row if needed
+func (cd *Card) AddAction(name, text string) *Action {
+	ac := cd.Actions()
+	if ac == nil {
+		ac = AddNewLayout(cd, "actions", LayoutHoriz)
+		AddNewStretch(ac, "stretch")
+	}
+	bt := AddNewAction(ac, name)
+	bt.SetText(text)
+	return bt
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  ListItem
+
+// ListItem is a composite list row with an optional leading icon, primary
+// and secondary text, and a trailing widget area (e.g., for a switch,
+// checkbox or count badge) -- add trailing content to the Trailing() layout.
+type ListItem struct {
+	PartsWidgetBase
+	Icon          IconName `view:"show-name" desc:"optional leading icon"`
+	Text          string   `xml:"text" desc:"primary text"`
+	SecondaryText string   `xml:"secondary-text" desc:"optional secondary text, rendered smaller and dimmer below the primary text"`
+}
+
+var KiT_ListItem = kit.Types.AddType(&ListItem{}, ListItemProps)
+
+// AddNewListItem adds a new list item to given parent node, with given name
+// and primary text.
+func AddNewListItem(parent ki.Ki, name, text string) *ListItem {
+	li := parent.AddNewChild(KiT_ListItem, name).(*ListItem)
+	li.Text = text
+	return li
+}
+
+func (nb *ListItem) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*ListItem)
+	nb.PartsWidgetBase.CopyFieldsFrom(&fr.PartsWidgetBase)
+	nb.Icon = fr.Icon
+	nb.Text = fr.Text
+	nb.SecondaryText = fr.SecondaryText
+}
+
+var ListItemProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"background-color": &Prefs.Colors.Background,
+	"color":            &Prefs.Colors.Font,
+	"padding":          units.NewPx(4),
+	"margin":           units.NewPx(0),
+	"#icon": ki.Props{
+		"width":   units.NewEm(1.5),
+		"height":  units.NewEm(1.5),
+		"margin":  units.NewPx(0),
+		"padding": units.NewPx(0),
+		"fill":    &Prefs.Colors.Icon,
+		"stroke":  &Prefs.Colors.Font,
+	},
+	"#text": ki.Props{
+		"margin":  units.NewPx(0),
+		"padding": units.NewPx(0),
+	},
+	"#secondary-text": ki.Props{
+		"margin":    units.NewPx(0),
+		"padding":   units.NewPx(0),
+		"font-size": units.NewPct(85),
+		"color":     "highlight-40",
+	},
+	LabelSelectors[LabelSelected]: ki.Props{
+		"background-color": &Prefs.Colors.Select,
+	},
+}
+
+func (li *ListItem) Init2D() {
+	li.Init2DWidget()
+	li.ConfigParts()
+}
+
+func (li *ListItem) Style2D() {
+	li.Style2DWidget()
+	li.ConfigParts()
+	li.LayData.SetFromStyle(&li.Sty.Layout) // also does reset
+}
+
+// Trailing returns the trailing widget area, creating it if needed -- add
+// e.g., a switch, checkbox or badge here
+func (li *ListItem) Trailing() *Layout {
+	ti := li.Parts.ChildByName("trailing", 2)
+	if ti != nil {
+		return ti.(*Layout)
+	}
+	li.ConfigParts()
+	tl := AddNewLayout(&li.Parts, "trailing", LayoutHoriz)
+	return tl
+}
+
+func (li *ListItem) ConfigParts() {
+	li.Parts.Lay = LayoutHoriz
+	config := kit.TypeAndNameList{}
+	icIdx := -1
+	if li.Icon.IsValid() {
+		icIdx = len(config)
+		config.Add(KiT_Icon, "icon")
+	}
+	txIdx := len(config)
+	config.Add(KiT_Layout, "text-lay")
+	config.Add(KiT_Stretch, "stretch")
+	if li.Parts.ChildByName("trailing", 2) != nil {
+		config.Add(KiT_Layout, "trailing")
+	}
+	mods, updt := li.Parts.ConfigChildren(config, false)
+	if icIdx >= 0 {
+		icon := li.Parts.Child(icIdx).(*Icon)
+		if set, _ := li.Icon.SetIcon(icon); set {
+			li.StylePart(Node2D(icon))
+		}
+	}
+	tl := li.Parts.Child(txIdx).(*Layout)
+	tl.Lay = LayoutVert
+	tconfig := kit.TypeAndNameList{}
+	tconfig.Add(KiT_Label, "text")
+	if li.SecondaryText != "" {
+		tconfig.Add(KiT_Label, "secondary-text")
+	}
+	tmods, tupdt := tl.ConfigChildren(tconfig, false)
+	lbl := tl.Child(0).(*Label)
+	if lbl.Text != li.Text {
+		li.StylePart(Node2D(lbl))
+		lbl.SetText(li.Text)
+	}
+	if li.SecondaryText != "" {
+		slbl := tl.Child(1).(*Label)
+		if slbl.Text != li.SecondaryText {
+			li.StylePart(Node2D(slbl))
+			slbl.SetText(li.SecondaryText)
+		}
+	}
+	if tmods {
+		tl.UpdateEnd(tupdt)
+	}
+	if mods {
+		li.UpdateEnd(updt)
+	}
+}
+
+func (li *ListItem) Layout2D(parBBox image.Rectangle, iter int) bool {
+	li.Layout2DBase(parBBox, true, iter)
+	li.Layout2DParts(parBBox, iter)
+	return li.Layout2DChildren(iter)
+}
+
+func (li *ListItem) Render2D() {
+	if li.FullReRenderIfNeeded() {
+		return
+	}
+	if li.PushBounds() {
+		li.This().(Node2D).ConnectEvents2D()
+		st := &li.Sty
+		rs := &li.Viewport.Render
+		rs.Lock()
+		li.RenderStdBox(st)
+		rs.Unlock()
+		li.Render2DParts()
+		li.Render2DChildren()
+		li.PopBounds()
+	} else {
+		li.DisconnectAllEvents(RegPri)
+	}
+}
+
+func (li *ListItem) ConnectEvents2D() {
+	li.WidgetMouseEvents(true, true) // selection and context menu
+	li.HoverTooltipEvent()
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"image/draw"
+	"sync"
+	"time"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/ki/kit"
+)
+
+// This file provides the shared text caret service: one blink timer and
+// one active (focused) caret at a time, shared by TextField, the text
+// editor, and any other widget that renders a text cursor.  Widgets
+// implement CaretOwner and call TheCaret.Start / Stop on focus changes;
+// the service handles the blink loop, pausing while the window is
+// unfocused, resizing, or updating.  The blink rate honors the
+// CursorBlinkMSec preference, and the caret shape, width, and color come
+// from the widget's style via MakeCaretSprite.
+
+// CursorBlinkMSec is number of milliseconds that cursor blinks on
+// and off -- set to 0 to disable blinking
+var CursorBlinkMSec = 500
+
+// CaretWidthDefault is the default caret bar width in dots, used when the
+// widget style does not specify a cursor-width
+var CaretWidthDefault = float32(2)
+
+// CaretShapes are the shapes a text caret can be rendered with
+type CaretShapes int64
+
+const (
+	// CaretBar is a vertical bar of cursor-width at the insert position
+	CaretBar CaretShapes = iota
+
+	// CaretBlock is a semi-transparent block covering the character cell
+	CaretBlock
+
+	// CaretUnderline is a horizontal bar at the bottom of the character cell
+	CaretUnderline
+
+	CaretShapesN
+)
+
+//go:generate stringer -type=CaretShapes
+
+var KiT_CaretShapes = kit.Enums.AddEnumAltLower(CaretShapesN, kit.NotBitFlag, StylePropProps, "Caret")
+
+// CaretShape is the shape used for all carets -- can be set in user
+// preferences / app startup
+var CaretShape = CaretBar
+
+// CaretOwner is the interface for widgets whose caret is managed by the
+// shared caret service (TheCaret): typically implemented by rendering the
+// caret as a window sprite (see MakeCaretSprite), positioned in
+// CaretRender
+type CaretOwner interface {
+	Node2D
+
+	// IsFocusActive returns whether keyboard focus is active in this widget
+	IsFocusActive() bool
+
+	// CaretRender renders the caret on or off at the current cursor
+	// position -- called from the blink loop and on cursor moves
+	CaretRender(on bool)
+}
+
+// CaretMgr manages the blinking of the single active caret -- use the
+// global TheCaret instance
+type CaretMgr struct {
+	Mu      sync.Mutex `desc:"protects all the caret state"`
+	On      bool       `desc:"current blink phase -- caret visible"`
+	owner   CaretOwner
+	blinker *time.Ticker
+}
+
+// TheCaret is the global caret service
+var TheCaret CaretMgr
+
+// Start makes given widget the blinking caret owner (there is only ever
+// one) and renders its caret on -- call on receiving focus and after
+// cursor moves
+func (cm *CaretMgr) Start(co CaretOwner) {
+	if co == nil || co.This() == nil {
+		return
+	}
+	if !co.IsVisible() {
+		return
+	}
+	if CursorBlinkMSec == 0 {
+		co.CaretRender(true)
+		return
+	}
+	cm.Mu.Lock()
+	if cm.blinker == nil {
+		cm.blinker = time.NewTicker(time.Duration(CursorBlinkMSec) * time.Millisecond)
+		go cm.blink()
+	}
+	cm.On = true
+	win := co.AsNode2D().ParentWindow()
+	if win != nil && !win.IsResizing() {
+		co.CaretRender(true)
+	}
+	cm.owner = co
+	cm.Mu.Unlock()
+}
+
+// Stop stops blinking for given widget, if it is the current owner --
+// call on losing focus.  The caller is responsible for rendering its
+// caret off (typically via CaretRender(false))
+func (cm *CaretMgr) Stop(co CaretOwner) {
+	cm.Mu.Lock()
+	if cm.owner == co {
+		cm.owner = nil
+	}
+	cm.Mu.Unlock()
+}
+
+// IsBlinking returns whether given widget is the currently-blinking caret
+// owner
+func (cm *CaretMgr) IsBlinking(co CaretOwner) bool {
+	cm.Mu.Lock()
+	defer cm.Mu.Unlock()
+	return cm.owner == co
+}
+
+// blink is the blink loop -- runs until the blinker is nil
+func (cm *CaretMgr) blink() {
+	for {
+		cm.Mu.Lock()
+		if cm.blinker == nil {
+			cm.Mu.Unlock()
+			return // shutdown..
+		}
+		tick := cm.blinker.C
+		cm.Mu.Unlock()
+		<-tick
+		cm.Mu.Lock()
+		co := cm.owner
+		if co == nil || co.This() == nil {
+			cm.Mu.Unlock()
+			continue
+		}
+		nb := co.AsNode2D()
+		if nb.IsDestroyed() || nb.IsDeleted() {
+			cm.owner = nil
+			cm.Mu.Unlock()
+			continue
+		}
+		if nb.Viewport == nil || !nb.HasFocus() || !co.IsFocusActive() || !co.IsVisible() {
+			cm.owner = nil
+			cm.Mu.Unlock()
+			continue
+		}
+		win := nb.ParentWindow()
+		if win == nil || win.IsResizing() || win.IsClosed() || !win.IsWindowInFocus() {
+			cm.Mu.Unlock()
+			continue
+		}
+		if win.IsUpdating() {
+			cm.Mu.Unlock()
+			continue
+		}
+		cm.On = !cm.On
+		co.CaretRender(cm.On)
+		cm.Mu.Unlock()
+	}
+}
+
+// MakeCaretSprite returns the window sprite of given name for a caret of
+// the global CaretShape, with given bar width, font height, and character
+// cell width (all in dots), in given color, making it if not already
+// present.  The sprite name should encode the height (and anything else
+// the image depends on) so that differently-sized carets get separate
+// sprites.
+func MakeCaretSprite(win *Window, nm string, width, height, charWd float32, clr Color) *Sprite {
+	if win == nil {
+		return nil
+	}
+	sp, ok := win.SpriteByName(nm)
+	if ok {
+		return sp
+	}
+	wd := int(math32.Ceil(width))
+	if wd < 2 { // at least 2
+		wd = 2
+	}
+	ht := int(math32.Ceil(height))
+	cwd := int(math32.Ceil(charWd))
+	if cwd < wd {
+		cwd = 2 * wd
+	}
+	switch CaretShape {
+	case CaretBlock:
+		sp = win.AddNewSprite(nm, image.Point{cwd, ht}, image.ZP)
+		bclr := clr
+		bclr.A = 128 // semi-transparent so the glyph shows through
+		draw.Draw(sp.Pixels, sp.Pixels.Bounds(), &image.Uniform{bclr}, image.ZP, draw.Src)
+	case CaretUnderline:
+		sp = win.AddNewSprite(nm, image.Point{cwd, ht}, image.ZP)
+		ub := sp.Pixels.Bounds()
+		ub.Min.Y = ub.Max.Y - wd
+		draw.Draw(sp.Pixels, ub, &image.Uniform{clr}, image.ZP, draw.Src)
+	default: // CaretBar
+		sp = win.AddNewSprite(nm, image.Point{wd, ht}, image.ZP)
+		draw.Draw(sp.Pixels, sp.Pixels.Bounds(), &image.Uniform{clr}, image.ZP, draw.Src)
+	}
+	return sp
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/goki/gi/oswin"
+)
+
+// TheClipHist is the global clipboard history: a ring of recent text clips
+// captured from Cut / Copy operations across widgets, available for
+// re-pasting via KeyFunPasteHist (see TextField.PasteHist).  In-memory by
+// default -- call SetPersist(true) to save across sessions in the GoGi
+// prefs directory.
+var TheClipHist ClipHist
+
+// ClipHistMax is the maximum number of clips retained in the history ring
+var ClipHistMax = 100
+
+// ClipHistChooseLen is the max length of clip text shown in the
+// paste-from-history chooser
+var ClipHistChooseLen = 40
+
+// ClipHistFileName is the file name for persisted clipboard history,
+// within the GoGi prefs directory
+var ClipHistFileName = "clip-history.json"
+
+// ClipHistItem is one entry in the clipboard history
+type ClipHistItem struct {
+	Text string    `desc:"the clip text"`
+	Time time.Time `desc:"when the clip was captured"`
+}
+
+// ClipHist is a ring of recent clipboard clips -- see TheClipHist
+type ClipHist struct {
+	Items   []ClipHistItem `desc:"recent clips, newest first"`
+	Persist bool           `desc:"whether history is saved to the prefs directory after each addition"`
+	Mu      sync.Mutex     `view:"-" desc:"mutex protecting Items"`
+}
+
+// Add adds given clip text to the top of the history ring -- duplicate of
+// the current top is ignored
+func (ch *ClipHist) Add(txt string) {
+	if txt == "" {
+		return
+	}
+	ch.Mu.Lock()
+	if len(ch.Items) > 0 && ch.Items[0].Text == txt {
+		ch.Mu.Unlock()
+		return
+	}
+	ch.Items = append(ch.Items, ClipHistItem{})
+	copy(ch.Items[1:], ch.Items[0:len(ch.Items)-1])
+	ch.Items[0] = ClipHistItem{Text: txt, Time: time.Now()}
+	if len(ch.Items) > ClipHistMax {
+		ch.Items = ch.Items[:ClipHistMax]
+	}
+	prs := ch.Persist
+	ch.Mu.Unlock()
+	if prs {
+		ch.Save()
+	}
+}
+
+// Clip returns the clip text at given index (0 = most recent), or "" if
+// out of range
+func (ch *ClipHist) Clip(idx int) string {
+	ch.Mu.Lock()
+	defer ch.Mu.Unlock()
+	if idx < 0 || idx >= len(ch.Items) {
+		return ""
+	}
+	return ch.Items[idx].Text
+}
+
+// ChooseList returns a string slice of length-limited clips, newest first,
+// for the paste-from-history chooser
+func (ch *ClipHist) ChooseList() []string {
+	ch.Mu.Lock()
+	defer ch.Mu.Unlock()
+	cl := make([]string, len(ch.Items))
+	for i, it := range ch.Items {
+		if len(it.Text) > ClipHistChooseLen {
+			cl[i] = it.Text[:ClipHistChooseLen]
+		} else {
+			cl[i] = it.Text
+		}
+	}
+	return cl
+}
+
+// SetPersist sets whether history is persisted to the prefs directory --
+// enabling loads any previously-saved history
+func (ch *ClipHist) SetPersist(persist bool) {
+	ch.Mu.Lock()
+	ch.Persist = persist
+	ch.Mu.Unlock()
+	if persist {
+		ch.Open()
+	}
+}
+
+func (ch *ClipHist) path() string {
+	return filepath.Join(oswin.TheApp.GoGiPrefsDir(), ClipHistFileName)
+}
+
+// Save saves the current history to the prefs directory
+func (ch *ClipHist) Save() error {
+	ch.Mu.Lock()
+	b, err := json.MarshalIndent(ch.Items, "", "  ")
+	ch.Mu.Unlock()
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return ioutil.WriteFile(ch.path(), b, 0600)
+}
+
+// Open loads saved history from the prefs directory, if present
+func (ch *ClipHist) Open() error {
+	b, err := ioutil.ReadFile(ch.path())
+	if err != nil {
+		return err // ok to be non-existent
+	}
+	ch.Mu.Lock()
+	defer ch.Mu.Unlock()
+	return json.Unmarshal(b, &ch.Items)
+}
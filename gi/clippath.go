@@ -0,0 +1,101 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/srwiley/rasterx"
+	"github.com/srwiley/scanx"
+	"golang.org/x/image/draw"
+)
+
+// Arbitrary-path clipping: PushBounds only clips rendering to rectangles.
+// PushClipPath rasterizes a path into an alpha coverage mask and installs
+// it as the current clip Mask, intersected with any existing one, so
+// rounded-corner frames and SVG clipPath elements can clip their children
+// to the actual path shape.  The mask is applied per-pixel to the edge
+// coverage of all fill / stroke rendering (clipSpanner), and to image
+// drawing as always, until the matching PopClipPath.
+
+// PushClipPath rasterizes the given path (as it would be filled, with
+// nonzero winding, in current device coordinates) into an alpha mask,
+// intersects it with the current clip mask, and makes the result the
+// current Mask -- the previous mask is saved on the clip stack and
+// restored by the matching PopClipPath
+func (rs *RenderState) PushClipPath(path rasterx.Path) {
+	clip := rs.RasterizeClipPath(path)
+	if rs.ClipStack == nil {
+		rs.ClipStack = make([]*image.Alpha, 0, 10)
+	}
+	rs.ClipStack = append(rs.ClipStack, rs.Mask) // nil ok -- restored as-is
+	if rs.Mask != nil {
+		mask := image.NewAlpha(rs.Image.Bounds())
+		draw.DrawMask(mask, mask.Bounds(), clip, image.ZP, rs.Mask, image.ZP, draw.Over)
+		clip = mask
+	}
+	rs.Mask = clip
+}
+
+// PopClipPath restores the clip mask saved by the matching PushClipPath
+func (rs *RenderState) PopClipPath() {
+	rs.PopClip()
+}
+
+// RasterizeClipPath rasterizes given path into an alpha coverage mask the
+// same size as the render image, using nonzero winding, for use as a clip
+// mask -- uses its own scanner so the main rasterization pipeline is
+// unaffected
+func (rs *RenderState) RasterizeClipPath(path rasterx.Path) *image.Alpha {
+	b := rs.Image.Bounds()
+	sz := b.Size()
+	clip := image.NewAlpha(b)
+	sc := rasterx.NewScannerGV(sz.X, sz.Y, clip, b)
+	rf := rasterx.NewFiller(sz.X, sz.Y, sc)
+	rf.SetColor(color.Opaque)
+	path.AddTo(rf)
+	rf.Draw()
+	rf.Clear()
+	return clip
+}
+
+// clipSpanner wraps the rendering spanner, multiplying edge coverage
+// per-pixel by the current clip Mask (when set), so fills and strokes are
+// clipped to arbitrary path shapes -- see PushClipPath
+type clipSpanner struct {
+	sp   scanx.Spanner
+	mask **image.Alpha // points at the owning RenderState's Mask
+}
+
+func (x *clipSpanner) SetColor(c interface{}) {
+	x.sp.SetColor(c)
+}
+
+func (x *clipSpanner) GetSpanFunc() scanx.SpanFunc {
+	fn := x.sp.GetSpanFunc()
+	mask := *x.mask
+	if mask == nil {
+		return fn
+	}
+	return func(yi, xi0, xi1 int, ma uint32) {
+		xi := xi0
+		i := mask.PixOffset(xi0, yi)
+		for xi < xi1 { // emit runs of equal mask alpha -- mostly 0 / 0xff
+			a := uint32(mask.Pix[i])
+			xr := xi + 1
+			ir := i + 1
+			for xr < xi1 && uint32(mask.Pix[ir]) == a {
+				xr++
+				ir++
+			}
+			if a > 0 {
+				fn(yi, xi, xr, ma*a/0xff)
+			}
+			xi = xr
+			i = ir
+		}
+	}
+}
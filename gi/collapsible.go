@@ -0,0 +1,292 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"time"
+
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// CollapseAnimMSec is the duration in milliseconds of the expand / collapse
+// animation in Collapsible -- set to 0 for instant open / close
+var CollapseAnimMSec = 120
+
+// Collapsible is a section with a header (disclosure triangle + title) and a
+// body that expands or collapses when the header is clicked.  Add content to
+// the Body() frame.  Useful in preference panels and inspectors -- see also
+// Accordion for managing a set of sections.
+type Collapsible struct {
+	Layout
+	Text        string    `xml:"text" desc:"title shown in the header"`
+	Open        bool      `desc:"whether the body is currently expanded"`
+	BodyHt      float32   `copy:"-" json:"-" xml:"-" view:"-" desc:"last laid-out body height in dots, used for the collapse / expand animation"`
+	CollapseSig ki.Signal `copy:"-" json:"-" xml:"-" view:"-" desc:"emitted when the open state changes from user action -- data is the new open state as bool"`
+}
+
+var KiT_Collapsible = kit.Types.AddType(&Collapsible{}, CollapsibleProps)
+
+// AddNewCollapsible adds a new collapsible section to given parent node,
+// with given name and header title -- starts out open
+func AddNewCollapsible(parent ki.Ki, name, title string) *Collapsible {
+	cl := parent.AddNewChild(KiT_Collapsible, name).(*Collapsible)
+	cl.Text = title
+	cl.Open = true
+	return cl
+}
+
+func (nb *Collapsible) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*Collapsible)
+	nb.Layout.CopyFieldsFrom(&fr.Layout)
+	nb.Text = fr.Text
+	nb.Open = fr.Open
+}
+
+func (cl *Collapsible) Disconnect() {
+	cl.Layout.Disconnect()
+	cl.CollapseSig.DisconnectAll()
+}
+
+var CollapsibleProps = ki.Props{
+	"EnumType:Flag": KiT_NodeFlags,
+}
+
+func (cl *Collapsible) Init2D() {
+	cl.Lay = LayoutVert
+	cl.Layout.Init2D()
+	cl.ConfigCollapsible()
+}
+
+func (cl *Collapsible) Style2D() {
+	cl.ConfigCollapsible()
+	cl.Layout.Style2D()
+}
+
+func (cl *Collapsible) Layout2D(parBBox image.Rectangle, iter int) bool {
+	redo := cl.Layout.Layout2D(parBBox, iter)
+	if cl.Open { // record natural body height for the animation
+		bd := cl.Body()
+		if bd != nil && bd.LayData.AllocSize.Y > 0 {
+			cl.BodyHt = bd.LayData.AllocSize.Y
+		}
+	}
+	return redo
+}
+
+// Header returns the header action, or nil if not yet configured
+func (cl *Collapsible) Header() *Action {
+	hi := cl.ChildByName("header", 0)
+	if hi == nil {
+		return nil
+	}
+	return hi.(*Action)
+}
+
+// Body returns the body frame that holds the section content, or nil if not
+// yet configured
+func (cl *Collapsible) Body() *Frame {
+	bi := cl.ChildByName("body", 1)
+	if bi == nil {
+		return nil
+	}
+	return bi.(*Frame)
+}
+
+// ConfigCollapsible configures the header and body if not already done, and
+// syncs the header with the current title and open state
+func (cl *Collapsible) ConfigCollapsible() {
+	config := kit.TypeAndNameList{}
+	config.Add(KiT_Action, "header")
+	config.Add(KiT_Frame, "body")
+	mods, updt := cl.ConfigChildren(config, false)
+	hdr := cl.Header()
+	if mods {
+		hdr.SetProp("text-align", AlignLeft)
+		bd := cl.Body()
+		bd.Lay = LayoutVert
+		hdr.ActionSig.Connect(cl.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			cll := recv.Embed(KiT_Collapsible).(*Collapsible)
+			cll.ToggleOpenAction()
+		})
+		cl.UpdateEnd(updt)
+	}
+	if hdr.Text != cl.Text {
+		hdr.SetText(cl.Text)
+	}
+	if cl.Open {
+		hdr.SetIcon("wedge-down")
+	} else {
+		hdr.SetIcon("wedge-right")
+	}
+	cl.SetBodyVis()
+}
+
+// SetBodyVis sets the body visibility and size props from the current Open
+// state
+func (cl *Collapsible) SetBodyVis() {
+	bd := cl.Body()
+	if bd == nil {
+		return
+	}
+	if cl.Open {
+		bd.ClearInvisible()
+		bd.DeleteProp("height")
+		bd.DeleteProp("max-height")
+	} else {
+		bd.SetInvisible()
+		bd.SetProp("height", units.NewPx(0))
+		bd.SetProp("max-height", units.NewPx(0))
+	}
+}
+
+// SetOpen sets the open state immediately, with no animation or signal
+func (cl *Collapsible) SetOpen(open bool) {
+	if cl.Open == open {
+		return
+	}
+	updt := cl.UpdateStart()
+	cl.Open = open
+	cl.ConfigCollapsible()
+	cl.SetFullReRender()
+	cl.UpdateEnd(updt)
+}
+
+// ToggleOpenAction toggles the open state with animation, emitting
+// CollapseSig with the new state -- this is what the header click does
+func (cl *Collapsible) ToggleOpenAction() {
+	cl.SetOpenAction(!cl.Open)
+}
+
+// SetOpenAction sets the open state with animation, emitting CollapseSig
+// with the new state
+func (cl *Collapsible) SetOpenAction(open bool) {
+	if cl.Open == open {
+		return
+	}
+	if CollapseAnimMSec <= 0 || ReducedMotion || cl.BodyHt <= 0 || cl.Viewport == nil {
+		cl.SetOpen(open)
+		cl.CollapseSig.Emit(cl.This(), 0, cl.Open)
+		return
+	}
+	cl.Open = open
+	cl.CollapseSig.Emit(cl.This(), 0, cl.Open)
+	go cl.AnimOpen(open)
+}
+
+// AnimOpen animates the body height between 0 and BodyHt -- runs in its own
+// goroutine, started by SetOpenAction
+func (cl *Collapsible) AnimOpen(open bool) {
+	bd := cl.Body()
+	if bd == nil {
+		return
+	}
+	if open {
+		bd.ClearInvisible()
+	}
+	start := time.Now()
+	dur := time.Duration(CollapseAnimMSec) * time.Millisecond
+	tick := time.NewTicker(time.Second / 60)
+	defer tick.Stop()
+	for now := range tick.C {
+		if cl.This() == nil || cl.IsDestroyed() || cl.IsDeleted() {
+			return
+		}
+		pct := float32(now.Sub(start)) / float32(dur)
+		done := pct >= 1
+		if done {
+			pct = 1
+		}
+		if !open {
+			pct = 1 - pct
+		}
+		ht := pct * cl.BodyHt
+		updt := cl.UpdateStart()
+		bd.SetProp("height", units.NewPx(ht))
+		bd.SetProp("max-height", units.NewPx(ht))
+		cl.SetFullReRender()
+		cl.UpdateEnd(updt)
+		if done {
+			break
+		}
+	}
+	if cl.This() == nil || cl.IsDestroyed() || cl.IsDeleted() {
+		return
+	}
+	updt := cl.UpdateStart() // end on the exact final state
+	cl.ConfigCollapsible()
+	cl.SetFullReRender()
+	cl.UpdateEnd(updt)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  Accordion
+
+// Accordion is a vertical container of Collapsible sections that can enforce
+// a single-open mode, where opening one section closes all the others --
+// default allows any number of sections open
+type Accordion struct {
+	Layout
+	SingleOpen bool `desc:"allow only one section open at a time -- opening a section closes the others"`
+}
+
+var KiT_Accordion = kit.Types.AddType(&Accordion{}, AccordionProps)
+
+// AddNewAccordion adds a new accordion to given parent node, with given name.
+func AddNewAccordion(parent ki.Ki, name string) *Accordion {
+	ac := parent.AddNewChild(KiT_Accordion, name).(*Accordion)
+	ac.Lay = LayoutVert
+	return ac
+}
+
+func (nb *Accordion) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*Accordion)
+	nb.Layout.CopyFieldsFrom(&fr.Layout)
+	nb.SingleOpen = fr.SingleOpen
+}
+
+var AccordionProps = ki.Props{
+	"EnumType:Flag": KiT_NodeFlags,
+}
+
+func (ac *Accordion) Init2D() {
+	ac.Lay = LayoutVert
+	ac.Layout.Init2D()
+}
+
+// AddNewSection adds a new collapsible section with given name and header
+// title, hooked up for single-open management
+func (ac *Accordion) AddNewSection(name, title string) *Collapsible {
+	cl := AddNewCollapsible(ac, name, title)
+	cl.CollapseSig.Connect(ac.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		open, ok := data.(bool)
+		if !ok || !open {
+			return
+		}
+		acc := recv.Embed(KiT_Accordion).(*Accordion)
+		if !acc.SingleOpen {
+			return
+		}
+		cll := send.Embed(KiT_Collapsible).(*Collapsible)
+		acc.CloseOthers(cll)
+	})
+	return cl
+}
+
+// CloseOthers closes all sections other than the given one
+func (ac *Accordion) CloseOthers(open *Collapsible) {
+	for _, k := range ac.Kids {
+		cli := k.Embed(KiT_Collapsible)
+		if cli == nil {
+			continue
+		}
+		cl := cli.(*Collapsible)
+		if cl != open {
+			cl.SetOpen(false)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"log"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/gi/oswin"
+)
+
+// ColorMgmt is the global color management state -- GoGi renders in sRGB,
+// and when enabled, window output is gamma-corrected per-monitor based on
+// the monitor's ICC profile (obtained via oswin if the platform supports
+// it), so that colors look consistent on displays whose response deviates
+// from the sRGB standard.  Wide-gamut colors can be specified with
+// Color.SetDisplayP3 and Color.SetLinearSRGB.  Off by default -- call
+// ColorMgmt.EnableFromScreen at app init for design / photo tools that
+// need it.
+var ColorMgmt ColorMgmtState
+
+// ColorMgmtState holds the state for monitor color correction -- see
+// ColorMgmt global
+type ColorMgmtState struct {
+	Enabled  bool    `desc:"whether window output gamma correction is enabled"`
+	Gamma    float32 `desc:"gamma of the target monitor, as read from its ICC profile -- sRGB standard is approximately 2.2, and correction is the ratio relative to that"`
+	P3Output bool    `desc:"convert sRGB rendered output into the Display-P3 color space on upload -- for wide-gamut monitors whose OS does not color-manage application output, where sRGB content otherwise shows oversaturated -- see SetOutputDisplayP3"`
+	lut      [256]uint8
+	lutFor   float32
+	decLut   [256]float32 // sRGB byte -> linear, for P3 output conversion
+	decLutOk bool
+}
+
+// SetOutputDisplayP3 enables (or disables) converting the sRGB rendered
+// output into the Display-P3 color space on window upload -- use on
+// wide-gamut monitors that interpret application output as P3 (i.e., where
+// the standard sRGB UI colors appear oversaturated).  This is a per-pixel
+// conversion of every uploaded region, so it has a real cost -- only
+// enable when needed.
+func (cm *ColorMgmtState) SetOutputDisplayP3(on bool) {
+	cm.P3Output = on
+	if on && !cm.decLutOk {
+		for i := 0; i < 256; i++ {
+			cm.decLut[i] = SRGBToLinear(float32(i) / 255)
+		}
+		cm.decLutOk = true
+	}
+}
+
+// toP3 converts one sRGB-encoded pixel to Display-P3 encoding, using the
+// linear sRGB -> linear P3-D65 matrix -- P3 shares the sRGB transfer curve
+func (cm *ColorMgmtState) toP3(r, g, b uint8) (uint8, uint8, uint8) {
+	lr := cm.decLut[r]
+	lg := cm.decLut[g]
+	lb := cm.decLut[b]
+	pr := 0.8225*lr + 0.1774*lg
+	pg := 0.0332*lr + 0.9669*lg
+	pb := 0.0171*lr + 0.0724*lg + 0.9108*lb
+	er := SRGBFromLinear(pr)
+	eg := SRGBFromLinear(pg)
+	eb := SRGBFromLinear(pb)
+	return uint8(mat32.Clamp(er*255+0.5, 0, 255)), uint8(mat32.Clamp(eg*255+0.5, 0, 255)), uint8(mat32.Clamp(eb*255+0.5, 0, 255))
+}
+
+// SetGamma sets the target monitor gamma and enables correction if it
+// differs meaningfully from the sRGB standard of 2.2 -- rebuilds the
+// lookup table used for output correction
+func (cm *ColorMgmtState) SetGamma(gamma float32) {
+	cm.Gamma = gamma
+	cm.Enabled = gamma > 0 && math32.Abs(gamma-2.2) > 0.01
+	if !cm.Enabled {
+		return
+	}
+	if cm.lutFor == gamma {
+		return
+	}
+	cm.lutFor = gamma
+	exp := 2.2 / gamma // re-encode sRGB-ish 2.2 output for actual monitor response
+	for i := 0; i < 256; i++ {
+		v := math32.Pow(float32(i)/255, exp)
+		cm.lut[i] = uint8(mat32.Clamp(v*255+0.5, 0, 255))
+	}
+}
+
+// EnableFromScreen reads the ICC profile of given screen (via oswin, if
+// the platform provides profiles) and enables gamma correction for it --
+// returns an error if profiles are unavailable on this platform or the
+// profile cannot be parsed
+func (cm *ColorMgmtState) EnableFromScreen(scrN int) error {
+	sp, ok := oswin.TheApp.(oswin.ScreenColorProfiler)
+	if !ok {
+		return fmt.Errorf("gi.ColorMgmt: screen color profiles not available on this platform")
+	}
+	icc, err := sp.ScreenColorProfile(scrN)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	gamma, err := ParseICCGamma(icc)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	cm.SetGamma(gamma)
+	return nil
+}
+
+// Correct returns given window image with the enabled output corrections
+// applied over given region: color space conversion to Display-P3 (if
+// P3Output) and per-monitor gamma correction (if Enabled) -- returns the
+// input image unchanged if no correction is active.  The input is never
+// modified.
+func (cm *ColorMgmtState) Correct(img *image.RGBA, reg image.Rectangle) *image.RGBA {
+	if !cm.Enabled && !cm.P3Output {
+		return img
+	}
+	reg = reg.Intersect(img.Bounds())
+	ci := image.NewRGBA(img.Bounds())
+	for y := reg.Min.Y; y < reg.Max.Y; y++ {
+		si := img.PixOffset(reg.Min.X, y)
+		di := ci.PixOffset(reg.Min.X, y)
+		for x := reg.Min.X; x < reg.Max.X; x++ {
+			r, g, b := img.Pix[si], img.Pix[si+1], img.Pix[si+2]
+			if cm.P3Output {
+				r, g, b = cm.toP3(r, g, b)
+			}
+			if cm.Enabled {
+				r, g, b = cm.lut[r], cm.lut[g], cm.lut[b]
+			}
+			ci.Pix[di] = r
+			ci.Pix[di+1] = g
+			ci.Pix[di+2] = b
+			ci.Pix[di+3] = img.Pix[si+3]
+			si += 4
+			di += 4
+		}
+	}
+	return ci
+}
+
+// ParseICCGamma extracts the red tone reproduction curve gamma from raw
+// ICC profile data -- only simple single-gamma 'curv' profiles are
+// supported, which covers the profiles generated by standard monitor
+// calibration -- returns an error for table-based or parametric curves
+func ParseICCGamma(icc []byte) (float32, error) {
+	if len(icc) < 132 {
+		return 0, fmt.Errorf("gi.ParseICCGamma: profile too short: %v bytes", len(icc))
+	}
+	ntag := int(binary.BigEndian.Uint32(icc[128:132]))
+	for i := 0; i < ntag; i++ {
+		ti := 132 + i*12
+		if ti+12 > len(icc) {
+			break
+		}
+		if string(icc[ti:ti+4]) != "rTRC" {
+			continue
+		}
+		off := int(binary.BigEndian.Uint32(icc[ti+4 : ti+8]))
+		if off+12 > len(icc) || string(icc[off:off+4]) != "curv" {
+			return 0, fmt.Errorf("gi.ParseICCGamma: rTRC tag is not a simple curve")
+		}
+		cnt := int(binary.BigEndian.Uint32(icc[off+8 : off+12]))
+		switch cnt {
+		case 0: // identity == linear
+			return 1, nil
+		case 1: // single u8Fixed8 gamma value
+			return float32(binary.BigEndian.Uint16(icc[off+12:off+14])) / 256, nil
+		}
+		return 0, fmt.Errorf("gi.ParseICCGamma: table-based curve with %v entries not supported", cnt)
+	}
+	return 0, fmt.Errorf("gi.ParseICCGamma: no rTRC tag in profile")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  Wide-gamut color specification
+
+// SRGBFromLinear converts a linear [0-1] component to sRGB-encoded [0-1]
+func SRGBFromLinear(lin float32) float32 {
+	if lin <= 0.0031308 {
+		return lin * 12.92
+	}
+	return 1.055*math32.Pow(lin, 1/2.4) - 0.055
+}
+
+// SRGBToLinear converts an sRGB-encoded [0-1] component to linear [0-1]
+func SRGBToLinear(srgb float32) float32 {
+	if srgb <= 0.04045 {
+		return srgb / 12.92
+	}
+	return math32.Pow((srgb+0.055)/1.055, 2.4)
+}
+
+// SetLinearSRGB sets color from linear (not gamma-encoded) sRGB values in
+// the [0-1] range, with full opacity
+func (c *Color) SetLinearSRGB(r, g, b float32) {
+	c.SetNPFloat32(SRGBFromLinear(r), SRGBFromLinear(g), SRGBFromLinear(b), 1)
+}
+
+// SetDisplayP3 sets color from Display-P3 encoded values in the [0-1]
+// range, with full opacity, converting into the sRGB space that GoGi
+// renders in -- out-of-gamut colors are clipped to the sRGB gamut
+func (c *Color) SetDisplayP3(r, g, b float32) {
+	// P3 uses the same transfer curve as sRGB, so decode, apply the
+	// linear P3-D65 -> linear sRGB matrix, and re-encode
+	lr := SRGBToLinear(r)
+	lg := SRGBToLinear(g)
+	lb := SRGBToLinear(b)
+	sr := 1.2249*lr - 0.2247*lg
+	sg := -0.0420*lr + 1.0419*lg
+	sb := -0.0197*lr - 0.0786*lg + 1.0979*lb
+	sr = mat32.Clamp(sr, 0, 1)
+	sg = mat32.Clamp(sg, 0, 1)
+	sb = mat32.Clamp(sb, 0, 1)
+	c.SetLinearSRGB(sr, sg, sb)
+}
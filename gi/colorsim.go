@@ -0,0 +1,110 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"github.com/goki/ki/kit"
+)
+
+// Color-blindness simulation and WCAG contrast checking, for picking
+// accessible palettes -- used by the giv.ColorView accessibility preview.
+
+// ColorBlinds are the types of color vision deficiency that can be
+// simulated -- see Color.SimulateColorBlind
+type ColorBlinds int32
+
+const (
+	// Protanopia is missing / non-functional long-wavelength (red) cones
+	Protanopia ColorBlinds = iota
+
+	// Deuteranopia is missing / non-functional medium-wavelength (green)
+	// cones -- the most common form
+	Deuteranopia
+
+	// Tritanopia is missing / non-functional short-wavelength (blue) cones
+	Tritanopia
+
+	ColorBlindsN
+)
+
+//go:generate stringer -type=ColorBlinds
+
+var KiT_ColorBlinds = kit.Enums.AddEnumAltLower(ColorBlindsN, kit.NotBitFlag, StylePropProps, "")
+
+func (ev ColorBlinds) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *ColorBlinds) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// colorBlindMats are the Machado et al. (2009) full-severity simulation
+// matrices, applied in linear RGB
+var colorBlindMats = [ColorBlindsN][9]float32{
+	{0.152286, 1.052583, -0.204868, 0.114503, 0.786281, 0.099216, -0.003882, -0.048116, 1.051998}, // Protanopia
+	{0.367322, 0.860646, -0.227968, 0.280085, 0.672501, 0.047413, -0.011820, 0.042940, 0.968881},  // Deuteranopia
+	{1.255528, -0.076749, -0.178779, -0.078411, 0.930809, 0.147602, 0.004733, 0.691367, 0.303900}, // Tritanopia
+}
+
+// SimulateColorBlind returns this color as it would be perceived under
+// given type of color vision deficiency, using the Machado et al. (2009)
+// projection in linear RGB -- alpha is unchanged
+func (c Color) SimulateColorBlind(cb ColorBlinds) Color {
+	if cb < 0 || cb >= ColorBlindsN {
+		return c
+	}
+	m := &colorBlindMats[cb]
+	r := SRGBToLinear(float32(c.R) / 255)
+	g := SRGBToLinear(float32(c.G) / 255)
+	b := SRGBToLinear(float32(c.B) / 255)
+	sr := m[0]*r + m[1]*g + m[2]*b
+	sg := m[3]*r + m[4]*g + m[5]*b
+	sb := m[6]*r + m[7]*g + m[8]*b
+	sc := Color{}
+	sc.SetUInt8(colorCompToUint8(SRGBFromLinear(sr)), colorCompToUint8(SRGBFromLinear(sg)), colorCompToUint8(SRGBFromLinear(sb)), c.A)
+	return sc
+}
+
+// colorCompToUint8 converts a [0-1] component to uint8, clamping
+func colorCompToUint8(v float32) uint8 {
+	if v <= 0 {
+		return 0
+	}
+	if v >= 1 {
+		return 255
+	}
+	return uint8(v*255 + 0.5)
+}
+
+// RelativeLuminance returns the WCAG relative luminance of this color:
+// the weighted sum of the linearized components, 0 for black, 1 for white
+func (c Color) RelativeLuminance() float32 {
+	r := SRGBToLinear(float32(c.R) / 255)
+	g := SRGBToLinear(float32(c.G) / 255)
+	b := SRGBToLinear(float32(c.B) / 255)
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+// ContrastRatio returns the WCAG contrast ratio between two colors, in
+// the range 1 (identical) to 21 (black on white) -- order does not matter
+func ContrastRatio(a, b Color) float32 {
+	la := a.RelativeLuminance()
+	lb := b.RelativeLuminance()
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// ContrastLevel returns the WCAG conformance level for given contrast
+// ratio for normal-size text: AAA (>= 7), AA (>= 4.5), AA-large (>= 3 --
+// large text only), or fail
+func ContrastLevel(ratio float32) string {
+	switch {
+	case ratio >= 7:
+		return "AAA"
+	case ratio >= 4.5:
+		return "AA"
+	case ratio >= 3:
+		return "AA-large"
+	}
+	return "fail"
+}
@@ -31,6 +31,8 @@ type Completer interface {
 type Complete struct {
 	ki.Node
 	MatchFunc   complete.MatchFunc   `desc:"function to get the list of possible completions"`
+	AsyncFunc   CompleteAsyncFunc    `json:"-" xml:"-" desc:"asynchronous completion provider (e.g., a language server client) -- if set, used instead of MatchFunc -- see CompleteRequest"`
+	ResolveFunc CompleteResolveFunc  `json:"-" xml:"-" desc:"optional function to resolve the full details of a completion when it is selected, before it is applied"`
 	EditFunc    complete.EditFunc    `desc:"function to edit text using the selected completion"`
 	Context     interface{}          `desc:"the object that implements complete.Func"`
 	SrcLn       int                  `desc:"line number in source that completion is operating on, if relevant"`
@@ -40,6 +42,8 @@ type Complete struct {
 	CompleteSig ki.Signal            `json:"-" xml:"-" view:"-" desc:"signal for complete -- see CompleteSignals for the types"`
 	Completion  string               `desc:"the user's completion selection'"`
 	Vp          *Viewport2D          `desc:"the viewport where the current popup menu is presented"`
+	CurReq      *CompleteRequest     `json:"-" xml:"-" desc:"current in-flight asynchronous completion request, if any"`
+	ReqSeq      int64                `json:"-" xml:"-" desc:"sequence counter for asynchronous requests"`
 	DelayTimer  *time.Timer
 	DelayMu     sync.Mutex
 	ShowMu      sync.Mutex
@@ -79,7 +83,7 @@ var CompleteMaxItems = 25
 // After delay, Calls ShowNow, which calls MatchFunc
 // to get a list of completions and builds the completion popup menu
 func (c *Complete) Show(text string, posLn, posCh int, vp *Viewport2D, pt image.Point, force bool) {
-	if c.MatchFunc == nil || vp == nil || vp.Win == nil {
+	if (c.MatchFunc == nil && c.AsyncFunc == nil) || vp == nil || vp.Win == nil {
 		return
 	}
 	cpop := vp.Win.CurPopup()
@@ -111,17 +115,28 @@ func (c *Complete) Show(text string, posLn, posCh int, vp *Viewport2D, pt image.
 // ShowNow actually calls MatchFunc to get a list of completions and builds the
 // completion popup menu
 func (c *Complete) ShowNow(text string, posLn, posCh int, vp *Viewport2D, pt image.Point, force bool) {
-	if c.MatchFunc == nil || vp == nil || vp.Win == nil {
+	if (c.MatchFunc == nil && c.AsyncFunc == nil) || vp == nil || vp.Win == nil {
 		return
 	}
 	cpop := vp.Win.CurPopup()
 	if PopupIsCompleter(cpop) {
 		vp.Win.SetDelPopup(cpop)
 	}
+	if c.AsyncFunc != nil {
+		c.StartRequest(text, posLn, posCh, vp, pt, force)
+		return
+	}
+	md := c.MatchFunc(c.Context, text, posLn, posCh)
+	c.ShowMatches(md, vp, pt, force)
+}
+
+// ShowMatches displays the popup menu for given set of matches -- called with
+// the results of MatchFunc, or from CompleteRequest.Send for asynchronous
+// providers
+func (c *Complete) ShowMatches(md complete.MatchData, vp *Viewport2D, pt image.Point, force bool) {
 	c.ShowMu.Lock()
 	defer c.ShowMu.Unlock()
 	c.Vp = nil
-	md := c.MatchFunc(c.Context, text, posLn, posCh)
 	c.Completions = md.Matches
 	c.Seed = md.Seed
 	count := len(c.Completions)
@@ -152,6 +167,7 @@ func (c *Complete) ShowNow(text string, posLn, posCh int, vp *Viewport2D, pt ima
 // Cancel cancels any pending completion -- call when new events nullify prior completions
 // returns true if canceled
 func (c *Complete) Cancel() bool {
+	c.CancelRequest()
 	if c.Vp == nil || c.Vp.Win == nil {
 		return false
 	}
@@ -175,6 +191,14 @@ func (c *Complete) Cancel() bool {
 // selection from the list of possible completions
 func (c *Complete) Complete(s string) {
 	c.Cancel()
+	if c.ResolveFunc != nil {
+		for i := range c.Completions {
+			if c.Completions[i].Text == s {
+				c.Completions[i] = c.ResolveFunc(c.Context, c.Completions[i])
+				break
+			}
+		}
+	}
 	c.Completion = s
 	c.CompleteSig.Emit(c.This(), int64(CompleteSelect), s)
 }
@@ -0,0 +1,118 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"sync"
+
+	"github.com/goki/pi/complete"
+)
+
+// This file provides the asynchronous completion interface, for providers
+// such as language server (LSP) clients that compute completions off the GUI
+// thread.  Instead of the synchronous complete.MatchFunc, set
+// Complete.AsyncFunc, which receives a CompleteRequest that it can check for
+// cancellation and send (possibly partial) results to as they arrive.
+
+// CompleteAsyncFunc is the function signature for an asynchronous completion
+// provider.  It is called on its own goroutine with the current in-flight
+// request, and should send results via req.Send, checking req.Canceled or
+// selecting on req.Done to abandon work when the request is superseded.
+type CompleteAsyncFunc func(data interface{}, text string, posLn, posCh int, req *CompleteRequest)
+
+// CompleteResolveFunc resolves the full details of a completion when the
+// user selects it (e.g., the LSP completionItem/resolve call for
+// documentation and additional text edits), returning the updated
+// completion.  It is only called for the one selected item, so providers can
+// return lightweight items from matching.
+type CompleteResolveFunc func(data interface{}, cmp complete.Completion) complete.Completion
+
+// CompleteRequest represents one in-flight asynchronous completion request.
+// It is created by Complete when dispatching to AsyncFunc, and canceled
+// automatically when a newer request starts or the completion popup is
+// dismissed.
+type CompleteRequest struct {
+	Comp   *Complete   `desc:"the Complete that issued this request"`
+	Seq    int64       `desc:"sequence number of this request -- only the most recent request shows results"`
+	Text   string      `desc:"the text the request is matching against"`
+	PosLn  int         `desc:"line position in source for the request"`
+	PosCh  int         `desc:"character position in source for the request"`
+	Vp     *Viewport2D `desc:"viewport to present the popup in"`
+	Pt     image.Point `desc:"position for the popup"`
+	Force  bool        `desc:"whether the completion was forced (explicitly requested)"`
+	done   chan struct{}
+	cancel sync.Once
+}
+
+// Cancel cancels the request -- Canceled will return true and the Done
+// channel is closed.  Safe to call multiple times and from any goroutine.
+func (cr *CompleteRequest) Cancel() {
+	cr.cancel.Do(func() {
+		close(cr.done)
+	})
+}
+
+// Canceled returns true if the request has been canceled -- providers should
+// poll this between stages of work.
+func (cr *CompleteRequest) Canceled() bool {
+	select {
+	case <-cr.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Done returns a channel that is closed when the request is canceled, for
+// use in select statements or as a context-style cancellation signal.
+func (cr *CompleteRequest) Done() <-chan struct{} {
+	return cr.done
+}
+
+// Send delivers a set of matches for this request, updating the completion
+// popup if the request is still the current one.  Can be called multiple
+// times as partial results arrive -- each call replaces the displayed list.
+// Returns false if the request has been canceled or superseded.
+func (cr *CompleteRequest) Send(md complete.MatchData) bool {
+	if cr.Canceled() {
+		return false
+	}
+	c := cr.Comp
+	c.DelayMu.Lock()
+	cur := c.CurReq == cr
+	c.DelayMu.Unlock()
+	if !cur {
+		return false
+	}
+	c.ShowMatches(md, cr.Vp, cr.Pt, cr.Force)
+	return true
+}
+
+// StartRequest cancels any current request and dispatches a new one to
+// AsyncFunc on its own goroutine.  Called from ShowNow when AsyncFunc is
+// set.
+func (c *Complete) StartRequest(text string, posLn, posCh int, vp *Viewport2D, pt image.Point, force bool) {
+	c.DelayMu.Lock()
+	if c.CurReq != nil {
+		c.CurReq.Cancel()
+	}
+	c.ReqSeq++
+	req := &CompleteRequest{Comp: c, Seq: c.ReqSeq, Text: text, PosLn: posLn, PosCh: posCh, Vp: vp, Pt: pt, Force: force, done: make(chan struct{})}
+	c.CurReq = req
+	c.DelayMu.Unlock()
+	go c.AsyncFunc(c.Context, text, posLn, posCh, req)
+}
+
+// CancelRequest cancels any current asynchronous request -- called from
+// Cancel so pending provider work is abandoned when the popup is dismissed.
+func (c *Complete) CancelRequest() {
+	c.DelayMu.Lock()
+	if c.CurReq != nil {
+		c.CurReq.Cancel()
+		c.CurReq = nil
+	}
+	c.DelayMu.Unlock()
+}
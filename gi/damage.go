@@ -0,0 +1,65 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+)
+
+// DamageTracking enables dirty-rectangle batching of window uploads: node
+// re-renders accumulate their regions as damage on the viewport, and the
+// union of damaged regions is uploaded once per update pass, instead of
+// one upload per node -- reduces upload traffic for updates touching many
+// nodes in large scenes.  Off by default.
+var DamageTracking = false
+
+// DamageMaxRects is the maximum number of separate damage rects tracked
+// per viewport -- when exceeded, all damage is collapsed to a single
+// bounding rect
+var DamageMaxRects = 8
+
+// AddDamage adds given viewport-coordinate region to the accumulated
+// damage for this viewport, merging with any overlapping existing region
+// -- winOff is the offset from viewport to window coordinates, which must
+// be consistent across calls within one update pass
+func (vp *Viewport2D) AddDamage(vpBBox image.Rectangle, winOff image.Point) {
+	if vpBBox.Empty() {
+		return
+	}
+	vp.StackMu.Lock()
+	defer vp.StackMu.Unlock()
+	vp.dmgOff = winOff
+	for i, dr := range vp.DmgRects {
+		if dr.Overlaps(vpBBox) {
+			vp.DmgRects[i] = dr.Union(vpBBox)
+			return
+		}
+	}
+	vp.DmgRects = append(vp.DmgRects, vpBBox)
+	if len(vp.DmgRects) > DamageMaxRects {
+		un := vp.DmgRects[0]
+		for _, dr := range vp.DmgRects[1:] {
+			un = un.Union(dr)
+		}
+		vp.DmgRects = vp.DmgRects[:1]
+		vp.DmgRects[0] = un
+	}
+}
+
+// FlushDamage uploads all accumulated damage regions to the window and
+// clears them -- called at the end of an update pass
+func (vp *Viewport2D) FlushDamage() {
+	vp.StackMu.Lock()
+	drs := vp.DmgRects
+	off := vp.dmgOff
+	vp.DmgRects = nil
+	vp.StackMu.Unlock()
+	if len(drs) == 0 {
+		return
+	}
+	for _, dr := range drs {
+		vp.This().(Viewport).VpUploadRegion(dr, dr.Add(off))
+	}
+}
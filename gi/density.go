@@ -0,0 +1,79 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"github.com/goki/ki/kit"
+)
+
+// Densities are the overall UI density settings, which scale the default
+// margins, paddings, layout spacing, and icon sizes of all widgets
+// consistently, for data-dense professional tools -- see the global Density
+// setting, set via Prefs.Density
+type Densities int32
+
+const (
+	// DensityComfortable is the standard spacing (the default)
+	DensityComfortable Densities = iota
+
+	// DensityCompact reduces spacing to 3/4, for denser layouts
+	DensityCompact
+
+	// DensityDense reduces spacing to 1/2, for maximum information density
+	DensityDense
+
+	DensitiesN
+)
+
+//go:generate stringer -type=Densities
+
+var KiT_Densities = kit.Enums.AddEnumAltLower(DensitiesN, kit.NotBitFlag, StylePropProps, "Density")
+
+func (ev Densities) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *Densities) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// Density is the active global UI density setting -- applied to all widgets
+// during styling, scaling margins, paddings, layout spacing, and icon sizes
+// by Mul -- set via Prefs.Density and applied in Prefs.Apply -- changing it
+// at runtime requires a full style rebuild (see Prefs.UpdateAll)
+var Density = DensityComfortable
+
+// Mul returns the scaling factor applied to spacing values for this
+// density setting
+func (dn Densities) Mul() float32 {
+	switch dn {
+	case DensityCompact:
+		return 0.75
+	case DensityDense:
+		return 0.5
+	}
+	return 1
+}
+
+// ApplyDensity scales the spacing-related values of given style (margin,
+// padding) by the density factor, and for icons also the explicit sizes --
+// called at the end of Style2DWidget after all style sources are applied,
+// so it is never cumulative
+func (wb *WidgetBase) ApplyDensity() {
+	if Density == DensityComfortable {
+		return
+	}
+	mul := Density.Mul()
+	ls := &wb.Sty.Layout
+	ls.Margin.Val *= mul
+	ls.Padding.Val *= mul
+	if _, isic := wb.This().(*Icon); isic {
+		ls.Width.Val *= mul
+		ls.Height.Val *= mul
+		ls.MinWidth.Val *= mul
+		ls.MinHeight.Val *= mul
+		if ls.MaxWidth.Val > 0 { // negative = stretch factor, not a size
+			ls.MaxWidth.Val *= mul
+		}
+		if ls.MaxHeight.Val > 0 {
+			ls.MaxHeight.Val *= mul
+		}
+	}
+}
@@ -81,6 +81,7 @@ type EventMgr struct {
 	curDNDHover     *mouse.DragEvent
 	dndHoverStarted bool
 	dndHoverTimer   *time.Timer
+	HitTest         HitTestIdx `desc:"spatial index over node WinBBoxes for positional event dispatch -- see HitTestIdx"`
 }
 
 // WinEventRecv is used to hold info about widgets receiving event signals to
@@ -188,24 +189,41 @@ func (em *EventMgr) SendEventSignal(evi oswin.Event, popup bool) {
 
 		esig := &em.EventSigs[et][pri]
 
-		esig.Mu.RLock()
-		for recv, fun := range esig.Cons {
-			if recv.IsDestroyed() {
-				// fmt.Printf("ki.Signal deleting destroyed receiver: %v type %T\n", recv.Name(), recv)
-				delete(esig.Cons, recv)
-				continue
-			}
-			if recv.IsDeleted() {
-				continue
+		if em.HitTest.Active && !popup && evi.HasPos() && em.Dragging == nil && em.Scrolling == nil {
+			// positional events only go to nodes containing the position, so
+			// only consider the nodes in the hit-test tile under it, checking
+			// each for a connection, instead of scanning every receiver
+			for _, recv := range em.HitTestCands(evi.Pos()) {
+				esig.Mu.RLock()
+				fun, has := esig.Cons[recv]
+				esig.Mu.RUnlock()
+				if !has || recv.IsDestroyed() || recv.IsDeleted() {
+					continue
+				}
+				if !em.SendEventSignalFunc(evi, popup, &rvs, recv, fun) {
+					break
+				}
 			}
-			esig.Mu.RUnlock()
-			cont := em.SendEventSignalFunc(evi, popup, &rvs, recv, fun)
+		} else {
 			esig.Mu.RLock()
-			if !cont {
-				break
+			for recv, fun := range esig.Cons {
+				if recv.IsDestroyed() {
+					// fmt.Printf("ki.Signal deleting destroyed receiver: %v type %T\n", recv.Name(), recv)
+					delete(esig.Cons, recv)
+					continue
+				}
+				if recv.IsDeleted() {
+					continue
+				}
+				esig.Mu.RUnlock()
+				cont := em.SendEventSignalFunc(evi, popup, &rvs, recv, fun)
+				esig.Mu.RLock()
+				if !cont {
+					break
+				}
 			}
+			esig.Mu.RUnlock()
 		}
-		esig.Mu.RUnlock()
 
 		if len(rvs) == 0 {
 			continue
@@ -818,46 +836,29 @@ func (em *EventMgr) SetFocus(k ki.Ki) bool {
 	return true
 }
 
-// 	FocusNext sets the focus on the next item that can accept focus after the
+//	FocusNext sets the focus on the next item that can accept focus after the
+//
 // given item (can be nil) -- returns true if a focus item found.
+// Order is the FocusOrder: explicit tab-index items first, then tree order.
 func (em *EventMgr) FocusNext(foc ki.Ki) bool {
-	gotFocus := false
-	focusNext := false // get the next guy
-	if foc == nil {
-		focusNext = true
+	ord := em.FocusOrder()
+	if len(ord) == 0 {
+		return false
 	}
-
-	focRoot := em.Master.FocusTopNode()
-
-	for i := 0; i < 2; i++ {
-		focRoot.FuncDownMeFirst(0, focRoot, func(k ki.Ki, level int, d interface{}) bool {
-			if gotFocus {
-				return false
-			}
-			_, ni := KiToNode2D(k)
-			if ni == nil || ni.This() == nil {
-				return true
-			}
-			if foc == k { // current focus can be a non-can-focus item
-				focusNext = true
-				return true
-			}
-			if !focusNext {
-				return true
-			}
-			if !ni.CanFocus() {
-				return true
-			}
-			em.SetFocus(k)
-			gotFocus = true
-			return false // done
-		})
-		if gotFocus {
+	if foc != nil {
+		if ci := focusOrderIdx(ord, foc); ci >= 0 {
+			em.SetFocus(ord[(ci+1)%len(ord)])
+			return true
+		}
+		// current focus can be a non-can-focus item -- take next focusable
+		// after it in tree order
+		if nxt := em.focusAfterInTree(foc); nxt != nil {
+			em.SetFocus(nxt)
 			return true
 		}
-		focusNext = true // this time around, just get the first one
 	}
-	return gotFocus
+	em.SetFocus(ord[0])
+	return true
 }
 
 // FocusOnOrNext sets the focus on the given item, or the next one that can
@@ -897,67 +898,41 @@ func (em *EventMgr) FocusOnOrPrev(foc ki.Ki) bool {
 }
 
 // FocusPrev sets the focus on the previous item before the given item (can be nil)
+// Order is the FocusOrder: explicit tab-index items first, then tree order.
 func (em *EventMgr) FocusPrev(foc ki.Ki) bool {
 	if foc == nil { // must have a current item here
 		em.FocusLast()
 		return false
 	}
-
-	gotFocus := false
-	var prevItem ki.Ki
-
-	focRoot := em.Master.FocusTopNode()
-
-	focRoot.FuncDownMeFirst(0, focRoot, func(k ki.Ki, level int, d interface{}) bool {
-		if gotFocus {
-			return false
-		}
-		// todo: see about 3D guys
-		_, ni := KiToNode2D(k)
-		if ni == nil || ni.This() == nil {
-			return true
-		}
-		if foc == k {
-			gotFocus = true
-			return false
-		}
-		if !ni.CanFocus() {
+	ord := em.FocusOrder()
+	if len(ord) == 0 {
+		return false
+	}
+	ci := focusOrderIdx(ord, foc)
+	if ci > 0 {
+		em.SetFocus(ord[ci-1])
+		return true
+	}
+	if ci < 0 {
+		// current focus can be a non-can-focus item -- take previous
+		// focusable before it in tree order
+		if prv := em.focusBeforeInTree(foc); prv != nil {
+			em.SetFocus(prv)
 			return true
 		}
-		prevItem = k
-		return true
-	})
-	if gotFocus && prevItem != nil {
-		em.SetFocus(prevItem)
-		return true
-	} else {
-		return em.FocusLast()
 	}
+	return em.FocusLast()
 }
 
-// FocusLast sets the focus on the last item in the tree -- returns true if a
-// focusable item was found
+// FocusLast sets the focus on the last item in the focus order -- returns
+// true if a focusable item was found
 func (em *EventMgr) FocusLast() bool {
-	var lastItem ki.Ki
-
-	focRoot := em.Master.FocusTopNode()
-
-	focRoot.FuncDownMeFirst(0, focRoot, func(k ki.Ki, level int, d interface{}) bool {
-		// todo: see about 3D guys
-		_, ni := KiToNode2D(k)
-		if ni == nil || ni.This() == nil {
-			return true
-		}
-		if !ni.CanFocus() {
-			return true
-		}
-		lastItem = k
-		return true
-	})
-	em.SetFocus(lastItem)
-	if lastItem == nil {
+	ord := em.FocusOrder()
+	if len(ord) == 0 {
+		em.SetFocus(nil)
 		return false
 	}
+	em.SetFocus(ord[len(ord)-1])
 	return true
 }
 
@@ -1149,11 +1124,23 @@ func (em *EventMgr) ManagerKeyChordEvents(e *key.ChordEvent) {
 	kf := KeyFun(cs)
 	switch kf {
 	case KeyFunFocusNext: // tab
-		em.FocusNext(em.CurFocus())
+		if !em.FocusPastGroup(1) { // tab jumps over an entire focus group
+			em.FocusNext(em.CurFocus())
+		}
 		e.SetProcessed()
 	case KeyFunFocusPrev: // shift-tab
-		em.FocusPrev(em.CurFocus())
+		if !em.FocusPastGroup(-1) {
+			em.FocusPrev(em.CurFocus())
+		}
 		e.SetProcessed()
+	case KeyFunMoveDown, KeyFunMoveRight: // arrows move within a focus group
+		if em.FocusNextInGroup(1) {
+			e.SetProcessed()
+		}
+	case KeyFunMoveUp, KeyFunMoveLeft:
+		if em.FocusNextInGroup(-1) {
+			e.SetProcessed()
+		}
 	case KeyFunGoGiEditor:
 		TheViewIFace.GoGiEditor(em.Master.FocusTopNode())
 		e.SetProcessed()
@@ -0,0 +1,262 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"sort"
+
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// Deterministic keyboard navigation order: by default, Tab focus moves in
+// tree order, which for dialogs and dense forms is not always the logical
+// order.  An explicit "tab-index" property (SetTabIndex) overrides this:
+// nodes with explicit indexes come first in ascending index order, before
+// all nodes without one, which keep tree order.  A container marked as a
+// focus group (SetFocusGroup) supports arrow-key navigation among its
+// focusable members (e.g., radio groups, toolbars), with Tab / Shift+Tab
+// moving past the entire group.
+
+// SetTabIndex sets an explicit keyboard focus order index on this node,
+// via the "tab-index" property -- see FocusOrder for ordering semantics
+func (nb *Node2DBase) SetTabIndex(idx int) {
+	nb.SetProp("tab-index", idx)
+}
+
+// TabIndex returns the explicit tab order index set on this node, and
+// whether one has been set
+func (nb *Node2DBase) TabIndex() (int, bool) {
+	p := nb.Prop("tab-index")
+	if p == nil {
+		return 0, false
+	}
+	idx, ok := kit.ToInt(p)
+	if !ok {
+		return 0, false
+	}
+	return int(idx), true
+}
+
+// SetFocusGroup marks this container as a focus group, via the
+// "focus-group" property: arrow keys move focus among its focusable
+// members, and Tab / Shift+Tab move past the entire group
+func (nb *Node2DBase) SetFocusGroup() {
+	nb.SetProp("focus-group", true)
+}
+
+// IsFocusGroup returns whether this node is marked as a focus group
+func (nb *Node2DBase) IsFocusGroup() bool {
+	p := nb.Prop("focus-group")
+	if p == nil {
+		return false
+	}
+	fg, _ := kit.ToBool(p)
+	return fg
+}
+
+// ParentFocusGroup returns the nearest parent (including this node) that
+// is marked as a focus group, or nil if none
+func (nb *Node2DBase) ParentFocusGroup() *Node2DBase {
+	var grp *Node2DBase
+	nb.FuncUp(0, nb.This(), func(k ki.Ki, level int, d interface{}) bool {
+		_, ni := KiToNode2D(k)
+		if ni == nil {
+			return false
+		}
+		if ni.IsFocusGroup() {
+			grp = ni
+			return false
+		}
+		return true
+	})
+	return grp
+}
+
+// focusOrderEnt is one focusable node in the keyboard navigation order
+type focusOrderEnt struct {
+	node ki.Ki
+	idx  int  // explicit tab-index
+	has  bool // has explicit tab-index
+	tree int  // tree traversal order, for stable fallback
+}
+
+// FocusOrder returns all currently-focusable nodes under the focus root in
+// keyboard navigation order: nodes with an explicit tab-index come first
+// in ascending index order, followed by the rest in tree order
+func (em *EventMgr) FocusOrder() []ki.Ki {
+	var ents []focusOrderEnt
+	focRoot := em.Master.FocusTopNode()
+	tree := 0
+	focRoot.FuncDownMeFirst(0, focRoot, func(k ki.Ki, level int, d interface{}) bool {
+		_, ni := KiToNode2D(k)
+		if ni == nil || ni.This() == nil {
+			return true
+		}
+		tree++
+		if !ni.CanFocus() {
+			return true
+		}
+		idx, has := ni.TabIndex()
+		ents = append(ents, focusOrderEnt{node: k, idx: idx, has: has, tree: tree})
+		return true
+	})
+	sort.SliceStable(ents, func(i, j int) bool {
+		ei, ej := &ents[i], &ents[j]
+		if ei.has != ej.has {
+			return ei.has // explicit indexes first
+		}
+		if ei.has {
+			if ei.idx != ej.idx {
+				return ei.idx < ej.idx
+			}
+		}
+		return ei.tree < ej.tree
+	})
+	ord := make([]ki.Ki, len(ents))
+	for i := range ents {
+		ord[i] = ents[i].node
+	}
+	return ord
+}
+
+// focusOrderIdx returns the index of given node in given focus order, or -1
+func focusOrderIdx(ord []ki.Ki, foc ki.Ki) int {
+	for i, k := range ord {
+		if k == foc {
+			return i
+		}
+	}
+	return -1
+}
+
+// focusAfterInTree returns the next focusable node after given (typically
+// non-focusable) node in tree order, or nil if none
+func (em *EventMgr) focusAfterInTree(foc ki.Ki) ki.Ki {
+	var nxt ki.Ki
+	after := false
+	focRoot := em.Master.FocusTopNode()
+	focRoot.FuncDownMeFirst(0, focRoot, func(k ki.Ki, level int, d interface{}) bool {
+		if nxt != nil {
+			return false
+		}
+		_, ni := KiToNode2D(k)
+		if ni == nil || ni.This() == nil {
+			return true
+		}
+		if foc == k {
+			after = true
+			return true
+		}
+		if after && ni.CanFocus() {
+			nxt = k
+			return false
+		}
+		return true
+	})
+	return nxt
+}
+
+// focusBeforeInTree returns the previous focusable node before given
+// (typically non-focusable) node in tree order, or nil if none
+func (em *EventMgr) focusBeforeInTree(foc ki.Ki) ki.Ki {
+	var prv ki.Ki
+	done := false
+	focRoot := em.Master.FocusTopNode()
+	focRoot.FuncDownMeFirst(0, focRoot, func(k ki.Ki, level int, d interface{}) bool {
+		if done {
+			return false
+		}
+		_, ni := KiToNode2D(k)
+		if ni == nil || ni.This() == nil {
+			return true
+		}
+		if foc == k {
+			done = true
+			return false
+		}
+		if ni.CanFocus() {
+			prv = k
+		}
+		return true
+	})
+	if !done {
+		return nil
+	}
+	return prv
+}
+
+// FocusNextInGroup moves focus to the next (or previous, for dir < 0)
+// focusable member of the focus group containing the current focus,
+// wrapping around -- returns false if current focus is not in a focus
+// group
+func (em *EventMgr) FocusNextInGroup(dir int) bool {
+	foc := em.CurFocus()
+	if foc == nil {
+		return false
+	}
+	_, ni := KiToNode2D(foc)
+	if ni == nil {
+		return false
+	}
+	grp := ni.ParentFocusGroup()
+	if grp == nil {
+		return false
+	}
+	ord := em.FocusOrder()
+	var mems []ki.Ki
+	for _, k := range ord {
+		_, mi := KiToNode2D(k)
+		if mi != nil && mi.ParentFocusGroup() == grp {
+			mems = append(mems, k)
+		}
+	}
+	n := len(mems)
+	if n == 0 {
+		return false
+	}
+	ci := focusOrderIdx(mems, foc)
+	if ci < 0 {
+		em.SetFocus(mems[0])
+		return true
+	}
+	em.SetFocus(mems[((ci+dir)%n+n)%n])
+	return true
+}
+
+// FocusPastGroup moves focus past the focus group containing the current
+// focus: to the next focusable node outside the group for dir > 0, or the
+// previous one for dir < 0, wrapping around -- returns false if current
+// focus is not in a focus group
+func (em *EventMgr) FocusPastGroup(dir int) bool {
+	foc := em.CurFocus()
+	if foc == nil {
+		return false
+	}
+	_, ni := KiToNode2D(foc)
+	if ni == nil {
+		return false
+	}
+	grp := ni.ParentFocusGroup()
+	if grp == nil {
+		return false
+	}
+	ord := em.FocusOrder()
+	n := len(ord)
+	ci := focusOrderIdx(ord, foc)
+	if ci < 0 || n == 0 {
+		return false
+	}
+	for i := 1; i <= n; i++ {
+		k := ord[((ci+i*dir)%n+n)%n]
+		_, mi := KiToNode2D(k)
+		if mi == nil || mi.ParentFocusGroup() == grp {
+			continue
+		}
+		em.SetFocus(k)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/key"
+)
+
+// Global hotkeys: system-wide key chords that activate functions in this
+// app even when it is not focused, for launcher / utility style apps.
+// Platform support comes from the optional oswin.GlobalHotkeyer interface
+// -- not all platforms permit global hotkeys (e.g., Wayland does not).
+// Bindings are saved to / loaded from the GoGi prefs directory, and can be
+// edited interactively via EditGlobalHotkeys.
+
+// GlobalHotkey is one system-wide hotkey binding: a name identifying the
+// action, and the key chord that triggers it -- the function to run is
+// registered separately under the same name via SetGlobalHotkeyFunc, so
+// chords can be edited and persisted independent of code.
+type GlobalHotkey struct {
+	Name  string    `width:"30" desc:"name of the action this hotkey triggers -- must match the name used in SetGlobalHotkeyFunc"`
+	Chord key.Chord `width:"20" desc:"key chord that triggers the action, system-wide"`
+}
+
+// GlobalHotkeys is an ordered list of global hotkey bindings
+type GlobalHotkeys []GlobalHotkey
+
+// AvailGlobalHotkeys is the list of global hotkey bindings for this app,
+// loaded from prefs and edited via EditGlobalHotkeys
+var AvailGlobalHotkeys GlobalHotkeys
+
+// AvailGlobalHotkeysChanged is used to update the global hotkeys editor
+// save menu -- set to true when bindings are edited
+var AvailGlobalHotkeysChanged = false
+
+// GlobalHotkeyFuncs are the action functions for global hotkeys, by name
+// -- see SetGlobalHotkeyFunc
+var GlobalHotkeyFuncs = map[string]func(){}
+
+// PrefsGlobalHotkeysFileName is the name of the preferences file in GoGi
+// prefs directory for saving / loading AvailGlobalHotkeys
+var PrefsGlobalHotkeysFileName = "global_hotkeys_prefs.json"
+
+// ChordInUse returns the name of the binding using given chord, if any --
+// used for conflict detection
+func (gh *GlobalHotkeys) ChordInUse(chord key.Chord) (string, bool) {
+	for _, hk := range *gh {
+		if hk.Chord == chord {
+			return hk.Name, true
+		}
+	}
+	return "", false
+}
+
+// Add adds a binding for given name and chord, returning an error if the
+// chord is already bound to another action (conflict detection) -- does
+// not activate it -- see Activate
+func (gh *GlobalHotkeys) Add(name string, chord key.Chord) error {
+	if nm, used := gh.ChordInUse(chord); used && nm != name {
+		return fmt.Errorf("gi.GlobalHotkeys: chord %v already bound to: %v", chord, nm)
+	}
+	for i := range *gh {
+		if (*gh)[i].Name == name {
+			(*gh)[i].Chord = chord
+			return nil
+		}
+	}
+	*gh = append(*gh, GlobalHotkey{Name: name, Chord: chord})
+	return nil
+}
+
+// Delete removes the binding for given name, returning true if found
+func (gh *GlobalHotkeys) Delete(name string) bool {
+	for i, hk := range *gh {
+		if hk.Name == name {
+			*gh = append((*gh)[:i], (*gh)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// OpenJSON opens global hotkeys from a JSON-formatted file.
+func (gh *GlobalHotkeys) OpenJSON(filename FileName) error {
+	b, err := ioutil.ReadFile(string(filename))
+	if err != nil {
+		return err
+	}
+	*gh = make(GlobalHotkeys, 0, 10) // reset
+	return json.Unmarshal(b, gh)
+}
+
+// SaveJSON saves global hotkeys to a JSON-formatted file.
+func (gh *GlobalHotkeys) SaveJSON(filename FileName) error {
+	b, err := json.MarshalIndent(gh, "", "  ")
+	if err != nil {
+		log.Println(err) // unlikely
+		return err
+	}
+	err = ioutil.WriteFile(string(filename), b, 0644)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// OpenPrefs opens GlobalHotkeys from GoGi standard prefs directory, using
+// PrefsGlobalHotkeysFileName
+func (gh *GlobalHotkeys) OpenPrefs() error {
+	pdir := oswin.TheApp.GoGiPrefsDir()
+	pnm := filepath.Join(pdir, PrefsGlobalHotkeysFileName)
+	AvailGlobalHotkeysChanged = false
+	return gh.OpenJSON(FileName(pnm))
+}
+
+// SavePrefs saves GlobalHotkeys to GoGi standard prefs directory, using
+// PrefsGlobalHotkeysFileName
+func (gh *GlobalHotkeys) SavePrefs() error {
+	pdir := oswin.TheApp.GoGiPrefsDir()
+	pnm := filepath.Join(pdir, PrefsGlobalHotkeysFileName)
+	AvailGlobalHotkeysChanged = false
+	return gh.SaveJSON(FileName(pnm))
+}
+
+// Activate registers all bindings that have a function in
+// GlobalHotkeyFuncs with the oswin driver, unregistering any prior
+// registrations first -- call after loading prefs or editing bindings --
+// returns false if the current oswin driver does not support global
+// hotkeys; per-binding registration errors are logged.
+func (gh *GlobalHotkeys) Activate() bool {
+	ghk, ok := oswin.TheApp.(oswin.GlobalHotkeyer)
+	if !ok {
+		return false
+	}
+	for nm := range GlobalHotkeyFuncs {
+		ghk.UnregisterGlobalHotkey(nm)
+	}
+	oswin.SetGlobalHotkeyFunc(RunGlobalHotkeyFunc)
+	for _, hk := range *gh {
+		if _, has := GlobalHotkeyFuncs[hk.Name]; !has {
+			continue
+		}
+		if err := ghk.RegisterGlobalHotkey(hk.Name, string(hk.Chord)); err != nil {
+			log.Println(err)
+		}
+	}
+	return true
+}
+
+// SetGlobalHotkeyFunc registers the function for given action name, and
+// adds a binding with given default chord if none exists yet in
+// AvailGlobalHotkeys (a prefs-loaded chord takes precedence) -- call
+// Activate after all functions are registered.
+func SetGlobalHotkeyFunc(name string, defChord key.Chord, fun func()) error {
+	GlobalHotkeyFuncs[name] = fun
+	for _, hk := range AvailGlobalHotkeys {
+		if hk.Name == name {
+			return nil
+		}
+	}
+	return AvailGlobalHotkeys.Add(name, defChord)
+}
+
+// RunGlobalHotkeyFunc runs the function for given action name, if
+// registered -- this is the oswin-level dispatch handler
+func RunGlobalHotkeyFunc(name string) {
+	if fun, has := GlobalHotkeyFuncs[name]; has && fun != nil {
+		fun()
+	}
+}
+
+// EditGlobalHotkeys opens an interactive editor of the global hotkey
+// bindings, with conflict detection and saving to prefs
+func EditGlobalHotkeys() {
+	TheViewIFace.GlobalHotkeysView(&AvailGlobalHotkeys)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"sync"
+	"sync/atomic"
+
+	"github.com/goki/ki/ki"
+)
+
+// Hit-test acceleration for event dispatch: positional events (mouse moves
+// in particular) are dispatched by testing the event position against the
+// WinBBox of every connected receiver, which hitches on windows with tens
+// of thousands of nodes.  HitTestIdx is a uniform tile grid over the
+// window, rebuilt lazily from the tree's WinBBoxes whenever geometry has
+// changed (every SetWinBBox invalidates it), so that positional dispatch
+// only considers the handful of nodes overlapping the tile under the event
+// position.  It is only activated for Window event managers -- embedded
+// masters (e.g., gi3d.EmbedViewport) keep the exhaustive scan, as their
+// node geometry updates outside the 2D layout pass.
+
+// HitTestTileSize is the tile edge size in pixels for the event dispatch
+// hit-test grid -- see HitTestIdx
+var HitTestTileSize = 128
+
+// HitTestIdx is a tile grid spatial index over node WinBBoxes, used by the
+// EventMgr to find candidate receivers for positional events without
+// scanning every connected node -- see file documentation
+type HitTestIdx struct {
+	Active bool            `desc:"whether this index is in use -- set by the Window for its event manager"`
+	Bounds image.Rectangle `desc:"union of indexed WinBBoxes, anchoring the tile grid"`
+	Nx     int             `desc:"number of tile columns"`
+	Ny     int             `desc:"number of tile rows"`
+	Tiles  [][]ki.Ki       `desc:"per-tile nodes whose WinBBox overlaps the tile, in tree order"`
+	Mu     sync.Mutex      `desc:"protects rebuilds of the grid"`
+	valid  int32           // atomic: 0 = needs rebuild
+}
+
+// Invalidate marks the index as stale, so it is rebuilt from the tree on
+// the next positional event -- called from SetWinBBox whenever any node's
+// window geometry changes -- safe to call from any goroutine
+func (ht *HitTestIdx) Invalidate() {
+	atomic.StoreInt32(&ht.valid, 0)
+}
+
+// IsValid returns whether the index is current
+func (ht *HitTestIdx) IsValid() bool {
+	return atomic.LoadInt32(&ht.valid) != 0
+}
+
+// hitTestEnt is one node collected during the index build
+type hitTestEnt struct {
+	node ki.Ki
+	bbox image.Rectangle
+}
+
+// Build rebuilds the tile grid from the WinBBoxes of all nodes under given
+// top node (the event master's top node) -- nodes with an empty WinBBox
+// are not indexed, as they cannot contain any position
+func (ht *HitTestIdx) Build(top ki.Ki) {
+	ht.Mu.Lock()
+	defer ht.Mu.Unlock()
+	var ents []hitTestEnt
+	bnds := image.ZR
+	top.FuncDownMeFirst(0, top, func(k ki.Ki, level int, d interface{}) bool {
+		gni, ok := k.(Node)
+		if !ok || k.This() == nil {
+			return true
+		}
+		gn := gni.AsGiNode()
+		if gn.WinBBox.Empty() {
+			return true
+		}
+		ents = append(ents, hitTestEnt{node: k, bbox: gn.WinBBox})
+		bnds = bnds.Union(gn.WinBBox)
+		return true
+	})
+	ht.Bounds = bnds
+	ht.Nx = (bnds.Dx() + HitTestTileSize - 1) / HitTestTileSize
+	ht.Ny = (bnds.Dy() + HitTestTileSize - 1) / HitTestTileSize
+	ht.Tiles = make([][]ki.Ki, ht.Nx*ht.Ny)
+	for _, ent := range ents {
+		tb := ent.bbox.Sub(bnds.Min)
+		x0 := tb.Min.X / HitTestTileSize
+		y0 := tb.Min.Y / HitTestTileSize
+		x1 := (tb.Max.X - 1) / HitTestTileSize
+		y1 := (tb.Max.Y - 1) / HitTestTileSize
+		for y := y0; y <= y1; y++ {
+			for x := x0; x <= x1; x++ {
+				ti := y*ht.Nx + x
+				ht.Tiles[ti] = append(ht.Tiles[ti], ent.node)
+			}
+		}
+	}
+	atomic.StoreInt32(&ht.valid, 1)
+}
+
+// Pick returns the candidate nodes whose WinBBox could contain given
+// window position: the tile under the position, in tree order -- nil if
+// the position is outside all indexed boxes
+func (ht *HitTestIdx) Pick(pos image.Point) []ki.Ki {
+	if !pos.In(ht.Bounds) {
+		return nil
+	}
+	tp := pos.Sub(ht.Bounds.Min)
+	ti := (tp.Y/HitTestTileSize)*ht.Nx + tp.X/HitTestTileSize
+	if ti < 0 || ti >= len(ht.Tiles) {
+		return nil
+	}
+	return ht.Tiles[ti]
+}
+
+// HitTestCands returns candidate receivers for a positional event at given
+// window position, rebuilding the index from the tree first if geometry
+// has changed since the last build
+func (em *EventMgr) HitTestCands(pos image.Point) []ki.Ki {
+	if !em.HitTest.IsValid() {
+		em.HitTest.Build(em.Master.EventTopNode())
+	}
+	return em.HitTest.Pick(pos)
+}
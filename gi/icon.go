@@ -7,6 +7,7 @@ package gi
 import (
 	"image"
 	"image/color"
+	"time"
 
 	"github.com/goki/gi/mat32"
 	"github.com/goki/ki/ki"
@@ -90,6 +91,64 @@ func (ic *Icon) SetIcon(name string) (bool, error) {
 	return false, err
 }
 
+// IconAnimMSec is the duration in milliseconds of animated icon state
+// transitions in SetStateAnimated
+var IconAnimMSec = 150
+
+// SetStateAnimated sets the icon by name as in SetIcon, but animates a short
+// morph from the current icon to the new one when both are set and their SVG
+// path structures match -- i.e., two-state icon pairs such as play/pause or
+// hamburger/close that are drawn with the same commands -- otherwise the
+// switch is instant
+func (ic *Icon) SetStateAnimated(name string) (bool, error) {
+	from := ic.UniqueNm
+	if IconAnimMSec <= 0 || ReducedMotion || ic.Viewport == nil || !ic.HasChildren() ||
+		IconName(from).IsNil() || from == name || IconName(name).IsNil() || !IconName(name).IsValid() {
+		return ic.SetIcon(name)
+	}
+	if TheIconMgr.InterpIcons(ic, from, name, 0) != nil { // structures don't match
+		return ic.SetIcon(name)
+	}
+	ic.UniqueNm = name // we are the new icon now, rendering the morph
+	go ic.AnimState(from, name)
+	return true, nil
+}
+
+// AnimState runs the morph animation between two named icons -- runs in its
+// own goroutine, started by SetStateAnimated
+func (ic *Icon) AnimState(from, to string) {
+	start := time.Now()
+	dur := time.Duration(IconAnimMSec) * time.Millisecond
+	tick := time.NewTicker(time.Second / 60)
+	defer tick.Stop()
+	for now := range tick.C {
+		if ic.This() == nil || ic.IsDestroyed() || ic.IsDeleted() {
+			return
+		}
+		pct := float32(now.Sub(start)) / float32(dur)
+		done := pct >= 1
+		if done {
+			pct = 1
+		}
+		pct = pct * pct * (3 - 2*pct) // smooth step ease
+		updt := ic.UpdateStart()
+		err := TheIconMgr.InterpIcons(ic, from, to, pct)
+		ic.SetFullReRender()
+		ic.UpdateEnd(updt)
+		if err != nil || done {
+			break
+		}
+	}
+	if ic.This() == nil || ic.IsDestroyed() || ic.IsDeleted() {
+		return
+	}
+	updt := ic.UpdateStart() // end on a pristine copy of the target icon
+	ic.UniqueNm = ""
+	ic.SetIcon(to)
+	ic.SetFullReRender()
+	ic.UpdateEnd(updt)
+}
+
 // SVGIcon returns the child svg icon, or nil
 func (ic *Icon) SVGIcon() *Viewport2D {
 	if !ic.HasChildren() {
@@ -169,6 +228,12 @@ type IconMgr interface {
 	// IconList returns the list of available icon names, optionally sorted
 	// alphabetically (otherwise in map-random order)
 	IconList(alphaSort bool) []IconName
+
+	// InterpIcons sets the rendered paths of ic to an interpolation pct
+	// (0 = all from, 1 = all to) between two named icons, which must have
+	// matching structure and path commands -- used for animated two-state
+	// icon transitions -- returns error if the structures do not match
+	InterpIcons(ic *Icon, fromName, toName string, pct float32) error
 }
 
 // TheIconMgr is set by loading the gi/svg package -- all final users must
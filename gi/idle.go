@@ -0,0 +1,107 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"sync"
+	"time"
+
+	"github.com/goki/ki/ki"
+)
+
+// TheUserIdle is the global user-idle monitor -- connect to IdleSig /
+// ResumeSig to implement auto-lock screens, presence status, or pausing of
+// expensive background refresh.  Idle detection is across all app windows:
+// any mouse, key, or touch input in any window counts as activity.
+var TheUserIdle UserIdleMon
+
+func init() {
+	TheUserIdle.InitName(&TheUserIdle, "user-idle")
+	TheUserIdle.Timeout = 5 * time.Minute
+}
+
+// UserIdleMon monitors user input across all app windows and signals when
+// the user has been idle (no input) for Timeout, and again when input
+// resumes.  It is driven by the window event loops, so it needs no polling
+// of its own.
+type UserIdleMon struct {
+	ki.Node
+	Timeout   time.Duration `desc:"how long without input before the user is considered idle -- default 5 minutes"`
+	LastInput time.Time     `desc:"time of the last user input event in any window"`
+	Idle      bool          `desc:"whether the user is currently considered idle"`
+	IdleSig   ki.Signal     `copy:"-" json:"-" xml:"-" view:"-" desc:"emitted once when the user becomes idle -- data is the Timeout as time.Duration"`
+	ResumeSig ki.Signal     `copy:"-" json:"-" xml:"-" view:"-" desc:"emitted when input resumes after an idle period -- data is how long the user was idle as time.Duration"`
+	Mu        sync.Mutex    `copy:"-" json:"-" xml:"-" view:"-" desc:"mutex protecting the monitor state"`
+	timer     *time.Timer
+	idleStart time.Time
+}
+
+// SetTimeout sets the idle timeout and restarts the countdown from now
+func (im *UserIdleMon) SetTimeout(timeout time.Duration) {
+	im.Mu.Lock()
+	im.Timeout = timeout
+	im.resetTimer()
+	im.Mu.Unlock()
+}
+
+// InputActivity records a user input event -- called by the window event
+// loops for every mouse, key, and touch event.  If the user was idle, emits
+// ResumeSig with the idle duration.
+func (im *UserIdleMon) InputActivity() {
+	im.Mu.Lock()
+	now := time.Now()
+	im.LastInput = now
+	wasIdle := im.Idle
+	var idleDur time.Duration
+	if wasIdle {
+		im.Idle = false
+		idleDur = now.Sub(im.idleStart)
+	}
+	im.resetTimer()
+	im.Mu.Unlock()
+	if wasIdle {
+		im.ResumeSig.Emit(im.This(), 0, idleDur)
+	}
+}
+
+// resetTimer restarts the idle countdown -- must be called under Mu
+func (im *UserIdleMon) resetTimer() {
+	if im.Timeout <= 0 {
+		return
+	}
+	if im.timer == nil {
+		im.timer = time.AfterFunc(im.Timeout, im.timerFired)
+	} else {
+		im.timer.Reset(im.Timeout)
+	}
+}
+
+// timerFired checks whether the timeout has really elapsed since the last
+// input, and either signals idleness or re-arms for the remainder
+func (im *UserIdleMon) timerFired() {
+	im.Mu.Lock()
+	if im.Idle || im.Timeout <= 0 {
+		im.Mu.Unlock()
+		return
+	}
+	elapsed := time.Since(im.LastInput)
+	if elapsed < im.Timeout {
+		im.timer.Reset(im.Timeout - elapsed)
+		im.Mu.Unlock()
+		return
+	}
+	im.Idle = true
+	im.idleStart = im.LastInput
+	timeout := im.Timeout
+	im.Mu.Unlock()
+	im.IdleSig.Emit(im.This(), 0, timeout)
+}
+
+// IsIdle returns whether the user is currently considered idle
+func (im *UserIdleMon) IsIdle() bool {
+	im.Mu.Lock()
+	defer im.Mu.Unlock()
+	return im.Idle
+}
@@ -83,6 +83,12 @@ const (
 	KeyFunHistPrev
 	KeyFunHistNext
 	KeyFunWinFocusNext
+	KeyFunAddCursorAbove // add an extra editing cursor on line above (multi-cursor editing)
+	KeyFunAddCursorBelow // add an extra editing cursor on line below (multi-cursor editing)
+	KeyFunFoldToggle     // fold / unfold the code folding region at cursor
+	KeyFunFoldAll        // fold all foldable regions
+	KeyFunUnfoldAll      // unfold all folded regions
+	KeyFunPresent        // toggle present mode: screencast annotations for clicks / keys
 	// Below are menu specific functions -- use these as shortcuts for menu actions
 	// allows uniqueness of mapping and easy customization of all key actions
 	KeyFunMenuNew
@@ -578,6 +584,7 @@ var StdKeyMaps = KeyMaps{
 		"Control+-":               KeyFunZoomOut,
 		"Shift+Control+_":         KeyFunZoomOut,
 		"Control+Alt+P":           KeyFunPrefs,
+		"Control+Alt+K":           KeyFunPresent,
 		"F5":                      KeyFunRefresh,
 		"Control+L":               KeyFunRecenter,
 		"Control+.":               KeyFunComplete,
@@ -585,6 +592,11 @@ var StdKeyMaps = KeyMaps{
 		"Meta+F":                  KeyFunFind,
 		"Meta+R":                  KeyFunReplace,
 		"Control+J":               KeyFunJump,
+		"Control+Alt+UpArrow":     KeyFunAddCursorAbove,
+		"Control+Alt+DownArrow":   KeyFunAddCursorBelow,
+		"Control+Alt+[":           KeyFunFoldToggle,
+		"Control+Alt+-":           KeyFunFoldAll,
+		"Control+Alt+]":           KeyFunUnfoldAll,
 		"Control+[":               KeyFunHistPrev,
 		"Control+]":               KeyFunHistNext,
 		"Meta+[":                  KeyFunHistPrev,
@@ -707,6 +719,7 @@ var StdKeyMaps = KeyMaps{
 		"Control+-":               KeyFunZoomOut,
 		"Shift+Control+_":         KeyFunZoomOut,
 		"Control+Alt+P":           KeyFunPrefs,
+		"Control+Alt+K":           KeyFunPresent,
 		"F5":                      KeyFunRefresh,
 		"Control+L":               KeyFunRecenter,
 		"Control+.":               KeyFunComplete,
@@ -715,6 +728,11 @@ var StdKeyMaps = KeyMaps{
 		"Meta+R":                  KeyFunReplace,
 		"Control+R":               KeyFunReplace,
 		"Control+J":               KeyFunJump,
+		"Control+Alt+UpArrow":     KeyFunAddCursorAbove,
+		"Control+Alt+DownArrow":   KeyFunAddCursorBelow,
+		"Control+Alt+[":           KeyFunFoldToggle,
+		"Control+Alt+-":           KeyFunFoldAll,
+		"Control+Alt+]":           KeyFunUnfoldAll,
 		"Control+[":               KeyFunHistPrev,
 		"Control+]":               KeyFunHistNext,
 		"Meta+[":                  KeyFunHistPrev,
@@ -825,6 +843,7 @@ var StdKeyMaps = KeyMaps{
 		"Control+-":               KeyFunZoomOut,
 		"Shift+Control+_":         KeyFunZoomOut,
 		"Control+Alt+P":           KeyFunPrefs,
+		"Control+Alt+K":           KeyFunPresent,
 		"F5":                      KeyFunRefresh,
 		"Control+L":               KeyFunRecenter,
 		"Control+.":               KeyFunComplete,
@@ -832,6 +851,11 @@ var StdKeyMaps = KeyMaps{
 		"Alt+F":                   KeyFunFind,
 		"Control+R":               KeyFunReplace,
 		"Control+J":               KeyFunJump,
+		"Control+Alt+UpArrow":     KeyFunAddCursorAbove,
+		"Control+Alt+DownArrow":   KeyFunAddCursorBelow,
+		"Control+Alt+[":           KeyFunFoldToggle,
+		"Control+Alt+-":           KeyFunFoldAll,
+		"Control+Alt+]":           KeyFunUnfoldAll,
 		"Control+[":               KeyFunHistPrev,
 		"Control+]":               KeyFunHistNext,
 		"Alt+F6":                  KeyFunWinFocusNext,
@@ -908,6 +932,7 @@ var StdKeyMaps = KeyMaps{
 		"Shift+Control+_":         KeyFunZoomOut,
 		"Shift+Control+P":         KeyFunPrefs,
 		"Control+Alt+P":           KeyFunPrefs,
+		"Control+Alt+K":           KeyFunPresent,
 		"F5":                      KeyFunRefresh,
 		"Control+L":               KeyFunRecenter,
 		"Control+.":               KeyFunComplete,
@@ -916,6 +941,11 @@ var StdKeyMaps = KeyMaps{
 		"Control+H":               KeyFunReplace,
 		"Control+R":               KeyFunReplace,
 		"Control+J":               KeyFunJump,
+		"Control+Alt+UpArrow":     KeyFunAddCursorAbove,
+		"Control+Alt+DownArrow":   KeyFunAddCursorBelow,
+		"Control+Alt+[":           KeyFunFoldToggle,
+		"Control+Alt+-":           KeyFunFoldAll,
+		"Control+Alt+]":           KeyFunUnfoldAll,
 		"Control+[":               KeyFunHistPrev,
 		"Control+]":               KeyFunHistNext,
 		"Control+N":               KeyFunMenuNew,
@@ -992,6 +1022,7 @@ var StdKeyMaps = KeyMaps{
 		"Shift+Control+_":         KeyFunZoomOut,
 		"Shift+Control+P":         KeyFunPrefs,
 		"Control+Alt+P":           KeyFunPrefs,
+		"Control+Alt+K":           KeyFunPresent,
 		"F5":                      KeyFunRefresh,
 		"Control+L":               KeyFunRecenter,
 		"Control+.":               KeyFunComplete,
@@ -1000,6 +1031,11 @@ var StdKeyMaps = KeyMaps{
 		"Control+H":               KeyFunReplace,
 		"Control+R":               KeyFunReplace,
 		"Control+J":               KeyFunJump,
+		"Control+Alt+UpArrow":     KeyFunAddCursorAbove,
+		"Control+Alt+DownArrow":   KeyFunAddCursorBelow,
+		"Control+Alt+[":           KeyFunFoldToggle,
+		"Control+Alt+-":           KeyFunFoldAll,
+		"Control+Alt+]":           KeyFunUnfoldAll,
 		"Control+[":               KeyFunHistPrev,
 		"Control+]":               KeyFunHistNext,
 		"Alt+F6":                  KeyFunWinFocusNext,
@@ -1076,6 +1112,7 @@ var StdKeyMaps = KeyMaps{
 		"Shift+Control+_":         KeyFunZoomOut,
 		"Shift+Control+P":         KeyFunPrefs,
 		"Control+Alt+P":           KeyFunPrefs,
+		"Control+Alt+K":           KeyFunPresent,
 		"F5":                      KeyFunRefresh,
 		"Control+L":               KeyFunRecenter,
 		"Control+.":               KeyFunComplete,
@@ -1084,6 +1121,11 @@ var StdKeyMaps = KeyMaps{
 		"Control+H":               KeyFunReplace,
 		"Control+R":               KeyFunReplace,
 		"Control+J":               KeyFunJump,
+		"Control+Alt+UpArrow":     KeyFunAddCursorAbove,
+		"Control+Alt+DownArrow":   KeyFunAddCursorBelow,
+		"Control+Alt+[":           KeyFunFoldToggle,
+		"Control+Alt+-":           KeyFunFoldAll,
+		"Control+Alt+]":           KeyFunUnfoldAll,
 		"Control+[":               KeyFunHistPrev,
 		"Control+]":               KeyFunHistNext,
 		"Alt+F6":                  KeyFunWinFocusNext,
@@ -63,24 +63,30 @@ func _() {
 	_ = x[KeyFunHistPrev-47]
 	_ = x[KeyFunHistNext-48]
 	_ = x[KeyFunWinFocusNext-49]
-	_ = x[KeyFunMenuNew-50]
-	_ = x[KeyFunMenuNewAlt1-51]
-	_ = x[KeyFunMenuNewAlt2-52]
-	_ = x[KeyFunMenuOpen-53]
-	_ = x[KeyFunMenuOpenAlt1-54]
-	_ = x[KeyFunMenuOpenAlt2-55]
-	_ = x[KeyFunMenuSave-56]
-	_ = x[KeyFunMenuSaveAs-57]
-	_ = x[KeyFunMenuSaveAlt-58]
-	_ = x[KeyFunMenuClose-59]
-	_ = x[KeyFunMenuCloseAlt1-60]
-	_ = x[KeyFunMenuCloseAlt2-61]
-	_ = x[KeyFunsN-62]
+	_ = x[KeyFunAddCursorAbove-50]
+	_ = x[KeyFunAddCursorBelow-51]
+	_ = x[KeyFunFoldToggle-52]
+	_ = x[KeyFunFoldAll-53]
+	_ = x[KeyFunUnfoldAll-54]
+	_ = x[KeyFunPresent-55]
+	_ = x[KeyFunMenuNew-56]
+	_ = x[KeyFunMenuNewAlt1-57]
+	_ = x[KeyFunMenuNewAlt2-58]
+	_ = x[KeyFunMenuOpen-59]
+	_ = x[KeyFunMenuOpenAlt1-60]
+	_ = x[KeyFunMenuOpenAlt2-61]
+	_ = x[KeyFunMenuSave-62]
+	_ = x[KeyFunMenuSaveAs-63]
+	_ = x[KeyFunMenuSaveAlt-64]
+	_ = x[KeyFunMenuClose-65]
+	_ = x[KeyFunMenuCloseAlt1-66]
+	_ = x[KeyFunMenuCloseAlt2-67]
+	_ = x[KeyFunsN-68]
 }
 
-const _KeyFuns_name = "KeyFunNilKeyFunMoveUpKeyFunMoveDownKeyFunMoveRightKeyFunMoveLeftKeyFunPageUpKeyFunPageDownKeyFunHomeKeyFunEndKeyFunDocHomeKeyFunDocEndKeyFunWordRightKeyFunWordLeftKeyFunFocusNextKeyFunFocusPrevKeyFunEnterKeyFunAcceptKeyFunCancelSelectKeyFunSelectModeKeyFunSelectAllKeyFunAbortKeyFunCopyKeyFunCutKeyFunPasteKeyFunPasteHistKeyFunBackspaceKeyFunBackspaceWordKeyFunDeleteKeyFunDeleteWordKeyFunKillKeyFunDuplicateKeyFunUndoKeyFunRedoKeyFunInsertKeyFunInsertAfterKeyFunGoGiEditorKeyFunWinSnapshotKeyFunZoomOutKeyFunZoomInKeyFunPrefsKeyFunRefreshKeyFunRecenterKeyFunCompleteKeyFunSearchKeyFunFindKeyFunReplaceKeyFunJumpKeyFunHistPrevKeyFunHistNextKeyFunWinFocusNextKeyFunMenuNewKeyFunMenuNewAlt1KeyFunMenuNewAlt2KeyFunMenuOpenKeyFunMenuOpenAlt1KeyFunMenuOpenAlt2KeyFunMenuSaveKeyFunMenuSaveAsKeyFunMenuSaveAltKeyFunMenuCloseKeyFunMenuCloseAlt1KeyFunMenuCloseAlt2KeyFunsN"
+const _KeyFuns_name = "KeyFunNilKeyFunMoveUpKeyFunMoveDownKeyFunMoveRightKeyFunMoveLeftKeyFunPageUpKeyFunPageDownKeyFunHomeKeyFunEndKeyFunDocHomeKeyFunDocEndKeyFunWordRightKeyFunWordLeftKeyFunFocusNextKeyFunFocusPrevKeyFunEnterKeyFunAcceptKeyFunCancelSelectKeyFunSelectModeKeyFunSelectAllKeyFunAbortKeyFunCopyKeyFunCutKeyFunPasteKeyFunPasteHistKeyFunBackspaceKeyFunBackspaceWordKeyFunDeleteKeyFunDeleteWordKeyFunKillKeyFunDuplicateKeyFunUndoKeyFunRedoKeyFunInsertKeyFunInsertAfterKeyFunGoGiEditorKeyFunWinSnapshotKeyFunZoomOutKeyFunZoomInKeyFunPrefsKeyFunRefreshKeyFunRecenterKeyFunCompleteKeyFunSearchKeyFunFindKeyFunReplaceKeyFunJumpKeyFunHistPrevKeyFunHistNextKeyFunWinFocusNextKeyFunAddCursorAboveKeyFunAddCursorBelowKeyFunFoldToggleKeyFunFoldAllKeyFunUnfoldAllKeyFunPresentKeyFunMenuNewKeyFunMenuNewAlt1KeyFunMenuNewAlt2KeyFunMenuOpenKeyFunMenuOpenAlt1KeyFunMenuOpenAlt2KeyFunMenuSaveKeyFunMenuSaveAsKeyFunMenuSaveAltKeyFunMenuCloseKeyFunMenuCloseAlt1KeyFunMenuCloseAlt2KeyFunsN"
 
-var _KeyFuns_index = [...]uint16{0, 9, 21, 35, 50, 64, 76, 90, 100, 109, 122, 134, 149, 163, 178, 193, 204, 216, 234, 250, 265, 276, 286, 295, 306, 321, 336, 355, 367, 383, 393, 408, 418, 428, 440, 457, 473, 490, 503, 515, 526, 539, 553, 567, 579, 589, 602, 612, 626, 640, 658, 671, 688, 705, 719, 737, 755, 769, 785, 802, 817, 836, 855, 863}
+var _KeyFuns_index = [...]uint16{0, 9, 21, 35, 50, 64, 76, 90, 100, 109, 122, 134, 149, 163, 178, 193, 204, 216, 234, 250, 265, 276, 286, 295, 306, 321, 336, 355, 367, 383, 393, 408, 418, 428, 440, 457, 473, 490, 503, 515, 526, 539, 553, 567, 579, 589, 602, 612, 626, 640, 658, 678, 698, 714, 727, 742, 755, 768, 785, 802, 816, 834, 852, 866, 882, 899, 914, 933, 952, 960}
 
 func (i KeyFuns) String() string {
 	if i < 0 || i >= KeyFuns(len(_KeyFuns_index)-1) {
@@ -0,0 +1,98 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"github.com/goki/gi/oswin"
+)
+
+// TheKiosk is the global kiosk mode manager -- see KioskMgr.Start
+var TheKiosk KioskMgr
+
+// KioskMgr implements kiosk mode for dedicated full-screen applications
+// (point-of-sale, signage, exhibits): it forces the window to cover the
+// full screen, blocks window closing and the window-level keyboard
+// shortcuts (preferences, editor, zoom, snapshot), inhibits the system
+// screensaver / sleep where the driver supports it, and relaunches the
+// main window via a watchdog if it is closed anyway.
+type KioskMgr struct {
+	Active         bool           `desc:"whether kiosk mode is currently active"`
+	BlockShortcuts bool           `desc:"block window-level keyboard shortcuts while active -- on by default in Start"`
+	Win            *Window        `desc:"the managed kiosk window"`
+	Relaunch       func() *Window `desc:"watchdog function that recreates the main window if it is closed -- nil disables the watchdog"`
+}
+
+// Start enters kiosk mode on given window: covers the full screen, blocks
+// close requests and window shortcuts, and inhibits the screensaver.  The
+// optional relaunch function is called to recreate the window if it is
+// closed anyway (e.g., killed by the OS) -- it should build and show the
+// window and return it, or nil to give up.
+func (km *KioskMgr) Start(win *Window, relaunch func() *Window) {
+	km.Active = true
+	km.BlockShortcuts = true
+	km.Win = win
+	km.Relaunch = relaunch
+	if sc := win.OSWin.Screen(); sc != nil {
+		win.OSWin.SetGeom(sc.Geometry.Min, sc.Geometry.Size())
+	}
+	win.OSWin.Raise()
+	win.SetCloseReqFunc(func(w *Window) {
+		if !TheKiosk.Active || TheKiosk.Win != w {
+			w.Close()
+		}
+	})
+	win.SetCloseCleanFunc(func(w *Window) {
+		TheKiosk.WinClosed(w)
+	})
+	km.InhibitScreenSaver(true)
+}
+
+// Stop leaves kiosk mode, restoring normal close behavior and releasing the
+// screensaver inhibition -- does not change the window size
+func (km *KioskMgr) Stop() {
+	km.Active = false
+	km.BlockShortcuts = false
+	km.InhibitScreenSaver(false)
+	if km.Win != nil {
+		km.Win.SetCloseReqFunc(func(w *Window) {
+			w.Close()
+		})
+	}
+	km.Win = nil
+	km.Relaunch = nil
+}
+
+// InhibitScreenSaver inhibits or releases the system screensaver / sleep if
+// the oswin driver supports it -- returns false if it does not
+func (km *KioskMgr) InhibitScreenSaver(inhibit bool) bool {
+	ssi, ok := oswin.TheApp.(oswin.ScreenSaverInhibitor)
+	if !ok {
+		return false
+	}
+	ssi.InhibitScreenSaver(inhibit)
+	return true
+}
+
+// WinClosed is the watchdog hook, called when the kiosk window has actually
+// closed -- relaunches via the Relaunch function if still active
+func (km *KioskMgr) WinClosed(w *Window) {
+	if !km.Active || w != km.Win || km.Relaunch == nil {
+		return
+	}
+	go func() {
+		nw := km.Relaunch()
+		if nw != nil {
+			km.Start(nw, km.Relaunch)
+		} else {
+			km.Stop()
+		}
+	}()
+}
+
+// BlocksShortcuts returns whether kiosk mode is blocking window-level
+// keyboard shortcuts for given window
+func (km *KioskMgr) BlocksShortcuts(w *Window) bool {
+	return km.Active && km.BlockShortcuts && km.Win == w
+}
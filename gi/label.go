@@ -21,10 +21,13 @@ import (
 // Label
 
 // Label is a widget for rendering text labels -- supports full widget model
-// including box rendering, and full HTML styling, including links -- LinkSig
-// emits link with data of URL -- opens default browser if nobody receiving
-// signal.  The default white-space option is 'pre' -- set to 'normal' or
-// other options to get word-wrapping etc.
+// including box rendering, and full inline HTML styling, so a single label
+// can mix multiple style runs: <b>, <i>, <u>, <s>, <sup>, <sub>, <mark>,
+// <code>, <span style=...> etc (see TextRender.SetHTML and
+// SetHTMLSimpleTag for the full tag set), plus <a href=...> links --
+// LinkSig emits link with data of URL -- opens default browser if nobody
+// receiving signal.  The default white-space option is 'pre' -- set to
+// 'normal' or other options to get word-wrapping etc.
 type Label struct {
 	WidgetBase
 	Text        string              `xml:"text" desc:"label to display"`
@@ -139,6 +142,48 @@ func (lb *Label) SetText(txt string) {
 	lb.UpdateEnd(updt)
 }
 
+// AppendText appends given text to the label, as a fast path for streaming
+// output (build logs, chat): only the appended text is shaped into new
+// render spans, which start on a new line -- existing spans keep their
+// shaping and rune positions and only overall layout is extended
+// (LayoutStdLR skips spans that are already positioned), instead of
+// re-shaping the entire content as SetText does.  Uploads are batched
+// through the standard update signal, so rapid appends between renders
+// coalesce into a single redraw.  Set Redrawable = true for labels updated
+// this way.
+func (lb *Label) AppendText(txt string) {
+	if txt == "" {
+		return
+	}
+	if lb.Text == "" || lb.Sty.Font.Size.Val == 0 { // nothing to extend / not yet styled
+		lb.SetText(lb.Text + txt)
+		return
+	}
+	updt := lb.UpdateStart()
+	lb.SetStateStyle()
+	lb.Text += txt
+	lb.Sty.Font.BgColor.Color.SetToNil() // always use transparent bg for actual text
+	var ntr TextRender
+	ntr.SetHTML(txt, &lb.Sty.Font, &lb.Sty.Text, &lb.Sty.UnContext, lb.CSSAgg)
+	nsp := len(lb.Render.Spans)
+	for _, tl := range ntr.Links { // re-index links into appended position
+		tl.StartSpan += nsp
+		tl.EndSpan += nsp
+		lb.Render.Links = append(lb.Render.Links, tl)
+	}
+	lb.Render.Spans = append(lb.Render.Spans, ntr.Spans...)
+	spc := lb.Sty.BoxSpace()
+	sz := lb.LayData.AllocSize
+	if sz.IsNil() {
+		sz = lb.LayData.SizePrefOrMax()
+	}
+	if !sz.IsNil() {
+		sz.SetSubScalar(2 * spc)
+	}
+	lb.Render.LayoutStdLR(&lb.Sty.Text, &lb.Sty.Font, &lb.Sty.UnContext, sz)
+	lb.UpdateEnd(updt)
+}
+
 // Label returns the display label for this node, satisfying the Labeler interface
 func (lb *Label) Label() string {
 	if lb.Text != "" {
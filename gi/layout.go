@@ -1134,15 +1134,7 @@ func (ly *Layout) Render2DChildren() {
 		}
 		// note: all nodes need to render to disconnect b/c of invisible
 	}
-	for _, kid := range ly.Kids {
-		if kid == nil {
-			continue
-		}
-		nii, _ := KiToNode2D(kid)
-		if nii != nil {
-			nii.Render2D()
-		}
-	}
+	render2DKids(&ly.Node2DBase)
 }
 
 func (ly *Layout) Move2DChildren(delta image.Point) {
@@ -1688,6 +1680,7 @@ func (ly *Layout) StyleLayout() {
 		kit.TypesMu.RUnlock()
 	}
 	ly.StyleFromProps(nil, ly.Props, ly.Viewport)
+	ly.Spacing.Val *= Density.Mul() // re-set from props above, so not cumulative
 	ly.StyleToDots(&ly.Sty.UnContext)
 	if hasTempl && saveTempl {
 		ly.Sty.SaveTemplate()
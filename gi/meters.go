@@ -0,0 +1,388 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// This file provides instrument-style widgets for monitoring dashboards:
+// Gauge (circular readout with colored threshold ranges), Dial (rotary
+// input) and LED (on / off indicator with optional blinking).  The value
+// setters take a mutex and update via the standard update signal, so they
+// can be called directly from background data-feed goroutines.
+
+///////////////////////////////////////////////////////////////////////////////
+//    Gauge
+
+// GaugeThreshold is one colored range on a Gauge: the arc from the previous
+// threshold (or the minimum) up to Value is drawn in Color
+type GaugeThreshold struct {
+	Value float32 `desc:"upper value of this range"`
+	Color Color   `desc:"color for this range of the arc"`
+}
+
+// Gauge is a circular gauge displaying a value within a range, with
+// optional colored threshold ranges (e.g., green / yellow / red), drawn as
+// a 270 degree arc with a needle.  SetValue can be called from background
+// goroutines.
+type Gauge struct {
+	WidgetBase
+	Value      float32          `desc:"current value displayed by the needle"`
+	Min        float32          `desc:"minimum of the displayed range"`
+	Max        float32          `desc:"maximum of the displayed range"`
+	Thresholds []GaugeThreshold `desc:"colored ranges of the arc, in increasing order of value -- the arc from the prior threshold to each threshold value is drawn in its color"`
+	ValMu      sync.Mutex       `copy:"-" json:"-" xml:"-" view:"-" desc:"mutex protecting Value updates from background feeds"`
+}
+
+var KiT_Gauge = kit.Types.AddType(&Gauge{}, GaugeProps)
+
+// AddNewGauge adds a new gauge to given parent node, with given name.
+func AddNewGauge(parent ki.Ki, name string) *Gauge {
+	gg := parent.AddNewChild(KiT_Gauge, name).(*Gauge)
+	gg.Max = 1
+	return gg
+}
+
+var GaugeProps = ki.Props{
+	"EnumType:Flag": KiT_NodeFlags,
+	"min-width":     units.NewEm(6),
+	"min-height":    units.NewEm(6),
+}
+
+// SetValue sets the displayed value, clamped to the range, and updates the
+// display -- safe to call from a background goroutine
+func (gg *Gauge) SetValue(val float32) {
+	gg.ValMu.Lock()
+	gg.Value = mat32.Clamp(val, gg.Min, gg.Max)
+	gg.ValMu.Unlock()
+	gg.UpdateSig()
+}
+
+// gaugeAngle returns the needle angle in radians for given value: the arc
+// runs 270 degrees from 135 (lower left) through 405 (lower right)
+func (gg *Gauge) gaugeAngle(val float32) float32 {
+	norm := float32(0)
+	if gg.Max > gg.Min {
+		norm = (val - gg.Min) / (gg.Max - gg.Min)
+	}
+	return mat32.DegToRad(135 + 270*norm)
+}
+
+func (gg *Gauge) RenderGauge() {
+	rs := &gg.Viewport.Render
+	rs.Lock()
+	pc := &rs.Paint
+	sty := &gg.Sty
+	pos := gg.LayData.AllocPos
+	sz := gg.LayData.AllocSize
+	ctr := pos.Add(sz.MulScalar(.5))
+	rad := .5*math32.Min(sz.X, sz.Y) - 4
+
+	gg.ValMu.Lock()
+	val := gg.Value
+	gg.ValMu.Unlock()
+
+	// threshold arcs
+	pc.StrokeStyle.Width.Dots = rad * .15
+	last := gg.Min
+	for _, th := range gg.Thresholds {
+		pc.StrokeStyle.SetColor(th.Color)
+		pc.DrawArc(rs, ctr.X, ctr.Y, rad*.85, gg.gaugeAngle(last), gg.gaugeAngle(th.Value))
+		pc.Stroke(rs)
+		last = th.Value
+	}
+	if last < gg.Max {
+		pc.StrokeStyle.SetColor(sty.Font.Color.Highlight(40))
+		pc.DrawArc(rs, ctr.X, ctr.Y, rad*.85, gg.gaugeAngle(last), gg.gaugeAngle(gg.Max))
+		pc.Stroke(rs)
+	}
+
+	// needle
+	ang := gg.gaugeAngle(val)
+	pc.StrokeStyle.SetColor(sty.Font.Color)
+	pc.StrokeStyle.Width.Dots = 2
+	pc.DrawLine(rs, ctr.X, ctr.Y, ctr.X+rad*.7*math32.Cos(ang), ctr.Y+rad*.7*math32.Sin(ang))
+	pc.Stroke(rs)
+	pc.FillStyle.SetColor(sty.Font.Color)
+	pc.DrawCircle(rs, ctr.X, ctr.Y, 3)
+	pc.Fill(rs)
+	rs.Unlock()
+
+	// value readout
+	fst := sty.Font
+	fst.BgColor.SetColor(nil)
+	var tr TextRender
+	tr.SetString(fmt.Sprintf("%.4g", val), &fst, &sty.UnContext, &sty.Text, true, 0, 0)
+	tr.Render(rs, mat32.Vec2{ctr.X - 12, ctr.Y + rad*.4})
+}
+
+func (gg *Gauge) Render2D() {
+	if gg.FullReRenderIfNeeded() {
+		return
+	}
+	if gg.PushBounds() {
+		gg.RenderGauge()
+		gg.Render2DChildren()
+		gg.PopBounds()
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    Dial
+
+// Dial is a rotary input knob: dragging vertically or scrolling changes
+// the value through the range, emitting DialSig as it changes
+type Dial struct {
+	WidgetBase
+	Value   float32    `desc:"current value of the dial"`
+	Min     float32    `desc:"minimum value"`
+	Max     float32    `desc:"maximum value"`
+	Step    float32    `desc:"value change per scroll step or per pixel of drag"`
+	DialSig ki.Signal  `copy:"-" json:"-" xml:"-" view:"-" desc:"signal emitted when the value changes -- data is the new value"`
+	ValMu   sync.Mutex `copy:"-" json:"-" xml:"-" view:"-" desc:"mutex protecting Value updates"`
+	dragVal float32    // value at start of drag
+}
+
+var KiT_Dial = kit.Types.AddType(&Dial{}, DialProps)
+
+// AddNewDial adds a new dial to given parent node, with given name.
+func AddNewDial(parent ki.Ki, name string) *Dial {
+	dl := parent.AddNewChild(KiT_Dial, name).(*Dial)
+	dl.Max = 1
+	dl.Step = .01
+	return dl
+}
+
+var DialProps = ki.Props{
+	"EnumType:Flag": KiT_NodeFlags,
+	"min-width":     units.NewEm(3),
+	"min-height":    units.NewEm(3),
+}
+
+func (dl *Dial) Disconnect() {
+	dl.WidgetBase.Disconnect()
+	dl.DialSig.DisconnectAll()
+}
+
+// SetValue sets the dial value, clamped to the range, and updates the
+// display -- safe to call from a background goroutine
+func (dl *Dial) SetValue(val float32) {
+	dl.ValMu.Lock()
+	dl.Value = mat32.Clamp(val, dl.Min, dl.Max)
+	dl.ValMu.Unlock()
+	dl.UpdateSig()
+}
+
+// SetValueAction sets the dial value and emits the DialSig signal
+func (dl *Dial) SetValueAction(val float32) {
+	dl.SetValue(val)
+	dl.DialSig.Emit(dl.This(), 0, dl.Value)
+}
+
+func (dl *Dial) MouseDragEvent() {
+	dl.ConnectEvent(oswin.MouseDragEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		me.SetProcessed()
+		dll := recv.Embed(KiT_Dial).(*Dial)
+		if dll.IsInactive() {
+			return
+		}
+		del := me.Where.Sub(me.From)
+		dll.SetValueAction(dll.Value - float32(del.Y)*dll.Step)
+	})
+}
+
+func (dl *Dial) MouseScrollEvent() {
+	dl.ConnectEvent(oswin.MouseScrollEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.ScrollEvent)
+		me.SetProcessed()
+		dll := recv.Embed(KiT_Dial).(*Dial)
+		if dll.IsInactive() {
+			return
+		}
+		dll.SetValueAction(dll.Value + float32(me.NonZeroDelta(false))*dll.Step)
+	})
+}
+
+func (dl *Dial) ConnectEvents2D() {
+	dl.MouseDragEvent()
+	dl.MouseScrollEvent()
+	dl.HoverTooltipEvent()
+}
+
+func (dl *Dial) RenderDial() {
+	rs := &dl.Viewport.Render
+	rs.Lock()
+	defer rs.Unlock()
+	pc := &rs.Paint
+	sty := &dl.Sty
+	pos := dl.LayData.AllocPos
+	sz := dl.LayData.AllocSize
+	ctr := pos.Add(sz.MulScalar(.5))
+	rad := .5*math32.Min(sz.X, sz.Y) - 2
+
+	dl.ValMu.Lock()
+	val := dl.Value
+	dl.ValMu.Unlock()
+
+	pc.FillStyle.SetColor(sty.Font.BgColor.Color.Highlight(10))
+	pc.StrokeStyle.SetColor(sty.Font.Color)
+	pc.StrokeStyle.Width.Dots = 1.5
+	pc.DrawCircle(rs, ctr.X, ctr.Y, rad)
+	pc.FillStrokeClear(rs)
+
+	norm := float32(0)
+	if dl.Max > dl.Min {
+		norm = (val - dl.Min) / (dl.Max - dl.Min)
+	}
+	ang := mat32.DegToRad(135 + 270*norm)
+	pc.StrokeStyle.Width.Dots = 2
+	pc.DrawLine(rs, ctr.X+rad*.3*math32.Cos(ang), ctr.Y+rad*.3*math32.Sin(ang),
+		ctr.X+rad*.9*math32.Cos(ang), ctr.Y+rad*.9*math32.Sin(ang))
+	pc.Stroke(rs)
+}
+
+func (dl *Dial) Render2D() {
+	if dl.FullReRenderIfNeeded() {
+		return
+	}
+	if dl.PushBounds() {
+		dl.This().(Node2D).ConnectEvents2D()
+		dl.RenderDial()
+		dl.Render2DChildren()
+		dl.PopBounds()
+	} else {
+		dl.DisconnectAllEvents(RegPri)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    LED
+
+// LED is a simple on / off indicator light with an optional blink mode,
+// for status displays -- SetOn and SetBlink are safe to call from
+// background goroutines
+type LED struct {
+	WidgetBase
+	On      bool       `desc:"whether the light is on"`
+	Color   Color      `desc:"color of the light when on -- shown dimmed when off"`
+	ValMu   sync.Mutex `copy:"-" json:"-" xml:"-" view:"-" desc:"mutex protecting updates from background feeds"`
+	blinker *time.Ticker
+	blinkCh chan struct{}
+}
+
+var KiT_LED = kit.Types.AddType(&LED{}, LEDProps)
+
+// AddNewLED adds a new LED to given parent node, with given name.
+func AddNewLED(parent ki.Ki, name string) *LED {
+	ld := parent.AddNewChild(KiT_LED, name).(*LED)
+	ld.Color = Color{0, 255, 0, 255}
+	return ld
+}
+
+var LEDProps = ki.Props{
+	"EnumType:Flag": KiT_NodeFlags,
+	"min-width":     units.NewEm(1),
+	"min-height":    units.NewEm(1),
+}
+
+// SetOn sets the light on or off, stopping any blinking -- safe to call
+// from a background goroutine
+func (ld *LED) SetOn(on bool) {
+	ld.BlinkStop()
+	ld.ValMu.Lock()
+	ld.On = on
+	ld.ValMu.Unlock()
+	ld.UpdateSig()
+}
+
+// SetBlink makes the light blink with given interval -- safe to call from
+// a background goroutine
+func (ld *LED) SetBlink(interval time.Duration) {
+	ld.BlinkStop()
+	ld.ValMu.Lock()
+	ld.blinker = time.NewTicker(interval)
+	ld.blinkCh = make(chan struct{})
+	tick := ld.blinker.C
+	done := ld.blinkCh
+	ld.ValMu.Unlock()
+	go func() {
+		for {
+			select {
+			case <-tick:
+				ld.ValMu.Lock()
+				ld.On = !ld.On
+				ld.ValMu.Unlock()
+				ld.UpdateSig()
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+// BlinkStop stops any blinking, leaving the light in its current state
+func (ld *LED) BlinkStop() {
+	ld.ValMu.Lock()
+	if ld.blinker != nil {
+		ld.blinker.Stop()
+		close(ld.blinkCh)
+		ld.blinker = nil
+		ld.blinkCh = nil
+	}
+	ld.ValMu.Unlock()
+}
+
+func (ld *LED) Disconnect() {
+	ld.BlinkStop()
+	ld.WidgetBase.Disconnect()
+}
+
+func (ld *LED) RenderLED() {
+	rs := &ld.Viewport.Render
+	rs.Lock()
+	defer rs.Unlock()
+	pc := &rs.Paint
+	pos := ld.LayData.AllocPos
+	sz := ld.LayData.AllocSize
+	ctr := pos.Add(sz.MulScalar(.5))
+	rad := .5*math32.Min(sz.X, sz.Y) - 1
+
+	ld.ValMu.Lock()
+	on := ld.On
+	ld.ValMu.Unlock()
+
+	clr := ld.Color
+	if !on {
+		clr = clr.Highlight(60)
+	}
+	pc.FillStyle.SetColor(clr)
+	pc.StrokeStyle.SetColor(ld.Sty.Font.Color)
+	pc.StrokeStyle.Width.Dots = 1
+	pc.DrawCircle(rs, ctr.X, ctr.Y, rad)
+	pc.FillStrokeClear(rs)
+}
+
+func (ld *LED) Render2D() {
+	if ld.FullReRenderIfNeeded() {
+		return
+	}
+	if ld.PushBounds() {
+		ld.RenderLED()
+		ld.Render2DChildren()
+		ld.PopBounds()
+	}
+}
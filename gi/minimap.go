@@ -0,0 +1,236 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	idraw "image/draw"
+
+	"github.com/goki/gi/mat32"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"golang.org/x/image/draw"
+)
+
+// MiniMap shows a scaled-down overview of the content of a scrolling
+// Layout (e.g., a text editor or node-graph canvas), with a draggable
+// rectangle marking the currently-visible region.  The overview is kept in
+// a cached content-sized image that is updated incrementally: whenever the
+// source renders, the visible region is copied from the viewport into the
+// cache at its scroll offset, so the full overview fills in as the content
+// is scrolled through, with no extra full-content render pass.  Clicking or
+// dragging in the minimap scrolls the source to the corresponding position.
+type MiniMap struct {
+	WidgetBase
+	Src      *Layout     `json:"-" xml:"-" desc:"the scrolling layout this minimap overviews"`
+	cacheImg *image.RGBA // content-sized cache of captured regions
+	sclImg   *image.RGBA // cache scaled to the minimap display size
+	sclDirty bool        // sclImg needs to be recomputed
+	dragging bool        // view rectangle is being dragged
+}
+
+var KiT_MiniMap = kit.Types.AddType(&MiniMap{}, MiniMapProps)
+
+// AddNewMiniMap adds a new minimap to given parent node, with given name.
+func AddNewMiniMap(parent ki.Ki, name string) *MiniMap {
+	return parent.AddNewChild(KiT_MiniMap, name).(*MiniMap)
+}
+
+var MiniMapProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"min-width":        units.NewEm(8),
+	"max-height":       -1,
+	"background-color": &Prefs.Colors.Background,
+}
+
+// SetSrc sets the layout this minimap overviews, resetting the cache
+func (mm *MiniMap) SetSrc(ly *Layout) {
+	mm.Src = ly
+	mm.cacheImg = nil
+	mm.sclImg = nil
+	mm.UpdateSig()
+}
+
+// SrcScroll returns the current scroll offset of the source layout, in dots
+func (mm *MiniMap) SrcScroll() mat32.Vec2 {
+	sc := mat32.Vec2{}
+	for d := mat32.X; d <= mat32.Y; d++ {
+		if mm.Src.HasScroll[d] && mm.Src.Scrolls[d] != nil {
+			sc.SetDim(d, mm.Src.Scrolls[d].Value)
+		}
+	}
+	return sc
+}
+
+// GrabSrc copies the currently-rendered region of the source from its
+// viewport into the content cache at the current scroll offset.  Call this
+// (or Refresh) after the source has re-rendered to keep the overview
+// current.
+func (mm *MiniMap) GrabSrc() {
+	if mm.Src == nil || mm.Src.Viewport == nil {
+		return
+	}
+	ly := mm.Src
+	csz := ly.ChildSize.ToPointCeil()
+	if csz.X <= 0 || csz.Y <= 0 {
+		return
+	}
+	if mm.cacheImg == nil || mm.cacheImg.Bounds().Size() != csz {
+		mm.cacheImg = image.NewRGBA(image.Rectangle{Max: csz})
+	}
+	vis := ly.VpBBox
+	if vis.Empty() {
+		return
+	}
+	sc := mm.SrcScroll()
+	off := image.Point{int(sc.X), int(sc.Y)}
+	dst := image.Rectangle{Min: off, Max: off.Add(vis.Size())}
+	idraw.Draw(mm.cacheImg, dst, ly.Viewport.Pixels, vis.Min, idraw.Src)
+	mm.sclDirty = true
+}
+
+// Refresh re-grabs the visible region from the source and re-renders
+func (mm *MiniMap) Refresh() {
+	mm.GrabSrc()
+	mm.UpdateSig()
+}
+
+// MapBox returns the area in window pixels where the overview is drawn,
+// preserving the aspect ratio of the content
+func (mm *MiniMap) MapBox() mat32.Box2 {
+	pos := mm.LayData.AllocPos
+	sz := mm.LayData.AllocSize
+	if mm.cacheImg == nil {
+		return mat32.Box2{Min: pos, Max: pos.Add(sz)}
+	}
+	csz := mm.cacheImg.Bounds().Size()
+	scl := mat32.Min(sz.X/float32(csz.X), sz.Y/float32(csz.Y))
+	msz := mat32.Vec2{float32(csz.X) * scl, float32(csz.Y) * scl}
+	return mat32.Box2{Min: pos, Max: pos.Add(msz)}
+}
+
+// ViewRect returns the rectangle in window pixels marking the visible
+// region of the source, within the MapBox
+func (mm *MiniMap) ViewRect() mat32.Box2 {
+	box := mm.MapBox()
+	if mm.Src == nil || mm.cacheImg == nil {
+		return box
+	}
+	csz := mm.cacheImg.Bounds().Size()
+	scl := (box.Max.X - box.Min.X) / float32(csz.X)
+	sc := mm.SrcScroll()
+	vsz := mat32.NewVec2FmPoint(mm.Src.VpBBox.Size())
+	min := box.Min.Add(sc.MulScalar(scl))
+	max := min.Add(vsz.MulScalar(scl))
+	return mat32.Box2{Min: min, Max: max.Min(box.Max)}
+}
+
+// ScrollToPix scrolls the source so that the view is centered on the
+// content position corresponding to given minimap window pixel point
+func (mm *MiniMap) ScrollToPix(pt mat32.Vec2) {
+	if mm.Src == nil || mm.cacheImg == nil {
+		return
+	}
+	box := mm.MapBox()
+	csz := mm.cacheImg.Bounds().Size()
+	scl := (box.Max.X - box.Min.X) / float32(csz.X)
+	if scl <= 0 {
+		return
+	}
+	cp := pt.Sub(box.Min).DivScalar(scl) // content coords
+	vsz := mat32.NewVec2FmPoint(mm.Src.VpBBox.Size())
+	tgt := cp.Sub(vsz.MulScalar(.5))
+	for d := mat32.X; d <= mat32.Y; d++ {
+		if mm.Src.HasScroll[d] && mm.Src.Scrolls[d] != nil {
+			sb := mm.Src.Scrolls[d]
+			sb.SetValueAction(mat32.Clamp(tgt.Dim(d), 0, sb.Max-sb.ThumbVal))
+		}
+	}
+	mm.UpdateSig()
+}
+
+func (mm *MiniMap) MouseEvent() {
+	mm.ConnectEvent(oswin.MouseEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		if me.Button != mouse.Left {
+			return
+		}
+		me.SetProcessed()
+		mmm := recv.Embed(KiT_MiniMap).(*MiniMap)
+		switch me.Action {
+		case mouse.Press:
+			mmm.dragging = true
+			mmm.ScrollToPix(mat32.NewVec2FmPoint(me.Pos()))
+		case mouse.Release:
+			mmm.dragging = false
+		}
+	})
+}
+
+func (mm *MiniMap) MouseDragEvent() {
+	mm.ConnectEvent(oswin.MouseDragEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		me.SetProcessed()
+		mmm := recv.Embed(KiT_MiniMap).(*MiniMap)
+		if mmm.dragging {
+			mmm.ScrollToPix(mat32.NewVec2FmPoint(me.Pos()))
+		}
+	})
+}
+
+func (mm *MiniMap) ConnectEvents2D() {
+	mm.MouseEvent()
+	mm.MouseDragEvent()
+	mm.HoverTooltipEvent()
+}
+
+// RenderMiniMap does the full rendering of the minimap
+func (mm *MiniMap) RenderMiniMap() {
+	mm.GrabSrc()
+	if mm.cacheImg == nil {
+		return
+	}
+	rs := &mm.Viewport.Render
+	rs.Lock()
+	defer rs.Unlock()
+	pc := &rs.Paint
+	box := mm.MapBox()
+	bsz := image.Point{int(box.Max.X - box.Min.X), int(box.Max.Y - box.Min.Y)}
+	if bsz.X <= 0 || bsz.Y <= 0 {
+		return
+	}
+	if mm.sclImg == nil || mm.sclImg.Bounds().Size() != bsz {
+		mm.sclImg = image.NewRGBA(image.Rectangle{Max: bsz})
+		mm.sclDirty = true
+	}
+	if mm.sclDirty {
+		draw.ApproxBiLinear.Scale(mm.sclImg, mm.sclImg.Bounds(), mm.cacheImg, mm.cacheImg.Bounds(), draw.Src, nil)
+		mm.sclDirty = false
+	}
+	pc.DrawImage(rs, mm.sclImg, int(box.Min.X), int(box.Min.Y))
+
+	vr := mm.ViewRect()
+	pc.StrokeStyle.SetColor(&Prefs.Colors.Select)
+	pc.StrokeStyle.Width.Dots = 1.5
+	pc.DrawRectangle(rs, vr.Min.X, vr.Min.Y, vr.Max.X-vr.Min.X, vr.Max.Y-vr.Min.Y)
+	pc.Stroke(rs)
+}
+
+func (mm *MiniMap) Render2D() {
+	if mm.FullReRenderIfNeeded() {
+		return
+	}
+	if mm.PushBounds() {
+		mm.This().(Node2D).ConnectEvents2D()
+		mm.RenderMiniMap()
+		mm.Render2DChildren()
+		mm.PopBounds()
+	} else {
+		mm.DisconnectAllEvents(RegPri)
+	}
+}
@@ -135,6 +135,13 @@ const (
 	// primarily about dragging (e.g., the Splitter handle)
 	InstaDrag
 
+	// CacheRender: this node's subtree is static -- it is rasterized into a
+	// retained image once, and that image is blitted on subsequent renders
+	// until invalidated (size change, FullReRender, or explicit
+	// InvalidateRenderCache) -- use for large unchanging subtrees such as
+	// toolbars or icon grids -- see Node2DBase.SetCacheRender
+	CacheRender
+
 	// can extend node flags from here
 	NodeFlagsN
 )
@@ -9,6 +9,7 @@ import (
 	"image"
 	"log"
 	"reflect"
+	"time"
 
 	"github.com/goki/gi/oswin"
 	"github.com/goki/gi/units"
@@ -62,9 +63,19 @@ For Widget / Layout nodes, rendering is done in 5 separate passes:
       parent SVG viewport
 
 */
+// RenderHook is a custom rendering callback that can be set on any node to
+// draw additional decorations (highlight borders, debug overlays, etc)
+// without subclassing -- called with the node, with its Viewport render
+// state locked to the node's bounds, so standard Paint calls just work
+type RenderHook func(nd Node2D)
+
 type Node2DBase struct {
 	NodeBase
-	Viewport *Viewport2D `copy:"-" json:"-" xml:"-" view:"-" desc:"our viewport -- set in Init2D (Base typically) and used thereafter"`
+	Viewport   *Viewport2D `copy:"-" json:"-" xml:"-" view:"-" desc:"our viewport -- set in Init2D (Base typically) and used thereafter"`
+	PreRender  RenderHook  `copy:"-" json:"-" xml:"-" view:"-" desc:"optional custom rendering hook called in PushBounds before the node renders itself -- draws behind the node"`
+	PostRender RenderHook  `copy:"-" json:"-" xml:"-" view:"-" desc:"optional custom rendering hook called in PopBounds after the node and its children have rendered -- draws on top"`
+	RendCache  *image.RGBA `copy:"-" json:"-" xml:"-" view:"-" desc:"retained rasterization of this subtree when the CacheRender flag is set -- blitted instead of re-rendering until invalidated -- see SetCacheRender"`
+	RendShare  string      `copy:"-" json:"-" xml:"-" view:"-" desc:"key for sharing one rasterization among identical repeated subtrees -- see SetRenderShared"`
 }
 
 var KiT_Node2DBase = kit.Types.AddType(&Node2DBase{}, Node2DBaseProps)
@@ -84,6 +95,19 @@ func (nb *Node2DBase) CopyFieldsFrom(frm interface{}) {
 	nb.NodeBase.CopyFieldsFrom(&fr.NodeBase)
 }
 
+// RunRenderHook calls the given render hook for this node, if non-nil, with
+// the render state locked -- the hook should make Paint calls directly,
+// without locking
+func (nb *Node2DBase) RunRenderHook(hook RenderHook) {
+	if hook == nil || nb.Viewport == nil {
+		return
+	}
+	rs := &nb.Viewport.Render
+	rs.Lock()
+	hook(nb.This().(Node2D))
+	rs.Unlock()
+}
+
 // Update2DTrace reports a trace of updates that trigger re-rendering -- can be set in PrefsDebug from prefs gui
 var Update2DTrace bool = false
 
@@ -643,6 +667,9 @@ func (nb *Node2DBase) DisconnectViewport() {
 func (nb *Node2DBase) SetWinBBox() {
 	if nb.Viewport != nil {
 		nb.WinBBox = nb.VpBBox.Add(nb.Viewport.WinBBox.Min)
+		if nb.Viewport.Win != nil {
+			nb.Viewport.Win.EventMgr.HitTest.Invalidate()
+		}
 	} else {
 		nb.WinBBox = nb.VpBBox
 	}
@@ -717,12 +744,18 @@ func (nb *Node2DBase) Style2DTree() {
 	// fmt.Printf("\n\n###################################\n%v\n", string(debug.Stack()))
 	pr := prof.Start("Node2D.Style2DTree." + nb.Type().Name())
 	nb.FuncDownMeFirst(0, nb.This(), func(k ki.Ki, level int, d interface{}) bool {
-		nii, _ := KiToNode2D(k)
+		nii, ni := KiToNode2D(k)
 		if nii == nil {
 			return false
 		}
 		// ppr := prof.Start("Style2DTree:" + nii.Type().Name())
-		nii.Style2D()
+		if ni.Viewport != nil && ni.Viewport.RenderProf.On {
+			st := time.Now()
+			nii.Style2D()
+			renderProfRec(ni.Viewport, "Style2D", nii, st)
+		} else {
+			nii.Style2D()
+		}
 		// ppr.End()
 		return true
 	})
@@ -811,9 +844,15 @@ func (nb *Node2DBase) Layout2DChildren(iter int) bool {
 	redo := false
 	cbb := nb.This().(Node2D).ChildrenBBox2D()
 	for _, kid := range nb.Kids {
-		nii, _ := KiToNode2D(kid)
+		nii, ni := KiToNode2D(kid)
 		if nii != nil {
-			if nii.Layout2D(cbb, iter) {
+			if ni.Viewport != nil && ni.Viewport.RenderProf.On {
+				st := time.Now()
+				if nii.Layout2D(cbb, iter) {
+					redo = true
+				}
+				renderProfRec(ni.Viewport, "Layout2D", nii, st)
+			} else if nii.Layout2D(cbb, iter) {
 				redo = true
 			}
 		}
@@ -834,13 +873,9 @@ func (nb *Node2DBase) Move2DChildren(delta image.Point) {
 }
 
 // Render2DChildren renders all of node's children -- default call at end of Render2D()
+// -- independent sub-viewport subtrees render in parallel if ParallelRender is enabled
 func (nb *Node2DBase) Render2DChildren() {
-	for _, kid := range nb.Kids {
-		nii, _ := KiToNode2D(kid)
-		if nii != nil {
-			nii.Render2D()
-		}
-	}
+	render2DKids(nb)
 }
 
 // BBoxReport reports on all the bboxes for everything in the tree
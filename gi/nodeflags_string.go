@@ -26,13 +26,14 @@ func _() {
 	_ = x[DNDHasEntered-26]
 	_ = x[NodeDragging-27]
 	_ = x[InstaDrag-28]
-	_ = x[NodeFlagsN-29]
-	_ = x[TextFieldFocusActive-29]
+	_ = x[CacheRender-29]
+	_ = x[NodeFlagsN-30]
+	_ = x[TextFieldFocusActive-30]
 }
 
-const _NodeFlags_name = "NoLayoutEventsConnectedCanFocusHasFocusFullReRenderReRenderAnchorInvisibleInactiveSelectedMouseHasEnteredDNDHasEnteredNodeDraggingInstaDragNodeFlagsN"
+const _NodeFlags_name = "NoLayoutEventsConnectedCanFocusHasFocusFullReRenderReRenderAnchorInvisibleInactiveSelectedMouseHasEnteredDNDHasEnteredNodeDraggingInstaDragCacheRenderNodeFlagsN"
 
-var _NodeFlags_index = [...]uint8{0, 8, 23, 31, 39, 51, 65, 74, 82, 90, 105, 118, 130, 139, 149}
+var _NodeFlags_index = [...]uint8{0, 8, 23, 31, 39, 51, 65, 74, 82, 90, 105, 118, 130, 139, 150, 160}
 
 func (i NodeFlags) String() string {
 	i -= 16
@@ -0,0 +1,46 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"runtime"
+)
+
+// NewOffscreenViewport2D creates a viewport for headless rendering into a
+// plain image.RGBA, without any window or oswin app -- for CLI tools and
+// server processes that render a scene to an image.  Initializes the font
+// library with standard system font paths if not already done (normally
+// handled by window opening).  Build the scene under the viewport, then
+// call RenderOffscreen and SavePNG (or read Pixels directly).
+func NewOffscreenViewport2D(width, height int) *Viewport2D {
+	vp := NewViewport2D(width, height)
+	vp.InitName(vp, "offscreen-vp")
+	vp.Fill = true
+	FontLibrary.InitFontPaths(OffscreenFontPaths()...)
+	return vp
+}
+
+// OffscreenFontPaths returns the standard system font paths for the
+// current platform -- same as those provided by the oswin drivers, for use
+// when no oswin app is running
+func OffscreenFontPaths() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"/System/Library/Fonts", "/Library/Fonts"}
+	case "windows":
+		return []string{"C:\\Windows\\Fonts"}
+	default:
+		return []string{"/usr/share/fonts/truetype"}
+	}
+}
+
+// RenderOffscreen does a complete init, style, layout, and render pass on
+// the scene under this viewport, for headless use where no window drives
+// the updates -- the result is in Pixels
+func (vp *Viewport2D) RenderOffscreen() {
+	vp.Geom.SetRect(image.Rectangle{Max: vp.Geom.Size})
+	vp.FullRender2DTree()
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"github.com/goki/gi/oswin"
+	"github.com/goki/ki/ki"
+)
+
+// TheOSOpen is the global manager surfacing OS open-file and open-URL
+// requests (double-clicked associated documents, custom URL schemes) as an
+// app-level signal -- see OSOpenMgr.ConnectOpenFiles.
+var TheOSOpen OSOpenMgr
+
+func init() {
+	TheOSOpen.InitName(&TheOSOpen, "os-open")
+}
+
+// OSOpenMgr delivers OS requests to open files or URLs in this app.  The
+// platform drivers capture the native events (e.g., Apple Events on macOS)
+// and requests arriving before the app has connected -- such as the
+// document that launched it -- are buffered and delivered on connect.
+type OSOpenMgr struct {
+	ki.Node
+	OpenFilesSig ki.Signal `copy:"-" json:"-" xml:"-" view:"-" desc:"emitted when the OS requests files or URLs be opened -- data is []string of file paths and / or URLs (URLs keep their scheme)"`
+}
+
+// ConnectOpenFiles connects given receiver and function to receive OS
+// open-file / open-URL requests, and activates delivery from the platform
+// driver, flushing any requests buffered from before the connection.  The
+// main window is raised before the signal is emitted.
+func (om *OSOpenMgr) ConnectOpenFiles(recv ki.Ki, fun ki.RecvFunc) {
+	om.OpenFilesSig.Connect(recv, fun)
+	oswin.SetOpenFilesFunc(om.openFiles)
+}
+
+// openFiles is the oswin handler delivering requests to the signal
+func (om *OSOpenMgr) openFiles(files []string) {
+	RaiseMainWindow()
+	om.OpenFilesSig.Emit(om.This(), 0, files)
+}
+
+// RaiseMainWindow raises the first main window of the app, if there is one
+func RaiseMainWindow() {
+	var win *Window
+	if len(MainWindows) > 0 {
+		win = MainWindows[0]
+	} else if len(AllWindows) > 0 {
+		win = AllWindows[0]
+	}
+	if win != nil && win.OSWin != nil {
+		win.OSWin.Raise()
+	}
+}
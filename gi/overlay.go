@@ -0,0 +1,91 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"image/draw"
+	"sort"
+
+	"github.com/goki/gi/oswin"
+)
+
+// OverlayDrawer is a function that paints transient decorations (drag
+// ghosts, drop indicators, focus rings, measurement annotations) into the
+// window overlay.  It is called on each overlay render with a Paint and
+// RenderState targeting a transparent window-sized image that is composited
+// above all regular content, so drawing here never perturbs layout or
+// requires re-rendering the viewport underneath.  Coordinates are window
+// pixels; wsz is the current window size.
+type OverlayDrawer func(pc *Paint, rs *RenderState, wsz image.Point)
+
+// OverlayDraws is the set of registered overlay drawers, by name
+type OverlayDraws map[string]OverlayDrawer
+
+// AddOverlayDrawer registers an overlay drawer under given name (replacing
+// any existing drawer of that name), and renders the overlays.  Drawers
+// are called in name order, so later layers can be ordered with name
+// prefixes.  The widget registering a drawer must remove it with
+// DeleteOverlayDrawer when its decoration is no longer needed (e.g., at
+// the end of a drag).
+func (w *Window) AddOverlayDrawer(nm string, fn OverlayDrawer) {
+	w.UpMu.Lock()
+	if w.OverDraws == nil {
+		w.OverDraws = make(OverlayDraws)
+	}
+	w.OverDraws[nm] = fn
+	w.UpMu.Unlock()
+	w.RenderOverlays()
+}
+
+// DeleteOverlayDrawer removes the overlay drawer of given name, and
+// re-renders the overlays without it
+func (w *Window) DeleteOverlayDrawer(nm string) {
+	w.UpMu.Lock()
+	if w.OverDraws == nil {
+		w.UpMu.Unlock()
+		return
+	}
+	delete(w.OverDraws, nm)
+	w.UpMu.Unlock()
+	w.RenderOverlays()
+}
+
+// UpdateOverlays re-renders the overlays -- call after state that a
+// registered drawer depends on has changed (e.g., the drag position moved)
+func (w *Window) UpdateOverlays() {
+	w.RenderOverlays()
+}
+
+// RenderOverDraws runs the registered overlay drawers into the overlay
+// scratch image and uploads it to OverTex -- called by RenderOverlays,
+// must be called under UpMu mutex lock
+func (w *Window) RenderOverDraws() {
+	if len(w.OverDraws) == 0 || w.OverTex == nil {
+		return
+	}
+	wsz := w.OverTex.Size()
+	if w.overImg == nil || w.overImg.Bounds().Size() != wsz {
+		w.overImg = image.NewRGBA(image.Rectangle{Max: wsz})
+		w.overRend.Init(wsz.X, wsz.Y, w.overImg)
+	}
+	draw.Draw(w.overImg, w.overImg.Bounds(), image.Transparent, image.ZP, draw.Src)
+	nms := make([]string, 0, len(w.OverDraws))
+	for nm := range w.OverDraws {
+		nms = append(nms, nm)
+	}
+	sort.Strings(nms)
+	rs := &w.overRend
+	rs.Lock()
+	for _, nm := range nms {
+		w.OverDraws[nm](&rs.Paint, rs, wsz)
+	}
+	rs.Unlock()
+	oswin.TheApp.RunOnMain(func() {
+		if w.OSWin.Activate() {
+			w.OverTex.SetSubImage(image.ZP, w.overImg, w.overImg.Bounds())
+		}
+	})
+}
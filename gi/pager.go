@@ -0,0 +1,371 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"time"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// PagerAnimMSec is the duration in milliseconds of the page slide animation
+// in Pager -- set to 0 for instant page switching
+var PagerAnimMSec = 200
+
+// PagerSwipeThr is the horizontal drag distance in pixels that triggers a
+// page flip in Pager
+var PagerSwipeThr = 60
+
+// Pager is a stacked container showing one full-size child page at a time,
+// with the new page sliding in horizontally when the page changes.  Pages
+// can be flipped programmatically, or by horizontal mouse / touch drag.
+// Use a Stepper for prev / next buttons and indicator dots -- see
+// Stepper.SetPager.
+type Pager struct {
+	Frame
+	PageIdx int       `desc:"index of the currently shown page"`
+	PageSig ki.Signal `copy:"-" json:"-" xml:"-" view:"-" desc:"emitted when the current page changes from user action -- data is the new page index"`
+	swipeX  float32   // accumulated horizontal drag for swipe detection
+}
+
+var KiT_Pager = kit.Types.AddType(&Pager{}, PagerProps)
+
+// AddNewPager adds a new pager to given parent node, with given name.
+func AddNewPager(parent ki.Ki, name string) *Pager {
+	pg := parent.AddNewChild(KiT_Pager, name).(*Pager)
+	pg.Lay = LayoutStacked
+	return pg
+}
+
+func (nb *Pager) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*Pager)
+	nb.Frame.CopyFieldsFrom(&fr.Frame)
+	nb.PageIdx = fr.PageIdx
+}
+
+func (pg *Pager) Disconnect() {
+	pg.Frame.Disconnect()
+	pg.PageSig.DisconnectAll()
+}
+
+var PagerProps = ki.Props{
+	"EnumType:Flag": KiT_NodeFlags,
+}
+
+func (pg *Pager) Init2D() {
+	pg.Lay = LayoutStacked
+	pg.Frame.Init2D()
+}
+
+// SetPage shows the page at given index immediately, with no animation or
+// signal -- index is clamped to the valid range
+func (pg *Pager) SetPage(idx int) {
+	idx = ints.MinInt(ints.MaxInt(idx, 0), pg.NumChildren()-1)
+	if idx == pg.PageIdx && pg.StackTop == idx {
+		return
+	}
+	updt := pg.UpdateStart()
+	pg.PageIdx = idx
+	pg.StackTop = idx
+	pg.SetFullReRender()
+	pg.UpdateEnd(updt)
+}
+
+// SetPageAction shows the page at given index with the slide animation,
+// emitting PageSig with the new index
+func (pg *Pager) SetPageAction(idx int) {
+	idx = ints.MinInt(ints.MaxInt(idx, 0), pg.NumChildren()-1)
+	if idx == pg.PageIdx {
+		return
+	}
+	dir := 1
+	if idx < pg.PageIdx {
+		dir = -1
+	}
+	pg.SetPage(idx)
+	pg.PageSig.Emit(pg.This(), 0, pg.PageIdx)
+	if PagerAnimMSec <= 0 || ReducedMotion || pg.Viewport == nil || pg.WinBBox.Dx() <= 0 {
+		return
+	}
+	go pg.AnimPage(idx, dir)
+}
+
+// NextPage flips to the next page with animation, if there is one
+func (pg *Pager) NextPage() {
+	pg.SetPageAction(pg.PageIdx + 1)
+}
+
+// PrevPage flips to the previous page with animation, if there is one
+func (pg *Pager) PrevPage() {
+	pg.SetPageAction(pg.PageIdx - 1)
+}
+
+// AnimPage slides the page at given index in from the left (dir < 0) or
+// right (dir > 0) -- runs in its own goroutine, started by SetPageAction
+func (pg *Pager) AnimPage(idx, dir int) {
+	nii, _ := KiToNode2D(pg.Child(idx))
+	if nii == nil {
+		return
+	}
+	w := pg.WinBBox.Dx()
+	cbb := pg.This().(Node2D).ChildrenBBox2D()
+	start := time.Now()
+	dur := time.Duration(PagerAnimMSec) * time.Millisecond
+	tick := time.NewTicker(time.Second / 60)
+	defer tick.Stop()
+	for now := range tick.C {
+		if pg.This() == nil || pg.IsDestroyed() || pg.IsDeleted() {
+			return
+		}
+		pct := float32(now.Sub(start)) / float32(dur)
+		done := pct >= 1
+		if done {
+			pct = 1
+		}
+		pct = pct * pct * (3 - 2*pct) // smoothstep
+		off := int((1 - pct) * float32(dir*w))
+		updt := pg.UpdateStart()
+		nii.Move2D(image.Point{off, 0}, cbb)
+		pg.UpdateEnd(updt)
+		if done {
+			break
+		}
+	}
+	if pg.This() == nil || pg.IsDestroyed() || pg.IsDeleted() {
+		return
+	}
+	updt := pg.UpdateStart() // normalize layout at the exact final position
+	pg.SetFullReRender()
+	pg.UpdateEnd(updt)
+}
+
+func (pg *Pager) MouseEvent() {
+	pg.ConnectEvent(oswin.MouseEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		pgg := recv.Embed(KiT_Pager).(*Pager)
+		if me.Action == mouse.Press {
+			pgg.swipeX = 0
+		}
+	})
+}
+
+func (pg *Pager) MouseDragEvent() {
+	pg.ConnectEvent(oswin.MouseDragEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		pgg := recv.Embed(KiT_Pager).(*Pager)
+		if pgg.IsInactive() {
+			return
+		}
+		del := me.Where.Sub(me.From)
+		pgg.swipeX += float32(del.X)
+		if math32.Abs(pgg.swipeX) < float32(PagerSwipeThr) {
+			return
+		}
+		me.SetProcessed()
+		if pgg.swipeX < 0 {
+			pgg.NextPage()
+		} else {
+			pgg.PrevPage()
+		}
+		pgg.swipeX = 0
+	})
+}
+
+func (pg *Pager) ConnectEvents2D() {
+	pg.MouseEvent()
+	pg.MouseDragEvent()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  Stepper
+
+// Stepper is a prev / next paging control with indicator dots showing the
+// current page -- connect to a Pager with SetPager, or handle StepperSig
+// directly for wizard-like flows
+type Stepper struct {
+	PartsWidgetBase
+	PageIdx    int       `desc:"index of the current page"`
+	Pages      int       `desc:"total number of pages, one indicator dot each"`
+	StepperSig ki.Signal `copy:"-" json:"-" xml:"-" view:"-" desc:"emitted when the user changes the page -- data is the new page index"`
+}
+
+var KiT_Stepper = kit.Types.AddType(&Stepper{}, StepperProps)
+
+// AddNewStepper adds a new stepper to given parent node, with given name
+// and number of pages.
+func AddNewStepper(parent ki.Ki, name string, pages int) *Stepper {
+	st := parent.AddNewChild(KiT_Stepper, name).(*Stepper)
+	st.Pages = pages
+	return st
+}
+
+func (nb *Stepper) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*Stepper)
+	nb.PartsWidgetBase.CopyFieldsFrom(&fr.PartsWidgetBase)
+	nb.PageIdx = fr.PageIdx
+	nb.Pages = fr.Pages
+}
+
+func (st *Stepper) Disconnect() {
+	st.PartsWidgetBase.Disconnect()
+	st.StepperSig.DisconnectAll()
+}
+
+var StepperProps = ki.Props{
+	"EnumType:Flag": KiT_NodeFlags,
+	"padding":       units.NewPx(2),
+	"margin":        units.NewPx(2),
+	"#prev": ki.Props{
+		"margin":  units.NewPx(0),
+		"padding": units.NewPx(0),
+	},
+	"#next": ki.Props{
+		"margin":  units.NewPx(0),
+		"padding": units.NewPx(0),
+	},
+}
+
+func (st *Stepper) Init2D() {
+	st.Init2DWidget()
+	st.ConfigParts()
+}
+
+func (st *Stepper) Style2D() {
+	st.Style2DWidget()
+	st.ConfigParts()
+	st.LayData.SetFromStyle(&st.Sty.Layout) // also does reset
+}
+
+// SetPage sets the current page, clamped to the valid range, with no signal
+func (st *Stepper) SetPage(idx int) {
+	idx = ints.MinInt(ints.MaxInt(idx, 0), st.Pages-1)
+	if idx == st.PageIdx {
+		return
+	}
+	updt := st.UpdateStart()
+	st.PageIdx = idx
+	st.UpdateEnd(updt)
+}
+
+// SetPageAction sets the current page and emits StepperSig with the new
+// index if it changed
+func (st *Stepper) SetPageAction(idx int) {
+	idx = ints.MinInt(ints.MaxInt(idx, 0), st.Pages-1)
+	if idx == st.PageIdx {
+		return
+	}
+	st.SetPage(idx)
+	st.StepperSig.Emit(st.This(), 0, st.PageIdx)
+}
+
+// SetPager connects the stepper to given pager bidirectionally: the stepper
+// buttons flip the pager's pages, and pager page changes (e.g., from swipes)
+// update the dots -- also sets Pages from the pager's current page count
+func (st *Stepper) SetPager(pg *Pager) {
+	st.Pages = pg.NumChildren()
+	st.PageIdx = pg.PageIdx
+	st.StepperSig.Connect(pg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		pgg := recv.Embed(KiT_Pager).(*Pager)
+		pgg.SetPageAction(data.(int))
+	})
+	pg.PageSig.Connect(st.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		stt := recv.Embed(KiT_Stepper).(*Stepper)
+		stt.SetPage(data.(int))
+	})
+}
+
+func (st *Stepper) ConfigParts() {
+	st.Parts.Lay = LayoutHoriz
+	config := kit.TypeAndNameList{}
+	config.Add(KiT_Action, "prev")
+	config.Add(KiT_Space, "dots")
+	config.Add(KiT_Action, "next")
+	mods, updt := st.Parts.ConfigChildren(config, false)
+	if mods {
+		pr := st.Parts.Child(0).(*Action)
+		pr.SetIcon("wedge-left")
+		st.StylePart(Node2D(pr))
+		pr.ActionSig.Connect(st.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			stt := recv.Embed(KiT_Stepper).(*Stepper)
+			stt.SetPageAction(stt.PageIdx - 1)
+		})
+		nx := st.Parts.Child(2).(*Action)
+		nx.SetIcon("wedge-right")
+		st.StylePart(Node2D(nx))
+		nx.ActionSig.Connect(st.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			stt := recv.Embed(KiT_Stepper).(*Stepper)
+			stt.SetPageAction(stt.PageIdx + 1)
+		})
+		st.UpdateEnd(updt)
+	}
+	dt := st.Parts.Child(1).(*Space)
+	dt.SetProp("width", units.NewEm(float32(st.Pages)*.8))
+	dt.SetProp("height", units.NewEm(1))
+}
+
+// RenderDots renders the page indicator dots over the dots space
+func (st *Stepper) RenderDots() {
+	if st.Pages <= 0 {
+		return
+	}
+	dt := st.Parts.Child(1).(*Space)
+	rs := &st.Viewport.Render
+	rs.Lock()
+	defer rs.Unlock()
+	pc := &rs.Paint
+	sty := &st.Sty
+	pos := dt.LayData.AllocPos
+	sz := dt.LayData.AllocSize
+	ctr := pos.Add(sz.MulScalar(.5))
+	spc := sz.X / float32(st.Pages)
+	rad := math32.Min(.3*spc, .25*sz.Y)
+	x0 := ctr.X - .5*spc*float32(st.Pages-1)
+	for i := 0; i < st.Pages; i++ {
+		if i == st.PageIdx {
+			pc.FillStyle.SetColor(sty.Font.Color)
+		} else {
+			pc.FillStyle.SetColor(sty.Font.Color.Highlight(50))
+		}
+		pc.DrawCircle(rs, x0+float32(i)*spc, ctr.Y, rad)
+		pc.Fill(rs)
+	}
+}
+
+func (st *Stepper) Layout2D(parBBox image.Rectangle, iter int) bool {
+	st.Layout2DBase(parBBox, true, iter)
+	st.Layout2DParts(parBBox, iter)
+	return st.Layout2DChildren(iter)
+}
+
+func (st *Stepper) Render2D() {
+	if st.FullReRenderIfNeeded() {
+		return
+	}
+	if st.PushBounds() {
+		st.This().(Node2D).ConnectEvents2D()
+		sty := &st.Sty
+		rs := &st.Viewport.Render
+		rs.Lock()
+		st.RenderStdBox(sty)
+		rs.Unlock()
+		st.Render2DParts()
+		st.RenderDots()
+		st.Render2DChildren()
+		st.PopBounds()
+	} else {
+		st.DisconnectAllEvents(RegPri)
+	}
+}
+
+func (st *Stepper) ConnectEvents2D() {
+	st.HoverTooltipEvent()
+}
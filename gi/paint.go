@@ -224,6 +224,7 @@ type RenderState struct {
 	// CompSpanner    *scanx.CompressSpanner `desc:"spanner for scanx"`
 	Scanner        *scanx.Scanner    `desc:"scanner for scanx"`
 	ImgSpanner     *scanx.ImgSpanner `desc:"spanner for scanx"`
+	AAQual         AAQualities       `desc:"anti-aliasing quality for fill / stroke rasterization -- see AAQualities -- initialized from the AAQuality default"`
 	Start          mat32.Vec2        `desc:"starting point, for close path"`
 	Current        mat32.Vec2        `desc:"current point"`
 	HasCurrent     bool              `desc:"is current point current?"`
@@ -235,30 +236,15 @@ type RenderState struct {
 	BoundsStack    []image.Rectangle `desc:"stack of bounds -- every render starts with a push onto this stack, and finishes with a pop"`
 	ClipStack      []*image.Alpha    `desc:"stack of clips, if needed"`
 	PaintBack      Paint             `desc:"backup of paint -- don't need a full stack but sometimes safer to backup and restore"`
+	Rec            *PaintRecorder    `desc:"if non-nil, all path / fill / stroke / text operations are also recorded here, for vector export (see RenderPDF)"`
 	RenderMu       sync.Mutex        `desc:"mutex for overall rendering"`
 	RasterMu       sync.Mutex        `desc:"mutex for final rasterx rendering -- only one at a time"`
 }
 
 // Init initializes RenderState -- must be called whenever image size changes
+// -- delegates to the active RenderBackend (default is the CPU rasterizer)
 func (rs *RenderState) Init(width, height int, img *image.RGBA) {
-	rs.Paint.Defaults()
-	rs.XForm = mat32.Identity2D()
-	rs.Image = img
-	// to use the golang.org/x/image/vector scanner, do this:
-	// rs.Scanner = rasterx.NewScannerGV(width, height, img, img.Bounds())
-	// and cut out painter:
-	/*
-		painter := scanFT.NewRGBAPainter(img)
-		rs.Scanner = scanFT.NewScannerFT(width, height, painter)
-	*/
-	/*
-		rs.CompSpanner = &scanx.CompressSpanner{}
-		rs.CompSpanner.SetBounds(img.Bounds())
-	*/
-	rs.ImgSpanner = scanx.NewImgSpanner(img)
-	rs.Scanner = scanx.NewScanner(rs.ImgSpanner, width, height)
-	// rs.Scanner = scanx.NewScanner(rs.CompSpanner, width, height)
-	rs.Raster = rasterx.NewDasher(width, height, rs.Scanner)
+	TheRenderBackend.InitState(rs, width, height, img)
 }
 
 // PushXForm pushes current xform onto stack and apply new xform on top of it
@@ -427,6 +413,9 @@ func (pc *Paint) MoveTo(rs *RenderState, x, y float32) {
 	}
 	p := pc.TransformPoint(rs, x, y)
 	rs.Path.Start(p.Fixed())
+	if rs.Rec != nil {
+		rs.Rec.PathMoveTo(p)
+	}
 	rs.Start = p
 	rs.Current = p
 	rs.HasCurrent = true
@@ -440,6 +429,9 @@ func (pc *Paint) LineTo(rs *RenderState, x, y float32) {
 	} else {
 		p := pc.TransformPoint(rs, x, y)
 		rs.Path.Line(p.Fixed())
+		if rs.Rec != nil {
+			rs.Rec.PathLineTo(p)
+		}
 		rs.Current = p
 	}
 }
@@ -454,6 +446,9 @@ func (pc *Paint) QuadraticTo(rs *RenderState, x1, y1, x2, y2 float32) {
 	p1 := pc.TransformPoint(rs, x1, y1)
 	p2 := pc.TransformPoint(rs, x2, y2)
 	rs.Path.QuadBezier(p1.Fixed(), p2.Fixed())
+	if rs.Rec != nil {
+		rs.Rec.PathQuadTo(rs.Current, p1, p2)
+	}
 	rs.Current = p2
 }
 
@@ -470,6 +465,9 @@ func (pc *Paint) CubicTo(rs *RenderState, x1, y1, x2, y2, x3, y3 float32) {
 	d := pc.TransformPoint(rs, x3, y3)
 
 	rs.Path.CubeBezier(b.Fixed(), c.Fixed(), d.Fixed())
+	if rs.Rec != nil {
+		rs.Rec.PathCubeTo(b, c, d)
+	}
 	rs.Current = d
 }
 
@@ -478,6 +476,9 @@ func (pc *Paint) CubicTo(rs *RenderState, x1, y1, x2, y2, x3, y3 float32) {
 func (pc *Paint) ClosePath(rs *RenderState) {
 	if rs.HasCurrent {
 		rs.Path.Stop(true)
+		if rs.Rec != nil {
+			rs.Rec.PathClose()
+		}
 		rs.Current = rs.Start
 	}
 }
@@ -486,6 +487,9 @@ func (pc *Paint) ClosePath(rs *RenderState) {
 // operation.
 func (pc *Paint) ClearPath(rs *RenderState) {
 	rs.Path.Clear()
+	if rs.Rec != nil {
+		rs.Rec.PathClear()
+	}
 	rs.HasCurrent = false
 }
 
@@ -554,6 +558,9 @@ func (pc *Paint) stroke(rs *RenderState) {
 	if rs.Raster == nil {
 		return
 	}
+	if rs.Rec != nil {
+		rs.Rec.Stroke(pc.StrokeStyle.Color.Color, pc.StrokeWidth(rs))
+	}
 	// pr := prof.Start("Paint.stroke")
 	// defer pr.End()
 
@@ -603,6 +610,9 @@ func (pc *Paint) fill(rs *RenderState) {
 	if rs.Raster == nil {
 		return
 	}
+	if rs.Rec != nil {
+		rs.Rec.Fill(pc.FillStyle.Color.Color, pc.FillStyle.Rule == FillRuleEvenOdd)
+	}
 	// pr := prof.Start("Paint.fill")
 	// pr.End()
 
@@ -683,9 +693,7 @@ func (pc *Paint) FillBoxColor(rs *RenderState, pos, size mat32.Vec2, clr color.C
 // clipping region with the current path as it would be filled by pc.Fill().
 // The path is preserved after this operation.
 func (pc *Paint) ClipPreserve(rs *RenderState) {
-	clip := image.NewAlpha(rs.Image.Bounds())
-	// painter := raster.NewAlphaOverPainter(clip) // todo!
-	pc.fill(rs)
+	clip := rs.RasterizeClipPath(rs.Path)
 	if rs.Mask == nil {
 		rs.Mask = clip
 	} else { // todo: this one operation MASSIVELY slows down clip usage -- unclear why
@@ -1009,6 +1017,9 @@ func (pc *Paint) DrawImageAnchored(rs *RenderState, fmIm image.Image, x, y int,
 	s := rs.Image.Bounds().Size()
 	x -= int(ax * float32(s.X))
 	y -= int(ay * float32(s.Y))
+	if rs.Rec != nil {
+		rs.Rec.Image(fmIm, mat32.Vec2{float32(x), float32(y)})
+	}
 	transformer := draw.BiLinear
 	fx, fy := float32(x), float32(y)
 	m := rs.XForm.Translate(fx, fy)
@@ -0,0 +1,81 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"sync"
+)
+
+// ParallelRender enables opt-in parallel rendering of independent child
+// subtrees: consecutive sibling sub-viewports (e.g., SVG icons, embedded
+// viewports) with non-overlapping VpBBoxes are dispatched to worker
+// goroutines -- each sub-viewport has its own RenderState, so clip and
+// transform stacks are per-goroutine, and non-overlapping bounds mean the
+// final draws into the parent image touch disjoint pixels.  Other
+// children render serially in order as usual.  Off by default.
+var ParallelRender = false
+
+// ParallelRenderThresh is the minimum number of children required before
+// parallel dispatch is considered
+var ParallelRenderThresh = 4
+
+// render2DKids renders all of node's children, using parallel dispatch of
+// independent sub-viewport runs when ParallelRender is enabled -- shared
+// impl for Node2DBase and Layout Render2DChildren
+func render2DKids(nb *Node2DBase) {
+	if !ParallelRender || len(nb.Kids) < ParallelRenderThresh {
+		for _, kid := range nb.Kids {
+			nii, ni := KiToNode2D(kid)
+			if nii != nil {
+				renderCachedOrLive(nii, ni)
+			}
+		}
+		return
+	}
+	var run []Node2D // current run of parallelizable sub-viewports
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if len(run) == 1 {
+			run[0].Render2D()
+		} else {
+			var wg sync.WaitGroup
+			for _, rii := range run {
+				wg.Add(1)
+				go func(rii Node2D) {
+					defer wg.Done()
+					rii.Render2D()
+				}(rii)
+			}
+			wg.Wait()
+		}
+		run = run[:0]
+	}
+	for _, kid := range nb.Kids {
+		nii, ni := KiToNode2D(kid)
+		if nii == nil {
+			continue
+		}
+		if nii.AsViewport2D() != nil && parIndepOfRun(ni, run) {
+			run = append(run, nii)
+			continue
+		}
+		flush()
+		renderCachedOrLive(nii, ni)
+	}
+	flush()
+}
+
+// parIndepOfRun returns true if given node's VpBBox does not overlap any
+// node already in the run, so they can render concurrently
+func parIndepOfRun(ni *Node2DBase, run []Node2D) bool {
+	for _, rii := range run {
+		if ni.VpBBox.Overlaps(rii.AsNode2D().VpBBox) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+
+	"github.com/goki/gi/mat32"
+)
+
+// PDF export: the scene is re-rendered with a PaintRecorder attached to
+// the RenderState, which captures path construction, fill / stroke, and
+// text operations as they happen, and replays them as vector operations
+// into a single-page PDF.  Limitations: gradients are flattened to their
+// solid color, text is emitted using the Helvetica core font (metrics are
+// approximate), and bitmap images are not included.
+
+// PDFOpts are options for Viewport2D.RenderPDF
+type PDFOpts struct {
+	Title string `desc:"document title, in PDF metadata"`
+}
+
+// RenderPDF re-renders the scene under this viewport and writes it as a
+// vector PDF page of the same size to given writer -- see PDFOpts
+func (vp *Viewport2D) RenderPDF(w io.Writer, opts PDFOpts) error {
+	rec := &PaintRecorder{}
+	vp.Render.Rec = rec
+	vp.FullRender2DTree()
+	vp.Render.Rec = nil
+	sz := vp.Geom.Size
+	return rec.WritePDF(w, float32(sz.X), float32(sz.Y), opts)
+}
+
+// pdfPathOp is one recorded path-construction operation
+type pdfPathOp struct {
+	Verb byte // 'm' move, 'l' line, 'c' cubic, 'h' close
+	Pts  [3]mat32.Vec2
+}
+
+// pdfCmd is one recorded painting command: a fill or stroke of the
+// current path, or a text run
+type pdfCmd struct {
+	Path    []pdfPathOp
+	Stroke  bool
+	Clr     Color
+	Width   float32
+	EvenOdd bool
+	Txt     string
+	Pos     mat32.Vec2
+	Size    float32
+	Img     image.Image
+}
+
+// PaintRecorder records Paint operations during rendering, for vector
+// export -- attach to RenderState.Rec
+type PaintRecorder struct {
+	cur  []pdfPathOp
+	Cmds []pdfCmd
+}
+
+func (pr *PaintRecorder) PathMoveTo(p mat32.Vec2) {
+	pr.cur = append(pr.cur, pdfPathOp{Verb: 'm', Pts: [3]mat32.Vec2{p}})
+}
+
+func (pr *PaintRecorder) PathLineTo(p mat32.Vec2) {
+	pr.cur = append(pr.cur, pdfPathOp{Verb: 'l', Pts: [3]mat32.Vec2{p}})
+}
+
+// PathQuadTo records a quadratic bezier from cur via p1 to p2, elevated
+// to the cubic form that PDF supports
+func (pr *PaintRecorder) PathQuadTo(cur, p1, p2 mat32.Vec2) {
+	c1 := cur.Add(p1.Sub(cur).MulScalar(2.0 / 3.0))
+	c2 := p2.Add(p1.Sub(p2).MulScalar(2.0 / 3.0))
+	pr.cur = append(pr.cur, pdfPathOp{Verb: 'c', Pts: [3]mat32.Vec2{c1, c2, p2}})
+}
+
+func (pr *PaintRecorder) PathCubeTo(b, c, d mat32.Vec2) {
+	pr.cur = append(pr.cur, pdfPathOp{Verb: 'c', Pts: [3]mat32.Vec2{b, c, d}})
+}
+
+func (pr *PaintRecorder) PathClose() {
+	pr.cur = append(pr.cur, pdfPathOp{Verb: 'h'})
+}
+
+func (pr *PaintRecorder) PathClear() {
+	pr.cur = nil
+}
+
+// Fill records a fill of the current path with given solid color
+func (pr *PaintRecorder) Fill(clr Color, evenOdd bool) {
+	if len(pr.cur) == 0 {
+		return
+	}
+	pth := make([]pdfPathOp, len(pr.cur))
+	copy(pth, pr.cur)
+	pr.Cmds = append(pr.Cmds, pdfCmd{Path: pth, Clr: clr, EvenOdd: evenOdd})
+}
+
+// Stroke records a stroke of the current path with given solid color and
+// line width
+func (pr *PaintRecorder) Stroke(clr Color, width float32) {
+	if len(pr.cur) == 0 {
+		return
+	}
+	pth := make([]pdfPathOp, len(pr.cur))
+	copy(pth, pr.cur)
+	pr.Cmds = append(pr.Cmds, pdfCmd{Path: pth, Stroke: true, Clr: clr, Width: width})
+}
+
+// Text records a text run at given baseline position and font size
+func (pr *PaintRecorder) Text(txt string, pos mat32.Vec2, size float32, clr Color) {
+	if txt == "" {
+		return
+	}
+	pr.Cmds = append(pr.Cmds, pdfCmd{Txt: txt, Pos: pos, Size: size, Clr: clr})
+}
+
+// pdfEscape escapes a string for inclusion in a PDF literal string
+func pdfEscape(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, "(", `\(`, -1)
+	s = strings.Replace(s, ")", `\)`, -1)
+	return s
+}
+
+// contentStream renders the recorded commands as a PDF content stream for
+// a page of given height (PDF origin is bottom-left, so y is flipped)
+func (pr *PaintRecorder) contentStream(ht float32) []byte {
+	var b bytes.Buffer
+	for _, cmd := range pr.Cmds {
+		if cmd.Img != nil {
+			continue // images not supported in PDF export
+		}
+		r, g, bl, _ := cmd.Clr.ToNPFloat32()
+		if cmd.Txt != "" {
+			fmt.Fprintf(&b, "%.3f %.3f %.3f rg BT /F1 %.2f Tf %.2f %.2f Td (%s) Tj ET\n",
+				r, g, bl, cmd.Size, cmd.Pos.X, ht-cmd.Pos.Y, pdfEscape(cmd.Txt))
+			continue
+		}
+		if cmd.Stroke {
+			fmt.Fprintf(&b, "%.3f %.3f %.3f RG %.2f w\n", r, g, bl, cmd.Width)
+		} else {
+			fmt.Fprintf(&b, "%.3f %.3f %.3f rg\n", r, g, bl)
+		}
+		for _, op := range cmd.Path {
+			switch op.Verb {
+			case 'm':
+				fmt.Fprintf(&b, "%.2f %.2f m\n", op.Pts[0].X, ht-op.Pts[0].Y)
+			case 'l':
+				fmt.Fprintf(&b, "%.2f %.2f l\n", op.Pts[0].X, ht-op.Pts[0].Y)
+			case 'c':
+				fmt.Fprintf(&b, "%.2f %.2f %.2f %.2f %.2f %.2f c\n",
+					op.Pts[0].X, ht-op.Pts[0].Y, op.Pts[1].X, ht-op.Pts[1].Y, op.Pts[2].X, ht-op.Pts[2].Y)
+			case 'h':
+				fmt.Fprintf(&b, "h\n")
+			}
+		}
+		switch {
+		case cmd.Stroke:
+			fmt.Fprintf(&b, "S\n")
+		case cmd.EvenOdd:
+			fmt.Fprintf(&b, "f*\n")
+		default:
+			fmt.Fprintf(&b, "f\n")
+		}
+	}
+	return b.Bytes()
+}
+
+// WritePDF writes the recorded commands as a single-page PDF of given
+// width and height (in points == pixels at 72 DPI) to given writer
+func (pr *PaintRecorder) WritePDF(w io.Writer, wd, ht float32, opts PDFOpts) error {
+	content := pr.contentStream(ht)
+	objs := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >>", wd, ht),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+	if opts.Title != "" {
+		objs = append(objs, fmt.Sprintf("<< /Title (%s) >>", pdfEscape(opts.Title)))
+	}
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offs := make([]int, len(objs))
+	for i, obj := range objs {
+		offs[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objs)+1)
+	for _, off := range offs {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R", len(objs)+1)
+	if opts.Title != "" {
+		fmt.Fprintf(&b, " /Info %d 0 R", len(objs))
+	}
+	fmt.Fprintf(&b, " >>\nstartxref\n%d\n%%%%EOF\n", xref)
+	_, err := w.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+)
+
+// Pixel-diff debugging: when PixelDiffTracking is on, each viewport keeps
+// a copy of its previous Pixels, and after each render pass logs the
+// bounding box of the pixels that actually changed -- a pass that changes
+// no pixels is flagged as a spurious re-render.  With PixelDiffHighlight
+// also on, changed pixels are tinted red on screen, visualizing exactly
+// what ReRender2DNode touched.  Debug-only -- adds a full image copy and
+// compare per render pass.
+
+// PixelDiffTracking turns on pixel-diff debugging of render passes --
+// see also PixelDiffHighlight
+var PixelDiffTracking = false
+
+// PixelDiffHighlight tints the changed pixels red on screen when
+// PixelDiffTracking is on
+var PixelDiffHighlight = false
+
+// PixelDiffUpdate compares Pixels against the copy saved from before the
+// previous render pass, logging the bounding box of changed pixels (and
+// tinting them if PixelDiffHighlight), then saves the current Pixels for
+// the next comparison -- called at the end of UpdateNodes when
+// PixelDiffTracking is on
+func (vp *Viewport2D) PixelDiffUpdate() {
+	if vp.Pixels == nil {
+		return
+	}
+	cur := vp.Pixels
+	prev := vp.PixDiffPrev
+	if prev == nil || prev.Bounds() != cur.Bounds() {
+		vp.PixDiffPrev = vpCopyPixels(cur)
+		return
+	}
+	dr, ndif := pixelDiffBBox(cur, prev)
+	if ndif == 0 {
+		fmt.Printf("PixelDiff: Viewport2D: %v render pass changed no pixels -- spurious re-render?\n", vp.PathUnique())
+	} else {
+		fmt.Printf("PixelDiff: Viewport2D: %v changed bbox: %v (%v pixels)\n", vp.PathUnique(), dr, ndif)
+	}
+	vp.PixDiffPrev = vpCopyPixels(cur) // save before any tinting
+	if PixelDiffHighlight && ndif > 0 {
+		pixelDiffTint(cur, prev, dr)
+	}
+}
+
+// vpCopyPixels returns a copy of given image
+func vpCopyPixels(img *image.RGBA) *image.RGBA {
+	cp := image.NewRGBA(img.Bounds())
+	copy(cp.Pix, img.Pix)
+	return cp
+}
+
+// pixelDiffBBox returns the bounding box and count of pixels that differ
+// between the two same-sized images
+func pixelDiffBBox(cur, prev *image.RGBA) (image.Rectangle, int) {
+	b := cur.Bounds()
+	dr := image.Rectangle{}
+	ndif := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		ci := cur.PixOffset(b.Min.X, y)
+		pi := prev.PixOffset(b.Min.X, y)
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if cur.Pix[ci] != prev.Pix[pi] || cur.Pix[ci+1] != prev.Pix[pi+1] ||
+				cur.Pix[ci+2] != prev.Pix[pi+2] || cur.Pix[ci+3] != prev.Pix[pi+3] {
+				if ndif == 0 {
+					dr = image.Rect(x, y, x+1, y+1)
+				} else {
+					dr = dr.Union(image.Rect(x, y, x+1, y+1))
+				}
+				ndif++
+			}
+			ci += 4
+			pi += 4
+		}
+	}
+	return dr, ndif
+}
+
+// pixelDiffTint tints the pixels within given bbox that differ between
+// the two images toward red, so they are visible on screen
+func pixelDiffTint(cur, prev *image.RGBA, dr image.Rectangle) {
+	for y := dr.Min.Y; y < dr.Max.Y; y++ {
+		ci := cur.PixOffset(dr.Min.X, y)
+		pi := prev.PixOffset(dr.Min.X, y)
+		for x := dr.Min.X; x < dr.Max.X; x++ {
+			if cur.Pix[ci] != prev.Pix[pi] || cur.Pix[ci+1] != prev.Pix[pi+1] ||
+				cur.Pix[ci+2] != prev.Pix[pi+2] || cur.Pix[ci+3] != prev.Pix[pi+3] {
+				cur.Pix[ci] = 255 - (255-cur.Pix[ci])/2 // push red up, green / blue down
+				cur.Pix[ci+1] /= 2
+				cur.Pix[ci+2] /= 2
+			}
+			ci += 4
+			pi += 4
+		}
+	}
+}
@@ -57,7 +57,10 @@ type ColorPrefs struct {
 type ParamPrefs struct {
 	DoubleClickMSec int  `min:"100" step:"50" desc:"the maximum time interval in msec between button press events to count as a double-click"`
 	ScrollWheelRate int  `min:"1" step:"1" desc:"how fast the scroll wheel moves -- typically pixels per wheel step -- only used for OS's that do not have a native preference for this (e.g., X11)"`
+	CursorBlinkMSec int  `min:"0" step:"50" desc:"number of milliseconds that the text cursor blinks on and off -- 0 disables blinking -- 0 in saved prefs means use the default"`
 	LocalMainMenu   bool `desc:"controls whether the main menu is displayed locally at top of each window, in addition to global menu at the top of the screen.  Mac native apps do not do this, but OTOH it makes things more consistent with other platforms, and with larger screens, it can be convenient to have access to all the menu items right there."`
+	PrimaryPaste    bool `desc:"support the X11 primary selection: selecting text sets it, and middle-click pastes it -- only has an effect on platforms with a primary selection (Linux / X11)"`
+	MaxPublishFPS   int  `step:"10" desc:"maximum number of window updates published to the screen per second -- extra updates within a frame interval are coalesced into one deferred update, so nothing is lost -- 0 = no limit, negative = limit to the screen's refresh rate (vsync)"`
 }
 
 // User basic user information that might be needed for different apps
@@ -74,6 +77,9 @@ type Preferences struct {
 	LogicalDPIScale      float32                `min:"0.1" step:"0.1" desc:"overall scaling factor for Logical DPI as a multiplier on Physical DPI -- smaller numbers produce smaller font sizes etc"`
 	ScreenPrefs          map[string]ScreenPrefs `desc:"screen-specific preferences -- will override overall defaults if set"`
 	Colors               ColorPrefs             `desc:"color preferences"`
+	Density              Densities              `desc:"overall UI density -- scales default margins, paddings, layout spacing, and icon sizes of all widgets -- compact / dense settings are useful for data-dense professional tools"`
+	HighContrast         bool                   `desc:"use maximum-contrast color variants (black on white, full-strength focus and selection colors) -- also turned on automatically when the OS accessibility settings request it"`
+	ReducedMotion        bool                   `desc:"disable non-essential animations (collapse, page slide, icon transitions) -- also turned on automatically when the OS accessibility settings request it"`
 	Params               ParamPrefs             `desc:"parameters controlling GUI behavior"`
 	KeyMap               KeyMapName             `desc:"select the active keymap from list of available keymaps -- see Edit KeyMaps for editing / saving / loading that list"`
 	SaveKeyMaps          bool                   `desc:"if set, the current available set of key maps is saved to your preferences directory, and automatically loaded at startup -- this should be set if you are using custom key maps, but it may be safer to keep it <i>OFF</i> if you are <i>not</i> using custom key maps, so that you'll always have the latest compiled-in standard key maps with all the current key functions bound to standard key chords"`
@@ -139,6 +145,7 @@ func (pf *ParamPrefs) Defaults() {
 	pf.DoubleClickMSec = 500
 	pf.ScrollWheelRate = 20
 	pf.LocalMainMenu = true // much better
+	pf.PrimaryPaste = true  // standard expectation on X11, no effect elsewhere
 }
 
 func (pf *Preferences) Defaults() {
@@ -156,16 +163,20 @@ func (pf *Preferences) Defaults() {
 // PrefsFileName is the name of the preferences file in GoGi prefs directory
 var PrefsFileName = "prefs.json"
 
-// Open preferences from GoGi standard prefs directory
+// PrefsStoreKey is the key under which the main preferences are stored in
+// the active PrefsStore backend -- derived from PrefsFileName
+func PrefsStoreKey() string {
+	return strings.TrimSuffix(PrefsFileName, ".json")
+}
+
+// Open preferences from the active PrefsStore backend (default is the
+// standard JSON file in the GoGi prefs directory)
 func (pf *Preferences) Open() error {
-	pdir := oswin.TheApp.GoGiPrefsDir()
-	pnm := filepath.Join(pdir, PrefsFileName)
-	b, err := ioutil.ReadFile(pnm)
+	err := ThePrefsStore.OpenPrefs(PrefsStoreKey(), pf)
 	if err != nil {
 		// log.Println(err) // ok to be non-existant
 		return err
 	}
-	err = json.Unmarshal(b, pf)
 	if pf.SaveKeyMaps {
 		err = AvailKeyMaps.OpenPrefs()
 		if err != nil {
@@ -182,16 +193,15 @@ func (pf *Preferences) Open() error {
 	return err
 }
 
-// Save Preferences to GoGi standard prefs directory
+// Save Preferences to the active PrefsStore backend (default is the
+// standard JSON file in the GoGi prefs directory)
 func (pf *Preferences) Save() error {
-	pdir := oswin.TheApp.GoGiPrefsDir()
-	pnm := filepath.Join(pdir, PrefsFileName)
-	b, err := json.MarshalIndent(pf, "", "  ")
-	if err != nil {
-		log.Println(err)
-		return err
+	if hcSavedColors != nil { // save the user's colors, not the high-contrast variants
+		clrs := pf.Colors
+		pf.Colors = *hcSavedColors
+		defer func() { pf.Colors = clrs }()
 	}
-	err = ioutil.WriteFile(pnm, b, 0644)
+	err := ThePrefsStore.SavePrefs(PrefsStoreKey(), pf)
 	if err != nil {
 		log.Println(err)
 	}
@@ -202,6 +212,7 @@ func (pf *Preferences) Save() error {
 		PrefsDet.Save()
 	}
 	pf.Changed = false
+	PrefsChanged()
 	return err
 }
 
@@ -233,7 +244,13 @@ func (pf *Preferences) Apply() {
 
 	mouse.DoubleClickMSec = pf.Params.DoubleClickMSec
 	mouse.ScrollWheelRate = pf.Params.ScrollWheelRate
+	if pf.Params.CursorBlinkMSec > 0 {
+		CursorBlinkMSec = pf.Params.CursorBlinkMSec
+	}
 	LocalMainMenu = pf.Params.LocalMainMenu
+	WinPublishFPS = pf.Params.MaxPublishFPS
+	Density = pf.Density
+	pf.ApplyAccess()
 
 	if pf.KeyMap != "" {
 		SetActiveKeyMapName(pf.KeyMap) // fills in missing pieces
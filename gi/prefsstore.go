@@ -0,0 +1,202 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/goki/gi/oswin"
+	"github.com/goki/ki/ki"
+)
+
+// PrefsStore is the interface for pluggable preference storage backends:
+// the standard JSON file in the GoGi prefs directory (FilePrefsStore,
+// the default), OS-native stores (NativePrefsStore: defaults on macOS,
+// registry on Windows), and an in-memory store for tests (MemPrefsStore).
+// Select a backend at app init with SetPrefsStore, before opening prefs.
+type PrefsStore interface {
+	// Name returns a short descriptive name of this store
+	Name() string
+
+	// OpenPrefs loads the preferences object stored under given key into
+	// prefs (a pointer) -- returns an error if not present
+	OpenPrefs(key string, prefs interface{}) error
+
+	// SavePrefs saves given preferences object under given key
+	SavePrefs(key string, prefs interface{}) error
+}
+
+// ThePrefsStore is the active preference storage backend -- default is the
+// standard JSON file -- set with SetPrefsStore
+var ThePrefsStore PrefsStore = &FilePrefsStore{}
+
+// ThePrefsNotify relays preference change notifications -- open Prefs
+// views connect to ChangedSig to refresh when preferences are saved or the
+// backend is switched
+var ThePrefsNotify PrefsNotifyMgr
+
+func init() {
+	ThePrefsNotify.InitName(&ThePrefsNotify, "prefs-notify")
+}
+
+// PrefsNotifyMgr relays preference change notifications via ChangedSig
+type PrefsNotifyMgr struct {
+	ki.Node
+	ChangedSig ki.Signal `copy:"-" json:"-" xml:"-" view:"-" desc:"emitted whenever preferences are saved or reloaded from a new backend"`
+}
+
+// PrefsChanged notifies any connected views that preferences have changed
+func PrefsChanged() {
+	ThePrefsNotify.ChangedSig.Emit(ThePrefsNotify.This(), 0, nil)
+}
+
+// SetPrefsStore sets the active preference storage backend and reloads the
+// main preferences from it, notifying any open views -- call at app init
+func SetPrefsStore(st PrefsStore) {
+	ThePrefsStore = st
+	Prefs.Open()
+	PrefsChanged()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  FilePrefsStore
+
+// FilePrefsStore stores each preferences object as a JSON file named after
+// its key in the GoGi prefs directory -- this is the default backend
+type FilePrefsStore struct {
+}
+
+func (st *FilePrefsStore) Name() string {
+	return "json-file"
+}
+
+func (st *FilePrefsStore) path(key string) string {
+	return filepath.Join(oswin.TheApp.GoGiPrefsDir(), key+".json")
+}
+
+func (st *FilePrefsStore) OpenPrefs(key string, prefs interface{}) error {
+	b, err := ioutil.ReadFile(st.path(key))
+	if err != nil {
+		return err // ok to be non-existent
+	}
+	return json.Unmarshal(b, prefs)
+}
+
+func (st *FilePrefsStore) SavePrefs(key string, prefs interface{}) error {
+	b, err := json.MarshalIndent(prefs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(st.path(key), b, 0644)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  MemPrefsStore
+
+// MemPrefsStore keeps preferences in memory only -- for tests and other
+// cases where nothing should touch the user's actual preferences
+type MemPrefsStore struct {
+	Mu   sync.Mutex        `view:"-" desc:"mutex protecting Data"`
+	Data map[string][]byte `desc:"stored preference objects as JSON, by key"`
+}
+
+func (st *MemPrefsStore) Name() string {
+	return "memory"
+}
+
+func (st *MemPrefsStore) OpenPrefs(key string, prefs interface{}) error {
+	st.Mu.Lock()
+	b, has := st.Data[key]
+	st.Mu.Unlock()
+	if !has {
+		return fmt.Errorf("gi.MemPrefsStore: no prefs stored under key: %v", key)
+	}
+	return json.Unmarshal(b, prefs)
+}
+
+func (st *MemPrefsStore) SavePrefs(key string, prefs interface{}) error {
+	b, err := json.Marshal(prefs)
+	if err != nil {
+		return err
+	}
+	st.Mu.Lock()
+	if st.Data == nil {
+		st.Data = make(map[string][]byte)
+	}
+	st.Data[key] = b
+	st.Mu.Unlock()
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  NativePrefsStore
+
+// NativePrefsStore stores preferences as JSON blobs in the OS-native
+// preference mechanism: defaults on macOS and the registry on Windows --
+// on other platforms it falls back to the standard JSON file.  The domain
+// should identify the app uniquely, e.g., com.example.myapp.
+type NativePrefsStore struct {
+	Domain string `desc:"app domain under which prefs are stored, e.g., com.example.myapp"`
+}
+
+// NewNativePrefsStore returns a native store for given app domain
+func NewNativePrefsStore(domain string) *NativePrefsStore {
+	return &NativePrefsStore{Domain: domain}
+}
+
+func (st *NativePrefsStore) Name() string {
+	return "os-native"
+}
+
+func (st *NativePrefsStore) regKey() string {
+	return `HKCU\Software\` + st.Domain
+}
+
+func (st *NativePrefsStore) OpenPrefs(key string, prefs interface{}) error {
+	switch runtime.GOOS {
+	case "darwin":
+		out, err := exec.Command("defaults", "read", st.Domain, key).Output()
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal([]byte(strings.TrimSpace(string(out))), prefs)
+	case "windows":
+		out, err := exec.Command("reg", "query", st.regKey(), "/v", key).Output()
+		if err != nil {
+			return err
+		}
+		ri := strings.Index(string(out), "REG_SZ")
+		if ri < 0 {
+			return fmt.Errorf("gi.NativePrefsStore: no registry value for key: %v", key)
+		}
+		val := strings.TrimSpace(string(out)[ri+len("REG_SZ"):])
+		return json.Unmarshal([]byte(val), prefs)
+	}
+	fst := &FilePrefsStore{}
+	return fst.OpenPrefs(key, prefs)
+}
+
+func (st *NativePrefsStore) SavePrefs(key string, prefs interface{}) error {
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		b, err := json.Marshal(prefs)
+		if err != nil {
+			return err
+		}
+		if runtime.GOOS == "darwin" {
+			return exec.Command("defaults", "write", st.Domain, key, "-string", string(b)).Run()
+		}
+		return exec.Command("reg", "add", st.regKey(), "/v", key, "/t", "REG_SZ", "/d", string(b), "/f").Run()
+	}
+	fst := &FilePrefsStore{}
+	return fst.SavePrefs(key, prefs)
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"time"
+
+	"github.com/goki/gi/mat32"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mouse"
+)
+
+// Present mode renders screencast annotations above all window content:
+// an expanding ripple at each mouse click, and an on-screen display of
+// recently-pressed key combinations -- for demos, screencasts, and remote
+// support sessions.  Toggle per-window with KeyFunPresent (Control+Alt+K
+// in the default keymaps) or Window.SetPresentMode.  Annotations are
+// drawn via the overlay drawer mechanism (see overlay.go) so they never
+// perturb layout or trigger re-rendering of the content underneath.
+
+// PresentOverlayName is the overlay drawer name used by present mode --
+// the zz- prefix sorts it after other drawers so annotations are on top
+const PresentOverlayName = "zz-present"
+
+var (
+	// PresentClickColor is the color of the click ripples in present mode
+	PresentClickColor = Color{255, 80, 80, 255}
+
+	// PresentRippleMSec is how long a click ripple takes to expand and fade
+	PresentRippleMSec = 500
+
+	// PresentRippleRadius is the full radius of a click ripple in dots
+	PresentRippleRadius = float32(40)
+
+	// PresentKeyMSec is how long a pressed key combination stays on screen
+	PresentKeyMSec = 2000
+
+	// PresentMaxKeys is the maximum number of key combinations shown at once
+	PresentMaxKeys = 4
+)
+
+// presentClick is one active click ripple
+type presentClick struct {
+	Pos   image.Point
+	Start time.Time
+}
+
+// presentKey is one key combination on the on-screen display
+type presentKey struct {
+	Chord string
+	Start time.Time
+	Count int
+}
+
+// PresentState is the active annotation state for a window in present
+// mode -- created by Window.SetPresentMode
+type PresentState struct {
+	Win    *Window        `desc:"our window"`
+	Mu     sync.Mutex     `desc:"protects Clicks, Keys, Anim"`
+	Clicks []presentClick `desc:"active click ripples"`
+	Keys   []presentKey   `desc:"key combinations currently on screen, oldest first"`
+	Anim   bool           `desc:"animation ticker goroutine is running"`
+}
+
+// IsPresentMode returns whether present mode is active on this window
+func (w *Window) IsPresentMode() bool {
+	return w.Present != nil
+}
+
+// SetPresentMode turns present mode on or off for this window, adding or
+// removing the annotation overlay drawer
+func (w *Window) SetPresentMode(on bool) {
+	if on == w.IsPresentMode() {
+		return
+	}
+	if on {
+		ps := &PresentState{Win: w}
+		w.Present = ps
+		w.AddOverlayDrawer(PresentOverlayName, ps.Draw)
+	} else {
+		w.Present = nil
+		w.DeleteOverlayDrawer(PresentOverlayName)
+	}
+}
+
+// TogglePresentMode toggles present mode -- this is what KeyFunPresent does
+func (w *Window) TogglePresentMode() {
+	w.SetPresentMode(!w.IsPresentMode())
+}
+
+// MousePress records a mouse press as a new click ripple -- called from
+// window event processing, does not consume the event
+func (ps *PresentState) MousePress(e *mouse.Event) {
+	ps.Mu.Lock()
+	ps.Clicks = append(ps.Clicks, presentClick{Pos: e.Pos(), Start: time.Now()})
+	ps.Mu.Unlock()
+	ps.StartAnim()
+}
+
+// KeyChord records a key combination for the on-screen display -- called
+// from window event processing, does not consume the event.  Only chords
+// with a Control / Alt / Meta modifier or without a printable rune are
+// shown, so ordinary typing (and anything sensitive) stays off screen.
+// Repeats of the same chord bump a count instead of stacking up.
+func (ps *PresentState) KeyChord(e *key.ChordEvent) {
+	if key.CodeIsModifier(e.Code) {
+		return
+	}
+	if e.Rune > 0 && !e.HasAnyModifier(key.Control, key.Alt, key.Meta) {
+		return
+	}
+	cs := string(e.Chord())
+	now := time.Now()
+	ps.Mu.Lock()
+	n := len(ps.Keys)
+	if n > 0 && ps.Keys[n-1].Chord == cs {
+		ps.Keys[n-1].Count++
+		ps.Keys[n-1].Start = now
+	} else {
+		ps.Keys = append(ps.Keys, presentKey{Chord: cs, Start: now, Count: 1})
+		if len(ps.Keys) > PresentMaxKeys {
+			ps.Keys = ps.Keys[len(ps.Keys)-PresentMaxKeys:]
+		}
+	}
+	ps.Mu.Unlock()
+	ps.StartAnim()
+}
+
+// StartAnim starts the animation ticker goroutine that re-renders the
+// overlays while annotations are active, if not already running
+func (ps *PresentState) StartAnim() {
+	ps.Mu.Lock()
+	if ps.Anim {
+		ps.Mu.Unlock()
+		return
+	}
+	ps.Anim = true
+	ps.Mu.Unlock()
+	go ps.animate()
+}
+
+// animate re-renders the overlays at ~30fps until all annotations have
+// expired, pruning them as they go
+func (ps *PresentState) animate() {
+	for {
+		time.Sleep(33 * time.Millisecond)
+		w := ps.Win
+		if w.IsClosed() || w.Present != ps {
+			return
+		}
+		now := time.Now()
+		ps.Mu.Lock()
+		nc := ps.Clicks[:0]
+		for _, cl := range ps.Clicks {
+			if now.Sub(cl.Start) < time.Duration(PresentRippleMSec)*time.Millisecond {
+				nc = append(nc, cl)
+			}
+		}
+		ps.Clicks = nc
+		nk := ps.Keys[:0]
+		for _, pk := range ps.Keys {
+			if now.Sub(pk.Start) < time.Duration(PresentKeyMSec)*time.Millisecond {
+				nk = append(nk, pk)
+			}
+		}
+		ps.Keys = nk
+		done := len(ps.Clicks) == 0 && len(ps.Keys) == 0
+		if done {
+			ps.Anim = false
+		}
+		ps.Mu.Unlock()
+		w.UpdateOverlays()
+		if done {
+			return
+		}
+	}
+}
+
+// Draw is the overlay drawer for present mode -- paints the click
+// ripples and the key combination display
+func (ps *PresentState) Draw(pc *Paint, rs *RenderState, wsz image.Point) {
+	now := time.Now()
+	ps.Mu.Lock()
+	clicks := append([]presentClick(nil), ps.Clicks...)
+	keys := append([]presentKey(nil), ps.Keys...)
+	ps.Mu.Unlock()
+
+	for _, cl := range clicks {
+		f := float32(now.Sub(cl.Start)) / float32(time.Duration(PresentRippleMSec)*time.Millisecond)
+		if f < 0 || f >= 1 {
+			continue
+		}
+		ef := 1 - (1-f)*(1-f) // ease-out
+		clr := PresentClickColor
+		clr.A = uint8(220 * (1 - f))
+		pc.FillStyle.SetColor(nil)
+		pc.StrokeStyle.SetColor(clr)
+		pc.StrokeStyle.Width.Dots = 1 + 3*(1-f)
+		pc.DrawCircle(rs, float32(cl.Pos.X), float32(cl.Pos.Y), PresentRippleRadius*ef)
+		pc.Stroke(rs)
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+	sty := &ps.Win.Viewport.Sty
+	fst := sty.Font
+	fst.BgColor.SetColor(nil)
+	fst.Color.SetUInt8(255, 255, 255, 255)
+	marg := float32(12)
+	pad := float32(8)
+	y := float32(wsz.Y) - marg
+	for i := len(keys) - 1; i >= 0; i-- {
+		pk := keys[i]
+		txt := pk.Chord
+		if pk.Count > 1 {
+			txt = fmt.Sprintf("%v x%v", pk.Chord, pk.Count)
+		}
+		left := float32(time.Duration(PresentKeyMSec)*time.Millisecond-now.Sub(pk.Start)) / float32(300*time.Millisecond)
+		alpha := float32(1)
+		if left < 1 { // fade in the last 300 msec
+			alpha = mat32.Max(left, 0)
+		}
+		fst.Color.A = uint8(255 * alpha)
+		var tr TextRender
+		tr.SetString(txt, &fst, &sty.UnContext, &sty.Text, true, 0, 0)
+		bw := tr.Size.X + 2*pad
+		bh := tr.Size.Y + 2*pad
+		bx := .5 * (float32(wsz.X) - bw)
+		y -= bh
+		pc.StrokeStyle.SetColor(nil)
+		pc.FillStyle.SetColor(Color{0, 0, 0, uint8(180 * alpha)})
+		pc.DrawRoundedRectangle(rs, bx, y, bw, bh, 6)
+		pc.Fill(rs)
+		tr.Render(rs, mat32.Vec2{bx + pad, y + pad})
+		y -= marg * .5
+	}
+}
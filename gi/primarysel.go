@@ -0,0 +1,47 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/clip"
+	"github.com/goki/gi/oswin/mimedata"
+	"github.com/goki/pi/filecat"
+)
+
+// The X11 primary selection: selecting text in a widget sets it, and
+// middle-click pastes it, per standard Linux behavior.  Only active on
+// platforms whose clip.Board implements clip.PrimaryBoarder, and governed
+// by the Prefs.Params.PrimaryPaste toggle.
+
+// PrimarySelWrite writes given text to the primary selection, if supported
+// by the platform and enabled in preferences
+func PrimarySelWrite(win *Window, txt string) {
+	if !Prefs.Params.PrimaryPaste || txt == "" || win == nil {
+		return
+	}
+	pb, ok := oswin.TheApp.ClipBoard(win.OSWin).(clip.PrimaryBoarder)
+	if !ok {
+		return
+	}
+	pb.WritePrimary(mimedata.NewText(txt))
+}
+
+// PrimarySelRead reads text from the primary selection, returning "" if
+// not supported by the platform or disabled in preferences
+func PrimarySelRead(win *Window) string {
+	if !Prefs.Params.PrimaryPaste || win == nil {
+		return ""
+	}
+	pb, ok := oswin.TheApp.ClipBoard(win.OSWin).(clip.PrimaryBoarder)
+	if !ok {
+		return ""
+	}
+	data := pb.ReadPrimary([]string{filecat.TextPlain})
+	if data == nil {
+		return ""
+	}
+	return data.Text(filecat.TextPlain)
+}
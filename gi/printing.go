@@ -0,0 +1,187 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	idraw "image/draw"
+
+	"github.com/goki/ki/kit"
+	"golang.org/x/image/draw"
+)
+
+// This file provides the basic printing subsystem: paper sizes, page setup
+// settings, and pagination of rendered content into page images.  Actual
+// transmission to a printer or PDF export is platform / driver specific and
+// is provided via the PrinterSendFunc and PDFExportFunc hooks -- see
+// PrintPreview for the interactive preview dialog.
+
+// PaperSize is one standard paper size, in mm, in portrait orientation
+type PaperSize struct {
+	Name string  `desc:"name of the paper size"`
+	Wd   float32 `desc:"width in mm, in portrait orientation"`
+	Ht   float32 `desc:"height in mm, in portrait orientation"`
+}
+
+// PaperSizes are the supported standard paper sizes
+var PaperSizes = []PaperSize{
+	{"A4", 210, 297},
+	{"Letter", 215.9, 279.4},
+	{"Legal", 215.9, 355.6},
+	{"A3", 297, 420},
+	{"A5", 148, 210},
+	{"Tabloid", 279.4, 431.8},
+}
+
+// PaperSizeByName returns the paper size of given name -- false if not found
+func PaperSizeByName(name string) (PaperSize, bool) {
+	for _, ps := range PaperSizes {
+		if ps.Name == name {
+			return ps, true
+		}
+	}
+	return PaperSizes[0], false
+}
+
+// PaperSizeNames returns the list of paper size names
+func PaperSizeNames() []string {
+	nms := make([]string, len(PaperSizes))
+	for i, ps := range PaperSizes {
+		nms[i] = ps.Name
+	}
+	return nms
+}
+
+// PageOrientations is the orientation of a printed page
+type PageOrientations int32
+
+const (
+	// Portrait orients the page vertically
+	Portrait PageOrientations = iota
+
+	// Landscape orients the page horizontally
+	Landscape
+
+	PageOrientationsN
+)
+
+//go:generate stringer -type=PageOrientations
+
+var KiT_PageOrientations = kit.Enums.AddEnumAltLower(PageOrientationsN, kit.NotBitFlag, StylePropProps, "")
+
+// PrintSettings are the page setup settings for printing
+type PrintSettings struct {
+	Paper  string           `desc:"name of the paper size -- see PaperSizes"`
+	Orient PageOrientations `desc:"page orientation"`
+	Margin float32          `desc:"page margin in mm, on all sides"`
+	DPI    float32          `desc:"resolution of page rendering, in dots per inch"`
+}
+
+// Defaults sets default print settings
+func (ps *PrintSettings) Defaults() {
+	ps.Paper = "A4"
+	ps.Orient = Portrait
+	ps.Margin = 15
+	ps.DPI = 150
+}
+
+// MMToDots returns the number of dots for given mm size at current DPI
+func (ps *PrintSettings) MMToDots(mm float32) int {
+	return int(mm / 25.4 * ps.DPI)
+}
+
+// PageDots returns the total page size in dots, including orientation
+func (ps *PrintSettings) PageDots() image.Point {
+	pap, _ := PaperSizeByName(ps.Paper)
+	sz := image.Point{ps.MMToDots(pap.Wd), ps.MMToDots(pap.Ht)}
+	if ps.Orient == Landscape {
+		sz.X, sz.Y = sz.Y, sz.X
+	}
+	return sz
+}
+
+// PrintableDots returns the printable area size in dots, excluding margins
+func (ps *PrintSettings) PrintableDots() image.Point {
+	sz := ps.PageDots()
+	mg := ps.MMToDots(ps.Margin)
+	sz.X -= 2 * mg
+	sz.Y -= 2 * mg
+	if sz.X < 1 {
+		sz.X = 1
+	}
+	if sz.Y < 1 {
+		sz.Y = 1
+	}
+	return sz
+}
+
+// PaginateImage slices given rendered content image into page images
+// according to given print settings: the content is scaled to the printable
+// width, then split vertically across as many pages as needed, with each
+// page drawn on a white background inside the margins
+func PaginateImage(img image.Image, ps *PrintSettings) []*image.RGBA {
+	isz := img.Bounds().Size()
+	if isz.X == 0 || isz.Y == 0 {
+		return nil
+	}
+	pgsz := ps.PageDots()
+	prsz := ps.PrintableDots()
+	mg := ps.MMToDots(ps.Margin)
+	scale := float32(prsz.X) / float32(isz.X)
+	ssz := image.Point{prsz.X, int(float32(isz.Y) * scale)}
+	if ssz.Y < 1 {
+		ssz.Y = 1
+	}
+	simg := image.NewRGBA(image.Rectangle{Max: ssz})
+	draw.ApproxBiLinear.Scale(simg, simg.Bounds(), img, img.Bounds(), draw.Over, nil)
+	np := (ssz.Y + prsz.Y - 1) / prsz.Y
+	pgs := make([]*image.RGBA, np)
+	for pi := 0; pi < np; pi++ {
+		pg := image.NewRGBA(image.Rectangle{Max: pgsz})
+		idraw.Draw(pg, pg.Bounds(), &image.Uniform{color.White}, image.Point{}, idraw.Src)
+		sp := image.Point{0, pi * prsz.Y}
+		dr := image.Rectangle{Min: image.Point{mg, mg}, Max: image.Point{mg + prsz.X, mg + prsz.Y}}
+		idraw.Draw(pg, dr, simg, sp, idraw.Over)
+		pgs[pi] = pg
+	}
+	return pgs
+}
+
+// PaginateViewport renders the current contents of given viewport into page
+// images according to given print settings
+func PaginateViewport(vp *Viewport2D, ps *PrintSettings) []*image.RGBA {
+	if vp == nil || vp.Pixels == nil {
+		return nil
+	}
+	return PaginateImage(vp.Pixels, ps)
+}
+
+// PrinterSendFunc, if non-nil, sends the given rendered pages to the
+// printer -- provided by a platform-specific printing driver
+var PrinterSendFunc func(pages []*image.RGBA, ps *PrintSettings) error
+
+// PDFExportFunc, if non-nil, exports the given rendered pages to a PDF file
+// -- provided by a PDF generation driver
+var PDFExportFunc func(fname string, pages []*image.RGBA, ps *PrintSettings) error
+
+// PrintPages sends given pages to the printer via PrinterSendFunc --
+// returns an error if no printing driver is installed
+func PrintPages(pages []*image.RGBA, ps *PrintSettings) error {
+	if PrinterSendFunc == nil {
+		return fmt.Errorf("gi.PrintPages: no printing driver installed -- set PrinterSendFunc")
+	}
+	return PrinterSendFunc(pages, ps)
+}
+
+// ExportPDF exports given pages to a PDF file via PDFExportFunc -- returns
+// an error if no PDF driver is installed
+func ExportPDF(fname string, pages []*image.RGBA, ps *PrintSettings) error {
+	if PDFExportFunc == nil {
+		return fmt.Errorf("gi.ExportPDF: no PDF export driver installed -- set PDFExportFunc")
+	}
+	return PDFExportFunc(fname, pages, ps)
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// PrintPreview shows the paginated print output of a viewport as zoomable
+// page thumbnails, with controls for paper size, orientation and margins --
+// the Print and PDF buttons use the PrinterSendFunc / PDFExportFunc drivers
+type PrintPreview struct {
+	Frame
+	Src      *Viewport2D   `json:"-" xml:"-" desc:"the viewport whose content is being printed"`
+	Settings PrintSettings `desc:"the current page setup settings"`
+	Pages    []*image.RGBA `json:"-" xml:"-" desc:"the current paginated page images"`
+	Zoom     float32       `desc:"zoom factor for the page thumbnails"`
+}
+
+var KiT_PrintPreview = kit.Types.AddType(&PrintPreview{}, PrintPreviewProps)
+
+// AddNewPrintPreview adds a new print preview to given parent node, with given name.
+func AddNewPrintPreview(parent ki.Ki, name string) *PrintPreview {
+	return parent.AddNewChild(KiT_PrintPreview, name).(*PrintPreview)
+}
+
+var PrintPreviewProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"max-width":        -1,
+	"max-height":       -1,
+	"background-color": &Prefs.Colors.Background,
+}
+
+// SetSrc sets the viewport to preview, and updates the pages
+func (pv *PrintPreview) SetSrc(vp *Viewport2D) {
+	if pv.Zoom == 0 {
+		pv.Zoom = 0.5
+		pv.Settings.Defaults()
+	}
+	pv.Src = vp
+	pv.Config()
+	pv.UpdatePages()
+}
+
+// Config configures the toolbar and pages layout
+func (pv *PrintPreview) Config() {
+	pv.Lay = LayoutVert
+	pv.SetStretchMax()
+	config := kit.TypeAndNameList{}
+	config.Add(KiT_ToolBar, "toolbar")
+	config.Add(KiT_Frame, "pages")
+	mods, updt := pv.ConfigChildren(config, true)
+	if mods {
+		pgs := pv.PagesFrame()
+		pgs.Lay = LayoutVert
+		pgs.SetStretchMax()
+		pgs.SetProp("spacing", StdDialogVSpaceUnits)
+		pv.ConfigToolBar()
+	}
+	pv.UpdateEnd(updt)
+}
+
+// ToolBar returns the toolbar
+func (pv *PrintPreview) ToolBar() *ToolBar {
+	return pv.ChildByName("toolbar", 0).(*ToolBar)
+}
+
+// PagesFrame returns the frame holding the page thumbnails
+func (pv *PrintPreview) PagesFrame() *Frame {
+	return pv.ChildByName("pages", 1).(*Frame)
+}
+
+// ConfigToolBar configures the page-setup and action controls
+func (pv *PrintPreview) ConfigToolBar() {
+	tb := pv.ToolBar()
+	if tb.HasChildren() {
+		return
+	}
+	tb.SetStretchMaxWidth()
+	pap := AddNewComboBox(tb, "paper")
+	pap.ItemsFromStringList(PaperSizeNames(), false, 0)
+	pap.SetCurVal(pv.Settings.Paper)
+	pap.Tooltip = "paper size"
+	pap.ComboSig.Connect(pv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		pvv := recv.Embed(KiT_PrintPreview).(*PrintPreview)
+		pvv.Settings.Paper = data.(string)
+		pvv.UpdatePages()
+	})
+	ort := AddNewComboBox(tb, "orient")
+	ort.ItemsFromEnum(KiT_PageOrientations, false, 0)
+	ort.SetCurIndex(int(pv.Settings.Orient))
+	ort.Tooltip = "page orientation"
+	ort.ComboSig.Connect(pv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		pvv := recv.Embed(KiT_PrintPreview).(*PrintPreview)
+		pvv.Settings.Orient = PageOrientations(sig)
+		pvv.UpdatePages()
+	})
+	AddNewLabel(tb, "margin-lbl", "Margin:")
+	mrg := AddNewSpinBox(tb, "margin")
+	mrg.SetMinMax(true, 0, true, 50)
+	mrg.Step = 1
+	mrg.SetValue(pv.Settings.Margin)
+	mrg.Tooltip = "page margin in mm, on all sides"
+	mrg.SpinBoxSig.Connect(pv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		pvv := recv.Embed(KiT_PrintPreview).(*PrintPreview)
+		pvv.Settings.Margin = data.(float32)
+		pvv.UpdatePages()
+	})
+	tb.AddAction(ActOpts{Label: "- ", Tooltip: "zoom out the page thumbnails"},
+		pv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			pvv := recv.Embed(KiT_PrintPreview).(*PrintPreview)
+			pvv.SetZoom(pvv.Zoom / 1.25)
+		})
+	tb.AddAction(ActOpts{Label: "+ ", Tooltip: "zoom in the page thumbnails"},
+		pv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			pvv := recv.Embed(KiT_PrintPreview).(*PrintPreview)
+			pvv.SetZoom(pvv.Zoom * 1.25)
+		})
+	tb.AddAction(ActOpts{Label: "Print", Icon: "file-save", Tooltip: "send the pages to the printer"},
+		pv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			pvv := recv.Embed(KiT_PrintPreview).(*PrintPreview)
+			pvv.Print()
+		})
+	tb.AddAction(ActOpts{Label: "PDF", Icon: "file-save", Tooltip: "export the pages to a PDF file"},
+		pv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			pvv := recv.Embed(KiT_PrintPreview).(*PrintPreview)
+			pvv.ExportPDF()
+		})
+}
+
+// SetZoom sets the thumbnail zoom factor and updates the display
+func (pv *PrintPreview) SetZoom(zoom float32) {
+	if zoom < 0.1 {
+		zoom = 0.1
+	}
+	if zoom > 4 {
+		zoom = 4
+	}
+	pv.Zoom = zoom
+	pv.ShowPages()
+}
+
+// UpdatePages re-paginates the source viewport and updates the thumbnails
+func (pv *PrintPreview) UpdatePages() {
+	pv.Pages = PaginateViewport(pv.Src, &pv.Settings)
+	pv.ShowPages()
+}
+
+// ShowPages rebuilds the page thumbnail bitmaps at the current zoom
+func (pv *PrintPreview) ShowPages() {
+	pgs := pv.PagesFrame()
+	updt := pgs.UpdateStart()
+	pgs.SetFullReRender()
+	pgs.DeleteChildren(true)
+	for i, pg := range pv.Pages {
+		bm := AddNewBitmap(pgs, fmt.Sprintf("page-%d", i))
+		sz := pg.Bounds().Size()
+		bm.SetImage(pg, float32(sz.X)*pv.Zoom, float32(sz.Y)*pv.Zoom)
+	}
+	pgs.UpdateEnd(updt)
+}
+
+// Print sends the current pages to the printer, reporting any error in a
+// prompt dialog
+func (pv *PrintPreview) Print() {
+	err := PrintPages(pv.Pages, &pv.Settings)
+	if err != nil {
+		PromptDialog(pv.Viewport, DlgOpts{Title: "Could not Print", Prompt: err.Error()}, AddOk, NoCancel, nil, nil)
+	}
+}
+
+// ExportPDF prompts for a file name and exports the current pages to PDF,
+// reporting any error in a prompt dialog
+func (pv *PrintPreview) ExportPDF() {
+	StringPromptDialog(pv.Viewport, "", "file name",
+		DlgOpts{Title: "Export PDF", Prompt: "File name to save the PDF to:"},
+		pv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			if sig != int64(DialogAccepted) {
+				return
+			}
+			pvv := recv.Embed(KiT_PrintPreview).(*PrintPreview)
+			dlg := send.Embed(KiT_Dialog).(*Dialog)
+			fname := StringPromptDialogValue(dlg)
+			err := ExportPDF(fname, pvv.Pages, &pvv.Settings)
+			if err != nil {
+				PromptDialog(pvv.Viewport, DlgOpts{Title: "Could not Export PDF", Prompt: err.Error()}, AddOk, NoCancel, nil, nil)
+			}
+		})
+}
+
+// PrintPreviewDialog opens a print preview dialog for the contents of given
+// viewport
+func PrintPreviewDialog(avp *Viewport2D, src *Viewport2D, opts DlgOpts) *Dialog {
+	dlg := NewStdDialog(opts, AddOk, NoCancel)
+	frame := dlg.Frame()
+	_, prIdx := dlg.PromptWidget(frame)
+	pv := frame.InsertNewChild(KiT_PrintPreview, prIdx+1, "print-preview").(*PrintPreview)
+	pv.Viewport = dlg.Embed(KiT_Viewport2D).(*Viewport2D)
+	pv.SetSrc(src)
+	dlg.UpdateEndNoSig(true)
+	dlg.Open(0, 0, avp, nil)
+	return dlg
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"time"
+)
+
+// Progressive rendering: when a viewport's full render pass takes longer
+// than ProgRenderThreshMSec (huge TableViews, dense SVG canvases), a
+// progressive viewport splits subsequent full renders in two: the
+// currently-visible region is rendered and uploaded first, and a fill-in
+// pass covering the full bounds is scheduled shortly after, so scrolling
+// and interaction stay responsive while the off-screen areas catch up.
+// Only useful for sub-viewports within a scrolling parent (for the master
+// window viewport, everything is visible) -- see also SetTiled, which
+// avoids rendering off-screen areas entirely.
+
+// ProgRenderThreshMSec is the full-render duration in milliseconds above
+// which a progressive viewport starts splitting its render passes -- see
+// Viewport2D.SetProgressive
+var ProgRenderThreshMSec = 100
+
+// ProgRenderIdleMSec is the delay in milliseconds after the
+// visible-region render before the off-screen fill-in pass is scheduled
+var ProgRenderIdleMSec = 50
+
+// SetProgressive sets (or clears) progressive rendering on this viewport
+// -- see ProgRenderThreshMSec
+func (vp *Viewport2D) SetProgressive(prog bool) {
+	if prog {
+		vp.SetFlag(int(VpFlagProgressive))
+	} else {
+		vp.ClearFlag(int(VpFlagProgressive))
+	}
+}
+
+// IsProgressive returns whether this viewport uses progressive rendering
+// -- see SetProgressive
+func (vp *Viewport2D) IsProgressive() bool {
+	return vp.HasFlag(int(VpFlagProgressive))
+}
+
+// progRenderStart is called at the start of a full render pass: if we are
+// progressive, the last full pass was slow, and this is not the scheduled
+// fill-in pass, restrict rendering to the currently-visible region --
+// returns the pass start time for progRenderDone
+func (vp *Viewport2D) progRenderStart() time.Time {
+	if vp.IsProgressive() && !vp.progFill && vp.lastRendMSec > float64(ProgRenderThreshMSec) {
+		vis := vp.TileVisBBox()
+		if !vis.Empty() && vp.Pixels != nil && !vp.Pixels.Bounds().In(vis) {
+			vp.progRestrict = vis
+		}
+	}
+	return time.Now()
+}
+
+// progRenderDone is called at the end of a full render pass: records the
+// duration of unrestricted passes, and after a restricted (visible-only)
+// pass schedules the off-screen fill-in pass via the standard update
+// channels
+func (vp *Viewport2D) progRenderDone(st time.Time) {
+	msec := float64(time.Now().Sub(st)) / float64(time.Millisecond)
+	if vp.progRestrict == image.ZR {
+		vp.lastRendMSec = msec
+		vp.progFill = false
+		return
+	}
+	vp.progRestrict = image.ZR
+	vp.progFill = true
+	time.AfterFunc(time.Duration(ProgRenderIdleMSec)*time.Millisecond, func() {
+		if vp.This() == nil || vp.IsDeleted() || vp.IsDestroyed() {
+			return
+		}
+		vp.SetNeedsFullRender()
+	})
+}
@@ -0,0 +1,293 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// Rating is a star-rating input widget: a row of Max icons of which the
+// first Value are shown filled.  Hovering previews the value under the
+// pointer, and clicking commits it, emitting RatingSig.  HalfSteps allows
+// half-icon increments.  The icons are configurable -- any icon with "-on",
+// "-off" and (for half steps) "-half" variants can be used.
+type Rating struct {
+	PartsWidgetBase
+	Value     float32   `xml:"value" desc:"current rating value, in range 0..Max"`
+	Max       int       `xml:"max" desc:"number of icons -- default 5"`
+	HalfSteps bool      `xml:"half-steps" desc:"allow half-step values -- requires a half-icon variant"`
+	IconOn    IconName  `view:"show-name" desc:"icon for a filled step -- default star-on"`
+	IconOff   IconName  `view:"show-name" desc:"icon for an empty step -- default star-off"`
+	IconHalf  IconName  `view:"show-name" desc:"icon for a half-filled step -- default star-half"`
+	HoverVal  float32   `copy:"-" json:"-" xml:"-" view:"-" desc:"value currently previewed under the pointer -- negative when not hovering"`
+	RatingSig ki.Signal `copy:"-" json:"-" xml:"-" view:"-" desc:"signal emitted when the user sets a new value -- data is the new value as float32"`
+}
+
+var KiT_Rating = kit.Types.AddType(&Rating{}, RatingProps)
+
+// AddNewRating adds a new rating widget to given parent node, with given
+// name -- defaults to 5 stars
+func AddNewRating(parent ki.Ki, name string) *Rating {
+	rt := parent.AddNewChild(KiT_Rating, name).(*Rating)
+	rt.Max = 5
+	rt.HoverVal = -1
+	return rt
+}
+
+func (nb *Rating) CopyFieldsFrom(frm interface{}) {
+	fr := frm.(*Rating)
+	nb.PartsWidgetBase.CopyFieldsFrom(&fr.PartsWidgetBase)
+	nb.Value = fr.Value
+	nb.Max = fr.Max
+	nb.HalfSteps = fr.HalfSteps
+	nb.IconOn = fr.IconOn
+	nb.IconOff = fr.IconOff
+	nb.IconHalf = fr.IconHalf
+}
+
+func (rt *Rating) Disconnect() {
+	rt.PartsWidgetBase.Disconnect()
+	rt.RatingSig.DisconnectAll()
+}
+
+var RatingProps = ki.Props{
+	"EnumType:Flag": KiT_NodeFlags,
+	"padding":       units.NewPx(1),
+	"margin":        units.NewPx(1),
+	"#icons": ki.Props{
+		"width":  units.NewEm(1.5),
+		"height": units.NewEm(1.5),
+		"fill":   &Prefs.Colors.Icon,
+		"stroke": &Prefs.Colors.Font,
+	},
+}
+
+func (rt *Rating) Init2D() {
+	rt.Init2DWidget()
+	rt.ConfigParts()
+}
+
+func (rt *Rating) Style2D() {
+	rt.Style2DWidget()
+	rt.ConfigParts()
+	rt.LayData.SetFromStyle(&rt.Sty.Layout) // also does reset
+}
+
+// SetValue sets the rating value, clamped and snapped to the allowed steps,
+// and updates the display -- does not emit RatingSig
+func (rt *Rating) SetValue(val float32) {
+	updt := rt.UpdateStart()
+	rt.Value = rt.SnapValue(val)
+	rt.UpdateIcons()
+	rt.UpdateEnd(updt)
+}
+
+// SetValueAction sets the rating value and emits RatingSig if it changed
+func (rt *Rating) SetValueAction(val float32) {
+	val = rt.SnapValue(val)
+	if rt.Value == val {
+		return
+	}
+	rt.SetValue(val)
+	rt.RatingSig.Emit(rt.This(), 0, rt.Value)
+}
+
+// SnapValue returns given value clamped to 0..Max and snapped to whole or
+// half steps per HalfSteps
+func (rt *Rating) SnapValue(val float32) float32 {
+	if rt.HalfSteps {
+		val = .5 * math32.Floor(2*val+.5)
+	} else {
+		val = math32.Floor(val + .5)
+	}
+	return math32.Min(math32.Max(val, 0), float32(rt.Max))
+}
+
+// PointToValue returns the rating value under given window-coordinate point,
+// used for hover preview and click
+func (rt *Rating) PointToValue(pos image.Point) float32 {
+	w := rt.WinBBox.Dx()
+	if w <= 0 {
+		return rt.Value
+	}
+	raw := float32(rt.Max) * float32(pos.X-rt.WinBBox.Min.X) / float32(w)
+	if rt.HalfSteps {
+		raw = .5 * math32.Ceil(2*raw)
+	} else {
+		raw = math32.Ceil(raw)
+	}
+	return math32.Min(math32.Max(raw, 0), float32(rt.Max))
+}
+
+// DispValue is the value currently displayed: the hover preview if hovering,
+// else Value
+func (rt *Rating) DispValue() float32 {
+	if rt.HoverVal >= 0 {
+		return rt.HoverVal
+	}
+	return rt.Value
+}
+
+// stackTop returns the stacked-icon index (0 = on, 1 = half, 2 = off) for
+// step i at given displayed value
+func (rt *Rating) stackTop(i int, val float32) int {
+	frac := val - float32(i)
+	switch {
+	case frac >= .75:
+		return 0
+	case rt.HalfSteps && frac >= .25:
+		return 1
+	case frac >= .5:
+		return 0
+	}
+	return 2
+}
+
+// UpdateIcons updates the shown icon variant of each step from the
+// currently displayed value
+func (rt *Rating) UpdateIcons() {
+	val := rt.DispValue()
+	for i := 0; i < rt.Parts.NumChildren(); i++ {
+		st, ok := rt.Parts.Child(i).(*Layout)
+		if !ok {
+			continue
+		}
+		st.StackTop = rt.stackTop(i, val)
+	}
+}
+
+func (rt *Rating) ConfigParts() {
+	if rt.Max <= 0 {
+		rt.Max = 5
+	}
+	if !rt.IconOn.IsValid() {
+		rt.IconOn = "star-on"
+	}
+	if !rt.IconOff.IsValid() {
+		rt.IconOff = "star-off"
+	}
+	if !rt.IconHalf.IsValid() {
+		rt.IconHalf = "star-half"
+	}
+	rt.Parts.Lay = LayoutHoriz
+	config := kit.TypeAndNameList{}
+	for i := 0; i < rt.Max; i++ {
+		config.Add(KiT_Layout, fmt.Sprintf("icons%d", i))
+	}
+	mods, updt := rt.Parts.ConfigChildren(config, false)
+	for i := 0; i < rt.Max; i++ {
+		st := rt.Parts.Child(i).(*Layout)
+		st.Lay = LayoutStacked
+		st.SetProp("width", units.NewEm(1.5))
+		st.SetProp("height", units.NewEm(1.5))
+		sconfig := kit.TypeAndNameList{}
+		sconfig.Add(KiT_Icon, "on")
+		sconfig.Add(KiT_Icon, "half")
+		sconfig.Add(KiT_Icon, "off")
+		_, supdt := st.ConfigChildren(sconfig, false)
+		for j, icn := range []IconName{rt.IconOn, rt.IconHalf, rt.IconOff} {
+			icon := st.Child(j).(*Icon)
+			if set, _ := icn.SetIcon(icon); set {
+				rt.StylePart(Node2D(icon))
+			}
+		}
+		st.UpdateEnd(supdt)
+	}
+	rt.UpdateIcons()
+	if mods {
+		rt.UpdateEnd(updt)
+	}
+}
+
+func (rt *Rating) SetHoverVal(val float32) {
+	if rt.HoverVal == val {
+		return
+	}
+	updt := rt.UpdateStart()
+	rt.HoverVal = val
+	rt.UpdateIcons()
+	rt.UpdateEnd(updt)
+}
+
+func (rt *Rating) MouseEvent() {
+	rt.ConnectEvent(oswin.MouseEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		rtt := recv.Embed(KiT_Rating).(*Rating)
+		if rtt.IsInactive() || me.Button != mouse.Left {
+			return
+		}
+		if me.Action == mouse.Release {
+			me.SetProcessed()
+			rtt.SetHoverVal(-1)
+			rtt.SetValueAction(rtt.PointToValue(me.Pos()))
+		}
+	})
+}
+
+func (rt *Rating) MouseMoveEvent() {
+	rt.ConnectEvent(oswin.MouseMoveEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.MoveEvent)
+		rtt := recv.Embed(KiT_Rating).(*Rating)
+		if rtt.IsInactive() {
+			return
+		}
+		me.SetProcessed()
+		rtt.SetHoverVal(rtt.PointToValue(me.Pos()))
+	})
+}
+
+func (rt *Rating) MouseFocusEvent() {
+	rt.ConnectEvent(oswin.MouseFocusEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.FocusEvent)
+		rtt := recv.Embed(KiT_Rating).(*Rating)
+		if rtt.IsInactive() {
+			return
+		}
+		me.SetProcessed()
+		if me.Action == mouse.Exit {
+			rtt.SetHoverVal(-1)
+		}
+	})
+}
+
+func (rt *Rating) ConnectEvents2D() {
+	rt.MouseEvent()
+	rt.MouseMoveEvent()
+	rt.MouseFocusEvent()
+	rt.HoverTooltipEvent()
+}
+
+func (rt *Rating) Layout2D(parBBox image.Rectangle, iter int) bool {
+	rt.Layout2DBase(parBBox, true, iter)
+	rt.Layout2DParts(parBBox, iter)
+	return rt.Layout2DChildren(iter)
+}
+
+func (rt *Rating) Render2D() {
+	if rt.FullReRenderIfNeeded() {
+		return
+	}
+	if rt.PushBounds() {
+		rt.This().(Node2D).ConnectEvents2D()
+		st := &rt.Sty
+		rs := &rt.Viewport.Render
+		rs.Lock()
+		rt.RenderStdBox(st)
+		rs.Unlock()
+		rt.Render2DParts()
+		rt.Render2DChildren()
+		rt.PopBounds()
+	} else {
+		rt.DisconnectAllEvents(RegPri)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"github.com/goki/gi/oswin"
+)
+
+// SetRecentDocuments mirrors the app's recent documents list (most-recent
+// first) into the OS-level recents UI -- the dock menu on macOS, the
+// taskbar jump list on Windows -- via the optional oswin.RecentDocumenter
+// interface, returning false if the current oswin driver does not support
+// it.  Activating an entry there delivers the path back to the running app
+// through the open-files mechanism: register a handler with
+// oswin.SetOpenFilesFunc to open it.  Call with an empty list to clear.
+func SetRecentDocuments(paths []string) bool {
+	rd, ok := oswin.TheApp.(oswin.RecentDocumenter)
+	if !ok {
+		return false
+	}
+	rd.SetRecentDocuments(paths)
+	return true
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/goki/gi/mat32"
+
+	"github.com/srwiley/rasterx"
+	"github.com/srwiley/scanx"
+)
+
+// RenderBackend is a pluggable rasterization backend for viewport
+// rendering.  The default CPURenderBackend rasterizes into the viewport's
+// image.RGBA using rasterx / scanx as always -- a GPU backend can instead
+// set up a pipeline that rasterizes into a texture, with Flush
+// synchronizing results back to where the window upload path can get
+// them.  Set with SetRenderBackend at app init, before windows are opened.
+type RenderBackend interface {
+	// Name returns a short descriptive name of this backend
+	Name() string
+
+	// InitState sets up the rasterization pipeline in given RenderState
+	// for rendering at given size into given image -- called whenever the
+	// viewport image is created or resized
+	InitState(rs *RenderState, width, height int, img *image.RGBA)
+
+	// Flush completes any pending rendering work for given state, making
+	// the results available to the window upload path -- no-op for the
+	// CPU backend, fence / readback point for GPU backends
+	Flush(rs *RenderState)
+}
+
+// TheRenderBackend is the default rasterization backend, used by
+// viewports that do not select their own -- default is the standard CPU
+// rasterizer -- set with SetRenderBackend
+var TheRenderBackend RenderBackend = &CPURenderBackend{}
+
+// RenderBackends is the registry of available rasterization backends by
+// name -- alternative backends (e.g., GPU tessellation / SDF) register
+// themselves here in an init function
+var RenderBackends = map[string]RenderBackend{}
+
+// RegisterRenderBackend adds given backend to the RenderBackends registry
+func RegisterRenderBackend(rb RenderBackend) {
+	RenderBackends[rb.Name()] = rb
+}
+
+// SetRenderBackend sets the default rasterization backend -- must be
+// called at app init, before any viewports are created
+func SetRenderBackend(rb RenderBackend) {
+	TheRenderBackend = rb
+}
+
+// SetRenderBackendName sets the default rasterization backend to the
+// registered backend of given name -- returns an error if not registered
+func SetRenderBackendName(name string) error {
+	rb, has := RenderBackends[name]
+	if !has {
+		return fmt.Errorf("gi.SetRenderBackendName: no render backend registered under name: %v", name)
+	}
+	TheRenderBackend = rb
+	return nil
+}
+
+// CPURenderBackend is the standard CPU rasterization backend, using
+// rasterx / scanx to render into the viewport's image.RGBA
+type CPURenderBackend struct {
+}
+
+func (rb *CPURenderBackend) Name() string {
+	return "cpu"
+}
+
+func (rb *CPURenderBackend) InitState(rs *RenderState, width, height int, img *image.RGBA) {
+	rs.Paint.Defaults()
+	rs.XForm = mat32.Identity2D()
+	rs.Image = img
+	// to use the golang.org/x/image/vector scanner, do this:
+	// rs.Scanner = rasterx.NewScannerGV(width, height, img, img.Bounds())
+	// and cut out painter:
+	/*
+		painter := scanFT.NewRGBAPainter(img)
+		rs.Scanner = scanFT.NewScannerFT(width, height, painter)
+	*/
+	/*
+		rs.CompSpanner = &scanx.CompressSpanner{}
+		rs.CompSpanner.SetBounds(img.Bounds())
+	*/
+	rs.AAQual = AAQuality
+	rs.ImgSpanner = scanx.NewImgSpanner(img)
+	rs.Scanner = scanx.NewScanner(&clipSpanner{sp: &aaSpanner{sp: rs.ImgSpanner, qual: &rs.AAQual}, mask: &rs.Mask}, width, height)
+	// rs.Scanner = scanx.NewScanner(rs.CompSpanner, width, height)
+	rs.Raster = rasterx.NewDasher(width, height, rs.Scanner)
+}
+
+func (rb *CPURenderBackend) Flush(rs *RenderState) {
+}
+
+func init() {
+	RegisterRenderBackend(&CPURenderBackend{})
+}
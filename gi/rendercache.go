@@ -0,0 +1,112 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"time"
+)
+
+// Render caching for static subtrees: a node with the CacheRender flag set
+// is rasterized normally once, the resulting pixels within its VpBBox are
+// retained in RendCache, and subsequent renders blit that image instead of
+// re-rendering the subtree.  The cache is dropped automatically when the
+// node's on-screen size changes or a FullReRender is flagged, and can be
+// dropped explicitly with InvalidateRenderCache.  Use for large unchanging
+// subtrees such as toolbars or icon grids, where re-rasterizing on every
+// FullRender2DTree is wasted work.  Events remain connected from the
+// original render, so interactive widgets still respond, but any visual
+// state change within the subtree requires invalidation to become visible.
+
+// SetCacheRender sets (or clears) the CacheRender flag on this node,
+// marking its subtree as static for render caching -- clearing also drops
+// any retained image
+func (nb *Node2DBase) SetCacheRender(cache bool) {
+	if cache {
+		nb.SetFlag(int(CacheRender))
+	} else {
+		nb.ClearFlag(int(CacheRender))
+		nb.RendCache = nil
+	}
+}
+
+// IsCacheRender returns whether the CacheRender flag is set on this node
+func (nb *Node2DBase) IsCacheRender() bool {
+	return nb.HasFlag(int(CacheRender))
+}
+
+// InvalidateRenderCache drops the retained rasterization of this subtree,
+// so the next render re-rasterizes it (and refills the cache) -- call
+// after programmatically changing anything visible under a CacheRender
+// node
+func (nb *Node2DBase) InvalidateRenderCache() {
+	nb.RendCache = nil
+}
+
+// renderCacheValid returns whether the retained image can be blitted for
+// the current render: it must exist, match the current on-screen size, and
+// no full re-render can be pending
+func (nb *Node2DBase) renderCacheValid() bool {
+	if nb.RendCache == nil || nb.NeedsFullReRender() {
+		return false
+	}
+	return nb.RendCache.Bounds().Size() == nb.VpBBox.Size()
+}
+
+// renderFromCache blits the retained image into the viewport at the
+// current VpBBox
+func (nb *Node2DBase) renderFromCache() {
+	draw.Draw(nb.Viewport.Pixels, nb.VpBBox, nb.RendCache, image.ZP, draw.Src)
+}
+
+// renderToCache copies the viewport pixels within the current VpBBox into
+// the retained image, after a live render
+func (nb *Node2DBase) renderToCache() {
+	sz := nb.VpBBox.Size()
+	if sz == image.ZP {
+		nb.RendCache = nil
+		return
+	}
+	if nb.RendCache == nil || nb.RendCache.Bounds().Size() != sz {
+		nb.RendCache = image.NewRGBA(image.Rectangle{Max: sz})
+	}
+	draw.Draw(nb.RendCache, nb.RendCache.Bounds(), nb.Viewport.Pixels, nb.VpBBox.Min, draw.Src)
+}
+
+// renderCachedOrLive renders given node, going through its retained render
+// cache when the CacheRender flag is set: a valid cache is blitted
+// directly, otherwise the node renders live and the cache is refilled --
+// called from render2DKids for each child
+func renderCachedOrLive(nii Node2D, ni *Node2DBase) {
+	if ni != nil && ni.Viewport != nil && ni.Viewport.RenderProf.On {
+		st := time.Now()
+		defer renderProfRec(ni.Viewport, "Render2D", nii, st)
+	}
+	if ni == nil || ni.Viewport == nil || ni.Viewport.Pixels == nil {
+		nii.Render2D()
+		return
+	}
+	if ni.RendShare != "" {
+		renderSharedOrLive(nii, ni)
+		return
+	}
+	if !ni.IsCacheRender() {
+		nii.Render2D()
+		return
+	}
+	if ni.renderCacheValid() && nii.IsVisible() {
+		if Render2DTrace {
+			fmt.Printf("Render: cached %v at %v\n", ni.PathUnique(), ni.VpBBox)
+		}
+		ni.renderFromCache()
+		return
+	}
+	nii.Render2D()
+	if nii.IsVisible() && !ni.VpBBox.Empty() {
+		ni.renderToCache()
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RenderProf is a built-in render profiler, available on every Viewport2D as
+// the RenderProf field: when On, the styling, layout, and render passes
+// record cumulative time both per Node2D type and per unique node path.
+// Times are inclusive: a container's time includes that of its children.
+// The prof.Start calls only cover whole passes -- this gives the per-node
+// breakdown needed to find which widgets are actually expensive.  Viewable
+// in GiEditor via the viewport's RenderProf field, or print ReportString.
+type RenderProf struct {
+	On    bool                      `desc:"whether profiling is currently recording -- set to true, exercise the gui, then inspect the report"`
+	Types map[string]*RenderProfEnt `desc:"cumulative stats per pass and node type"`
+	Paths map[string]*RenderProfEnt `desc:"cumulative stats per pass and unique node path"`
+	Mu    sync.Mutex                `copy:"-" json:"-" xml:"-" view:"-" desc:"protects map updates -- render pass can run in parallel"`
+}
+
+// RenderProfEnt is the cumulative profiling record for one pass and one node
+// type or unique node path
+type RenderProfEnt struct {
+	Pass  string        `desc:"which pass: Style2D, Layout2D, or Render2D"`
+	Name  string        `desc:"node type name or unique node path"`
+	N     int           `desc:"number of times recorded"`
+	Total time.Duration `desc:"cumulative (inclusive) time"`
+}
+
+// Avg returns the average time per call
+func (pe *RenderProfEnt) Avg() time.Duration {
+	if pe.N == 0 {
+		return 0
+	}
+	return pe.Total / time.Duration(pe.N)
+}
+
+// Reset clears all accumulated records (does not change On)
+func (rp *RenderProf) Reset() {
+	rp.Mu.Lock()
+	rp.Types = nil
+	rp.Paths = nil
+	rp.Mu.Unlock()
+}
+
+// Record adds one timing observation for given pass and node -- called from
+// the pass drivers when On
+func (rp *RenderProf) Record(pass string, nii Node2D, dur time.Duration) {
+	tnm := nii.Type().Name()
+	pth := nii.PathUnique()
+	rp.Mu.Lock()
+	if rp.Types == nil {
+		rp.Types = make(map[string]*RenderProfEnt)
+		rp.Paths = make(map[string]*RenderProfEnt)
+	}
+	tkey := pass + ":" + tnm
+	te := rp.Types[tkey]
+	if te == nil {
+		te = &RenderProfEnt{Pass: pass, Name: tnm}
+		rp.Types[tkey] = te
+	}
+	te.N++
+	te.Total += dur
+	pkey := pass + ":" + pth
+	pe := rp.Paths[pkey]
+	if pe == nil {
+		pe = &RenderProfEnt{Pass: pass, Name: pth}
+		rp.Paths[pkey] = pe
+	}
+	pe.N++
+	pe.Total += dur
+	rp.Mu.Unlock()
+}
+
+// Report returns the accumulated records sorted by cumulative time,
+// largest first -- byPath selects the per-unique-path records instead of the
+// per-type ones
+func (rp *RenderProf) Report(byPath bool) []*RenderProfEnt {
+	rp.Mu.Lock()
+	src := rp.Types
+	if byPath {
+		src = rp.Paths
+	}
+	ents := make([]*RenderProfEnt, 0, len(src))
+	for _, pe := range src {
+		ents = append(ents, pe)
+	}
+	rp.Mu.Unlock()
+	sort.Slice(ents, func(i, j int) bool {
+		return ents[i].Total > ents[j].Total
+	})
+	return ents
+}
+
+// ReportString returns the sorted report as a printable table -- see Report
+func (rp *RenderProf) ReportString(byPath bool) string {
+	ents := rp.Report(byPath)
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-10s %8s %12s %12s  %s\n", "Pass", "N", "Total", "Avg", "Name"))
+	for _, pe := range ents {
+		sb.WriteString(fmt.Sprintf("%-10s %8d %12v %12v  %s\n", pe.Pass, pe.N, pe.Total, pe.Avg(), pe.Name))
+	}
+	return sb.String()
+}
+
+// renderProfRec records time since given start for given pass and node into
+// the viewport's profiler, if it is on -- no-op otherwise, so it can be
+// called unconditionally from the pass drivers
+func renderProfRec(vp *Viewport2D, pass string, nii Node2D, st time.Time) {
+	if vp == nil || !vp.RenderProf.On {
+		return
+	}
+	vp.RenderProf.Record(pass, nii, time.Now().Sub(st))
+}
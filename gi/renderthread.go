@@ -0,0 +1,106 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"sync"
+	"time"
+)
+
+// Render threading: by default, rendering happens on whatever goroutine
+// emitted the update signal (typically the window event loop), so a heavy
+// render blocks event handling.  When RenderThreading is on, viewport
+// render requests are instead queued to a dedicated render goroutine with
+// frame scheduling: all requests arriving within one frame are coalesced
+// into a single UpdateNodes pass per viewport, paced at RenderFPS, and
+// the event loop returns immediately.  The pending-node bookkeeping in
+// NodeUpdated is already mutex-protected and coalescing, so queuing is
+// just a matter of where UpdateNodes runs.
+
+// RenderThreading runs viewport rendering on a dedicated render goroutine
+// with frame scheduling, instead of synchronously on the updating
+// goroutine -- keeps the event loop responsive during heavy renders.
+// Set at app init.  Note that updates are then asynchronous: code that
+// depends on rendering being complete after UpdateEnd must not use this.
+var RenderThreading = false
+
+// RenderFPS is the maximum frame rate of the dedicated render goroutine
+// when RenderThreading is on -- renders are coalesced to at most this
+// many passes per second per viewport
+var RenderFPS = 60
+
+// renderThread is the dedicated render goroutine state -- viewports with
+// pending updates are queued in order and drained once per frame
+type renderThread struct {
+	Mu    sync.Mutex
+	Queue []*Viewport2D
+	Wake  chan struct{}
+	Start sync.Once
+}
+
+var theRenderThread = renderThread{Wake: make(chan struct{}, 1)}
+
+// QueueUpdateNodes processes this viewport's pending update signals --
+// directly (the default), or queued to the dedicated render goroutine
+// when RenderThreading is on
+func (vp *Viewport2D) QueueUpdateNodes() {
+	if !RenderThreading {
+		vp.UpdateNodes()
+		return
+	}
+	theRenderThread.Add(vp)
+}
+
+// Add queues given viewport for rendering on the next frame, starting the
+// render goroutine on first use -- a viewport already queued is not added
+// again (its pending nodes accumulate in its update stacks)
+func (rt *renderThread) Add(vp *Viewport2D) {
+	rt.Start.Do(func() { go rt.Run() })
+	rt.Mu.Lock()
+	has := false
+	for _, qvp := range rt.Queue {
+		if qvp == vp {
+			has = true
+			break
+		}
+	}
+	if !has {
+		rt.Queue = append(rt.Queue, vp)
+	}
+	rt.Mu.Unlock()
+	select {
+	case rt.Wake <- struct{}{}:
+	default:
+	}
+}
+
+// Drain returns and clears the queued viewports
+func (rt *renderThread) Drain() []*Viewport2D {
+	rt.Mu.Lock()
+	vps := rt.Queue
+	rt.Queue = nil
+	rt.Mu.Unlock()
+	return vps
+}
+
+// Run is the render goroutine main loop: drain the queued viewports,
+// render each, then sleep out the remainder of the frame, coalescing any
+// requests that arrived in the meantime into the next frame
+func (rt *renderThread) Run() {
+	for range rt.Wake {
+		st := time.Now()
+		for _, vp := range rt.Drain() {
+			if vp.This() == nil || vp.IsDeleted() || vp.IsDestroyed() {
+				continue
+			}
+			vp.UpdateNodes()
+		}
+		fdur := time.Second / time.Duration(RenderFPS)
+		el := time.Now().Sub(st)
+		if el < fdur {
+			time.Sleep(fdur - el)
+		}
+	}
+}
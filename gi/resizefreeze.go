@@ -0,0 +1,86 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"time"
+
+	"github.com/goki/gi/oswin"
+)
+
+// Resize freezing: interactive window resizing normally drives a full
+// restyle / layout / render on every resize event, which flickers and lags
+// on complex windows.  When WinResizeFreeze is on, resize events during
+// the drag instead just re-publish the last rendered frame scaled to the
+// new window size, and the full resize is debounced until the drag pauses
+// for WinResizeFreezeMSec -- then applied once, on the event loop, via the
+// same custom-event dispatch as the window timers.
+
+// WinResizeFreeze freezes window contents during interactive resizing:
+// the last frame is scaled to track the drag, and the full restyle /
+// layout / render is deferred until resizing pauses -- eliminates resize
+// flicker on complex windows, at the cost of briefly showing stretched
+// content.  Off by default.
+var WinResizeFreeze = false
+
+// WinResizeFreezeMSec is how long resizing must pause, in msec, before the
+// deferred full resize is applied when WinResizeFreeze is on
+var WinResizeFreezeMSec = 150
+
+// resizeFreezeDone is the custom event marker that applies the deferred
+// resize once the debounce timer fires
+type resizeFreezeDone struct{}
+
+// resizeFreezePending returns whether a frozen resize is awaiting its
+// debounced application -- during this time the window publishes the last
+// frame scaled to the current window size
+func (w *Window) resizeFreezePending() bool {
+	w.resizeMu.Lock()
+	defer w.resizeMu.Unlock()
+	return w.resizePending != image.ZP
+}
+
+// freezeResize records the current window size as the pending deferred
+// resize, (re)starts the debounce timer, and re-publishes the frozen frame
+// scaled to the new size -- called from the event filter for each resize
+// event when WinResizeFreeze is on
+func (w *Window) freezeResize() {
+	w.resizeMu.Lock()
+	w.resizePending = w.OSWin.Size()
+	dur := time.Duration(WinResizeFreezeMSec) * time.Millisecond
+	if w.resizeFreezeTimer != nil {
+		w.resizeFreezeTimer.Reset(dur)
+	} else {
+		w.resizeFreezeTimer = time.AfterFunc(dur, func() {
+			w.SendCustomEvent(resizeFreezeDone{})
+		})
+	}
+	w.resizeMu.Unlock()
+	w.Publish()
+}
+
+// ProcessResizeFreezeEvent applies the deferred resize when the debounce
+// marker event arrives -- returns true if the event was ours and has been
+// handled
+func (w *Window) ProcessResizeFreezeEvent(evi oswin.Event) bool {
+	ce, ok := evi.(*oswin.CustomEvent)
+	if !ok {
+		return false
+	}
+	if _, ok := ce.Data.(resizeFreezeDone); !ok {
+		return false
+	}
+	ce.SetProcessed()
+	w.resizeMu.Lock()
+	w.resizeFreezeTimer = nil
+	sz := w.resizePending
+	w.resizePending = image.ZP
+	w.resizeMu.Unlock()
+	if sz != image.ZP {
+		w.Resized(sz)
+	}
+	return true
+}
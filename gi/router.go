@@ -0,0 +1,137 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"log"
+	"strings"
+
+	"github.com/goki/ki/ki"
+)
+
+// Router maps URL-style string paths to view constructors, for
+// multi-screen apps: register routes with Route, navigate with Navigate,
+// and the router rebuilds the content frame from the matching
+// constructor.  Navigation pushes onto a history that Back / Forward move
+// through (driven by the KeyFunHistPrev / KeyFunHistNext key functions
+// when the router is installed on a window via SetRouter), and the
+// current route is exposed via CurRoute for deep-linking and session
+// restore -- save CurRoute at exit and Navigate to it at startup.
+type Router struct {
+	Frame    *Frame               `desc:"content frame whose children are rebuilt by the route view constructors on navigation"`
+	Routes   map[string]RouteFunc `desc:"registered routes -- path to view constructor -- paths ending in * match as prefixes"`
+	Hist     []string             `desc:"navigation history of route paths"`
+	HistIdx  int                  `desc:"current position in Hist"`
+	RouteSig ki.Signal            `json:"-" xml:"-" view:"-" desc:"signal emitted when the current route changes -- data is the new route path"`
+}
+
+// RouteFunc is a view constructor for one route: it builds the view for
+// given path (the full path navigated to, for wildcard routes) as
+// children of given frame
+type RouteFunc func(rt *Router, path string, frame *Frame)
+
+// NewRouter returns a new router building views into given content frame
+func NewRouter(frame *Frame) *Router {
+	return &Router{Frame: frame, Routes: make(map[string]RouteFunc), HistIdx: -1}
+}
+
+// SetRouter installs given router on this window, so the
+// KeyFunHistPrev / KeyFunHistNext key functions navigate its history
+func (w *Window) SetRouter(rt *Router) {
+	w.Router = rt
+}
+
+// Route registers a view constructor for given path.  A path ending in *
+// matches any path with that prefix -- longest prefix wins -- otherwise
+// matching is exact.
+func (rt *Router) Route(path string, fun RouteFunc) {
+	rt.Routes[path] = fun
+}
+
+// CurRoute returns the current route path -- empty if nothing has been
+// navigated to yet
+func (rt *Router) CurRoute() string {
+	if rt.HistIdx < 0 || rt.HistIdx >= len(rt.Hist) {
+		return ""
+	}
+	return rt.Hist[rt.HistIdx]
+}
+
+// Match returns the view constructor matching given path -- exact match
+// first, then the longest registered * prefix -- nil if no match
+func (rt *Router) Match(path string) RouteFunc {
+	if fun, has := rt.Routes[path]; has {
+		return fun
+	}
+	var best string
+	var bestFun RouteFunc
+	for rp, fun := range rt.Routes {
+		if !strings.HasSuffix(rp, "*") {
+			continue
+		}
+		pfx := strings.TrimSuffix(rp, "*")
+		if strings.HasPrefix(path, pfx) && len(pfx) >= len(best) {
+			best = pfx
+			bestFun = fun
+		}
+	}
+	return bestFun
+}
+
+// Navigate shows the view for given path, pushing it onto the history
+// (dropping any forward entries, as in a browser) -- returns false and
+// does nothing if no route matches
+func (rt *Router) Navigate(path string) bool {
+	if path == rt.CurRoute() {
+		return true
+	}
+	if rt.Match(path) == nil {
+		log.Printf("gi.Router Navigate: no route matches path: %v\n", path)
+		return false
+	}
+	rt.Hist = append(rt.Hist[:rt.HistIdx+1], path)
+	rt.HistIdx = len(rt.Hist) - 1
+	rt.ShowRoute(path)
+	return true
+}
+
+// Back navigates to the previous route in the history -- returns false
+// if already at the start
+func (rt *Router) Back() bool {
+	if rt.HistIdx <= 0 {
+		return false
+	}
+	rt.HistIdx--
+	rt.ShowRoute(rt.Hist[rt.HistIdx])
+	return true
+}
+
+// Forward navigates to the next route in the history -- returns false if
+// already at the end
+func (rt *Router) Forward() bool {
+	if rt.HistIdx >= len(rt.Hist)-1 {
+		return false
+	}
+	rt.HistIdx++
+	rt.ShowRoute(rt.Hist[rt.HistIdx])
+	return true
+}
+
+// ShowRoute rebuilds the content frame from the view constructor
+// matching given path, and emits RouteSig -- does not affect the history
+// (use Navigate)
+func (rt *Router) ShowRoute(path string) {
+	fun := rt.Match(path)
+	if fun == nil {
+		return
+	}
+	fr := rt.Frame
+	updt := fr.UpdateStart()
+	fr.SetFullReRender()
+	fr.DeleteChildren(true)
+	fun(rt, path, fr)
+	fr.UpdateEnd(updt)
+	rt.RouteSig.Emit(fr.This(), 0, path)
+}
@@ -0,0 +1,342 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// RulerSignals are signals sent by a Ruler as guides are edited
+type RulerSignals int64
+
+const (
+	// RulerGuideAdded is sent when a new guide has been dragged out of the
+	// ruler -- data is the index of the guide in Guides
+	RulerGuideAdded RulerSignals = iota
+
+	// RulerGuideMoved is sent when a guide has been dragged to a new
+	// position -- data is the index of the guide
+	RulerGuideMoved
+
+	// RulerGuideDeleted is sent after a guide has been dragged back onto
+	// the ruler and deleted -- data is the former index of the guide
+	RulerGuideDeleted
+
+	RulerSignalsN
+)
+
+//go:generate stringer -type=RulerSignals
+
+// Ruler is a horizontal or vertical ruler for a design-tool canvas,
+// displaying tick marks and labels in the canvas coordinate system, kept in
+// sync with the canvas pan / zoom via SyncView.  Tick labels are in Units
+// (px, mm, in, etc.), converted using the display DPI.  Dragging from the
+// ruler creates a guide line at that canvas coordinate; dragging an
+// existing guide moves it (snapping to the tick increment if Snap is set),
+// and dragging it back onto the ruler deletes it.  The canvas is
+// responsible for actually drawing the guide lines across its surface,
+// using the Guides list, and for forwarding drag events over the guides --
+// RulerSig signals all guide edits.  SetMarker displays a line tracking the
+// current pointer position on the canvas.
+type Ruler struct {
+	WidgetBase
+	Dim      mat32.Dims `desc:"which axis this ruler runs along: X = horizontal ruler, Y = vertical"`
+	Offset   float32    `desc:"canvas coordinate (in dots) at the start of the ruler"`
+	Scale    float32    `desc:"zoom factor from canvas coordinates to window pixels"`
+	Units    units.Unit `desc:"units displayed on the tick labels"`
+	Guides   []float32  `desc:"canvas coordinates (in dots) of the guides along this axis"`
+	Snap     bool       `desc:"snap guides to the minor tick increment when dragging"`
+	Marker   float32    `desc:"canvas coordinate of the pointer marker line -- set via SetMarker"`
+	HasMark  bool       `desc:"display the pointer marker line"`
+	RulerSig ki.Signal  `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for guide edits -- see RulerSignals -- data is the guide index"`
+	dragIdx  int        // index of the guide being dragged -- -1 = new guide
+	dragging bool
+}
+
+var KiT_Ruler = kit.Types.AddType(&Ruler{}, RulerProps)
+
+// AddNewRuler adds a new ruler to given parent node, with given name and
+// dimension
+func AddNewRuler(parent ki.Ki, name string, dim mat32.Dims) *Ruler {
+	rl := parent.AddNewChild(KiT_Ruler, name).(*Ruler)
+	rl.Dim = dim
+	rl.Scale = 1
+	rl.Units = units.Px
+	return rl
+}
+
+// RulerWd is the thickness of a ruler, in pixels
+var RulerWd = float32(20)
+
+var RulerProps = ki.Props{
+	"EnumType:Flag":    KiT_NodeFlags,
+	"background-color": &Prefs.Colors.Control,
+}
+
+func (rl *Ruler) Disconnect() {
+	rl.WidgetBase.Disconnect()
+	rl.RulerSig.DisconnectAll()
+}
+
+func (rl *Ruler) Size2D(iter int) {
+	rl.InitLayout2D()
+	if rl.Dim == mat32.X {
+		rl.LayData.AllocSize = mat32.Vec2{100, RulerWd}
+		rl.SetStretchMaxWidth()
+	} else {
+		rl.LayData.AllocSize = mat32.Vec2{RulerWd, 100}
+		rl.SetStretchMaxHeight()
+	}
+}
+
+// SyncView sets the pan offset (canvas coordinate in dots at the ruler
+// start) and zoom scale, and updates the display -- call whenever the
+// canvas pans or zooms
+func (rl *Ruler) SyncView(offset, scale float32) {
+	rl.Offset = offset
+	if scale > 0 {
+		rl.Scale = scale
+	}
+	rl.UpdateSig()
+}
+
+// SetMarker sets the pointer marker to given canvas coordinate and shows it
+func (rl *Ruler) SetMarker(coord float32) {
+	rl.Marker = coord
+	rl.HasMark = true
+	rl.UpdateSig()
+}
+
+// DotsPerUnit returns the number of canvas dots per display unit
+func (rl *Ruler) DotsPerUnit() float32 {
+	return rl.Sty.UnContext.ToDotsFactor(rl.Units)
+}
+
+// CoordToPix returns the window pixel along the ruler axis for given
+// canvas coordinate (in dots)
+func (rl *Ruler) CoordToPix(c float32) float32 {
+	return rl.LayData.AllocPos.Dim(rl.Dim) + (c-rl.Offset)*rl.Scale
+}
+
+// PixToCoord returns the canvas coordinate (in dots) for given window
+// pixel along the ruler axis
+func (rl *Ruler) PixToCoord(p float32) float32 {
+	return rl.Offset + (p-rl.LayData.AllocPos.Dim(rl.Dim))/rl.Scale
+}
+
+// rulerTickIncs are the "nice" tick increments, in display units
+var rulerTickIncs = []float32{.1, .25, .5, 1, 2, 5, 10, 25, 50, 100, 250, 500, 1000}
+
+// TickInc returns the minor tick increment in display units: the smallest
+// nice increment that is at least 6 pixels on screen
+func (rl *Ruler) TickInc() float32 {
+	dpu := rl.DotsPerUnit() * rl.Scale // pixels per unit
+	for _, inc := range rulerTickIncs {
+		if inc*dpu >= 6 {
+			return inc
+		}
+	}
+	return rulerTickIncs[len(rulerTickIncs)-1]
+}
+
+// SnapCoord returns given canvas coordinate snapped to the minor tick
+// increment, if Snap is on
+func (rl *Ruler) SnapCoord(c float32) float32 {
+	if !rl.Snap {
+		return c
+	}
+	dinc := rl.TickInc() * rl.DotsPerUnit()
+	return math32.Floor(c/dinc+.5) * dinc
+}
+
+// GuideAtPix returns the index of the guide near given window pixel along
+// the ruler axis -- -1 if none
+func (rl *Ruler) GuideAtPix(p float32) int {
+	for i, g := range rl.Guides {
+		if math32.Abs(rl.CoordToPix(g)-p) <= 4 {
+			return i
+		}
+	}
+	return -1
+}
+
+// DeleteGuide deletes the guide at given index
+func (rl *Ruler) DeleteGuide(idx int) {
+	if idx < 0 || idx >= len(rl.Guides) {
+		return
+	}
+	rl.Guides = append(rl.Guides[:idx], rl.Guides[idx+1:]...)
+	rl.RulerSig.Emit(rl.This(), int64(RulerGuideDeleted), idx)
+	rl.UpdateSig()
+}
+
+func (rl *Ruler) MouseEvent() {
+	rl.ConnectEvent(oswin.MouseEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		if me.Button != mouse.Left {
+			return
+		}
+		me.SetProcessed()
+		rll := recv.Embed(KiT_Ruler).(*Ruler)
+		p := mat32.NewVec2FmPoint(me.Pos()).Dim(rll.Dim)
+		switch me.Action {
+		case mouse.Press:
+			rll.dragging = true
+			rll.dragIdx = rll.GuideAtPix(p)
+		case mouse.Release:
+			if !rll.dragging {
+				return
+			}
+			rll.dragging = false
+			if rll.dragIdx < 0 { // new guide
+				rll.Guides = append(rll.Guides, rll.SnapCoord(rll.PixToCoord(p)))
+				rll.RulerSig.Emit(rll.This(), int64(RulerGuideAdded), len(rll.Guides)-1)
+				rll.UpdateSig()
+			}
+		}
+	})
+}
+
+func (rl *Ruler) MouseDragEvent() {
+	rl.ConnectEvent(oswin.MouseDragEvent, RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		me.SetProcessed()
+		rll := recv.Embed(KiT_Ruler).(*Ruler)
+		if !rll.dragging || rll.dragIdx < 0 {
+			return
+		}
+		// dragging an existing guide: off-axis movement back over the
+		// ruler deletes it, otherwise it tracks the pointer with snapping
+		odim := mat32.OtherDim(rll.Dim)
+		op := mat32.NewVec2FmPoint(me.Pos()).Dim(odim)
+		if op < rll.LayData.AllocPos.Dim(odim)+RulerWd {
+			idx := rll.dragIdx
+			rll.dragIdx = -1
+			rll.dragging = false
+			rll.DeleteGuide(idx)
+			return
+		}
+		p := mat32.NewVec2FmPoint(me.Pos()).Dim(rll.Dim)
+		rll.Guides[rll.dragIdx] = rll.SnapCoord(rll.PixToCoord(p))
+		rll.RulerSig.Emit(rll.This(), int64(RulerGuideMoved), rll.dragIdx)
+		rll.UpdateSig()
+	})
+}
+
+func (rl *Ruler) ConnectEvents2D() {
+	rl.MouseEvent()
+	rl.MouseDragEvent()
+	rl.HoverTooltipEvent()
+}
+
+// RenderRuler does the full rendering of the ruler
+func (rl *Ruler) RenderRuler() {
+	rs := &rl.Viewport.Render
+	pc := &rs.Paint
+	sty := &rl.Sty
+	pos := rl.LayData.AllocPos
+	sz := rl.LayData.AllocSize
+	st := pos.Dim(rl.Dim)
+	ed := st + sz.Dim(rl.Dim)
+	base := pos.Dim(mat32.OtherDim(rl.Dim))
+	wd := sz.Dim(mat32.OtherDim(rl.Dim))
+
+	dpu := rl.DotsPerUnit()
+	inc := rl.TickInc()
+	majInc := inc * 5
+	c0 := rl.PixToCoord(st) / dpu // in units
+	c1 := rl.PixToCoord(ed) / dpu
+
+	rs.Lock()
+	pc.StrokeStyle.SetColor(sty.Font.Color)
+	pc.StrokeStyle.Width.Dots = 1
+	tick := math32.Floor(c0/inc) * inc
+	for ; tick <= c1; tick += inc {
+		tp := rl.CoordToPix(tick * dpu)
+		if tp < st || tp > ed {
+			continue
+		}
+		tht := wd * .25
+		if math32.Mod(math32.Abs(tick)+inc*.01, majInc) < inc*.02 {
+			tht = wd * .6
+		}
+		if rl.Dim == mat32.X {
+			pc.DrawLine(rs, tp, base+wd-tht, tp, base+wd)
+		} else {
+			pc.DrawLine(rs, base+wd-tht, tp, base+wd, tp)
+		}
+		pc.Stroke(rs)
+	}
+	// guide positions on the ruler
+	pc.StrokeStyle.SetColor(&Prefs.Colors.Select)
+	pc.StrokeStyle.Width.Dots = 2
+	for _, g := range rl.Guides {
+		gp := rl.CoordToPix(g)
+		if gp < st || gp > ed {
+			continue
+		}
+		if rl.Dim == mat32.X {
+			pc.DrawLine(rs, gp, base, gp, base+wd)
+		} else {
+			pc.DrawLine(rs, base, gp, base+wd, gp)
+		}
+		pc.Stroke(rs)
+	}
+	// pointer marker
+	if rl.HasMark {
+		mp := rl.CoordToPix(rl.Marker)
+		if mp >= st && mp <= ed {
+			pc.StrokeStyle.SetColor(sty.Font.Color.Highlight(20))
+			pc.StrokeStyle.Width.Dots = 1
+			if rl.Dim == mat32.X {
+				pc.DrawLine(rs, mp, base, mp, base+wd)
+			} else {
+				pc.DrawLine(rs, base, mp, base+wd, mp)
+			}
+			pc.Stroke(rs)
+		}
+	}
+	rs.Unlock()
+
+	// major tick labels
+	fst := sty.Font
+	fst.BgColor.SetColor(nil)
+	tick = math32.Floor(c0/majInc) * majInc
+	for ; tick <= c1; tick += majInc {
+		tp := rl.CoordToPix(tick * dpu)
+		if tp < st || tp > ed-10 {
+			continue
+		}
+		var tr TextRender
+		tr.SetString(fmt.Sprintf("%g", tick), &fst, &sty.UnContext, &sty.Text, true, 0, 0)
+		if rl.Dim == mat32.X {
+			tr.Render(rs, mat32.Vec2{tp + 2, base})
+		} else {
+			tr.Render(rs, mat32.Vec2{base + 1, tp + 1})
+		}
+	}
+}
+
+func (rl *Ruler) Render2D() {
+	if rl.FullReRenderIfNeeded() {
+		return
+	}
+	if rl.PushBounds() {
+		rl.This().(Node2D).ConnectEvents2D()
+		rl.RenderRuler()
+		rl.Render2DChildren()
+		rl.PopBounds()
+	} else {
+		rl.DisconnectAllEvents(RegPri)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"sort"
+
+	"github.com/goki/gi/mat32"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/ki/ki"
+)
+
+// SelectMgr manages an index-based selection for canvas-like widgets and
+// item views: click selection with the standard shift / control modifier
+// behavior (via mouse.SelectModes), rubber-band rectangle selection, and
+// select-all / cancel-select key functions.  The embedding widget routes
+// its events here: SelectAction from mouse presses on items, Rubber*
+// during background drags, and KeyInput for key chords; it renders the
+// rubber band via DrawRubberBand and reads the selection via IsSelected /
+// Selected.  SelectSig is emitted on every selection change, with the
+// sorted list of selected indexes as the data.
+type SelectMgr struct {
+	Sel       map[int]bool `desc:"the set of selected item indexes"`
+	LastSel   int          `desc:"index of the most recently selected item -- -1 if none"`
+	SelectSig ki.Signal    `copy:"-" json:"-" xml:"-" view:"-" desc:"emitted when the selection changes -- data is the sorted []int of selected indexes"`
+	rubber    bool
+	rubStart  mat32.Vec2
+	rubCur    mat32.Vec2
+}
+
+// Init initializes the selection state -- call before first use
+func (sm *SelectMgr) Init() {
+	sm.Sel = make(map[int]bool)
+	sm.LastSel = -1
+}
+
+// IsSelected returns whether item at given index is selected
+func (sm *SelectMgr) IsSelected(idx int) bool {
+	return sm.Sel[idx]
+}
+
+// HasSelected returns whether anything is selected
+func (sm *SelectMgr) HasSelected() bool {
+	return len(sm.Sel) > 0
+}
+
+// Selected returns the sorted list of selected indexes
+func (sm *SelectMgr) Selected() []int {
+	idxs := make([]int, 0, len(sm.Sel))
+	for i := range sm.Sel {
+		idxs = append(idxs, i)
+	}
+	sort.Ints(idxs)
+	return idxs
+}
+
+// emit sends SelectSig from given host node
+func (sm *SelectMgr) emit(host ki.Ki) {
+	sm.SelectSig.Emit(host, int64(sm.LastSel), sm.Selected())
+}
+
+// Clear unselects everything, emitting SelectSig from host if anything
+// was selected
+func (sm *SelectMgr) Clear(host ki.Ki) {
+	if len(sm.Sel) == 0 {
+		return
+	}
+	for i := range sm.Sel {
+		delete(sm.Sel, i)
+	}
+	sm.LastSel = -1
+	sm.emit(host)
+}
+
+// SelectAll selects indexes [0, n), emitting SelectSig from host
+func (sm *SelectMgr) SelectAll(host ki.Ki, n int) {
+	for i := 0; i < n; i++ {
+		sm.Sel[i] = true
+	}
+	sm.emit(host)
+}
+
+// SelectAction updates the selection for a click on item at given index
+// (-1 = background) with given selection mode (typically
+// me.SelectMode()), emitting SelectSig from host on any change
+func (sm *SelectMgr) SelectAction(host ki.Ki, idx int, mode mouse.SelectModes) {
+	switch mode {
+	case mouse.ExtendOne:
+		if idx < 0 {
+			return
+		}
+		if sm.Sel[idx] {
+			delete(sm.Sel, idx)
+			sm.LastSel = -1
+		} else {
+			sm.Sel[idx] = true
+			sm.LastSel = idx
+		}
+	case mouse.ExtendContinuous:
+		if idx < 0 {
+			return
+		}
+		if sm.LastSel >= 0 {
+			lo, hi := sm.LastSel, idx
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			for i := lo; i <= hi; i++ {
+				sm.Sel[i] = true
+			}
+		} else {
+			sm.Sel[idx] = true
+		}
+		sm.LastSel = idx
+	case mouse.Unselect:
+		if idx < 0 || !sm.Sel[idx] {
+			return
+		}
+		delete(sm.Sel, idx)
+	default: // SelectOne
+		if idx >= 0 && sm.Sel[idx] && len(sm.Sel) == 1 {
+			return // no change
+		}
+		for i := range sm.Sel {
+			delete(sm.Sel, i)
+		}
+		if idx >= 0 {
+			sm.Sel[idx] = true
+		}
+		sm.LastSel = idx
+	}
+	sm.emit(host)
+}
+
+// KeyInput processes select-all and cancel-select key functions for given
+// key event, with n = total number of items -- returns true if handled
+// (and sets the event processed)
+func (sm *SelectMgr) KeyInput(host ki.Ki, kt *key.ChordEvent, n int) bool {
+	kf := KeyFun(kt.Chord())
+	switch kf {
+	case KeyFunSelectAll:
+		kt.SetProcessed()
+		sm.SelectAll(host, n)
+		return true
+	case KeyFunCancelSelect:
+		kt.SetProcessed()
+		sm.Clear(host)
+		return true
+	}
+	return false
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    Rubber-band selection
+
+// RubberStart starts a rubber-band drag at given point (in the same
+// coordinates as the item boxes passed to RubberEnd)
+func (sm *SelectMgr) RubberStart(pt mat32.Vec2) {
+	sm.rubber = true
+	sm.rubStart = pt
+	sm.rubCur = pt
+}
+
+// RubberMove updates the rubber band to given point -- the widget should
+// re-render to show it
+func (sm *SelectMgr) RubberMove(pt mat32.Vec2) {
+	sm.rubCur = pt
+}
+
+// RubberActive returns whether a rubber-band drag is in progress
+func (sm *SelectMgr) RubberActive() bool {
+	return sm.rubber
+}
+
+// RubberBox returns the current rubber-band rectangle
+func (sm *SelectMgr) RubberBox() mat32.Box2 {
+	return mat32.Box2{Min: sm.rubStart.Min(sm.rubCur), Max: sm.rubStart.Max(sm.rubCur)}
+}
+
+// RubberEnd completes a rubber-band drag: items whose boxes are fully
+// inside the band are selected, per given selection mode (SelectOne
+// replaces the selection, ExtendOne / ExtendContinuous add to it),
+// emitting SelectSig from host
+func (sm *SelectMgr) RubberEnd(host ki.Ki, boxes []mat32.Box2, mode mouse.SelectModes) {
+	if !sm.rubber {
+		return
+	}
+	sm.rubber = false
+	band := sm.RubberBox()
+	if mode == mouse.SelectOne {
+		for i := range sm.Sel {
+			delete(sm.Sel, i)
+		}
+	}
+	for i, bx := range boxes {
+		if bx.Min.X >= band.Min.X && bx.Max.X <= band.Max.X && bx.Min.Y >= band.Min.Y && bx.Max.Y <= band.Max.Y {
+			sm.Sel[i] = true
+			sm.LastSel = i
+		}
+	}
+	sm.emit(host)
+}
+
+// DrawRubberBand renders the rubber-band rectangle if a drag is in
+// progress -- the render state must already be locked
+func (sm *SelectMgr) DrawRubberBand(rs *RenderState, pc *Paint) {
+	if !sm.rubber {
+		return
+	}
+	band := sm.RubberBox()
+	sz := band.Size()
+	pc.StrokeStyle.SetColor(&Prefs.Colors.Select)
+	pc.StrokeStyle.Width.Dots = 1
+	pc.DrawRectangle(rs, band.Min.X, band.Min.Y, sz.X, sz.Y)
+	pc.Stroke(rs)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"time"
+
+	"github.com/goki/ki/ki"
+)
+
+// SettingsBundleVersion is the current version of the settings bundle
+// format -- bundles with a newer version than this are rejected on import
+const SettingsBundleVersion = 1
+
+// SettingsBundle is a single portable archive of the user's GoGi
+// environment: preferences, key maps, colors, and custom styles, with
+// versioning -- export on one machine with SaveSettingsBundle and import on
+// another with OpenSettingsBundle / ImportSettingsDialog
+type SettingsBundle struct {
+	Version      int         `desc:"bundle format version -- see SettingsBundleVersion"`
+	GoGiVersion  string      `desc:"GoGi version that exported this bundle"`
+	Exported     time.Time   `desc:"time the bundle was exported"`
+	Prefs        Preferences `desc:"the main preferences"`
+	KeyMaps      KeyMaps     `desc:"the available key maps, including any custom ones"`
+	Colors       ColorPrefs  `desc:"color preferences -- also part of Prefs, separate here for selective import"`
+	CustomStyles ki.Props    `desc:"custom style sheet -- also part of Prefs, separate here for selective import"`
+}
+
+// NewSettingsBundle returns a bundle of the current settings
+func NewSettingsBundle() *SettingsBundle {
+	sb := &SettingsBundle{
+		Version:      SettingsBundleVersion,
+		GoGiVersion:  Version,
+		Exported:     time.Now(),
+		Prefs:        Prefs,
+		Colors:       Prefs.Colors,
+		CustomStyles: Prefs.CustomStyles,
+	}
+	sb.KeyMaps.CopyFrom(AvailKeyMaps)
+	return sb
+}
+
+// SaveSettingsBundle exports the current settings to given file as a single
+// JSON bundle
+func SaveSettingsBundle(filename FileName) error {
+	sb := NewSettingsBundle()
+	b, err := json.MarshalIndent(sb, "", "  ")
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	err = ioutil.WriteFile(string(filename), b, 0644)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// OpenSettingsBundle opens a settings bundle from given file, checking the
+// format version -- does not apply it: see ApplySettingsBundle
+func OpenSettingsBundle(filename FileName) (*SettingsBundle, error) {
+	b, err := ioutil.ReadFile(string(filename))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	sb := &SettingsBundle{}
+	err = json.Unmarshal(b, sb)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	if sb.Version > SettingsBundleVersion {
+		err = fmt.Errorf("gi.OpenSettingsBundle: bundle version %v is newer than supported version %v -- update GoGi to import it", sb.Version, SettingsBundleVersion)
+		log.Println(err)
+		return nil, err
+	}
+	return sb, nil
+}
+
+// ApplySettingsBundle selectively applies components of given bundle to the
+// current settings, saves them, and updates open windows -- the flags
+// select which components to import
+func ApplySettingsBundle(sb *SettingsBundle, prefs, keymaps, colors, styles bool) {
+	if prefs {
+		clr := Prefs.Colors
+		csty := Prefs.CustomStyles
+		Prefs = sb.Prefs
+		if !colors { // keep current unless explicitly imported
+			Prefs.Colors = clr
+		}
+		if !styles {
+			Prefs.CustomStyles = csty
+		}
+	}
+	if keymaps {
+		AvailKeyMaps.CopyFrom(sb.KeyMaps)
+		Prefs.SaveKeyMaps = true
+	}
+	if colors {
+		Prefs.Colors = sb.Colors
+	}
+	if styles {
+		Prefs.CustomStyles = sb.CustomStyles
+	}
+	Prefs.Save()
+	Prefs.Update()
+}
+
+// ImportSettingsDialog opens given settings bundle file and presents a
+// dialog for selecting which components to import -- applies the selected
+// ones on Ok
+func ImportSettingsDialog(avp *Viewport2D, filename FileName) {
+	sb, err := OpenSettingsBundle(filename)
+	if err != nil {
+		PromptDialog(avp, DlgOpts{Title: "Import Failed", Prompt: err.Error()}, AddOk, NoCancel, nil, nil)
+		return
+	}
+	opts := DlgOpts{Title: "Import Settings",
+		Prompt: fmt.Sprintf("Select which settings to import from bundle exported %v (GoGi %v):", sb.Exported.Format("2006-01-02"), sb.GoGiVersion)}
+	dlg := NewStdDialog(opts, AddOk, AddCancel)
+	dlg.Modal = true
+
+	frame := dlg.Frame()
+	_, prIdx := dlg.PromptWidget(frame)
+	crow := frame.InsertNewChild(KiT_Layout, prIdx+1, "comp-row").(*Layout)
+	crow.Lay = LayoutVert
+	items := []struct{ name, label string }{
+		{"prefs", "Preferences"},
+		{"keymaps", "Key Maps"},
+		{"colors", "Colors"},
+		{"styles", "Custom Styles"},
+	}
+	for _, it := range items {
+		cb := AddNewCheckBox(crow, it.name)
+		cb.SetText(it.label)
+		cb.SetChecked(true)
+	}
+
+	dlg.DialogSig.Connect(avp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig != int64(DialogAccepted) {
+			return
+		}
+		ddlg := send.Embed(KiT_Dialog).(*Dialog)
+		cr := ddlg.Frame().ChildByName("comp-row", 0).(*Layout)
+		get := func(nm string) bool {
+			return cr.ChildByName(nm, 0).(*CheckBox).IsChecked()
+		}
+		ApplySettingsBundle(sb, get("prefs"), get("keymaps"), get("colors"), get("styles"))
+	})
+	dlg.UpdateEndNoSig(true)
+	dlg.Open(0, 0, avp, nil)
+}
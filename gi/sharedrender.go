@@ -0,0 +1,95 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+)
+
+// Shared render stamping: nodes given the same key via SetRenderShared
+// share one retained rasterization per viewport.  The first such node to
+// render live fills the shared image, and every other node with the same
+// key and the same on-screen size blits ("stamps") it instead of
+// re-rendering -- use for identical repeated subtrees such as per-row
+// decorations or repeated icons in huge tables, where each instance would
+// otherwise rasterize the same pixels.  Invalidation follows the same
+// rules as the CacheRender mechanism in rendercache.go: a node flagged for
+// full re-render ignores the shared image, renders live, and refreshes it,
+// so a change within any instance's subtree propagates to all stampers --
+// or drop it explicitly with Viewport2D.InvalidateSharedRender.
+
+// SetRenderShared sets (or, with an empty key, clears) the shared render
+// key for this node -- all nodes on the same viewport with the same key
+// must render identically at the same size, as they share one
+// rasterization -- see also InvalidateSharedRender
+func (nb *Node2DBase) SetRenderShared(key string) {
+	nb.RendShare = key
+}
+
+// InvalidateSharedRender drops the shared rasterization for given key, so
+// the next node rendered with that key re-rasterizes live and refills it --
+// call after programmatically changing anything visible in the shared
+// subtrees
+func (vp *Viewport2D) InvalidateSharedRender(key string) {
+	if vp.RendShared != nil {
+		delete(vp.RendShared, key)
+	}
+}
+
+// sharedRenderValid returns whether the shared image for this node's key
+// can be stamped for the current render: it must exist, match the current
+// on-screen size, and no full re-render can be pending on this node
+func (nb *Node2DBase) sharedRenderValid() bool {
+	if nb.NeedsFullReRender() {
+		return false
+	}
+	img := nb.Viewport.RendShared[nb.RendShare]
+	return img != nil && img.Bounds().Size() == nb.VpBBox.Size()
+}
+
+// renderFromShared stamps the shared image into the viewport at the
+// current VpBBox
+func (nb *Node2DBase) renderFromShared() {
+	draw.Draw(nb.Viewport.Pixels, nb.VpBBox, nb.Viewport.RendShared[nb.RendShare], image.ZP, draw.Src)
+}
+
+// renderToShared copies the viewport pixels within the current VpBBox into
+// the shared image for this node's key, after a live render
+func (nb *Node2DBase) renderToShared() {
+	sz := nb.VpBBox.Size()
+	if sz == image.ZP {
+		return
+	}
+	vp := nb.Viewport
+	if vp.RendShared == nil {
+		vp.RendShared = make(map[string]*image.RGBA)
+	}
+	img := vp.RendShared[nb.RendShare]
+	if img == nil || img.Bounds().Size() != sz {
+		img = image.NewRGBA(image.Rectangle{Max: sz})
+		vp.RendShared[nb.RendShare] = img
+	}
+	draw.Draw(img, img.Bounds(), vp.Pixels, nb.VpBBox.Min, draw.Src)
+}
+
+// renderSharedOrLive renders given node through the shared rasterization
+// for its key: a valid shared image is stamped directly, otherwise the
+// node renders live and refreshes it -- called from renderCachedOrLive for
+// nodes with a RendShare key
+func renderSharedOrLive(nii Node2D, ni *Node2DBase) {
+	if ni.sharedRenderValid() && nii.IsVisible() {
+		if Render2DTrace {
+			fmt.Printf("Render: shared %v stamp %v at %v\n", ni.RendShare, ni.PathUnique(), ni.VpBBox)
+		}
+		ni.renderFromShared()
+		return
+	}
+	nii.Render2D()
+	if nii.IsVisible() && !ni.VpBBox.Empty() {
+		ni.renderToShared()
+	}
+}
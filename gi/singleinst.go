@@ -0,0 +1,146 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/goki/ki/ki"
+)
+
+// TheSingleInst is the global single-instance manager -- see
+// SingleInstMgr.Start.  Connect to OpenFilesSig to receive arguments
+// (e.g., files to open) forwarded from subsequent launches.
+var TheSingleInst SingleInstMgr
+
+func init() {
+	TheSingleInst.InitName(&TheSingleInst, "single-inst")
+}
+
+// SingleInstMgr ensures that only one instance of the application runs at a
+// time: the first instance listens on a per-app local socket (unix domain
+// socket, or localhost tcp on Windows), and later instances forward their
+// command-line arguments to it and exit.  The running instance raises its
+// main window and emits OpenFilesSig with the forwarded arguments.
+type SingleInstMgr struct {
+	ki.Node
+	AppName      string    `desc:"application name used to derive the socket path -- must be unique per app"`
+	Primary      bool      `desc:"whether this process is the primary (listening) instance"`
+	OpenFilesSig ki.Signal `copy:"-" json:"-" xml:"-" view:"-" desc:"emitted in the primary instance when another launch forwards its arguments -- data is the arguments as []string"`
+	listener     net.Listener
+	sockPath     string
+	addrPath     string
+}
+
+// Start attempts to become the single running instance for given app name.
+// If another instance is already running, the given arguments (typically
+// os.Args[1:]) are forwarded to it and false is returned -- the caller
+// should then exit.  Otherwise this process becomes the primary instance,
+// listening for forwarded arguments, and true is returned.
+func (si *SingleInstMgr) Start(appName string, args []string) bool {
+	si.AppName = appName
+	si.sockPath = filepath.Join(os.TempDir(), appName+"-gi-single.sock")
+	si.addrPath = filepath.Join(os.TempDir(), appName+"-gi-single.addr")
+	if conn := si.dialExisting(); conn != nil {
+		json.NewEncoder(conn).Encode(args)
+		conn.Close()
+		return false
+	}
+	if !si.listen() {
+		// can't listen either -- just run standalone
+		return true
+	}
+	si.Primary = true
+	go si.acceptLoop()
+	return true
+}
+
+// Stop stops listening and removes the socket -- apps should call this on
+// shutdown (e.g., from the main window close-clean function)
+func (si *SingleInstMgr) Stop() {
+	if si.listener != nil {
+		si.listener.Close()
+		si.listener = nil
+	}
+	if si.Primary {
+		os.Remove(si.sockPath)
+		os.Remove(si.addrPath)
+		si.Primary = false
+	}
+}
+
+// dialExisting attempts to connect to an already-running instance,
+// returning nil if there is none
+func (si *SingleInstMgr) dialExisting() net.Conn {
+	if runtime.GOOS == "windows" {
+		ab, err := ioutil.ReadFile(si.addrPath)
+		if err != nil {
+			return nil
+		}
+		conn, err := net.Dial("tcp", strings.TrimSpace(string(ab)))
+		if err != nil {
+			return nil
+		}
+		return conn
+	}
+	conn, err := net.Dial("unix", si.sockPath)
+	if err != nil {
+		return nil
+	}
+	return conn
+}
+
+// listen starts the local socket listener, clearing any stale socket from a
+// crashed prior instance
+func (si *SingleInstMgr) listen() bool {
+	var err error
+	if runtime.GOOS == "windows" {
+		si.listener, err = net.Listen("tcp", "127.0.0.1:0")
+		if err == nil {
+			err = ioutil.WriteFile(si.addrPath, []byte(si.listener.Addr().String()), 0644)
+		}
+	} else {
+		os.Remove(si.sockPath) // stale socket from a crashed instance
+		si.listener, err = net.Listen("unix", si.sockPath)
+	}
+	if err != nil {
+		log.Printf("gi.SingleInstMgr: could not listen for forwarded launches: %v\n", err)
+		if si.listener != nil {
+			si.listener.Close()
+			si.listener = nil
+		}
+		return false
+	}
+	return true
+}
+
+// acceptLoop receives forwarded arguments from later launches, raises the
+// main window, and emits OpenFilesSig -- runs in its own goroutine
+func (si *SingleInstMgr) acceptLoop() {
+	for {
+		conn, err := si.listener.Accept()
+		if err != nil {
+			return // listener closed
+		}
+		var args []string
+		if err := json.NewDecoder(conn).Decode(&args); err == nil {
+			si.RaiseMainWindow()
+			si.OpenFilesSig.Emit(si.This(), 0, args)
+		}
+		conn.Close()
+	}
+}
+
+// RaiseMainWindow raises the first main window of the app, if there is one
+func (si *SingleInstMgr) RaiseMainWindow() {
+	RaiseMainWindow()
+}
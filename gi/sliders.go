@@ -55,6 +55,7 @@ type SliderBase struct {
 	Dim         mat32.Dims           `desc:"dimension along which the slider slides"`
 	Tracking    bool                 `xml:"tracking" desc:"if true, will send continuous updates of value changes as user moves the slider -- otherwise only at the end -- see TrackThr for a threshold on amount of change"`
 	TrackThr    float32              `xml:"track-thr" desc:"threshold for amount of change in scroll value before emitting a signal in Tracking mode"`
+	TrackThrot  Throttle             `desc:"time-based throttling of Tracking signal emissions -- e.g., ThrottleInterval with IntervalMSec limits updates to every-N-ms, and ThrottleRelease defers everything to the final value on release -- for views bound to expensive recomputation"`
 	Snap        bool                 `xml:"snap" desc:"snap the values to Step size increments"`
 	Off         bool                 `desc:"can turn off e.g., scrollbar rendering with this flag -- just prevents rendering"`
 	State       SliderStates         `json:"-" xml:"-" desc:"state of slider"`
@@ -88,6 +89,8 @@ func (nb *SliderBase) CopyFieldsFrom(frm interface{}) {
 	nb.VisPos = fr.VisPos
 	nb.Tracking = fr.Tracking
 	nb.TrackThr = fr.TrackThr
+	nb.TrackThrot.Mode = fr.TrackThrot.Mode
+	nb.TrackThrot.IntervalMSec = fr.TrackThrot.IntervalMSec
 	nb.Snap = fr.Snap
 	nb.Off = fr.Off
 }
@@ -199,6 +202,7 @@ func (sb *SliderBase) SliderReleased() {
 	updt := sb.UpdateStart()
 	sb.SetSliderState(SliderActive)
 	sb.SliderSig.Emit(sb.This(), int64(SliderReleased), sb.Value)
+	sb.TrackThrot.Flush() // any pending throttled value is covered by final emit below
 	if wasPressed && sb.Value != sb.EmitValue {
 		sb.SliderSig.Emit(sb.This(), int64(SliderValueChanged), sb.Value)
 		sb.EmitValue = sb.Value
@@ -262,7 +266,7 @@ func (sb *SliderBase) SetSliderPos(pos float32) {
 		sb.UpdatePosFromValue()
 	}
 	if sb.Tracking && sb.Value != sb.EmitValue {
-		if math32.Abs(sb.Value-sb.EmitValue) > sb.TrackThr {
+		if math32.Abs(sb.Value-sb.EmitValue) > sb.TrackThr && sb.TrackThrot.Allow() {
 			sb.SliderSig.Emit(sb.This(), int64(SliderValueChanged), sb.Value)
 			sb.EmitValue = sb.Value
 		}
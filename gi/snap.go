@@ -0,0 +1,160 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/mat32"
+)
+
+// SnapGuide is one active alignment guide produced by a SnapEngine: a line
+// at Pos along given dimension, spanning [Min, Max] in the other dimension,
+// marking the edge or center that the dragged item snapped to
+type SnapGuide struct {
+	Dim mat32.Dims `desc:"dimension the guide constrains: X = vertical line at Pos, Y = horizontal"`
+	Pos float32    `desc:"coordinate of the guide line in the snapped dimension"`
+	Min float32    `desc:"start of the guide line in the other dimension"`
+	Max float32    `desc:"end of the guide line in the other dimension"`
+}
+
+// SnapEngine snaps dragged items to a grid and to the edges and centers of
+// sibling items, producing smart-guide lines for rendering.  It is a plain
+// computational helper usable by any drag interaction (node graphs, canvas
+// items, docking): set the candidate Targets (bounding boxes of the other
+// items, in whatever coordinate system the drag operates in), call SnapBox
+// or SnapPoint with the proposed position each drag step, apply the
+// returned snapped position, and render the resulting Guides (e.g., via
+// DrawGuides).  Grid snapping applies where no target snap is within
+// tolerance.
+type SnapEngine struct {
+	GridOn  bool         `desc:"snap to the grid"`
+	Grid    float32      `desc:"grid spacing"`
+	EdgesOn bool         `desc:"snap to edges and centers of the target items"`
+	Tol     float32      `desc:"maximum distance within which snapping applies"`
+	Targets []mat32.Box2 `desc:"bounding boxes of the items to align against"`
+	Guides  []SnapGuide  `desc:"the guides active after the last snap call"`
+}
+
+// Defaults sets default snap settings: both modes on, 8 unit grid, 6 unit
+// tolerance
+func (se *SnapEngine) Defaults() {
+	se.GridOn = true
+	se.Grid = 8
+	se.EdgesOn = true
+	se.Tol = 6
+}
+
+// SetTargets sets the bounding boxes of the items to align against --
+// exclude the item being dragged
+func (se *SnapEngine) SetTargets(boxes []mat32.Box2) {
+	se.Targets = boxes
+}
+
+// SnapGrid returns given value snapped to the grid
+func (se *SnapEngine) SnapGrid(v float32) float32 {
+	if !se.GridOn || se.Grid <= 0 {
+		return v
+	}
+	return math32.Floor(v/se.Grid+.5) * se.Grid
+}
+
+// SnapPoint returns given point snapped to the grid -- no edge alignment
+// or guides
+func (se *SnapEngine) SnapPoint(p mat32.Vec2) mat32.Vec2 {
+	return mat32.Vec2{se.SnapGrid(p.X), se.SnapGrid(p.Y)}
+}
+
+// snapCands returns the candidate snap coordinates of given box along
+// given dimension: min edge, center, max edge
+func snapCands(box mat32.Box2, d mat32.Dims) [3]float32 {
+	mn := box.Min.Dim(d)
+	mx := box.Max.Dim(d)
+	return [3]float32{mn, .5 * (mn + mx), mx}
+}
+
+// SnapBox returns given box snapped to the targets and grid, and records
+// the active alignment guides in Guides.  For each dimension the nearest
+// target edge / center alignment within tolerance wins; failing that, the
+// min edge snaps to the grid.
+func (se *SnapEngine) SnapBox(box mat32.Box2) mat32.Box2 {
+	se.Guides = se.Guides[:0]
+	sz := box.Size()
+	for d := mat32.X; d <= mat32.Y; d++ {
+		od := mat32.OtherDim(d)
+		best := se.Tol + 1
+		bestPos := float32(0)
+		var bestTarg mat32.Box2
+		got := false
+		if se.EdgesOn {
+			mine := snapCands(box, d)
+			for _, tb := range se.Targets {
+				for _, tc := range snapCands(tb, d) {
+					for _, mc := range mine {
+						del := math32.Abs(tc - mc)
+						if del <= se.Tol && del < best {
+							best = del
+							// new min edge such that this candidate lands on tc
+							bestPos = tc - (mc - mine[0])
+							bestTarg = tb
+							got = true
+						}
+					}
+				}
+			}
+		}
+		if got {
+			nmin := box.Min
+			nmin.SetDim(d, bestPos)
+			box.Min = nmin
+			box.Max = box.Min.Add(sz)
+			// guide spans from the target to the snapped box
+			gmin := math32.Min(bestTarg.Min.Dim(od), box.Min.Dim(od))
+			gmax := math32.Max(bestTarg.Max.Dim(od), box.Max.Dim(od))
+			se.Guides = append(se.Guides, SnapGuide{Dim: d, Pos: alignedCoord(box, bestTarg, d), Min: gmin, Max: gmax})
+		} else if se.GridOn && se.Grid > 0 {
+			nmin := box.Min
+			nmin.SetDim(d, se.SnapGrid(nmin.Dim(d)))
+			box.Min = nmin
+		}
+	}
+	box.Max = box.Min.Add(sz)
+	return box
+}
+
+// alignedCoord returns the coordinate along given dimension where the
+// snapped box and target are aligned (matching edge or center), for
+// drawing the guide line
+func alignedCoord(box, targ mat32.Box2, d mat32.Dims) float32 {
+	bc := snapCands(box, d)
+	tc := snapCands(targ, d)
+	for _, t := range tc {
+		for _, b := range bc {
+			if math32.Abs(t-b) < .5 {
+				return t
+			}
+		}
+	}
+	return bc[0]
+}
+
+// DrawGuides renders the currently-active guides as lines in given paint
+// context -- coordinates are used as-is, so the caller must have Targets
+// and boxes in window pixels (or transform Guides itself).  The render
+// state must already be locked.
+func (se *SnapEngine) DrawGuides(rs *RenderState, pc *Paint) {
+	if len(se.Guides) == 0 {
+		return
+	}
+	pc.StrokeStyle.SetColor(&Prefs.Colors.Select)
+	pc.StrokeStyle.Width.Dots = 1
+	for _, g := range se.Guides {
+		if g.Dim == mat32.X {
+			pc.DrawLine(rs, g.Pos, g.Min, g.Pos, g.Max)
+		} else {
+			pc.DrawLine(rs, g.Min, g.Pos, g.Max, g.Pos)
+		}
+		pc.Stroke(rs)
+	}
+}
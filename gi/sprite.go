@@ -16,6 +16,7 @@ import (
 type Sprite struct {
 	On     bool        `desc:"whether this sprite is active now or not"`
 	Name   string      `desc:"unique name of sprite"`
+	Layer  int         `desc:"compositing layer -- sprites render in ascending Layer order, name order within a layer -- default 0"`
 	Geom   Geom2DInt   `desc:"position and size of the image within the overlay window texture"`
 	Pixels *image.RGBA `desc:"pixels to render -- should be same size as Geom.Size"`
 	Bg     *image.RGBA `desc:"optional background image which is rendered first before IMage"`
@@ -0,0 +1,144 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/goki/ki/ki"
+)
+
+// Reactive state: State is an observable value cell, and BindState
+// attaches an update function to a widget that reads such cells -- the
+// reads are tracked as dependencies, and when a cell value changes, only
+// the widgets bound to it are updated and re-rendered.  This is a
+// lightweight alternative to manual signal wiring for app state: instead
+// of connecting signals and routing update messages, the widget declares
+// how it derives its content from state, and the dependency bookkeeping is
+// automatic.  Dependencies are re-tracked on every run, so bindings that
+// read different cells on different code paths stay correct.
+//
+//	counter := gi.NewState(0)
+//	gi.BindState(lbl, func(n gi.Node2D) {
+//		lbl.SetText(fmt.Sprintf("count: %v", counter.Get()))
+//	})
+//	...
+//	counter.Set(counter.Get().(int) + 1) // re-renders only lbl
+//
+// Binding updates are serialized, one at a time -- Set is safe to call
+// from any goroutine, but a binding function must not itself call Set
+// (derive values with Get and do any Set calls from event handlers).
+
+// State is an observable value cell for reactive updates -- see BindState
+type State struct {
+	val  interface{}
+	deps map[*stateBinding]struct{}
+}
+
+// stateMu protects the dependency maps and current-binding tracking
+var stateMu sync.Mutex
+
+// stateRunMu serializes binding runs, so only one binding function is
+// (re)running at a time
+var stateRunMu sync.Mutex
+
+// stateCurBinding is the binding currently being (re)run, for dependency
+// tracking -- protected by stateMu
+var stateCurBinding *stateBinding
+
+// stateBinding is one widget's reactive update function and its
+// currently-tracked dependencies
+type stateBinding struct {
+	node   ki.Ki
+	fun    func(n Node2D)
+	states map[*State]struct{}
+}
+
+// NewState returns a new observable state cell with given initial value
+func NewState(val interface{}) *State {
+	return &State{val: val}
+}
+
+// Get returns the current value -- when called from within a binding
+// function, the binding is registered as depending on this cell, so it
+// re-runs when the value changes
+func (st *State) Get() interface{} {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	if stateCurBinding != nil {
+		if st.deps == nil {
+			st.deps = make(map[*stateBinding]struct{})
+		}
+		st.deps[stateCurBinding] = struct{}{}
+		stateCurBinding.states[st] = struct{}{}
+	}
+	return st.val
+}
+
+// Set sets a new value and re-runs the binding functions of all widgets
+// that read this cell, re-rendering them -- no-op if the value is
+// unchanged (reflect.DeepEqual) -- safe to call from any goroutine, but
+// must not be called from within a binding function
+func (st *State) Set(val interface{}) {
+	stateMu.Lock()
+	if reflect.DeepEqual(st.val, val) {
+		stateMu.Unlock()
+		return
+	}
+	st.val = val
+	deps := make([]*stateBinding, 0, len(st.deps))
+	for sb := range st.deps {
+		deps = append(deps, sb)
+	}
+	stateMu.Unlock()
+	for _, sb := range deps {
+		sb.run()
+	}
+}
+
+// BindState attaches a reactive update function to given widget: fun is
+// run immediately, and re-run (within an update bracket on the widget, so
+// changes render) whenever any State cell it reads via Get changes -- the
+// binding is dropped automatically when the widget is destroyed
+func BindState(n Node2D, fun func(n Node2D)) {
+	sb := &stateBinding{node: n.AsGiNode().This(), fun: fun, states: make(map[*State]struct{})}
+	sb.run()
+}
+
+// run re-runs this binding with dependency tracking, inside an update
+// bracket on its node -- drops the binding if the node is destroyed
+func (sb *stateBinding) run() {
+	stateRunMu.Lock()
+	defer stateRunMu.Unlock()
+	stateMu.Lock()
+	if sb.node == nil || sb.node.IsDestroyed() || sb.node.IsDeleted() {
+		sb.release()
+		stateMu.Unlock()
+		return
+	}
+	sb.release() // re-track dependencies from scratch each run
+	prev := stateCurBinding
+	stateCurBinding = sb
+	stateMu.Unlock()
+
+	nii, _ := KiToNode2D(sb.node)
+	updt := sb.node.UpdateStart()
+	sb.fun(nii)
+	sb.node.UpdateEnd(updt)
+
+	stateMu.Lock()
+	stateCurBinding = prev
+	stateMu.Unlock()
+}
+
+// release removes this binding from all cells it depends on -- stateMu
+// must be held
+func (sb *stateBinding) release() {
+	for st := range sb.states {
+		delete(st.deps, sb)
+	}
+	sb.states = make(map[*State]struct{})
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"io/ioutil"
+
+	"github.com/goki/gi/mat32"
+)
+
+// SVG export: like PDF export (see pdf.go), the scene is re-rendered with
+// a PaintRecorder attached, and the recorded path, text, and image
+// operations are emitted as SVG elements.  Gradients are flattened to
+// their solid color; text uses the font family name with browser-side
+// metrics, so exact layout may differ slightly from the rendered pixels.
+
+// EncodeSVG re-renders the scene under this viewport and writes it as an
+// SVG document of the same size to given writer
+func (vp *Viewport2D) EncodeSVG(w io.Writer) error {
+	rec := &PaintRecorder{}
+	vp.Render.Rec = rec
+	vp.FullRender2DTree()
+	vp.Render.Rec = nil
+	sz := vp.Geom.Size
+	return rec.WriteSVG(w, float32(sz.X), float32(sz.Y))
+}
+
+// SaveSVG re-renders the scene under this viewport and saves it as an SVG
+// document to given file
+func (vp *Viewport2D) SaveSVG(path string) error {
+	var b bytes.Buffer
+	if err := vp.EncodeSVG(&b); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b.Bytes(), 0644)
+}
+
+// svgPathData renders recorded path ops as an SVG path data string
+func svgPathData(pth []pdfPathOp) string {
+	var b bytes.Buffer
+	for _, op := range pth {
+		switch op.Verb {
+		case 'm':
+			fmt.Fprintf(&b, "M %.2f %.2f ", op.Pts[0].X, op.Pts[0].Y)
+		case 'l':
+			fmt.Fprintf(&b, "L %.2f %.2f ", op.Pts[0].X, op.Pts[0].Y)
+		case 'c':
+			fmt.Fprintf(&b, "C %.2f %.2f %.2f %.2f %.2f %.2f ",
+				op.Pts[0].X, op.Pts[0].Y, op.Pts[1].X, op.Pts[1].Y, op.Pts[2].X, op.Pts[2].Y)
+		case 'h':
+			fmt.Fprintf(&b, "Z ")
+		}
+	}
+	return b.String()
+}
+
+// WriteSVG writes the recorded commands as an SVG document of given width
+// and height to given writer
+func (pr *PaintRecorder) WriteSVG(w io.Writer, wd, ht float32) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%g\" height=\"%g\" viewBox=\"0 0 %g %g\">\n", wd, ht, wd, ht)
+	for _, cmd := range pr.Cmds {
+		switch {
+		case cmd.Txt != "":
+			var esc bytes.Buffer
+			xml.EscapeText(&esc, []byte(cmd.Txt))
+			fmt.Fprintf(&b, "  <text x=\"%.2f\" y=\"%.2f\" font-size=\"%.2f\" fill=\"%s\">%s</text>\n",
+				cmd.Pos.X, cmd.Pos.Y, cmd.Size, cmd.Clr.HexString(), esc.String())
+		case cmd.Img != nil:
+			var ib bytes.Buffer
+			if err := png.Encode(&ib, cmd.Img); err != nil {
+				continue
+			}
+			isz := cmd.Img.Bounds().Size()
+			fmt.Fprintf(&b, "  <image x=\"%.2f\" y=\"%.2f\" width=\"%d\" height=\"%d\" xlink:href=\"data:image/png;base64,%s\"/>\n",
+				cmd.Pos.X, cmd.Pos.Y, isz.X, isz.Y, base64.StdEncoding.EncodeToString(ib.Bytes()))
+		case cmd.Stroke:
+			fmt.Fprintf(&b, "  <path d=\"%s\" fill=\"none\" stroke=\"%s\" stroke-width=\"%.2f\"/>\n",
+				svgPathData(cmd.Path), cmd.Clr.HexString(), cmd.Width)
+		default:
+			rule := "nonzero"
+			if cmd.EvenOdd {
+				rule = "evenodd"
+			}
+			fmt.Fprintf(&b, "  <path d=\"%s\" fill=\"%s\" fill-rule=\"%s\" stroke=\"none\"/>\n",
+				svgPathData(cmd.Path), cmd.Clr.HexString(), rule)
+		}
+	}
+	fmt.Fprintf(&b, "</svg>\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// Image records an image draw at given position, for vector export
+func (pr *PaintRecorder) Image(img image.Image, pos mat32.Vec2) {
+	if img == nil {
+		return
+	}
+	pr.Cmds = append(pr.Cmds, pdfCmd{Img: img, Pos: pos})
+}
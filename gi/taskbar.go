@@ -0,0 +1,51 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"github.com/goki/gi/oswin"
+)
+
+// Taskbar / dock indicators: thin wrappers over the optional
+// oswin.TaskbarIndicator interface, so long-running operations shown in
+// the GUI can also be surfaced at the OS level (dock badge / bounce on
+// macOS, flashing taskbar entry elsewhere).  Each returns false if the
+// current oswin driver does not support the indicator.
+
+// SetTaskbarProgress shows progress (0..1) on the app's taskbar / dock
+// presence -- on = false removes the indicator -- returns false if the
+// oswin driver does not support it
+func SetTaskbarProgress(pct float32, on bool) bool {
+	ti, ok := oswin.TheApp.(oswin.TaskbarIndicator)
+	if !ok {
+		return false
+	}
+	ti.SetTaskbarProgress(pct, on)
+	return true
+}
+
+// SetTaskbarBadge sets a numeric badge on the app's taskbar / dock icon --
+// 0 clears it -- returns false if the oswin driver does not support it
+func SetTaskbarBadge(count int) bool {
+	ti, ok := oswin.TheApp.(oswin.TaskbarIndicator)
+	if !ok {
+		return false
+	}
+	ti.SetTaskbarBadge(count)
+	return true
+}
+
+// RequestUserAttention asks the OS to draw the user's attention to the app
+// (bouncing dock icon / flashing taskbar) -- critical requests are more
+// insistent on platforms that distinguish -- returns false if the oswin
+// driver does not support it
+func RequestUserAttention(critical bool) bool {
+	ti, ok := oswin.TheApp.(oswin.TaskbarIndicator)
+	if !ok {
+		return false
+	}
+	ti.RequestUserAttention(critical)
+	return true
+}
@@ -852,6 +852,14 @@ func (tr *TextRender) Render(rs *RenderState, pos mat32.Vec2) {
 			Face: curFace,
 		}
 
+		if rs.Rec != nil {
+			mtr := curFace.Metrics()
+			em := mat32.FromFixed(mtr.Ascent + mtr.Descent)
+			var tclr Color
+			tclr.SetColor(curColor)
+			rs.Rec.Text(string(sr.Text), tpos, em, tclr)
+		}
+
 		// todo: cache flags if these are actually needed
 		if bitflag.Has32(int32(sr.HasDeco), int(DecoBgColor)) {
 			sr.RenderBg(rs, tpos)
@@ -7,10 +7,8 @@ package gi
 import (
 	"fmt"
 	"image"
-	"image/draw"
 	"strings"
 	"sync"
-	"time"
 	"unicode"
 
 	"github.com/chewxy/math32"
@@ -31,14 +29,13 @@ import (
 const force = true
 const dontForce = false
 
-// CursorBlinkMSec is number of milliseconds that cursor blinks on
-// and off -- set to 0 to disable blinking
-var CursorBlinkMSec = 500
-
 ////////////////////////////////////////////////////////////////////////////////////////
 // TextField
 
-// TextField is a widget for editing a line of text
+// TextField is a widget for editing a line of text -- for multi-line
+// editing with word wrap, scrolling, and selection, use the giv.TextView
+// widget with a giv.TextBuf buffer (giv depends on gi, so the pointer can
+// only go in this direction)
 type TextField struct {
 	PartsWidgetBase
 	Txt          string                  `json:"-" xml:"text" desc:"the last saved value of the text string being edited"`
@@ -533,6 +530,9 @@ func (tf *TextField) SelectUpdate() {
 		if tf.SelectEnd > ed {
 			tf.SelectEnd = ed
 		}
+		if tf.Viewport != nil {
+			PrimarySelWrite(tf.Viewport.Win, tf.Selection())
+		}
 	} else {
 		tf.SelectReset()
 	}
@@ -545,6 +545,7 @@ func (tf *TextField) Cut() string {
 	cut := tf.DeleteSelection()
 	if cut != "" {
 		oswin.TheApp.ClipBoard(tf.Viewport.Win.OSWin).Write(mimedata.NewText(cut))
+		TheClipHist.Add(cut)
 	}
 	return cut
 }
@@ -583,6 +584,7 @@ func (tf *TextField) Copy(reset bool) string {
 	}
 	cpy := tf.Selection()
 	oswin.TheApp.ClipBoard(tf.Viewport.Win.OSWin).Write(mimedata.NewText(cpy))
+	TheClipHist.Add(cpy)
 	if reset {
 		tf.SelectReset()
 	}
@@ -603,6 +605,28 @@ func (tf *TextField) Paste() {
 	}
 }
 
+// PasteHist presents a chooser of clip history items, pastes into text if selected
+func (tf *TextField) PasteHist() {
+	cl := TheClipHist.ChooseList()
+	if len(cl) == 0 {
+		return
+	}
+	StringsChooserPopup(cl, "", tf, func(recv, send ki.Ki, sig int64, data interface{}) {
+		ac := send.(*Action)
+		idx := ac.Data.(int)
+		clip := TheClipHist.Clip(idx)
+		if clip != "" {
+			wupdt := tf.TopUpdateStart()
+			defer tf.TopUpdateEnd(wupdt)
+			oswin.TheApp.ClipBoard(tf.Viewport.Win.OSWin).Write(mimedata.NewText(clip))
+			if tf.CursorPos >= tf.SelectStart && tf.CursorPos < tf.SelectEnd {
+				tf.DeleteSelection()
+			}
+			tf.InsertAtCursor(clip)
+		}
+	})
+}
+
 // InsertAtCursor inserts given text at current cursor position
 func (tf *TextField) InsertAtCursor(str string) {
 	updt := tf.UpdateStart()
@@ -762,58 +786,14 @@ func (tf *TextField) CharStartPos(charidx int, wincoords bool) mat32.Vec2 {
 	return mat32.Vec2{pos.X + cpos, pos.Y}
 }
 
-// TextFieldBlinkMu is mutex protecting TextFieldBlink updating and access
-var TextFieldBlinkMu sync.Mutex
-
-// TextFieldBlinker is the time.Ticker for blinking cursors for text fields,
-// only one of which can be active at at a time
-var TextFieldBlinker *time.Ticker
-
-// BlinkingTextField is the text field that is blinking
-var BlinkingTextField *TextField
-
 // TextFieldSpriteName is the name of the window sprite used for the cursor
 var TextFieldSpriteName = "gi.TextField.Cursor"
 
-// TextFieldBlink is function that blinks text field cursor
-func TextFieldBlink() {
-	for {
-		TextFieldBlinkMu.Lock()
-		if TextFieldBlinker == nil {
-			TextFieldBlinkMu.Unlock()
-			return // shutdown..
-		}
-		TextFieldBlinkMu.Unlock()
-		<-TextFieldBlinker.C
-		TextFieldBlinkMu.Lock()
-		if BlinkingTextField == nil || BlinkingTextField.This() == nil {
-			TextFieldBlinkMu.Unlock()
-			continue
-		}
-		if BlinkingTextField.IsDestroyed() || BlinkingTextField.IsDeleted() {
-			BlinkingTextField = nil
-			TextFieldBlinkMu.Unlock()
-			continue
-		}
-		tf := BlinkingTextField
-		if tf.Viewport == nil || !tf.HasFocus() || !tf.IsFocusActive() || !tf.This().(Node2D).IsVisible() {
-			BlinkingTextField = nil
-			TextFieldBlinkMu.Unlock()
-			continue
-		}
-		win := tf.ParentWindow()
-		if win == nil || win.IsResizing() || win.IsClosed() || !win.IsWindowInFocus() {
-			TextFieldBlinkMu.Unlock()
-			continue
-		}
-		if win.IsUpdating() {
-			TextFieldBlinkMu.Unlock()
-			continue
-		}
-		tf.BlinkOn = !tf.BlinkOn
-		tf.RenderCursor(tf.BlinkOn)
-		TextFieldBlinkMu.Unlock()
-	}
+// CaretRender renders the caret on or off -- implements CaretOwner for
+// the shared caret service (see gi.TheCaret)
+func (tf *TextField) CaretRender(on bool) {
+	tf.BlinkOn = on
+	tf.RenderCursor(on)
 }
 
 // StartCursor starts the cursor blinking and renders it
@@ -821,26 +801,8 @@ func (tf *TextField) StartCursor() {
 	if tf == nil || tf.This() == nil {
 		return
 	}
-	if !tf.This().(Node2D).IsVisible() {
-		return
-	}
-	tf.BlinkOn = true
-	if CursorBlinkMSec == 0 {
-		tf.RenderCursor(true)
-		return
-	}
-	TextFieldBlinkMu.Lock()
-	if TextFieldBlinker == nil {
-		TextFieldBlinker = time.NewTicker(time.Duration(CursorBlinkMSec) * time.Millisecond)
-		go TextFieldBlink()
-	}
 	tf.BlinkOn = true
-	win := tf.ParentWindow()
-	if win != nil && !win.IsResizing() {
-		tf.RenderCursor(true)
-	}
-	BlinkingTextField = tf
-	TextFieldBlinkMu.Unlock()
+	TheCaret.Start(tf)
 }
 
 // ClearCursor turns off cursor and stops it from blinking
@@ -857,14 +819,7 @@ func (tf *TextField) StopCursor() {
 	if tf == nil || tf.This() == nil {
 		return
 	}
-	if !tf.This().(Node2D).IsVisible() {
-		return
-	}
-	TextFieldBlinkMu.Lock()
-	if BlinkingTextField == tf {
-		BlinkingTextField = nil
-	}
-	TextFieldBlinkMu.Unlock()
+	TheCaret.Stop(tf)
 }
 
 // RenderCursor renders the cursor on or off, as a sprite that is either on or off
@@ -912,17 +867,8 @@ func (tf *TextField) CursorSprite() *Sprite {
 		return nil
 	}
 	sty := &tf.StateStyles[TextFieldActive]
-	spnm := fmt.Sprintf("%v-%v", TextFieldSpriteName, tf.FontHeight)
-	sp, ok := win.SpriteByName(spnm)
-	if !ok {
-		bbsz := image.Point{int(math32.Ceil(tf.CursorWidth.Dots)), int(math32.Ceil(tf.FontHeight))}
-		if bbsz.X < 2 { // at least 2
-			bbsz.X = 2
-		}
-		sp = win.AddNewSprite(spnm, bbsz, image.ZP)
-		draw.Draw(sp.Pixels, sp.Pixels.Bounds(), &image.Uniform{sty.Font.Color}, image.ZP, draw.Src)
-	}
-	return sp
+	spnm := fmt.Sprintf("%v-%v-%v", TextFieldSpriteName, tf.FontHeight, CaretShape)
+	return MakeCaretSprite(win, spnm, tf.CursorWidth.Dots, tf.FontHeight, sty.Font.Face.Metrics.Ch, sty.Font.Color)
 }
 
 // RenderSelect renders the selected region, if any, underneath the text
@@ -1210,6 +1156,10 @@ func (tf *TextField) KeyInput(kt *key.ChordEvent) {
 		kt.SetProcessed()
 		tf.CancelComplete()
 		tf.Paste()
+	case KeyFunPasteHist:
+		kt.SetProcessed()
+		tf.CancelComplete()
+		tf.PasteHist()
 	case KeyFunComplete:
 		kt.SetProcessed()
 		tf.OfferComplete(force)
@@ -1265,7 +1215,11 @@ func (tf *TextField) HandleMouseEvent(me *mouse.Event) {
 			me.SetProcessed()
 			pt := tf.PointToRelPos(me.Pos())
 			tf.SetCursorFromPixel(float32(pt.X), me.SelectMode())
-			tf.Paste()
+			if ptxt := PrimarySelRead(tf.Viewport.Win); ptxt != "" {
+				tf.InsertAtCursor(ptxt)
+			} else {
+				tf.Paste()
+			}
 		}
 	case mouse.Right:
 		if me.Action == mouse.Press {
@@ -1461,7 +1415,7 @@ func (tf *TextField) Layout2D(parBBox image.Rectangle, iter int) bool {
 }
 
 func (tf *TextField) Render2D() {
-	if tf.HasFocus() && tf.IsFocusActive() && BlinkingTextField == tf {
+	if tf.HasFocus() && tf.IsFocusActive() && TheCaret.IsBlinking(tf) {
 		tf.ScrollLayoutToCursor()
 	}
 	if tf.FullReRenderIfNeeded() {
@@ -0,0 +1,77 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"time"
+
+	"github.com/goki/ki/kit"
+)
+
+// Throttle rate-limits high-frequency signal emissions, such as slider
+// Tracking updates, scroll-driven updates, and mouse-move driven updates,
+// so that receivers doing expensive recomputation are not churned on every
+// event.  Call Allow at each candidate emission point -- it returns whether
+// the emission should go out now -- and call Flush when the interaction
+// ends (e.g., on release) to find out whether a final emission is pending.
+// The zero value passes everything through (ThrottleNone).
+type Throttle struct {
+	Mode         ThrottleModes `desc:"throttling mode -- how emissions are limited"`
+	IntervalMSec int           `desc:"minimum number of milliseconds between emissions for ThrottleInterval mode"`
+	last         time.Time
+	pending      bool
+}
+
+// ThrottleModes are the modes for limiting high-frequency signal emissions
+type ThrottleModes int64
+
+const (
+	// ThrottleNone does no throttling -- every emission goes through
+	ThrottleNone ThrottleModes = iota
+
+	// ThrottleInterval allows at most one emission every IntervalMSec
+	// milliseconds -- suppressed emissions are reported as pending by Flush
+	ThrottleInterval
+
+	// ThrottleRelease suppresses all intermediate emissions -- only the
+	// final value at the end of the interaction is delivered (via Flush)
+	ThrottleRelease
+
+	ThrottleModesN
+)
+
+//go:generate stringer -type=ThrottleModes
+
+var KiT_ThrottleModes = kit.Enums.AddEnumAltLower(ThrottleModesN, kit.NotBitFlag, StylePropProps, "Throttle")
+
+// Allow returns whether an emission should go out now, updating the
+// throttle state -- if false, the emission is recorded as pending
+func (th *Throttle) Allow() bool {
+	switch th.Mode {
+	case ThrottleRelease:
+		th.pending = true
+		return false
+	case ThrottleInterval:
+		now := time.Now()
+		if th.IntervalMSec <= 0 || now.Sub(th.last) >= time.Duration(th.IntervalMSec)*time.Millisecond {
+			th.last = now
+			th.pending = false
+			return true
+		}
+		th.pending = true
+		return false
+	}
+	return true
+}
+
+// Flush resets the throttle state at the end of an interaction, returning
+// whether an emission was suppressed since the last allowed one -- if true,
+// the caller should emit the final value
+func (th *Throttle) Flush() bool {
+	p := th.pending
+	th.pending = false
+	th.last = time.Time{}
+	return p
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"image"
 	"image/draw"
+	"image/jpeg"
 	"image/png"
 	"io"
 	"log"
@@ -75,18 +76,31 @@ type Viewport interface {
 // with a convenience forwarding of the Paint methods operating on the current Paint
 type Viewport2D struct {
 	WidgetBase
-	Fill         bool         `desc:"fill the viewport with background-color from style"`
-	Geom         Geom2DInt    `desc:"Viewport-level viewbox within any parent Viewport2D"`
-	Render       RenderState  `copy:"-" json:"-" xml:"-" view:"-" desc:"render state for rendering"`
-	Pixels       *image.RGBA  `copy:"-" json:"-" xml:"-" view:"-" desc:"live pixels that we render into"`
-	Win          *Window      `copy:"-" json:"-" xml:"-" desc:"our parent window that we render into"`
-	CurStyleNode Node2D       `copy:"-" json:"-" xml:"-" view:"-" desc:"CurStyleNode2D is always set to the current node that is being styled used for finding url references -- only active during a Style pass"`
-	CurColor     Color        `copy:"-" json:"-" xml:"-" view:"-" desc:"CurColor is automatically updated from the Color setting of a Style and accessible as a color name in any other style as currentcolor use accessor routines for concurrent-safe access"`
-	UpdtMu       sync.Mutex   `copy:"-" json:"-" xml:"-" view:"-" desc:"UpdtMu is mutex for viewport updates"`
-	UpdtStack    []Node2D     `copy:"-" json:"-" xml:"-" view:"-" desc:"stack of nodes requring basic updating"`
-	ReStack      []Node2D     `copy:"-" json:"-" xml:"-" view:"-" desc:"stack of nodes requiring a ReRender (i.e., anchors)"`
-	StackMu      sync.Mutex   `copy:"-" json:"-" xml:"-" view:"-" desc:"StackMu is mutex for adding to UpdtStack"`
-	StyleMu      sync.RWMutex `copy:"-" json:"-" xml:"-" view:"-" desc:"StyleMu is RW mutex protecting access to Style-related global vars"`
+	Fill         bool                   `desc:"fill the viewport with background-color from style"`
+	Opacity      float32                `min:"0" max:"1" step:"0.05" desc:"overall opacity applied when compositing this viewport into its parent -- 0 (the zero-value default) and 1 both mean fully opaque"`
+	BlendMode    BlendModes             `desc:"blend mode used when compositing this viewport into its parent -- default is standard alpha compositing"`
+	Scale        float32                `min:"0.25" max:"4" step:"0.1" desc:"scale transform applied to the entire scene when it is drawn to the window -- 0 (the zero-value default) and 1 both mean no scaling -- for the master window viewport, the scene is laid out and rendered at window size divided by Scale and stretched up on the GPU, and incoming event coordinates are inverse-transformed to match -- see Window.SetZoomScale"`
+	Geom         Geom2DInt              `desc:"Viewport-level viewbox within any parent Viewport2D"`
+	Render       RenderState            `copy:"-" json:"-" xml:"-" view:"-" desc:"render state for rendering"`
+	Pixels       *image.RGBA            `copy:"-" json:"-" xml:"-" view:"-" desc:"live pixels that we render into"`
+	Win          *Window                `copy:"-" json:"-" xml:"-" desc:"our parent window that we render into"`
+	CurStyleNode Node2D                 `copy:"-" json:"-" xml:"-" view:"-" desc:"CurStyleNode2D is always set to the current node that is being styled used for finding url references -- only active during a Style pass"`
+	CurColor     Color                  `copy:"-" json:"-" xml:"-" view:"-" desc:"CurColor is automatically updated from the Color setting of a Style and accessible as a color name in any other style as currentcolor use accessor routines for concurrent-safe access"`
+	UpdtMu       sync.Mutex             `copy:"-" json:"-" xml:"-" view:"-" desc:"UpdtMu is mutex for viewport updates"`
+	UpdtStack    []Node2D               `copy:"-" json:"-" xml:"-" view:"-" desc:"stack of nodes requring basic updating"`
+	ReStack      []Node2D               `copy:"-" json:"-" xml:"-" view:"-" desc:"stack of nodes requiring a ReRender (i.e., anchors)"`
+	DmgRects     []image.Rectangle      `copy:"-" json:"-" xml:"-" view:"-" desc:"accumulated damage regions pending upload, in viewport coordinates -- see DamageTracking"`
+	RendBackend  RenderBackend          `copy:"-" json:"-" xml:"-" view:"-" desc:"rasterization backend for this viewport -- nil means use the default TheRenderBackend"`
+	RenderProf   RenderProf             `copy:"-" json:"-" xml:"-" desc:"built-in render profiler -- set On to record cumulative time per node type and per unique path across the Style2D, Layout2D, and Render2D passes -- see Report / ReportString for sorted results"`
+	Capture      *VpCapture             `copy:"-" json:"-" xml:"-" view:"-" desc:"in-progress animated capture of this viewport, grabbing Pixels after each upload -- see StartCapture / StopCapture"`
+	RendShared   map[string]*image.RGBA `copy:"-" json:"-" xml:"-" view:"-" desc:"shared rasterizations of identical repeated subtrees, keyed by share key -- see Node2DBase.SetRenderShared"`
+	PixDiffPrev  *image.RGBA            `copy:"-" json:"-" xml:"-" view:"-" desc:"copy of Pixels from before the last render pass, for pixel-diff debugging -- see PixelDiffTracking"`
+	dmgOff       image.Point
+	progRestrict image.Rectangle // restriction of current render pass to visible region -- see SetProgressive
+	progFill     bool            // next full render is the scheduled off-screen fill-in pass
+	lastRendMSec float64         // duration of last unrestricted full render pass, in msec
+	StackMu      sync.Mutex      `copy:"-" json:"-" xml:"-" view:"-" desc:"StackMu is mutex for adding to UpdtStack"`
+	StyleMu      sync.RWMutex    `copy:"-" json:"-" xml:"-" view:"-" desc:"StyleMu is RW mutex protecting access to Style-related global vars"`
 }
 
 var KiT_Viewport2D = kit.Types.AddType(&Viewport2D{}, Viewport2DProps)
@@ -101,6 +115,9 @@ func (vp *Viewport2D) CopyFieldsFrom(frm interface{}) {
 	fr := frm.(*Viewport2D)
 	vp.WidgetBase.CopyFieldsFrom(&fr.WidgetBase)
 	vp.Fill = fr.Fill
+	vp.Opacity = fr.Opacity
+	vp.BlendMode = fr.BlendMode
+	vp.Scale = fr.Scale
 	vp.Geom = fr.Geom
 }
 
@@ -112,15 +129,94 @@ func NewViewport2D(width, height int) *Viewport2D {
 		Geom: Geom2DInt{Size: sz},
 	}
 	vp.Pixels = image.NewRGBA(image.Rectangle{Max: sz})
-	vp.Render.Init(width, height, vp.Pixels)
+	vp.Backend().InitState(&vp.Render, width, height, vp.Pixels)
 	return vp
 }
 
+// VpTileSize is the tile edge size in pixels for viewports in tiled mode
+// -- see Viewport2D.SetTiled
+var VpTileSize = 512
+
+// SetTiled sets (or clears) tiled mode on this viewport: only the tiles of
+// the Pixels image intersecting the currently-visible region within the
+// parent are allocated and rendered, instead of one giant image covering
+// the full Geom size -- use for very large viewports (e.g., a huge canvas
+// inside a scroll Layout) to bound memory and render time -- not for the
+// master window viewport.  Newly-exposed tiles are rendered as the visible
+// region changes (e.g., from scrolling), via the normal re-render of the
+// containing Layout.
+func (vp *Viewport2D) SetTiled(tiled bool) {
+	if tiled {
+		vp.SetFlag(int(VpFlagTiled))
+	} else {
+		vp.ClearFlag(int(VpFlagTiled))
+	}
+}
+
+// IsTiled returns whether this viewport is in tiled mode -- see SetTiled
+func (vp *Viewport2D) IsTiled() bool {
+	return vp.HasFlag(int(VpFlagTiled))
+}
+
+// TileVisBBox returns the currently-visible region of this viewport in
+// viewport-local coordinates, from the intersection of our Geom bounds
+// with the parent's children bbox -- full bounds if no parent
+func (vp *Viewport2D) TileVisBBox() image.Rectangle {
+	r := vp.Geom.Bounds()
+	if vp.Par != nil {
+		pni, _ := KiToNode2D(vp.Par)
+		if pni != nil {
+			r = r.Intersect(pni.ChildrenBBox2D())
+		}
+	}
+	return r.Sub(vp.Geom.Pos)
+}
+
+// TileRect returns the region that Pixels must cover in tiled mode: the
+// visible bbox expanded out to VpTileSize boundaries, clamped to the full
+// viewport size
+func (vp *Viewport2D) TileRect() image.Rectangle {
+	vis := vp.TileVisBBox()
+	ts := VpTileSize
+	tr := image.Rect((vis.Min.X/ts)*ts, (vis.Min.Y/ts)*ts,
+		((vis.Max.X+ts-1)/ts)*ts, ((vis.Max.Y+ts-1)/ts)*ts)
+	return tr.Intersect(image.Rectangle{Max: vp.Geom.Size})
+}
+
+// AllocTiles ensures that Pixels covers the tiles intersecting the
+// currently-visible region, (re)allocating the image and render state only
+// when the covered region changes -- the image retains its offset bounds,
+// so all viewport-local rendering coordinates work unchanged -- no-op
+// unless tiled mode is on
+func (vp *Viewport2D) AllocTiles() {
+	if !vp.IsTiled() || vp.Geom.Size == image.ZP {
+		return
+	}
+	tr := vp.TileRect()
+	if tr.Empty() {
+		return // stay with whatever we have until something is visible
+	}
+	if vp.Pixels != nil && vp.Pixels.Bounds() == tr {
+		return
+	}
+	vp.Pixels = image.NewRGBA(tr)
+	// scanner extent must reach the far edge of the covered region
+	vp.Backend().InitState(&vp.Render, tr.Max.X, tr.Max.Y, vp.Pixels)
+	if Render2DTrace {
+		fmt.Printf("Render: vp %v allocated tiles: %v of full: %v\n", vp.PathUnique(), tr, vp.Geom.Size)
+	}
+}
+
 // Resize resizes the viewport, creating a new image -- updates Geom Size
 func (vp *Viewport2D) Resize(nwsz image.Point) {
 	if nwsz.X == 0 || nwsz.Y == 0 {
 		return
 	}
+	if vp.IsTiled() {
+		vp.Geom.Size = nwsz
+		vp.AllocTiles()
+		return
+	}
 	if vp.Pixels != nil {
 		ib := vp.Pixels.Bounds().Size()
 		if ib == nwsz {
@@ -132,7 +228,7 @@ func (vp *Viewport2D) Resize(nwsz image.Point) {
 		vp.Pixels = nil
 	}
 	vp.Pixels = image.NewRGBA(image.Rectangle{Max: nwsz})
-	vp.Render.Init(nwsz.X, nwsz.Y, vp.Pixels)
+	vp.Backend().InitState(&vp.Render, nwsz.X, nwsz.Y, vp.Pixels)
 	vp.Geom.Size = nwsz // make sure
 	// fmt.Printf("vp %v resized to: %v, bounds: %v\n", vp.PathUnique(), nwsz, vp.Pixels.Bounds())
 }
@@ -189,6 +285,19 @@ const (
 	// underlying data has changed.
 	VpFlagDoingFullRender
 
+	// VpFlagTiled means that this viewport only allocates and renders the
+	// tiles of its Pixels image that intersect the currently-visible region
+	// within its parent, instead of one giant image covering the full Geom
+	// size -- bounds memory and render time for very large viewports (e.g.,
+	// a huge canvas inside a scroll Layout) -- see SetTiled and VpTileSize
+	VpFlagTiled
+
+	// VpFlagProgressive means that this viewport uses progressive rendering
+	// when its render passes are slow: the visible region is rendered and
+	// uploaded first, and the off-screen remainder is filled in shortly
+	// after -- see SetProgressive and ProgRenderThreshMSec
+	VpFlagProgressive
+
 	VpFlagsN
 )
 
@@ -301,6 +410,8 @@ func (vp *Viewport2D) VpUploadAll() {
 	if !vp.This().(Viewport).VpIsVisible() {
 		return
 	}
+	vp.Backend().Flush(&vp.Render)
+	vp.captureUpload()
 	vp.Win.UploadAllViewports()
 }
 
@@ -310,6 +421,8 @@ func (vp *Viewport2D) VpUploadVp() {
 	if !vp.This().(Viewport).VpIsVisible() {
 		return
 	}
+	vp.Backend().Flush(&vp.Render)
+	vp.captureUpload()
 	vp.Win.UploadVp(vp, vp.WinBBox.Min)
 }
 
@@ -318,9 +431,32 @@ func (vp *Viewport2D) VpUploadRegion(vpBBox, winBBox image.Rectangle) {
 	if !vp.This().(Viewport).VpIsVisible() {
 		return
 	}
+	vp.Backend().Flush(&vp.Render)
+	vp.captureUpload()
 	vp.Win.UploadVpRegion(vp, vpBBox, winBBox)
 }
 
+// Backend returns the rasterization backend for this viewport -- the
+// per-viewport RendBackend if set, else the default TheRenderBackend
+func (vp *Viewport2D) Backend() RenderBackend {
+	if vp.RendBackend != nil {
+		return vp.RendBackend
+	}
+	return TheRenderBackend
+}
+
+// SetBackendName sets the rasterization backend for this viewport to the
+// registered backend of given name -- returns an error if not registered
+// -- takes effect at the next resize / init
+func (vp *Viewport2D) SetBackendName(name string) error {
+	rb, has := RenderBackends[name]
+	if !has {
+		return fmt.Errorf("gi.Viewport2D.SetBackendName: no render backend registered under name: %v", name)
+	}
+	vp.RendBackend = rb
+	return nil
+}
+
 // set our window pointer to point to the current window we are under
 func (vp *Viewport2D) SetCurWin() {
 	pwin := vp.ParentWindow()
@@ -348,7 +484,11 @@ func (vp *Viewport2D) DrawIntoParent(parVp *Viewport2D) {
 	if Render2DTrace {
 		fmt.Printf("Render: vp DrawIntoParent: %v parVp: %v rect: %v sp: %v\n", vp.PathUnique(), parVp.PathUnique(), r, sp)
 	}
-	draw.Draw(parVp.Pixels, r, vp.Pixels, sp, draw.Over)
+	if vp.BlendMode != BlendOver || (vp.Opacity > 0 && vp.Opacity < 1) {
+		BlendImage(parVp.Pixels, r, vp.Pixels, sp, vp.BlendMode, vp.Opacity)
+	} else {
+		CompositeDraw(parVp.Pixels, r, vp.Pixels, sp, draw.Over)
+	}
 }
 
 // ReRender2DNode re-renders a specific node, including uploading updated bits to
@@ -366,7 +506,11 @@ func (vp *Viewport2D) ReRender2DNode(gni Node2D) {
 	// pr := prof.Start("vp.ReRender2DNode")
 	gn.Render2DTree()
 	// pr.End()
-	vp.This().(Viewport).VpUploadRegion(gn.VpBBox, gn.WinBBox)
+	if DamageTracking {
+		vp.AddDamage(gn.VpBBox, gn.WinBBox.Min.Sub(gn.VpBBox.Min))
+	} else {
+		vp.This().(Viewport).VpUploadRegion(gn.VpBBox, gn.WinBBox)
+	}
 }
 
 // ReRender2DAnchor re-renders an anchor node -- the KEY diff from
@@ -388,7 +532,11 @@ func (vp *Viewport2D) ReRender2DAnchor(gni Node2D) {
 	// pr := prof.Start("vp.ReRender2DNode")
 	pw.ReRender2DTree()
 	// pr.End()
-	vp.This().(Viewport).VpUploadRegion(pw.VpBBox, pw.WinBBox)
+	if DamageTracking {
+		vp.AddDamage(pw.VpBBox, pw.WinBBox.Min.Sub(pw.VpBBox.Min))
+	} else {
+		vp.This().(Viewport).VpUploadRegion(pw.VpBBox, pw.WinBBox)
+	}
 }
 
 // Delete this popup viewport -- has already been disconnected from window
@@ -569,6 +717,9 @@ func (vp *Viewport2D) PushBounds() bool {
 	}
 	rs := &vp.Render
 	bb := vp.Pixels.Bounds() // our bounds.. not vp.VpBBox)
+	if vp.progRestrict != image.ZR {
+		bb = bb.Intersect(vp.progRestrict)
+	}
 	rs.PushBounds(bb)
 	if Render2DTrace {
 		fmt.Printf("Render: %v at %v\n", vp.PathUnique(), bb)
@@ -600,6 +751,7 @@ func (vp *Viewport2D) Render2D() {
 	if vp.FullReRenderIfNeeded() {
 		return
 	}
+	vp.AllocTiles() // no-op unless tiled -- tracks visible region
 	if vp.PushBounds() {
 		if vp.Fill {
 			vp.FillViewport()
@@ -687,7 +839,7 @@ func (vp *Viewport2D) NodeUpdated(nii Node2D, sig int64, data interface{}) {
 	}
 
 	if !vp.IsUpdatingNode() {
-		vp.UpdateNodes() // do all pending nodes
+		vp.QueueUpdateNodes() // do all pending nodes
 	}
 }
 
@@ -730,7 +882,7 @@ func (vp *Viewport2D) SetNeedsFullRender() {
 		vp.StackMu.Unlock()
 	}
 	if !vp.IsUpdatingNode() {
-		vp.UpdateNodes() // do all pending nodes
+		vp.QueueUpdateNodes() // do all pending nodes
 	}
 }
 
@@ -765,11 +917,13 @@ func (vp *Viewport2D) UpdateNodes() {
 			vp.UpdtStack = nil
 			vp.ClearFlag(int(VpFlagNeedsFullRender))
 			vp.StackMu.Unlock()
+			st := vp.progRenderStart()
 			if vp.Viewport == nil { // top level
 				vp.FullRender2DTree()
 			} else {
 				vp.ReRender2DTree() // embedded
 			}
+			vp.progRenderDone(st)
 			break
 		}
 		vp.StackMu.Lock()
@@ -793,6 +947,12 @@ func (vp *Viewport2D) UpdateNodes() {
 		}
 	}
 
+	if DamageTracking {
+		vp.FlushDamage()
+	}
+	if PixelDiffTracking {
+		vp.PixelDiffUpdate()
+	}
 	vp.ClearFlag(int(VpFlagUpdatingNode))
 	vp.UpdtMu.Unlock()
 }
@@ -869,6 +1029,32 @@ func (vp *Viewport2D) SavePNG(path string) error {
 	return SavePNG(path, vp.Pixels)
 }
 
+// SaveJPEG encodes the image as a JPEG at given quality (1..100, e.g., 90)
+// and writes it to disk.
+func (vp *Viewport2D) SaveJPEG(path string, quality int) error {
+	return SaveJPEG(path, vp.Pixels, quality)
+}
+
+// EncodeJPEG encodes the image as a JPEG at given quality (1..100, e.g.,
+// 90) and writes it to the provided io.Writer.
+func (vp *Viewport2D) EncodeJPEG(w io.Writer, quality int) error {
+	return jpeg.Encode(w, vp.Pixels, &jpeg.Options{Quality: quality})
+}
+
+// SaveWebP encodes the image as a WebP at given quality (1..100, 100 =
+// lossless) and writes it to disk -- requires a registered WebP encoder,
+// see EncodeWebPFunc.
+func (vp *Viewport2D) SaveWebP(path string, quality int) error {
+	return SaveWebP(path, vp.Pixels, quality)
+}
+
+// EncodeWebP encodes the image as a WebP at given quality (1..100, 100 =
+// lossless) and writes it to the provided io.Writer -- requires a
+// registered WebP encoder, see EncodeWebPFunc.
+func (vp *Viewport2D) EncodeWebP(w io.Writer, quality int) error {
+	return EncodeWebP(w, vp.Pixels, quality)
+}
+
 // EncodePNG encodes the image as a PNG and writes it to the provided io.Writer.
 func (vp *Viewport2D) EncodePNG(w io.Writer) error {
 	return png.Encode(w, vp.Pixels)
@@ -31,6 +31,10 @@ type ViewIFace interface {
 	// KeyMapsView opens an interactive view of KeyMaps object
 	KeyMapsView(maps *KeyMaps)
 
+	// GlobalHotkeysView opens an interactive view of the global hotkey
+	// bindings -- see gi.EditGlobalHotkeys
+	GlobalHotkeysView(hk *GlobalHotkeys)
+
 	// PrefsDetView opens an interactive view of given detailed preferences object
 	PrefsDetView(prefs *PrefsDetailed)
 
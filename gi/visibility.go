@@ -0,0 +1,107 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// IsActuallyVisible returns whether this node is currently actually
+// visible on screen: the IsVisible flag chain is visible, its bounding box
+// is non-empty (i.e., it is not scrolled out of view, clipped away, or in
+// an unselected tab), and its window is shown and not minimized.  Use this
+// to pause timers, animations, or background feeds driving widgets that
+// are not currently being seen -- see VisTicker for a ready-made driver.
+func (nb *Node2DBase) IsActuallyVisible() bool {
+	if !nb.This().(Node2D).IsVisible() {
+		return false
+	}
+	if nb.VpBBox.Empty() {
+		return false
+	}
+	win := nb.ParentWindow()
+	if win == nil || !win.IsVisible() {
+		return false
+	}
+	if win.OSWin != nil && win.OSWin.IsMinimized() {
+		return false
+	}
+	return true
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    Render culling stats
+
+// RenderCullTrace -- report to stdout the subtrees that render passes skip
+// because their bounding box is empty (scrolled out of view, clipped, or
+// in an unselected tab)
+var RenderCullTrace bool
+
+// renderedNodes and culledNodes count PushBounds outcomes since the last
+// RenderStatsReset -- updated atomically during render passes
+var (
+	renderedNodes int64
+	culledNodes   int64
+)
+
+// RenderStats returns the number of widgets rendered, and the number of
+// subtrees culled (skipped because not visible), since the last
+// RenderStatsReset
+func RenderStats() (rendered, culled int64) {
+	return atomic.LoadInt64(&renderedNodes), atomic.LoadInt64(&culledNodes)
+}
+
+// RenderStatsReset resets the render culling stats counters
+func RenderStatsReset() {
+	atomic.StoreInt64(&renderedNodes, 0)
+	atomic.StoreInt64(&culledNodes, 0)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    VisTicker
+
+// VisTicker drives an animation or polling function at a regular interval,
+// automatically pausing while its widget is not actually visible: the
+// function is simply not called for ticks that occur while the widget is
+// scrolled away, in an unselected tab, or its window is minimized.  The
+// function is called from the ticker goroutine, so it should use the
+// standard async update protocols (e.g., UpdateSig).  Call Stop when the
+// widget is disconnected.
+type VisTicker struct {
+	Node   Node2D `desc:"the widget whose visibility gates the ticks"`
+	ticker *time.Ticker
+	done   chan struct{}
+}
+
+// NewVisTicker starts a new visibility-gated ticker calling fn every
+// interval while nd is actually visible
+func NewVisTicker(nd Node2D, interval time.Duration, fn func()) *VisTicker {
+	vt := &VisTicker{Node: nd, ticker: time.NewTicker(interval), done: make(chan struct{})}
+	go func() {
+		for {
+			select {
+			case <-vt.ticker.C:
+				nb := vt.Node.AsNode2D()
+				if nb != nil && nb.IsActuallyVisible() {
+					fn()
+				}
+			case <-vt.done:
+				return
+			}
+		}
+	}()
+	return vt
+}
+
+// Stop stops the ticker -- no further calls will be made
+func (vt *VisTicker) Stop() {
+	if vt.ticker == nil {
+		return
+	}
+	vt.ticker.Stop()
+	close(vt.done)
+	vt.ticker = nil
+}
@@ -13,27 +13,29 @@ func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the stringer command to generate them again.
 	var x [1]struct{}
-	_ = x[VpFlagPopup-29]
-	_ = x[VpFlagMenu-30]
-	_ = x[VpFlagCompleter-31]
-	_ = x[VpFlagCorrector-32]
-	_ = x[VpFlagTooltip-33]
-	_ = x[VpFlagPopupDestroyAll-34]
-	_ = x[VpFlagSVG-35]
-	_ = x[VpFlagUpdatingNode-36]
-	_ = x[VpFlagNeedsFullRender-37]
-	_ = x[VpFlagDoingFullRender-38]
-	_ = x[VpFlagsN-39]
+	_ = x[VpFlagPopup-30]
+	_ = x[VpFlagMenu-31]
+	_ = x[VpFlagCompleter-32]
+	_ = x[VpFlagCorrector-33]
+	_ = x[VpFlagTooltip-34]
+	_ = x[VpFlagPopupDestroyAll-35]
+	_ = x[VpFlagSVG-36]
+	_ = x[VpFlagUpdatingNode-37]
+	_ = x[VpFlagNeedsFullRender-38]
+	_ = x[VpFlagDoingFullRender-39]
+	_ = x[VpFlagTiled-40]
+	_ = x[VpFlagProgressive-41]
+	_ = x[VpFlagsN-42]
 }
 
-const _VpFlags_name = "VpFlagPopupVpFlagMenuVpFlagCompleterVpFlagCorrectorVpFlagTooltipVpFlagPopupDestroyAllVpFlagSVGVpFlagUpdatingNodeVpFlagNeedsFullRenderVpFlagDoingFullRenderVpFlagsN"
+const _VpFlags_name = "VpFlagPopupVpFlagMenuVpFlagCompleterVpFlagCorrectorVpFlagTooltipVpFlagPopupDestroyAllVpFlagSVGVpFlagUpdatingNodeVpFlagNeedsFullRenderVpFlagDoingFullRenderVpFlagTiledVpFlagProgressiveVpFlagsN"
 
-var _VpFlags_index = [...]uint8{0, 11, 21, 36, 51, 64, 85, 94, 112, 133, 154, 162}
+var _VpFlags_index = [...]uint8{0, 11, 21, 36, 51, 64, 85, 94, 112, 133, 154, 165, 182, 190}
 
 func (i VpFlags) String() string {
-	i -= 29
+	i -= 30
 	if i < 0 || i >= VpFlags(len(_VpFlags_index)-1) {
-		return "VpFlags(" + strconv.FormatInt(int64(i+29), 10) + ")"
+		return "VpFlags(" + strconv.FormatInt(int64(i+30), 10) + ")"
 	}
 	return _VpFlags_name[_VpFlags_index[i]:_VpFlags_index[i+1]]
 }
@@ -41,7 +43,7 @@ func (i VpFlags) String() string {
 func StringToVpFlags(s string) (VpFlags, error) {
 	for i := 0; i < len(_VpFlags_index)-1; i++ {
 		if s == _VpFlags_name[_VpFlags_index[i]:_VpFlags_index[i+1]] {
-			return VpFlags(i + 29), nil
+			return VpFlags(i + 30), nil
 		}
 	}
 	return 0, errors.New("String: " + s + " is not a valid option for type: VpFlags")
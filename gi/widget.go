@@ -9,6 +9,7 @@ import (
 	"image"
 	"log"
 	"strings"
+	"sync/atomic"
 
 	"github.com/goki/gi/mat32"
 	"github.com/goki/gi/oswin"
@@ -29,6 +30,7 @@ type WidgetBase struct {
 	Node2DBase
 	Tooltip      string       `desc:"text for tooltip for this widget -- can use HTML formatting"`
 	Sty          Style        `json:"-" xml:"-" desc:"styling settings for this widget -- set in SetStyle2D during an initialization step, and when the structure changes"`
+	ParInactive  bool         `copy:"-" json:"-" xml:"-" view:"-" desc:"our Inactive state was set from an Inactive parent during styling -- cleared again when the parent becomes active, unlike a directly-set Inactive flag"`
 	DefStyle     *Style       `copy:"-" view:"-" json:"-" xml:"-" desc:"default style values computed by a parent widget for us -- if set, we are a part of a parent widget and should use these as our starting styles instead of type-based defaults"`
 	LayData      LayoutData   `copy:"-" json:"-" xml:"-" desc:"all the layout information for this item"`
 	WidgetSig    ki.Signal    `copy:"-" json:"-" xml:"-" view:"-" desc:"general widget signals supported by all widgets, including select, focus, and context menu (right mouse button) events, which can be used by views and other compound widgets"`
@@ -188,14 +190,63 @@ func (wb *WidgetBase) Style2DWidget() {
 	AggCSS(&wb.CSSAgg, wb.CSS)
 	wb.Sty.StyleCSS(gii, wb.CSSAgg, "", wb.Viewport)
 
+	wb.ApplyDensity() // global density scaling of margin / padding etc
+
 	wb.Sty.SetUnitContext(wb.Viewport, mat32.Vec2Zero) // todo: test for use of el-relative
 	if wb.Sty.Inactive {                               // inactive can only set, not clear
 		wb.SetInactive()
 	}
+	wb.StyleInactiveFromParent()
+	wb.StyleInactiveState()
 
 	wb.Sty.Use(wb.Viewport) // activates currentColor etc
 }
 
+// InactiveContrastPct is the percent by which the font color of an Inactive
+// widget is blended toward its background (via Highlight), for the standard
+// reduced-contrast disabled appearance -- applies in StyleInactiveState to
+// widgets that do not define their own :inactive state style
+var InactiveContrastPct = float32(50)
+
+// StyleInactiveFromParent propagates the Inactive state down from an
+// Inactive parent widget during styling, so that disabling a container
+// visually and interactively disables everything in it.  State set this way
+// is tracked in ParInactive and is cleared again when the parent becomes
+// active, unlike a directly-set Inactive flag.  Called in Style2DWidget --
+// styling is top-down so the parent state is already resolved.
+func (wb *WidgetBase) StyleInactiveFromParent() {
+	var pwb *WidgetBase
+	if wb.Par != nil {
+		if pwi, ok := wb.Par.(Node2D); ok {
+			pwb = pwi.AsWidget()
+		}
+	}
+	parInact := pwb != nil && pwb.IsInactive()
+	if parInact && !wb.IsInactive() {
+		wb.SetInactive()
+		wb.ParInactive = true
+	} else if !parInact && wb.ParInactive {
+		wb.ClearInactive()
+		wb.ParInactive = false
+	}
+}
+
+// StyleInactiveState applies the standard disabled appearance to an
+// Inactive widget: font color reduced in contrast by InactiveContrastPct,
+// which also dims icons and other elements rendered with currentColor.
+// Widgets that define their own :inactive state style are left alone.
+// Called in Style2DWidget, before the style is activated with Use.
+func (wb *WidgetBase) StyleInactiveState() {
+	if !wb.IsInactive() {
+		return
+	}
+	tprops := *kit.Types.Properties(wb.Type(), true) // true = makeNew
+	if _, has := ki.SubProps(tprops, ":inactive"); has {
+		return // has its own inactive styling
+	}
+	wb.Sty.Font.Color = wb.Sty.Font.Color.Highlight(InactiveContrastPct)
+}
+
 // StylePart sets the style properties for a child in parts (or any other
 // child) based on its name -- only call this when new parts were created --
 // name of properties is #partname (lower cased) and it should contain a
@@ -368,11 +419,17 @@ func (wb *WidgetBase) PushBounds() bool {
 	}
 	if wb.VpBBox.Empty() {
 		wb.ClearFullReRender()
+		atomic.AddInt64(&culledNodes, 1)
+		if RenderCullTrace {
+			fmt.Printf("Render: culled %v\n", wb.PathUnique())
+		}
 		return false
 	}
 	rs := &wb.Viewport.Render
 	rs.PushBounds(wb.VpBBox)
 	wb.ConnectToViewport()
+	wb.RunRenderHook(wb.PreRender)
+	atomic.AddInt64(&renderedNodes, 1)
 	if Render2DTrace {
 		fmt.Printf("Render: %v at %v\n", wb.PathUnique(), wb.VpBBox)
 	}
@@ -386,6 +443,7 @@ func (wb *WidgetBase) PopBounds() {
 	if wb.This() == nil || wb.Viewport == nil {
 		return
 	}
+	wb.RunRenderHook(wb.PostRender)
 	rs := &wb.Viewport.Render
 	rs.PopBounds()
 }
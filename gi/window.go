@@ -18,6 +18,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/goki/gi/mat32"
 	"github.com/goki/gi/oswin"
 	"github.com/goki/gi/oswin/cursor"
 	"github.com/goki/gi/oswin/dnd"
@@ -145,9 +146,15 @@ type Window struct {
 	OverTex           oswin.Texture     `json:"-" xml:"-" view:"-" desc:"overlay texture that is updated from Sprites"`
 	Sprites           Sprites           `json:"-" xml:"-" desc:"sprites are named images that are rendered into the overtex."`
 	ActiveSprites     int               `json:"-" xml:"-" desc:"number of currently active sprites -- must use ActivateSprite to keep track of whether there are active sprites."`
+	OverDraws         OverlayDraws      `json:"-" xml:"-" view:"-" desc:"registered overlay drawers that paint transient decorations into the overtex each overlay render -- see AddOverlayDrawer"`
+	Tickers           []*WinTicker      `json:"-" xml:"-" view:"-" desc:"active tickers managed by this window -- see AddTicker"`
+	overImg           *image.RGBA       // scratch image the overlay drawers render into
+	overRend          RenderState       // render state for the overlay drawers
 	DirectUps         map[Node2D]Node2D `json:"-" xml:"-" view:"-" desc:"list of objects that do direct upload rendering to window (e.g., gi3d.Scene)"`
 	UpMu              sync.Mutex        `json:"-" xml:"-" view:"-" desc:"mutex that protects all updating / uploading of Textures"`
 	Shortcuts         Shortcuts         `json:"-" xml:"-" desc:"currently active shortcuts for this window (shortcuts are always window-wide -- use widget key event processing for more local key functions)"`
+	Router            *Router           `json:"-" xml:"-" desc:"optional route navigation for multi-screen apps -- KeyFunHistPrev / Next navigate its history -- see SetRouter"`
+	Present           *PresentState     `json:"-" xml:"-" view:"-" desc:"active present-mode screencast annotation state, non-nil while present mode is on -- see SetPresentMode"`
 	Popup             ki.Ki             `json:"-" xml:"-" desc:"Current popup viewport that gets all events"`
 	PopupStack        []ki.Ki           `json:"-" xml:"-" desc:"stack of popups"`
 	NextPopup         ki.Ki             `json:"-" xml:"-" desc:"this popup will be pushed at the end of the current event cycle -- use SetNextPopup"`
@@ -155,6 +162,14 @@ type Window struct {
 	DelPopup          ki.Ki             `json:"-" xml:"-" desc:"this popup will be popped at the end of the current event cycle -- use SetDelPopup"`
 	PopMu             sync.RWMutex      `json:"-" xml:"-" view:"-" desc:"read-write mutex that protects popup updating and access"`
 	lastWinMenuUpdate time.Time
+	lastPublish       time.Time     // time of last actual publish, for frame pacing -- see WinPublishFPS
+	publishTimer      *time.Timer   // pending deferred publish, for frame pacing
+	publishMu         sync.Mutex    // protects lastPublish, publishTimer
+	asyncSigs         asyncSigQueue // queued async signal emissions -- see EmitSignalAsync
+	upBatch           []wupRegion   // batched region uploads pending the next publish -- see WinUploadBatching -- protected by UpMu
+	resizeMu          sync.Mutex    // protects resizePending, resizeFreezeTimer
+	resizePending     image.Point   // deferred resize size during freeze -- see WinResizeFreeze
+	resizeFreezeTimer *time.Timer   // debounce timer for applying the deferred resize
 	// below are internal vars used during the event loop
 	delPop        bool
 	skippedResize *window.Event
@@ -307,6 +322,7 @@ func NewWindow(name, title string, opts *oswin.NewWindowOptions) *Window {
 	win := &Window{}
 	win.InitName(win, name)
 	win.EventMgr.Master = win
+	win.EventMgr.HitTest.Active = true // window geometry invalidates it via SetWinBBox
 	win.Title = title
 	win.SetOnlySelfUpdate() // has its own PublishImage update logic
 	var err error
@@ -609,6 +625,90 @@ func (w *Window) ZoomDPI(steps int) {
 	w.FullReRender()
 }
 
+// ZoomWholeUI makes KeyFunZoomIn / KeyFunZoomOut scale the entire window
+// contents via the viewport Scale transform (see SetZoomScale), instead of
+// adjusting the logical DPI (which only rescales unit-based sizes such as
+// fonts) -- see also ZoomScaleStep
+var ZoomWholeUI = false
+
+// ZoomScaleStep is the multiplicative step applied per step in
+// Window.ZoomScale
+var ZoomScaleStep = float32(1.1)
+
+// RenderSize returns the size at which the master viewport is laid out and
+// rendered for given window pixel size, which is that size divided by the
+// viewport zoom Scale when whole-UI zoom is active -- the rendered image is
+// stretched back up to window size on the GPU when published.
+func (w *Window) RenderSize(sz image.Point) image.Point {
+	sc := w.Viewport.Scale
+	if sc <= 0 || sc == 1 {
+		return sz
+	}
+	return image.Point{int(mat32.Ceil(float32(sz.X) / sc)), int(mat32.Ceil(float32(sz.Y) / sc))}
+}
+
+// SetZoomScale sets the whole-UI zoom scale factor for this window (clamped
+// to 0.25 .. 4 -- 1 = no scaling), re-laying-out the scene at the inversely
+// scaled size and re-rendering -- incoming mouse event coordinates are
+// inverse-transformed to match (see ZoomScaleEvent).
+func (w *Window) SetZoomScale(sc float32) {
+	sc = mat32.Clamp(sc, 0.25, 4)
+	if sc == 1 {
+		sc = 0
+	}
+	if w.Viewport.Scale == sc {
+		return
+	}
+	w.InactivateAllSprites()
+	w.Viewport.Scale = sc
+	w.UpMu.Lock()
+	w.Viewport.Resize(w.RenderSize(w.OSWin.Size()))
+	w.UpMu.Unlock()
+	w.FullReRender()
+}
+
+// ZoomScale steps the whole-UI zoom scale factor by given number of steps
+// (positive = larger) in multiples of ZoomScaleStep -- see SetZoomScale
+func (w *Window) ZoomScale(steps int) {
+	sc := w.Viewport.Scale
+	if sc <= 0 {
+		sc = 1
+	}
+	for ; steps > 0; steps-- {
+		sc *= ZoomScaleStep
+	}
+	for ; steps < 0; steps++ {
+		sc /= ZoomScaleStep
+	}
+	w.SetZoomScale(sc)
+}
+
+// ZoomScaleEvent inverse-transforms the window coordinates of given event
+// by the viewport zoom Scale, so widgets laid out in the scaled render
+// coordinates receive matching positions -- no-op when no scaling is active
+func (w *Window) ZoomScaleEvent(evi oswin.Event) {
+	sc := w.Viewport.Scale
+	if sc <= 0 || sc == 1 {
+		return
+	}
+	zpt := func(p image.Point) image.Point {
+		return image.Point{int(float32(p.X)/sc + 0.5), int(float32(p.Y)/sc + 0.5)}
+	}
+	switch e := evi.(type) {
+	case *mouse.MoveEvent:
+		e.Where = zpt(e.Where)
+		e.From = zpt(e.From)
+	case *mouse.DragEvent:
+		e.Where = zpt(e.Where)
+		e.From = zpt(e.From)
+	case *mouse.ScrollEvent:
+		e.Where = zpt(e.Where)
+		e.Delta = zpt(e.Delta)
+	case *mouse.Event:
+		e.Where = zpt(e.Where)
+	}
+}
+
 // WinViewport2D returns the viewport directly under this window that serves
 // as the master viewport for the entire window.
 func (w *Window) WinViewport2D() *Viewport2D {
@@ -648,7 +748,8 @@ func (w *Window) Resized(sz image.Point) {
 		return
 	}
 	curSz := w.Viewport.Geom.Size
-	if curSz == sz {
+	rsz := w.RenderSize(sz)
+	if curSz == rsz {
 		if WinEventTrace {
 			fmt.Printf("Win: %v skipped same-size Resized: %v\n", w.Nm, curSz)
 		}
@@ -677,7 +778,7 @@ func (w *Window) Resized(sz image.Point) {
 	}
 	w.OverTex = nil // dynamically allocated when needed
 	w.ClearFlag(int(WinFlagOverTexActive))
-	w.Viewport.Resize(sz)
+	w.Viewport.Resize(rsz)
 	WinGeomPrefs.RecordPref(w)
 	w.UpMu.Unlock()
 	w.FullReRender()
@@ -749,6 +850,7 @@ func (w *Window) Closed() {
 	w.OverTex = nil
 	w.Sprites = nil
 	w.UpMu.Unlock()
+	w.StopTickers()
 }
 
 // IsClosed reports if the window has been closed
@@ -909,6 +1011,24 @@ func (w *Window) FullReRender() {
 	w.InitialFocus()
 }
 
+// ScreenChangedDPI handles this window having moved to a screen with a
+// possibly different DPI (window.ScreenChanged event): rebuilds the styles
+// so that all LogicalDPI-dependent units are recomputed for the new screen,
+// and does a full relayout and re-render of the main viewport
+func (w *Window) ScreenChangedDPI() {
+	if !w.IsVisible() {
+		return
+	}
+	if WinEventTrace {
+		fmt.Printf("Win: %v screen changed: %v\n", w.Nm, w.OSWin.Screen().Name)
+	}
+	RebuildDefaultStyles = true
+	styleTemplates = nil
+	w.SetSize(w.OSWin.Size())
+	RebuildDefaultStyles = false
+	w.FullReRender()
+}
+
 // InitialFocus estabishes the initial focus for the window if no focus
 // is set -- uses ActivateStartFocus or FocusNext as backup.
 func (w *Window) InitialFocus() {
@@ -920,6 +1040,20 @@ func (w *Window) InitialFocus() {
 	}
 }
 
+// WinUploadBatching batches the texture uploads from UploadVpRegion: the
+// regions are accumulated on the window and uploaded in one merged pass
+// just before the next publish, instead of one small upload per node
+// re-render -- reduces upload traffic for bursts of updates (e.g., a
+// slider driving several value displays) within one update cycle.  Safe to
+// leave on, as texture contents only reach the screen at publish time.
+var WinUploadBatching = true
+
+// wupRegion is one batched region upload pending the next publish
+type wupRegion struct {
+	vp              *Viewport2D
+	vpBBox, winBBox image.Rectangle
+}
+
 // UploadVpRegion uploads image for one viewport region on the screen, using
 // vpBBox bounding box for the viewport, and winBBox bounding box for the
 // window -- called after re-rendering specific nodes to update only the
@@ -933,17 +1067,59 @@ func (w *Window) UploadVpRegion(vp *Viewport2D, vpBBox, winBBox image.Rectangle)
 		w.UpMu.Unlock()
 		return
 	}
+	if WinUploadBatching {
+		w.batchVpRegion(vp, vpBBox, winBBox)
+		w.UpMu.Unlock()
+		return
+	}
 	w.SetWinUpdating()
 	// pr := prof.Start("win.UploadVpRegion")
 	if Render2DTrace || WinEventTrace {
 		fmt.Printf("Win: %v uploading region Vp %v, vpbbox: %v, wintex bounds: %v\n", w.PathUnique(), vp.PathUnique(), vpBBox, w.OSWin.WinTex().Bounds())
 	}
-	w.OSWin.SetWinTexSubImage(winBBox.Min, vp.Pixels, vpBBox)
+	w.OSWin.SetWinTexSubImage(winBBox.Min, ColorMgmt.Correct(vp.Pixels, vpBBox), vpBBox)
 	// pr.End()
 	w.ClearWinUpdating()
 	w.UpMu.Unlock()
 }
 
+// batchVpRegion adds given region to the pending upload batch, merging
+// with any overlapping region from the same viewport -- UpMu must be held
+func (w *Window) batchVpRegion(vp *Viewport2D, vpBBox, winBBox image.Rectangle) {
+	if vpBBox.Empty() {
+		return
+	}
+	for i := range w.upBatch {
+		ub := &w.upBatch[i]
+		if ub.vp == vp && ub.winBBox.Overlaps(winBBox) {
+			ub.vpBBox = ub.vpBBox.Union(vpBBox)
+			ub.winBBox = ub.winBBox.Union(winBBox)
+			return
+		}
+	}
+	w.upBatch = append(w.upBatch, wupRegion{vp: vp, vpBBox: vpBBox, winBBox: winBBox})
+}
+
+// flushUpBatch uploads all batched regions accumulated since the last
+// publish -- UpMu must be held -- the pixels are read at flush time, so
+// each region reflects the latest rendered content
+func (w *Window) flushUpBatch() {
+	if len(w.upBatch) == 0 {
+		return
+	}
+	for i := range w.upBatch {
+		ub := &w.upBatch[i]
+		if ub.vp.Pixels == nil { // viewport deleted since batching (e.g., popup)
+			continue
+		}
+		if Render2DTrace || WinEventTrace {
+			fmt.Printf("Win: %v uploading batched region Vp %v, vpbbox: %v\n", w.PathUnique(), ub.vp.PathUnique(), ub.vpBBox)
+		}
+		w.OSWin.SetWinTexSubImage(ub.winBBox.Min, ColorMgmt.Correct(ub.vp.Pixels, ub.vpBBox), ub.vpBBox)
+	}
+	w.upBatch = w.upBatch[:0]
+}
+
 // UploadVp uploads entire viewport image for given viewport -- e.g., for
 // popups etc updating separately
 func (w *Window) UploadVp(vp *Viewport2D, offset image.Point) {
@@ -957,11 +1133,12 @@ func (w *Window) UploadVp(vp *Viewport2D, offset image.Point) {
 	}
 	w.SetWinUpdating()
 	updt := w.UpdateStart()
+	w.flushUpBatch() // pending regions first, so this vp draws over them
 	// pr := prof.Start("win.UploadVp")
 	if Render2DTrace || WinEventTrace {
 		fmt.Printf("Win: %v uploading Vp %v, image bound: %v, wintex bounds: %v\n", w.PathUnique(), vp.PathUnique(), vp.Pixels.Bounds(), w.OSWin.WinTex().Bounds())
 	}
-	w.OSWin.SetWinTexSubImage(offset, vp.Pixels, vp.Pixels.Bounds())
+	w.OSWin.SetWinTexSubImage(offset, ColorMgmt.Correct(vp.Pixels, vp.Pixels.Bounds()), vp.Pixels.Bounds())
 	// pr.End()
 	w.ClearWinUpdating()
 	w.ClearFlag(int(WinFlagPublishFullReRender))
@@ -969,6 +1146,24 @@ func (w *Window) UploadVp(vp *Viewport2D, offset image.Point) {
 	w.UpdateEnd(updt) // drives publish
 }
 
+// SetDarkTitleBar sets whether the native window title bar is drawn in
+// dark mode, to match a dark app theme -- only has an effect on platforms
+// with configurable window chrome (currently Windows)
+func (w *Window) SetDarkTitleBar(dark bool) {
+	if wc, ok := w.OSWin.(oswin.WindowChromer); ok {
+		wc.SetDarkTitleBar(dark)
+	}
+}
+
+// SetBackdrop sets the native backdrop material drawn behind the window
+// contents (e.g., mica / acrylic on Windows 11) -- only has an effect on
+// platforms with configurable window chrome
+func (w *Window) SetBackdrop(bd oswin.WindowBackdrops) {
+	if wc, ok := w.OSWin.(oswin.WindowChromer); ok {
+		wc.SetBackdrop(bd)
+	}
+}
+
 // DirectUploads tells directuploaders to upload to WinTex
 func (w *Window) DirectUploads() {
 	for _, du := range w.DirectUps {
@@ -1014,37 +1209,25 @@ func (w *Window) UploadAllViewports() {
 	w.SetWinUpdating()
 	// pr := prof.Start("win.UploadAllViewports")
 	updt := w.UpdateStart()
+	w.upBatch = w.upBatch[:0] // full upload supersedes any pending regions
 	if Render2DTrace || WinEventTrace {
 		fmt.Printf("Win: %v uploading full Vp, image bound: %v, wintex bounds: %v updt: %v\n", w.PathUnique(), w.Viewport.Pixels.Bounds(), w.OSWin.WinTex().Bounds(), updt)
 	}
-	w.OSWin.SetWinTexSubImage(image.ZP, w.Viewport.Pixels, w.Viewport.Pixels.Bounds())
+	w.OSWin.SetWinTexSubImage(image.ZP, ColorMgmt.Correct(w.Viewport.Pixels, w.Viewport.Pixels.Bounds()), w.Viewport.Pixels.Bounds())
 	// next any direct uploaders
 	w.DirectUploads()
 	// then all the current popups
 	w.PopMu.RLock()
 	// fmt.Printf("upload all views pop locked: %v\n", w.Nm)
-	if w.PopupStack != nil {
-		for _, pop := range w.PopupStack {
-			gii, _ := KiToNode2D(pop)
-			if gii != nil {
-				vp := gii.AsViewport2D()
-				r := vp.Geom.Bounds()
-				if Render2DTrace {
-					fmt.Printf("Win: %v uploading popup stack Vp %v, image bound: %v, wintex bounds: %v\n", w.PathUnique(), vp.PathUnique(), r.Min, vp.Pixels.Bounds())
-				}
-				w.OSWin.SetWinTexSubImage(r.Min, vp.Pixels, vp.Pixels.Bounds())
-			}
-		}
-	}
-	if w.Popup != nil {
-		gii, _ := KiToNode2D(w.Popup)
+	for _, pop := range w.popupZOrder() { // stack plus top popup, in z-index order
+		gii, _ := KiToNode2D(pop)
 		if gii != nil {
 			vp := gii.AsViewport2D()
 			r := vp.Geom.Bounds()
-			if Render2DTrace || WinEventTrace {
-				fmt.Printf("Win: %v uploading top popup Vp %v, image bound: %v, wintex bounds: %v\n", w.PathUnique(), vp.PathUnique(), r.Min, vp.Pixels.Bounds())
+			if Render2DTrace {
+				fmt.Printf("Win: %v uploading popup Vp %v, image bound: %v, wintex bounds: %v\n", w.PathUnique(), vp.PathUnique(), r.Min, vp.Pixels.Bounds())
 			}
-			w.OSWin.SetWinTexSubImage(r.Min, vp.Pixels, vp.Pixels.Bounds())
+			w.OSWin.SetWinTexSubImage(r.Min, ColorMgmt.Correct(vp.Pixels, vp.Pixels.Bounds()), vp.Pixels.Bounds())
 		}
 	}
 	w.PopMu.RUnlock()
@@ -1071,6 +1254,51 @@ func (w *Window) ClearWinUpdating() {
 	w.ClearFlag(int(WinFlagUpdating))
 }
 
+// WinPublishFPS limits how often window updates are published to the screen:
+// at most this many frames per second.  Under heavy signal traffic, many
+// publishes can be triggered per frame -- the limiter coalesces them,
+// deferring the last one until the frame interval has elapsed, so no update
+// is ever lost.  0 = no limit (default); negative = limit to the screen's
+// refresh rate as reported by oswin (i.e., vsync).  Set via
+// Prefs.Params.MaxPublishFPS.
+var WinPublishFPS = 0
+
+// publishPaced implements the WinPublishFPS frame pacing: returns true if
+// this publish should be skipped because one was already done within the
+// current frame interval -- in that case a deferred publish is scheduled (if
+// not already pending) so the final state always reaches the screen
+func (w *Window) publishPaced() bool {
+	fps := float32(WinPublishFPS)
+	if fps < 0 {
+		fps = 60
+		if sc := w.OSWin.Screen(); sc != nil && sc.RefreshRate > 0 {
+			fps = sc.RefreshRate
+		}
+	}
+	if fps == 0 {
+		return false
+	}
+	interval := time.Duration(float32(time.Second) / fps)
+	w.publishMu.Lock()
+	defer w.publishMu.Unlock()
+	now := time.Now()
+	since := now.Sub(w.lastPublish)
+	if since >= interval {
+		w.lastPublish = now
+		return false
+	}
+	if w.publishTimer != nil { // deferred publish already pending
+		return true
+	}
+	w.publishTimer = time.AfterFunc(interval-since, func() {
+		w.publishMu.Lock()
+		w.publishTimer = nil
+		w.publishMu.Unlock()
+		w.Publish()
+	})
+	return true
+}
+
 // Publish does the final step of updating of the window based on the current
 // texture (and overlay texture if active)
 func (w *Window) Publish() {
@@ -1080,6 +1308,9 @@ func (w *Window) Publish() {
 		}
 		return
 	}
+	if w.publishPaced() {
+		return
+	}
 	w.UpMu.Lock()       // block all updates while we publish
 	if !w.IsVisible() { // could have closed while we waited for lock
 		if WinEventTrace {
@@ -1098,6 +1329,7 @@ func (w *Window) Publish() {
 	if Update2DTrace || WinEventTrace {
 		fmt.Printf("Win %v doing publish\n", w.Nm)
 	}
+	w.flushUpBatch() // merged upload of regions batched during the update cycle
 	if w.HasFlag(int(WinFlagPublishFullReRender)) {
 		// fmt.Printf("Win %v doing full re-render direct upload\n", w.Nm)
 		w.ClearFlag(int(WinFlagPublishFullReRender))
@@ -1106,9 +1338,20 @@ func (w *Window) Publish() {
 	// pr := prof.Start("win.Publish")
 	wt := w.OSWin.WinTex()
 	if wt != nil {
-		w.OSWin.Copy(image.ZP, wt, wt.Bounds(), oswin.Src, nil)
-		if w.OverTex != nil && w.HasFlag(int(WinFlagOverTexActive)) {
-			w.OSWin.Copy(image.ZP, w.OverTex, w.OverTex.Bounds(), oswin.Over, nil)
+		if sc := w.Viewport.Scale; w.resizeFreezePending() || (sc > 0 && sc != 1) {
+			// whole-UI zoom, or frozen resize snapshot tracking the drag:
+			// stretch the render up (or down) to the current window size
+			wr := image.Rectangle{Max: w.OSWin.Size()}
+			sr := image.Rectangle{Max: w.Viewport.Geom.Size}
+			w.OSWin.Scale(wr, wt, sr, oswin.Src, nil)
+			if w.OverTex != nil && w.HasFlag(int(WinFlagOverTexActive)) {
+				w.OSWin.Scale(wr, w.OverTex, sr, oswin.Over, nil)
+			}
+		} else {
+			w.OSWin.Copy(image.ZP, wt, wt.Bounds(), oswin.Src, nil)
+			if w.OverTex != nil && w.HasFlag(int(WinFlagOverTexActive)) {
+				w.OSWin.Copy(image.ZP, w.OverTex, w.OverTex.Bounds(), oswin.Over, nil)
+			}
 		}
 		w.OSWin.Publish()
 	}
@@ -1177,7 +1420,7 @@ func (w *Window) RenderOverlays() {
 	if !w.IsVisible() {
 		return
 	}
-	if w.ActiveSprites == 0 || len(w.Sprites) == 0 {
+	if (w.ActiveSprites == 0 || len(w.Sprites) == 0) && len(w.OverDraws) == 0 {
 		w.ClearFlag(int(WinFlagOverTexActive))
 		return
 	}
@@ -1194,12 +1437,10 @@ func (w *Window) RenderOverlays() {
 			w.OverTex.Fill(w.OverTex.Bounds(), color.Transparent, draw.Src)
 		}
 	})
-	for _, sp := range w.Sprites {
-		if !sp.On {
-			continue
-		}
+	for _, sp := range w.spritesInOrder() {
 		w.RenderSprite(sp)
 	}
+	w.RenderOverDraws()
 	w.ClearFlag(int(WinFlagPublishFullReRender))
 	w.UpMu.Unlock()
 	w.UpdateEnd(updt) // drives the publish
@@ -1450,6 +1691,19 @@ func (w *Window) ProcessEvent(evi oswin.Event) {
 		fmt.Printf("Win: %v got out-of-range event: %v\n", w.Nm, et)
 		return
 	}
+	if w.ProcessWinTimerEvent(evi) { // ticker / idle callbacks
+		return
+	}
+	if w.ProcessResizeFreezeEvent(evi) { // deferred resize after freeze
+		return
+	}
+	if w.ProcessAsyncSigEvent(evi) { // queued async signal emissions
+		return
+	}
+	w.ZoomScaleEvent(evi)                                  // map window pixel coords into scaled render coords
+	if et >= oswin.MouseEvent && et <= oswin.RotateEvent { // actual user input
+		TheUserIdle.InputActivity()
+	}
 
 	{ // popup delete check
 		w.PopMu.RLock()
@@ -1472,7 +1726,7 @@ func (w *Window) ProcessEvent(evi oswin.Event) {
 	w.EventMgr.LagLastSkipped = false
 	w.lastEt = et
 
-	if w.skippedResize != nil || w.Viewport.Geom.Size != w.OSWin.Size() {
+	if !w.resizeFreezePending() && (w.skippedResize != nil || w.Viewport.Geom.Size != w.RenderSize(w.OSWin.Size())) {
 		w.SetFlag(int(WinFlagIsResizing))
 		w.Resized(w.OSWin.Size())
 		w.skippedResize = nil
@@ -1593,6 +1847,11 @@ func (w *Window) FilterEvent(evi oswin.Event) bool {
 		w.SetFlag(int(WinFlagIsResizing))
 		we := evi.(*window.Event)
 		// fmt.Printf("resize\n")
+		if WinResizeFreeze && w.Viewport.Geom.Size != image.ZP {
+			we.SetProcessed()
+			w.freezeResize() // scaled snapshot now, full resize when drag pauses
+			return false
+		}
 		if lagMs > EventSkipLagMSec {
 			if WinEventTrace {
 				fmt.Printf("Win: %v skipped et %v lag %v size: %v\n", w.Nm, et, lag, w.OSWin.Size())
@@ -1630,7 +1889,7 @@ func (w *Window) HiPriorityEvents(evi oswin.Event) bool {
 			if w.HasFlag(int(WinFlagDoFullRender)) {
 				w.ClearFlag(int(WinFlagDoFullRender))
 				// fmt.Printf("Doing full render at size: %v\n", w.Viewport.Geom.Size)
-				if w.Viewport.Geom.Size != w.OSWin.Size() {
+				if w.Viewport.Geom.Size != w.RenderSize(w.OSWin.Size()) {
 					w.Resized(w.OSWin.Size())
 				} else {
 					w.FullReRender() // note: this is currently needed for focus to actually
@@ -1676,6 +1935,9 @@ func (w *Window) HiPriorityEvents(evi oswin.Event) bool {
 				Prefs.ApplyDPI()
 				Prefs.Update()
 			}
+		case window.ScreenChanged:
+			e.SetProcessed()
+			w.ScreenChangedDPI()
 		}
 		return false // don't do anything else!
 	case *mouse.DragEvent:
@@ -1687,6 +1949,9 @@ func (w *Window) HiPriorityEvents(evi oswin.Event) bool {
 			}
 		}
 	case *mouse.Event:
+		if w.Present != nil && e.Action == mouse.Press {
+			w.Present.MousePress(e)
+		}
 		if w.EventMgr.DNDData != nil && e.Action == mouse.Release {
 			w.DNDDropEvent(e)
 		}
@@ -1697,7 +1962,7 @@ func (w *Window) HiPriorityEvents(evi oswin.Event) bool {
 				w.ClearFlag(int(WinFlagDoFullRender))
 				// if we are getting mouse input, and still haven't done this, do it..
 				// fmt.Printf("Doing full render at size: %v\n", w.Viewport.Geom.Size)
-				if w.Viewport.Geom.Size != w.OSWin.Size() {
+				if w.Viewport.Geom.Size != w.RenderSize(w.OSWin.Size()) {
 					w.Resized(w.OSWin.Size())
 				} else {
 					w.FullReRender()
@@ -1714,6 +1979,9 @@ func (w *Window) HiPriorityEvents(evi oswin.Event) bool {
 			}
 		}
 	case *key.ChordEvent:
+		if w.Present != nil {
+			w.Present.KeyChord(e)
+		}
 		keyDelPop := w.KeyChordEventHiPri(e)
 		if keyDelPop {
 			w.delPop = true
@@ -2063,6 +2331,9 @@ func (w *Window) KeyChordEventLowPri(e *key.ChordEvent) bool {
 	if e.IsProcessed() {
 		return false
 	}
+	if TheKiosk.BlocksShortcuts(w) {
+		return false
+	}
 	w.EventMgr.ManagerKeyChordEvents(e)
 	if e.IsProcessed() {
 		return false
@@ -2075,11 +2346,22 @@ func (w *Window) KeyChordEventLowPri(e *key.ChordEvent) bool {
 		dstr := time.Now().Format("Mon_Jan_2_15:04:05_MST_2006")
 		SaveImage("GrabOf_"+w.Nm+"_"+dstr+".png", w.Viewport.Pixels)
 		e.SetProcessed()
+	case KeyFunPresent:
+		w.TogglePresentMode()
+		e.SetProcessed()
 	case KeyFunZoomIn:
-		w.ZoomDPI(1)
+		if ZoomWholeUI {
+			w.ZoomScale(1)
+		} else {
+			w.ZoomDPI(1)
+		}
 		e.SetProcessed()
 	case KeyFunZoomOut:
-		w.ZoomDPI(-1)
+		if ZoomWholeUI {
+			w.ZoomScale(-1)
+		} else {
+			w.ZoomDPI(-1)
+		}
 		e.SetProcessed()
 	case KeyFunRefresh:
 		fmt.Printf("Win: %v display refreshed\n", w.Nm)
@@ -2090,6 +2372,14 @@ func (w *Window) KeyChordEventLowPri(e *key.ChordEvent) bool {
 	case KeyFunWinFocusNext:
 		e.SetProcessed()
 		AllWindows.FocusNext()
+	case KeyFunHistPrev:
+		if w.Router != nil && w.Router.Back() {
+			e.SetProcessed()
+		}
+	case KeyFunHistNext:
+		if w.Router != nil && w.Router.Forward() {
+			e.SetProcessed()
+		}
 	}
 	switch cs { // some other random special codes, during dev..
 	case "Control+Alt+R":
@@ -2183,6 +2473,7 @@ func (w *Window) StartDragNDrop(src ki.Ki, data mimedata.Mimes, sp *Sprite) {
 	w.DeleteSprite(DNDSpriteName)
 	sp.Name = DNDSpriteName
 	sp.On = true
+	sp.Layer = SpriteDragLayer // drag ghost above all other sprites
 	w.AddSprite(sp)
 	w.DNDSetCursor(dnd.DefaultModBits(w.EventMgr.LastModBits))
 	w.RenderOverlays()
@@ -0,0 +1,119 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"time"
+
+	"github.com/goki/gi/oswin"
+)
+
+// This file provides window-managed timing callbacks: AddTicker for
+// animations (blinking cursors, spinners, data feeds) and OnIdle for
+// deferred work.  Both kinds of callbacks are dispatched through the
+// window's event loop via custom events, so they always run on the event
+// processing goroutine, are automatically paused while the window is
+// minimized or hidden, and are stopped when the window closes -- widgets
+// using them never leak goroutines past the window lifetime.
+
+// WinTicker is a ticker managed by a Window -- see Window.AddTicker
+type WinTicker struct {
+	Win      *Window       `desc:"the window this ticker belongs to"`
+	Interval time.Duration `desc:"interval between calls"`
+	Func     func()        `desc:"the function called on each tick, on the window event loop"`
+	ticker   *time.Ticker
+	stop     chan struct{}
+}
+
+// Stop permanently stops this ticker -- called automatically when the
+// window closes
+func (wt *WinTicker) Stop() {
+	if wt.ticker == nil {
+		return
+	}
+	wt.ticker.Stop()
+	close(wt.stop)
+	wt.ticker = nil
+}
+
+// winIdleFunc wraps an idle callback for event dispatch
+type winIdleFunc struct {
+	fn func()
+}
+
+// AddTicker adds a ticker calling given function every interval, for
+// driving animations such as blinking cursors and progress spinners.  The
+// function runs on the window's event loop (so it can safely do standard
+// updates), is not called while the window is minimized or not visible,
+// and the ticker is stopped automatically when the window closes.  Call
+// Stop on the returned ticker to end it earlier (e.g., when the widget
+// driving it is disconnected).
+func (w *Window) AddTicker(interval time.Duration, fn func()) *WinTicker {
+	wt := &WinTicker{Win: w, Interval: interval, Func: fn}
+	wt.ticker = time.NewTicker(interval)
+	wt.stop = make(chan struct{})
+	w.UpMu.Lock()
+	w.Tickers = append(w.Tickers, wt)
+	w.UpMu.Unlock()
+	go func() {
+		for {
+			select {
+			case <-wt.ticker.C:
+				if w.IsClosing() || w.IsClosed() {
+					return
+				}
+				if !w.IsVisible() || (w.OSWin != nil && w.OSWin.IsMinimized()) {
+					continue // paused while not visible
+				}
+				w.SendCustomEvent(wt)
+			case <-wt.stop:
+				return
+			}
+		}
+	}()
+	return wt
+}
+
+// OnIdle queues given function to be run once by the event loop after the
+// current events have been processed -- for deferring work out of the
+// current call chain.  The function runs on the event loop goroutine; it
+// is dropped (not run) if the window closes first.
+func (w *Window) OnIdle(fn func()) {
+	if w.IsClosing() || w.IsClosed() {
+		return
+	}
+	w.SendCustomEvent(winIdleFunc{fn})
+}
+
+// StopTickers stops all the tickers for this window -- called when the
+// window closes
+func (w *Window) StopTickers() {
+	for _, wt := range w.Tickers {
+		wt.Stop()
+	}
+	w.Tickers = nil
+}
+
+// ProcessWinTimerEvent runs ticker and idle callbacks dispatched as custom
+// events -- returns true if the event was one of ours and has been handled
+func (w *Window) ProcessWinTimerEvent(evi oswin.Event) bool {
+	ce, ok := evi.(*oswin.CustomEvent)
+	if !ok {
+		return false
+	}
+	switch d := ce.Data.(type) {
+	case *WinTicker:
+		ce.SetProcessed()
+		if d.ticker != nil { // not stopped since dispatch
+			d.Func()
+		}
+		return true
+	case winIdleFunc:
+		ce.SetProcessed()
+		d.fn()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"sort"
+
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// Z-order management: popups composite in creation (stack) order and
+// sprites in map order by default, which makes layering of tooltips,
+// menus, modal scrims, and drag sprites accidental.  Popup viewports can
+// be given an explicit compositing layer with SetZIndex, and sprites with
+// the Sprite.Layer field -- within the same layer, popups keep stack order
+// and sprites render in name order, so everything is deterministic.
+
+// SpriteDragLayer is the Layer assigned to the drag-n-drop sprite, so that
+// it renders above ordinary sprites
+var SpriteDragLayer = 1000
+
+// SetZIndex sets the compositing layer for this popup viewport (the
+// "z-index" property): popups with higher values composite later (on top)
+// -- popups without an explicit z-index have index 0 and keep their
+// creation order -- use e.g., a negative value for a modal scrim that must
+// stay under subsequently-opened menus
+func (vp *Viewport2D) SetZIndex(z int) {
+	vp.SetProp("z-index", z)
+}
+
+// ZIndex returns the compositing layer for this popup viewport -- see
+// SetZIndex -- 0 if not explicitly set
+func (vp *Viewport2D) ZIndex() int {
+	if p := vp.Prop("z-index"); p != nil {
+		if z, ok := kit.ToInt(p); ok {
+			return int(z)
+		}
+	}
+	return 0
+}
+
+// popupZOrder returns all current popups (the popup stack plus the active
+// top popup) in compositing order: ascending z-index, keeping stack order
+// (with the active popup last) within the same index -- must be called
+// under PopMu read lock
+func (w *Window) popupZOrder() []ki.Ki {
+	np := len(w.PopupStack)
+	if w.Popup != nil {
+		np++
+	}
+	if np == 0 {
+		return nil
+	}
+	pops := make([]ki.Ki, 0, np)
+	pops = append(pops, w.PopupStack...)
+	if w.Popup != nil {
+		pops = append(pops, w.Popup)
+	}
+	sort.SliceStable(pops, func(i, j int) bool {
+		return popupZIndex(pops[i]) < popupZIndex(pops[j])
+	})
+	return pops
+}
+
+// popupZIndex returns the z-index for given popup node -- 0 if not a
+// viewport or not explicitly set
+func popupZIndex(pop ki.Ki) int {
+	gii, _ := KiToNode2D(pop)
+	if gii == nil {
+		return 0
+	}
+	vp := gii.AsViewport2D()
+	if vp == nil {
+		return 0
+	}
+	return vp.ZIndex()
+}
+
+// spritesInOrder returns the active sprites in rendering order: ascending
+// Layer, then name order within a layer -- must be called under UpMu lock
+func (w *Window) spritesInOrder() []*Sprite {
+	sps := make([]*Sprite, 0, len(w.Sprites))
+	for _, sp := range w.Sprites {
+		if sp.On {
+			sps = append(sps, sp)
+		}
+	}
+	sort.Slice(sps, func(i, j int) bool {
+		if sps[i].Layer != sps[j].Layer {
+			return sps[i].Layer < sps[j].Layer
+		}
+		return sps[i].Name < sps[j].Name
+	})
+	return sps
+}
@@ -247,6 +247,9 @@ func (nb *Node3DBase) UpdateBBox2D(size mat32.Vec2, sc *Scene) {
 		} else {
 			nb.WinBBox = nb.VpBBox
 		}
+		if sc.Win != nil { // 3D bboxes move without a 2D layout pass
+			sc.Win.EventMgr.HitTest.Invalidate()
+		}
 	} else {
 		// fmt.Printf("not vis: %v  wbb: %v\n", nb.Name(), nb.WorldBBox.BBox)
 		nb.ObjBBox = image.ZR
@@ -13,9 +13,9 @@ func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the stringer command to generate them again.
 	var x [1]struct{}
-	_ = x[WorldMatrixUpdated-29]
-	_ = x[VectorsUpdated-30]
-	_ = x[NodeFlagsN-31]
+	_ = x[WorldMatrixUpdated-30]
+	_ = x[VectorsUpdated-31]
+	_ = x[NodeFlagsN-32]
 }
 
 const _NodeFlags_name = "WorldMatrixUpdatedVectorsUpdatedNodeFlagsN"
@@ -23,9 +23,9 @@ const _NodeFlags_name = "WorldMatrixUpdatedVectorsUpdatedNodeFlagsN"
 var _NodeFlags_index = [...]uint8{0, 18, 32, 42}
 
 func (i NodeFlags) String() string {
-	i -= 29
+	i -= 30
 	if i < 0 || i >= NodeFlags(len(_NodeFlags_index)-1) {
-		return "NodeFlags(" + strconv.FormatInt(int64(i+29), 10) + ")"
+		return "NodeFlags(" + strconv.FormatInt(int64(i+30), 10) + ")"
 	}
 	return _NodeFlags_name[_NodeFlags_index[i]:_NodeFlags_index[i+1]]
 }
@@ -33,7 +33,7 @@ func (i NodeFlags) String() string {
 func StringToNodeFlags(s string) (NodeFlags, error) {
 	for i := 0; i < len(_NodeFlags_index)-1; i++ {
 		if s == _NodeFlags_name[_NodeFlags_index[i]:_NodeFlags_index[i+1]] {
-			return NodeFlags(i + 29), nil
+			return NodeFlags(i + 30), nil
 		}
 	}
 	return 0, errors.New("String: " + s + " is not a valid option for type: NodeFlags")
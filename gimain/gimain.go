@@ -32,6 +32,25 @@ func Main(mainrun func()) {
 	})
 }
 
+// Init initializes the GUI driver without taking over the main loop, for
+// embedding gi in an application that already owns the main thread (e.g.,
+// a game engine or a bridge to another toolkit): mainrun is started on its
+// own goroutine, and the host must then call MainIteration regularly from
+// the main thread to keep the GUI running.
+func Init(mainrun func()) {
+	DebugEnumSizes()
+	driver.Init(func(app oswin.App) {
+		mainrun()
+	})
+}
+
+// MainIteration runs one non-blocking iteration of the GUI main loop, for
+// apps started with Init -- call regularly (e.g., once per host frame)
+// from the main thread -- returns false once the app has finished
+func MainIteration() bool {
+	return driver.MainIteration()
+}
+
 var quit = make(chan struct{})
 
 var started int32
@@ -10,6 +10,7 @@ import (
 	"reflect"
 
 	"github.com/goki/gi/gi"
+	"github.com/goki/gi/mat32"
 	"github.com/goki/gi/units"
 	"github.com/goki/ki/ki"
 	"github.com/goki/ki/kit"
@@ -211,6 +212,12 @@ func (vv *SliceValueView) Activate(vp *gi.Viewport2D, recv ki.Ki, dlgFunc ki.Rec
 	vpath := vv.ViewPath + "/" + newPath
 	desc, _ := vv.Tag("desc")
 	vvp := kit.OnePtrValue(vv.Value)
+	if vvp.Kind() != reflect.Ptr {
+		// non-addressable value (e.g., a slice inside a map) -- edit a temp
+		// copy, which SaveTmp writes back to the owner
+		vv.CreateTempIfNotPtr()
+		vvp = kit.OnePtrValue(vv.Value)
+	}
 	if vvp.Kind() != reflect.Ptr {
 		log.Printf("giv.SliceValueView: Cannot view slices with non-pointer struct elements\n")
 		return
@@ -690,6 +697,182 @@ func (vv *FloatValueView) ConfigWidget(widg gi.Node2D) {
 	vv.UpdateWidget()
 }
 
+////////////////////////////////////////////////////////////////////////////////////////
+//  SliderValueView
+
+// SliderValueView presents a slider for a numeric value, for direct
+// manipulation in parameter-tuning UIs -- activated by a view:"slider"
+// struct tag on a numeric field, with min, max, and step tags setting the
+// range -- view:"slider-spin" adds a linked spinbox for exact numeric entry
+type SliderValueView struct {
+	ValueViewBase
+}
+
+var KiT_SliderValueView = kit.Types.AddType(&SliderValueView{}, nil)
+
+func (vv *SliderValueView) WidgetType() reflect.Type {
+	vv.WidgetTyp = gi.KiT_Layout
+	return vv.WidgetTyp
+}
+
+// HasSpin returns whether the view tag requested a linked spinbox
+func (vv *SliderValueView) HasSpin() bool {
+	vwtag, _ := vv.Tag("view")
+	return vwtag == "slider-spin"
+}
+
+func (vv *SliderValueView) UpdateWidget() {
+	if vv.Widget == nil {
+		return
+	}
+	ly := vv.Widget.(*gi.Layout)
+	npv := kit.NonPtrValue(vv.Value)
+	fv, ok := kit.ToFloat32(npv.Interface())
+	if !ok {
+		return
+	}
+	sl := ly.Child(0).(*gi.Slider)
+	sl.SetValue(fv)
+	if vv.HasSpin() {
+		sb := ly.Child(1).(*gi.SpinBox)
+		sb.SetValue(fv)
+	}
+}
+
+func (vv *SliderValueView) ConfigWidget(widg gi.Node2D) {
+	vv.Widget = widg
+	vv.StdConfigWidget(widg)
+	ly := vv.Widget.(*gi.Layout)
+	ly.Lay = gi.LayoutHoriz
+	config := kit.TypeAndNameList{}
+	config.Add(gi.KiT_Slider, "slider")
+	hasSpin := vv.HasSpin()
+	if hasSpin {
+		config.Add(gi.KiT_SpinBox, "spin")
+	}
+	ly.ConfigChildren(config, true)
+	sl := ly.Child(0).(*gi.Slider)
+	sl.Tooltip, _ = vv.Tag("desc")
+	sl.SetInactiveState(vv.This().(ValueView).IsInactive())
+	sl.Defaults()
+	sl.Dim = mat32.X
+	sl.Tracking = true
+	sl.SetMinPrefWidth(units.NewCh(20))
+	sl.SetMinPrefHeight(units.NewEm(1.5))
+	vk := kit.NonPtrValue(vv.Value).Kind()
+	if vk >= reflect.Int && vk <= reflect.Uint64 {
+		sl.Step = 1
+		sl.Snap = true
+		sl.TrackThr = 1
+	} else {
+		sl.Step = .1
+	}
+	if mintag, ok := vv.Tag("min"); ok {
+		if minv, ok := kit.ToFloat32(mintag); ok {
+			sl.Min = minv
+		}
+	}
+	if maxtag, ok := vv.Tag("max"); ok {
+		if maxv, ok := kit.ToFloat32(maxtag); ok {
+			sl.Max = maxv
+		}
+	}
+	if steptag, ok := vv.Tag("step"); ok {
+		if step, ok := kit.ToFloat32(steptag); ok {
+			sl.Step = step
+		}
+	}
+	sl.PageStep = 10 * sl.Step
+	sl.SliderSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig != int64(gi.SliderValueChanged) {
+			return
+		}
+		vvv, _ := recv.Embed(KiT_SliderValueView).(*SliderValueView)
+		slv := send.Embed(gi.KiT_Slider).(*gi.Slider)
+		if vvv.SetValue(slv.Value) {
+			vvv.UpdateWidget()
+		}
+	})
+	if hasSpin {
+		sb := ly.Child(1).(*gi.SpinBox)
+		sb.Tooltip = sl.Tooltip
+		sb.SetInactiveState(vv.This().(ValueView).IsInactive())
+		sb.Defaults()
+		sb.HasMin = true
+		sb.Min = sl.Min
+		sb.HasMax = true
+		sb.Max = sl.Max
+		sb.Step = sl.Step
+		sb.PageStep = sl.PageStep
+		sb.SetProp("#textfield", ki.Props{
+			"width": units.NewCh(5),
+		})
+		sb.SpinBoxSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			vvv, _ := recv.Embed(KiT_SliderValueView).(*SliderValueView)
+			sbb := vvv.Widget.(*gi.Layout).Child(1).(*gi.SpinBox)
+			if vvv.SetValue(sbb.Value) {
+				vvv.UpdateWidget()
+			}
+		})
+	}
+	vv.UpdateWidget()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  RatingValueView
+
+// RatingValueView presents a star-rating widget for a numeric value --
+// activated by a view:"rating" struct tag on a numeric field, with a max tag
+// setting the number of stars (default 5) and step:"0.5" enabling half steps
+type RatingValueView struct {
+	ValueViewBase
+}
+
+var KiT_RatingValueView = kit.Types.AddType(&RatingValueView{}, nil)
+
+func (vv *RatingValueView) WidgetType() reflect.Type {
+	vv.WidgetTyp = gi.KiT_Rating
+	return vv.WidgetTyp
+}
+
+func (vv *RatingValueView) UpdateWidget() {
+	if vv.Widget == nil {
+		return
+	}
+	rt := vv.Widget.(*gi.Rating)
+	npv := kit.NonPtrValue(vv.Value)
+	if fv, ok := kit.ToFloat32(npv.Interface()); ok {
+		rt.SetValue(fv)
+	}
+}
+
+func (vv *RatingValueView) ConfigWidget(widg gi.Node2D) {
+	vv.Widget = widg
+	vv.StdConfigWidget(widg)
+	rt := vv.Widget.(*gi.Rating)
+	rt.Tooltip, _ = vv.Tag("desc")
+	rt.SetInactiveState(vv.This().(ValueView).IsInactive())
+	rt.Max = 5
+	if maxtag, ok := vv.Tag("max"); ok {
+		if maxv, ok := kit.ToFloat32(maxtag); ok {
+			rt.Max = int(maxv)
+		}
+	}
+	if steptag, ok := vv.Tag("step"); ok {
+		if step, ok := kit.ToFloat32(steptag); ok {
+			rt.HalfSteps = step <= .5
+		}
+	}
+	rt.RatingSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		vvv, _ := recv.Embed(KiT_RatingValueView).(*RatingValueView)
+		rtt := send.Embed(gi.KiT_Rating).(*gi.Rating)
+		if vvv.SetValue(rtt.Value) {
+			vvv.UpdateWidget()
+		}
+	})
+	vv.UpdateWidget()
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 //  EnumValueView
 
@@ -756,6 +939,74 @@ func (vv *EnumValueView) ConfigWidget(widg gi.Node2D) {
 	vv.UpdateWidget()
 }
 
+////////////////////////////////////////////////////////////////////////////////////////
+//  RadioValueView
+
+// RadioValueView presents a RadioGroup of radio buttons for choosing enums,
+// used for the view:"radio" struct tag
+type RadioValueView struct {
+	ValueViewBase
+	AltType reflect.Type // alternative type, e.g., from EnumType: property
+}
+
+var KiT_RadioValueView = kit.Types.AddType(&RadioValueView{}, nil)
+
+func (vv *RadioValueView) WidgetType() reflect.Type {
+	vv.WidgetTyp = gi.KiT_RadioGroup
+	return vv.WidgetTyp
+}
+
+func (vv *RadioValueView) EnumType() reflect.Type {
+	if vv.AltType != nil {
+		return vv.AltType
+	}
+	// derive type indirectly from the interface instead of directly from the value
+	// because that works for interface{} types as in property maps
+	typ := kit.NonPtrType(reflect.TypeOf(vv.Value.Interface()))
+	return typ
+}
+
+func (vv *RadioValueView) SetEnumValueFromInt(ival int64) bool {
+	typ := vv.EnumType()
+	eval := kit.EnumIfaceFromInt64(ival, typ)
+	return vv.SetValue(eval)
+}
+
+func (vv *RadioValueView) UpdateWidget() {
+	if vv.Widget == nil {
+		return
+	}
+	rg := vv.Widget.(*gi.RadioGroup)
+	npv := kit.NonPtrValue(vv.Value)
+	iv, ok := kit.ToInt(npv.Interface())
+	if ok {
+		rg.SetSelIdx(int(iv)) // todo: currently only working for 0-based values
+	}
+}
+
+func (vv *RadioValueView) ConfigWidget(widg gi.Node2D) {
+	vv.Widget = widg
+	rg := vv.Widget.(*gi.RadioGroup)
+	vv.StdConfigWidget(&rg.Parts)
+	rg.Parts.Lay = gi.LayoutHoriz
+	rg.Mutex = true
+	rg.Tooltip, _ = vv.Tag("desc")
+	rg.SetInactiveState(vv.This().(ValueView).IsInactive())
+	rg.SetProp("padding", units.NewPx(2))
+	rg.SetProp("margin", units.NewPx(2))
+
+	typ := vv.EnumType()
+	rg.ItemsFromEnum(typ)
+	rg.ConfigParts()
+	rg.ButtonSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		vvv, _ := recv.Embed(KiT_RadioValueView).(*RadioValueView)
+		if vvv.SetEnumValueFromInt(sig) { // todo: using index
+			vvv.UpdateWidget()
+		}
+	})
+	vv.UpdateWidget()
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 //  BitFlagView
 
@@ -54,6 +54,27 @@ func (cm *ColorMap) Map(val float64) gi.Color {
 	return lclr.Blend(float32(cmix)*100, uclr)
 }
 
+// Reversed returns a new colormap that is the reverse of this one, with
+// "-r" appended to the name, per the standard convention
+func (cm *ColorMap) Reversed() *ColorMap {
+	nc := len(cm.Colors)
+	rm := &ColorMap{Name: cm.Name + "-r", NoColor: cm.NoColor, Colors: make([]gi.Color, nc)}
+	for i, c := range cm.Colors {
+		rm.Colors[nc-1-i] = c
+	}
+	return rm
+}
+
+// GradientString returns a CSS linear-gradient specification of the
+// colormap, for gradient preview strips -- direction is e.g., "to right"
+func (cm *ColorMap) GradientString(dir string) string {
+	gs := "linear-gradient(" + dir
+	for _, c := range cm.Colors {
+		gs += ", " + c.HexString()
+	}
+	return gs + ")"
+}
+
 // see https://matplotlib.org/tutorials/colors/colormap-manipulation.html
 // for how to read out matplotlib scales -- still don't understand segmented ones!
 
@@ -65,6 +86,7 @@ var StdColorMaps = map[string]*ColorMap{
 	"Viridis":        &ColorMap{"Viridis", gi.Color{200, 200, 200, 255}, []gi.Color{{72, 33, 114, 255}, {67, 62, 133, 255}, {56, 87, 140, 255}, {45, 111, 142, 255}, {36, 133, 142, 255}, {30, 155, 138, 255}, {42, 176, 127, 255}, {81, 197, 105, 255}, {134, 212, 73, 255}, {194, 223, 35, 255}, {253, 231, 37, 255}}},
 	"Plasma":         &ColorMap{"Plasma", gi.Color{200, 200, 200, 255}, []gi.Color{{61, 4, 155, 255}, {99, 0, 167, 255}, {133, 6, 166, 255}, {166, 32, 152, 255}, {192, 58, 131, 255}, {213, 84, 110, 255}, {231, 111, 90, 255}, {246, 141, 69, 255}, {253, 174, 50, 255}, {252, 210, 36, 255}, {240, 248, 33, 255}}},
 	"Inferno":        &ColorMap{"Inferno", gi.Color{200, 200, 200, 255}, []gi.Color{{37, 12, 3, 255}, {19, 11, 52, 255}, {57, 9, 99, 255}, {95, 19, 110, 255}, {133, 33, 107, 255}, {169, 46, 94, 255}, {203, 65, 73, 255}, {230, 93, 47, 255}, {247, 131, 17, 255}, {252, 174, 19, 255}, {245, 219, 76, 255}, {252, 254, 164, 255}}},
+	"Magma":          &ColorMap{"Magma", gi.Color{200, 200, 200, 255}, []gi.Color{{0, 0, 4, 255}, {28, 16, 68, 255}, {79, 18, 123, 255}, {129, 37, 129, 255}, {181, 54, 122, 255}, {229, 80, 100, 255}, {251, 135, 97, 255}, {254, 194, 135, 255}, {252, 253, 191, 255}}},
 	"BlueBlackRed":   &ColorMap{"BlueBlackRed", gi.Color{200, 200, 200, 255}, []gi.Color{{0, 0, 255, 255}, {76, 76, 76, 255}, {255, 0, 0, 255}}},
 	"BlueGreyRed":    &ColorMap{"BlueGreyRed", gi.Color{200, 200, 200, 255}, []gi.Color{{0, 0, 255, 255}, {127, 127, 127, 255}, {255, 0, 0, 255}}},
 	"BlueWhiteRed":   &ColorMap{"BlueWhiteRed", gi.Color{200, 200, 200, 255}, []gi.Color{{0, 0, 255, 255}, {230, 230, 230, 255}, {255, 0, 0, 255}}},
@@ -82,6 +104,8 @@ var AvailColorMaps = map[string]*ColorMap{}
 func init() {
 	for k, v := range StdColorMaps {
 		AvailColorMaps[k] = v
+		rv := v.Reversed()
+		AvailColorMaps[rv.Name] = rv
 	}
 }
 
@@ -259,6 +283,11 @@ func (vv *ColorMapValueView) UpdateWidget() {
 	if txt == "" {
 		txt = "(none, click to select)"
 	}
+	if cm, ok := AvailColorMaps[txt]; ok { // gradient preview strip
+		ac.SetProp("background-color", cm.GradientString("to right"))
+	} else {
+		ac.DeleteProp("background-color")
+	}
 	ac.SetText(txt)
 }
 
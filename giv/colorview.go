@@ -5,10 +5,12 @@
 package giv
 
 import (
+	"fmt"
 	"image/color"
 	"log"
 	"reflect"
 	"sort"
+	"strings"
 
 	"github.com/goki/gi/gi"
 	"github.com/goki/gi/mat32"
@@ -24,11 +26,12 @@ import (
 // ColorView shows a color, using sliders to set values,
 type ColorView struct {
 	gi.Frame
-	Color    gi.Color  `desc:"the color that we view"`
-	NumView  ValueView `desc:"inline struct view of the numbers"`
-	TmpSave  ValueView `json:"-" xml:"-" desc:"value view that needs to have SaveTmp called on it whenever a change is made to one of the underlying values -- pass this down to any sub-views created from a parent"`
-	ViewSig  ki.Signal `json:"-" xml:"-" desc:"signal for valueview -- only one signal sent when a value has been set -- all related value views interconnect with each other to update when others update"`
-	ViewPath string    `desc:"a record of parent View names that have led up to this view -- displayed as extra contextual information in view dialog windows"`
+	Color      gi.Color  `desc:"the color that we view"`
+	ContrastBg gi.Color  `desc:"background color that the WCAG contrast ratio in the accessibility row is computed against -- defaults to white -- set with SetContrastBg"`
+	NumView    ValueView `desc:"inline struct view of the numbers"`
+	TmpSave    ValueView `json:"-" xml:"-" desc:"value view that needs to have SaveTmp called on it whenever a change is made to one of the underlying values -- pass this down to any sub-views created from a parent"`
+	ViewSig    ki.Signal `json:"-" xml:"-" desc:"signal for valueview -- only one signal sent when a value has been set -- all related value views interconnect with each other to update when others update"`
+	ViewPath   string    `desc:"a record of parent View names that have led up to this view -- displayed as extra contextual information in view dialog windows"`
 }
 
 var KiT_ColorView = kit.Types.AddType(&ColorView{}, ColorViewProps)
@@ -63,10 +66,12 @@ func (cv *ColorView) Config() {
 	config := kit.TypeAndNameList{}
 	config.Add(gi.KiT_Layout, "slider-lay")
 	config.Add(gi.KiT_Layout, "num-lay")
+	config.Add(gi.KiT_Layout, "access-lay")
 	mods, updt := cv.ConfigChildren(config, true)
 	if mods {
 		cv.SliderLayConfig()
 		cv.NumLayConfig()
+		cv.AccessLayConfig()
 	} else {
 		updt = cv.UpdateStart()
 	}
@@ -121,6 +126,10 @@ func (cv *ColorView) SliderGrid() *gi.Layout {
 	return cv.SliderLay().ChildByName("slider-grid", 0).(*gi.Layout)
 }
 
+func (cv *ColorView) AccessLay() *gi.Layout {
+	return cv.ChildByName("access-lay", 2).(*gi.Layout)
+}
+
 func (cv *ColorView) SetRGBValue(val float32, rgb int) {
 	if val > 0 && cv.Color.IsNil() { // starting out with dummy color
 		cv.Color.A = 255
@@ -288,6 +297,65 @@ func (cv *ColorView) UpdateSliderGrid() {
 	sg.UpdateEnd(updt)
 }
 
+// AccessLayConfig configures the accessibility preview row: swatches of
+// the color under protanopia / deuteranopia / tritanopia simulation, and
+// the WCAG contrast ratio against ContrastBg -- see gi.ColorBlinds,
+// gi.ContrastRatio
+func (cv *ColorView) AccessLayConfig() {
+	al := cv.AccessLay()
+	al.Lay = gi.LayoutHoriz
+	al.SetProp("spacing", gi.StdDialogVSpaceUnits)
+	if cv.ContrastBg.IsNil() {
+		cv.ContrastBg.SetUInt8(255, 255, 255, 255)
+	}
+	config := kit.TypeAndNameList{}
+	config.Add(gi.KiT_Label, "sim-lab")
+	config.Add(gi.KiT_Frame, "protan")
+	config.Add(gi.KiT_Frame, "deutan")
+	config.Add(gi.KiT_Frame, "tritan")
+	config.Add(gi.KiT_Label, "contrast-lab")
+	mods, updt := al.ConfigChildren(config, true)
+	if mods {
+		cv.ConfigLabel(al.ChildByName("sim-lab", 0).Embed(gi.KiT_Label).(*gi.Label), "Sim:")
+		for i := gi.Protanopia; i < gi.ColorBlindsN; i++ {
+			sw := al.Child(1 + int(i)).(*gi.Frame)
+			sw.SetProp("min-width", units.NewEm(2))
+			sw.SetProp("min-height", units.NewEm(2))
+			sw.Tooltip = "color as perceived under " + strings.ToLower(i.String())
+		}
+		clab := al.ChildByName("contrast-lab", 4).Embed(gi.KiT_Label).(*gi.Label)
+		cv.ConfigLabel(clab, "")
+		clab.Tooltip = "WCAG contrast ratio of the color against the chosen background (SetContrastBg), with the conformance level for normal-size text"
+	} else {
+		updt = al.UpdateStart()
+	}
+	al.UpdateEnd(updt)
+}
+
+// SetContrastBg sets the background color that the WCAG contrast ratio is
+// computed against, and updates the display
+func (cv *ColorView) SetContrastBg(clr color.Color) {
+	cv.ContrastBg.SetColor(clr)
+	if cv.IsConfiged() {
+		cv.Update()
+	}
+}
+
+// UpdateAccessLay updates the accessibility preview row from the current
+// color
+func (cv *ColorView) UpdateAccessLay() {
+	al := cv.AccessLay()
+	updt := al.UpdateStart()
+	for i := gi.Protanopia; i < gi.ColorBlindsN; i++ {
+		sw := al.Child(1 + int(i)).(*gi.Frame)
+		sw.Sty.Font.BgColor.Color = cv.Color.SimulateColorBlind(i)
+	}
+	ctr := gi.ContrastRatio(cv.Color, cv.ContrastBg)
+	clab := al.ChildByName("contrast-lab", 4).Embed(gi.KiT_Label).(*gi.Label)
+	clab.SetText(fmt.Sprintf("Contrast: %.2f (%s)", ctr, gi.ContrastLevel(ctr)))
+	al.UpdateEnd(updt)
+}
+
 // NumLayConfig configures the numerical layout
 func (cv *ColorView) NumLayConfig() {
 	nl := cv.NumLay()
@@ -312,6 +380,7 @@ func (cv *ColorView) Update() {
 	cv.NumView.UpdateWidget()
 	v := cv.Value()
 	v.Sty.Font.BgColor.Color = cv.Color // direct copy
+	cv.UpdateAccessLay()
 	cv.UpdateEnd(updt)
 }
 
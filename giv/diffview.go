@@ -0,0 +1,414 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"bytes"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// DiffView is a side-by-side or unified viewer of the differences between
+// two texts, building on TextBuf.DiffBufs.  In side-by-side mode, the two
+// texts are shown in two aligned TextViews with blank filler lines so
+// corresponding lines are at the same height, with changed regions
+// highlighted (down to the intra-line changed segment for replaced lines).
+// Toolbar actions navigate between hunks and apply a hunk to either side,
+// for version-control frontends built on gi.
+type DiffView struct {
+	gi.Frame
+	BufA    *TextBuf  `json:"-" xml:"-" desc:"the first (a) buffer being compared"`
+	BufB    *TextBuf  `json:"-" xml:"-" desc:"the second (b) buffer being compared"`
+	Unified bool      `desc:"show as a unified diff in a single view, instead of side-by-side"`
+	Diffs   TextDiffs `json:"-" xml:"-" desc:"the current diff operations between BufA and BufB"`
+	CurDiff int       `json:"-" xml:"-" desc:"index of current diff hunk for navigation"`
+	AlignsA []int     `json:"-" xml:"-" desc:"aligned display line for the start of each diff op, for scrolling to hunks"`
+	DispA   *TextBuf  `json:"-" xml:"-" desc:"display buffer for the a side, with filler lines for alignment"`
+	DispB   *TextBuf  `json:"-" xml:"-" desc:"display buffer for the b side"`
+	DispU   *TextBuf  `json:"-" xml:"-" desc:"display buffer for unified mode"`
+	ViewSig ki.Signal `json:"-" xml:"-" view:"-" desc:"signal sent when a hunk is applied to one of the buffers -- data is the buffer"`
+}
+
+var KiT_DiffView = kit.Types.AddType(&DiffView{}, DiffViewProps)
+
+// AddNewDiffView adds a new diffview to given parent node, with given name.
+func AddNewDiffView(parent ki.Ki, name string) *DiffView {
+	return parent.AddNewChild(KiT_DiffView, name).(*DiffView)
+}
+
+var DiffViewProps = ki.Props{
+	"EnumType:Flag":    gi.KiT_NodeFlags,
+	"max-width":        -1,
+	"max-height":       -1,
+	"background-color": &gi.Prefs.Colors.Background,
+}
+
+// SetBufs sets the two buffers to compare, and updates the display
+func (dv *DiffView) SetBufs(a, b *TextBuf) {
+	dv.BufA = a
+	dv.BufB = b
+	dv.Config()
+	dv.UpdateDiffs()
+}
+
+// SetTexts sets the two texts to compare from raw bytes, and updates the
+// display
+func (dv *DiffView) SetTexts(a, b []byte) {
+	ab := &TextBuf{}
+	ab.InitName(ab, "diff-buf-a")
+	ab.SetText(a)
+	bb := &TextBuf{}
+	bb.InitName(bb, "diff-buf-b")
+	bb.SetText(b)
+	dv.SetBufs(ab, bb)
+}
+
+// Config configures the toolbar and text views
+func (dv *DiffView) Config() {
+	dv.Lay = gi.LayoutVert
+	dv.SetStretchMax()
+	config := kit.TypeAndNameList{}
+	config.Add(gi.KiT_ToolBar, "toolbar")
+	config.Add(gi.KiT_SplitView, "splits")
+	mods, updt := dv.ConfigChildren(config, true)
+	if !mods {
+		return
+	}
+	dv.ConfigToolBar()
+	dv.ConfigSplits()
+	dv.UpdateEnd(updt)
+}
+
+// SplitView returns the split view containing the text views
+func (dv *DiffView) SplitView() *gi.SplitView {
+	return dv.ChildByName("splits", 1).(*gi.SplitView)
+}
+
+// ToolBar returns the toolbar
+func (dv *DiffView) ToolBar() *gi.ToolBar {
+	return dv.ChildByName("toolbar", 0).(*gi.ToolBar)
+}
+
+// ViewA returns the text view showing the a side
+func (dv *DiffView) ViewA() *TextView {
+	return dv.SplitView().ChildByName("lay-a", 0).Child(0).(*TextView)
+}
+
+// ViewB returns the text view showing the b side
+func (dv *DiffView) ViewB() *TextView {
+	return dv.SplitView().ChildByName("lay-b", 1).Child(0).(*TextView)
+}
+
+// ConfigToolBar configures the toolbar actions
+func (dv *DiffView) ConfigToolBar() {
+	tb := dv.ToolBar()
+	if tb.HasChildren() {
+		return
+	}
+	tb.SetStretchMaxWidth()
+	tb.AddAction(gi.ActOpts{Label: "Prev", Icon: "wedge-up", Tooltip: "go to previous diff hunk"},
+		dv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			recv.Embed(KiT_DiffView).(*DiffView).PrevDiff()
+		})
+	tb.AddAction(gi.ActOpts{Label: "Next", Icon: "wedge-down", Tooltip: "go to next diff hunk"},
+		dv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			recv.Embed(KiT_DiffView).(*DiffView).NextDiff()
+		})
+	tb.AddAction(gi.ActOpts{Label: "A <- B", Tooltip: "apply current hunk to the A side, taking the B version"},
+		dv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			dvv := recv.Embed(KiT_DiffView).(*DiffView)
+			dvv.ApplyDiff(dvv.CurDiff, true)
+		})
+	tb.AddAction(gi.ActOpts{Label: "A -> B", Tooltip: "apply current hunk to the B side, taking the A version"},
+		dv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			dvv := recv.Embed(KiT_DiffView).(*DiffView)
+			dvv.ApplyDiff(dvv.CurDiff, false)
+		})
+	cb := gi.AddNewCheckBox(tb, "unified")
+	cb.SetText("Unified")
+	cb.Tooltip = "show as a unified diff in a single view"
+	cb.ButtonSig.Connect(dv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.ButtonToggled) {
+			dvv := recv.Embed(KiT_DiffView).(*DiffView)
+			dvv.SetUnified(send.(*gi.CheckBox).IsChecked())
+		}
+	})
+}
+
+// ConfigSplits configures the two text view panels
+func (dv *DiffView) ConfigSplits() {
+	sv := dv.SplitView()
+	sv.Dim = gi.X
+	config := kit.TypeAndNameList{}
+	config.Add(gi.KiT_Layout, "lay-a")
+	config.Add(gi.KiT_Layout, "lay-b")
+	mods, updt := sv.ConfigChildren(config, true)
+	if mods {
+		la := sv.Child(0).(*gi.Layout)
+		la.SetStretchMax()
+		tva := AddNewTextView(la, "view-a")
+		tva.SetInactive()
+		lb := sv.Child(1).(*gi.Layout)
+		lb.SetStretchMax()
+		tvb := AddNewTextView(lb, "view-b")
+		tvb.SetInactive()
+		sv.SetSplits(.5, .5)
+		sv.UpdateEnd(updt)
+	}
+}
+
+// SetUnified switches between unified and side-by-side display
+func (dv *DiffView) SetUnified(unified bool) {
+	dv.Unified = unified
+	dv.UpdateDiffs()
+}
+
+// UpdateDiffs recomputes the diffs between the buffers and updates the
+// display views
+func (dv *DiffView) UpdateDiffs() {
+	if dv.BufA == nil || dv.BufB == nil {
+		return
+	}
+	dv.Diffs = dv.BufA.DiffBufs(dv.BufB)
+	dv.CurDiff = dv.NextDiffIdx(-1)
+	if dv.Unified {
+		dv.UpdateUnified()
+		return
+	}
+	dv.UpdateSideBySide()
+}
+
+// UpdateUnified shows the unified diff text in the a-side view, hiding the
+// b side
+func (dv *DiffView) UpdateUnified() {
+	if dv.DispU == nil {
+		dv.DispU = &TextBuf{}
+		dv.DispU.InitName(dv.DispU, "diff-unified")
+	}
+	dv.DispU.SetText(dv.BufA.DiffBufsUnified(dv.BufB, 3))
+	sv := dv.SplitView()
+	dv.ViewA().SetBuf(dv.DispU)
+	sv.SetSplits(1, 0)
+	sv.UpdateSig()
+}
+
+// UpdateSideBySide fills the two display buffers with aligned versions of
+// the two texts, and highlights the changed regions in each view
+func (dv *DiffView) UpdateSideBySide() {
+	if dv.DispA == nil {
+		dv.DispA = &TextBuf{}
+		dv.DispA.InitName(dv.DispA, "diff-disp-a")
+		dv.DispB = &TextBuf{}
+		dv.DispB.InitName(dv.DispB, "diff-disp-b")
+	}
+	var ab, bb bytes.Buffer
+	var hiA, hiB []TextRegion
+	dv.AlignsA = make([]int, len(dv.Diffs))
+	aln := 0 // current aligned line
+	wln := func(b *bytes.Buffer, txt []rune) {
+		b.WriteString(string(txt))
+		b.WriteString("\n")
+	}
+	for di, df := range dv.Diffs {
+		dv.AlignsA[di] = aln
+		switch df.Tag {
+		case 'e':
+			for i := df.I1; i < df.I2; i++ {
+				wln(&ab, dv.BufA.Line(i))
+				wln(&bb, dv.BufB.Line(df.J1+(i-df.I1)))
+				aln++
+			}
+		case 'r':
+			na := df.I2 - df.I1
+			nb := df.J2 - df.J1
+			mx := na
+			if nb > mx {
+				mx = nb
+			}
+			for i := 0; i < mx; i++ {
+				var la, lb []rune
+				if i < na {
+					la = dv.BufA.Line(df.I1 + i)
+				}
+				if i < nb {
+					lb = dv.BufB.Line(df.J1 + i)
+				}
+				wln(&ab, la)
+				wln(&bb, lb)
+				if i < na && i < nb {
+					sc, eca, ecb := runesDiffSpan(la, lb)
+					hiA = append(hiA, NewTextRegion(aln, sc, aln, eca))
+					hiB = append(hiB, NewTextRegion(aln, sc, aln, ecb))
+				} else if i < na {
+					hiA = append(hiA, NewTextRegion(aln, 0, aln, len(la)))
+				} else {
+					hiB = append(hiB, NewTextRegion(aln, 0, aln, len(lb)))
+				}
+				aln++
+			}
+		case 'd':
+			for i := df.I1; i < df.I2; i++ {
+				la := dv.BufA.Line(i)
+				wln(&ab, la)
+				wln(&bb, nil)
+				hiA = append(hiA, NewTextRegion(aln, 0, aln, len(la)))
+				aln++
+			}
+		case 'i':
+			for j := df.J1; j < df.J2; j++ {
+				lb := dv.BufB.Line(j)
+				wln(&ab, nil)
+				wln(&bb, lb)
+				hiB = append(hiB, NewTextRegion(aln, 0, aln, len(lb)))
+				aln++
+			}
+		}
+	}
+	dv.DispA.SetText(ab.Bytes())
+	dv.DispB.SetText(bb.Bytes())
+	sv := dv.SplitView()
+	tva := dv.ViewA()
+	tvb := dv.ViewB()
+	tva.SetBuf(dv.DispA)
+	tvb.SetBuf(dv.DispB)
+	tva.Highlights = hiA
+	tvb.Highlights = hiB
+	sv.SetSplits(.5, .5)
+	sv.UpdateSig()
+}
+
+// runesDiffSpan returns the changed span between two rune lines, in terms of
+// the common start column and the end columns in each line beyond which the
+// lines are equal again -- used for intra-line change highlighting
+func runesDiffSpan(la, lb []rune) (sc, eca, ecb int) {
+	na := len(la)
+	nb := len(lb)
+	mn := na
+	if nb < mn {
+		mn = nb
+	}
+	for sc < mn && la[sc] == lb[sc] {
+		sc++
+	}
+	ea := na
+	eb := nb
+	for ea > sc && eb > sc && la[ea-1] == lb[eb-1] {
+		ea--
+		eb--
+	}
+	return sc, ea, eb
+}
+
+// DiffIsChange returns true if given diff op index is an actual change
+// (not an equal region)
+func (dv *DiffView) DiffIsChange(idx int) bool {
+	if idx < 0 || idx >= len(dv.Diffs) {
+		return false
+	}
+	return dv.Diffs[idx].Tag != 'e'
+}
+
+// NextDiffIdx returns the index of the next change hunk after given index,
+// -1 if none
+func (dv *DiffView) NextDiffIdx(idx int) int {
+	for i := idx + 1; i < len(dv.Diffs); i++ {
+		if dv.Diffs[i].Tag != 'e' {
+			return i
+		}
+	}
+	return -1
+}
+
+// PrevDiffIdx returns the index of the previous change hunk before given
+// index, -1 if none
+func (dv *DiffView) PrevDiffIdx(idx int) int {
+	for i := idx - 1; i >= 0; i-- {
+		if dv.Diffs[i].Tag != 'e' {
+			return i
+		}
+	}
+	return -1
+}
+
+// ShowDiff scrolls both views to the start of given diff hunk
+func (dv *DiffView) ShowDiff(idx int) {
+	if idx < 0 || idx >= len(dv.Diffs) {
+		return
+	}
+	dv.CurDiff = idx
+	if dv.Unified {
+		return // unified text has its own hunk headers
+	}
+	aln := dv.AlignsA[idx]
+	dv.ViewA().JumpToLine(aln + 1)
+	dv.ViewB().JumpToLine(aln + 1)
+}
+
+// NextDiff navigates to the next change hunk
+func (dv *DiffView) NextDiff() {
+	idx := dv.NextDiffIdx(dv.CurDiff)
+	if idx < 0 {
+		return
+	}
+	dv.ShowDiff(idx)
+}
+
+// PrevDiff navigates to the previous change hunk
+func (dv *DiffView) PrevDiff() {
+	idx := dv.PrevDiffIdx(dv.CurDiff)
+	if idx < 0 {
+		return
+	}
+	dv.ShowDiff(idx)
+}
+
+// ApplyDiff applies the given diff hunk to one side: if toA is true, the A
+// buffer takes the B version of the hunk, and vice-versa -- diffs are then
+// recomputed and the display updated
+func (dv *DiffView) ApplyDiff(idx int, toA bool) {
+	if !dv.DiffIsChange(idx) {
+		return
+	}
+	df := dv.Diffs[idx]
+	if toA {
+		patchBufLines(dv.BufA, df.I1, df.I2, dv.BufB, df.J1, df.J2)
+		dv.ViewSig.Emit(dv.This(), 0, dv.BufA)
+	} else {
+		patchBufLines(dv.BufB, df.J1, df.J2, dv.BufA, df.I1, df.I2)
+		dv.ViewSig.Emit(dv.This(), 0, dv.BufB)
+	}
+	dv.UpdateDiffs()
+}
+
+// patchBufLines replaces lines [st, ed) in given buffer with lines
+// [ost, oed) from the other buffer
+func patchBufLines(tb *TextBuf, st, ed int, ob *TextBuf, ost, oed int) {
+	bufUpdt, winUpdt, autoSave := tb.BatchUpdateStart()
+	defer tb.BatchUpdateEnd(bufUpdt, winUpdt, autoSave)
+	if ed > st {
+		edp := TextPos{Ln: ed}
+		if ed >= tb.NumLines() {
+			edp = tb.EndPos()
+		}
+		tb.DeleteText(TextPos{Ln: st}, edp, true, true)
+	}
+	if oed <= ost {
+		return
+	}
+	var ins bytes.Buffer
+	for i := ost; i < oed; i++ {
+		ins.WriteString(string(ob.Line(i)))
+		ins.WriteString("\n")
+	}
+	txt := ins.Bytes()
+	ip := TextPos{Ln: st}
+	if st >= tb.NumLines() { // appending at end
+		ip = tb.EndPos()
+		txt = append([]byte("\n"), bytes.TrimSuffix(txt, []byte("\n"))...)
+	}
+	tb.InsertText(ip, txt, true, true)
+}
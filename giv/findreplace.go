@@ -0,0 +1,382 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"regexp"
+	"unicode"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// FindReplaceBar is a standard find / replace toolbar that can be attached
+// to any TextView (including ones used as log views), supporting regular
+// expressions, case sensitivity, whole-word matching, highlighting of all
+// matches, and replace / replace-all with undo grouping.  Use SetTextView to
+// attach to a view -- KeyFunFind / KeyFunSearch then open the bar, and
+// KeyFunReplace opens it with focus in the replace field.
+type FindReplaceBar struct {
+	gi.Layout
+	View      *TextView         `json:"-" xml:"-" desc:"the text view that we search in"`
+	Regexp    bool              `desc:"interpret the find string as a regular expression"`
+	UseCase   bool              `desc:"if true, search is case-sensitive"`
+	WholeWord bool              `desc:"only match entire words (not applied to regexp searches)"`
+	Matches   []FileSearchMatch `json:"-" xml:"-" desc:"current search matches"`
+	CurIdx    int               `json:"-" xml:"-" desc:"index of currently-selected match"`
+}
+
+var KiT_FindReplaceBar = kit.Types.AddType(&FindReplaceBar{}, FindReplaceBarProps)
+
+// AddNewFindReplaceBar adds a new find / replace bar to given parent node,
+// with given name.
+func AddNewFindReplaceBar(parent ki.Ki, name string) *FindReplaceBar {
+	return parent.AddNewChild(KiT_FindReplaceBar, name).(*FindReplaceBar)
+}
+
+var FindReplaceBarProps = ki.Props{
+	"EnumType:Flag":    gi.KiT_NodeFlags,
+	"max-width":        -1,
+	"spacing":          units.NewPx(4),
+	"background-color": &gi.Prefs.Colors.Control,
+}
+
+// SetTextView attaches this find / replace bar to given text view -- the
+// view's find / search / replace key functions then drive this bar
+func (fb *FindReplaceBar) SetTextView(tv *TextView) {
+	if fb.View != nil && fb.View != tv {
+		fb.View.FindBar = nil
+	}
+	fb.View = tv
+	if tv != nil {
+		tv.FindBar = fb
+	}
+}
+
+// FindText returns the current find string
+func (fb *FindReplaceBar) FindText() string {
+	ft := fb.ChildByName("find-str", 1)
+	if ft == nil {
+		return ""
+	}
+	return ft.(*gi.TextField).Text()
+}
+
+// ReplaceText returns the current replace string
+func (fb *FindReplaceBar) ReplaceText() string {
+	rt := fb.ChildByName("repl-str", 1)
+	if rt == nil {
+		return ""
+	}
+	return rt.(*gi.TextField).Text()
+}
+
+// Config configures the children of the bar -- called during Init2D
+func (fb *FindReplaceBar) Config() {
+	if fb.HasChildren() {
+		return
+	}
+	updt := fb.UpdateStart()
+	defer fb.UpdateEnd(updt)
+	fb.Lay = gi.LayoutHoriz
+	fb.SetStretchMaxWidth()
+
+	ft := gi.AddNewTextField(fb, "find-str")
+	ft.SetProp("width", units.NewCh(20))
+	ft.Tooltip = "string to find -- Enter searches and goes to next match"
+	ft.TextFieldSig.Connect(fb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.TextFieldDone) {
+			fbb := recv.Embed(KiT_FindReplaceBar).(*FindReplaceBar)
+			fbb.FindAction()
+		}
+	})
+
+	prev := gi.AddNewAction(fb, "find-prev")
+	prev.SetIcon("wedge-up")
+	prev.Tooltip = "go to previous match"
+	prev.ActionSig.Connect(fb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_FindReplaceBar).(*FindReplaceBar).PrevMatch()
+	})
+
+	next := gi.AddNewAction(fb, "find-next")
+	next.SetIcon("wedge-down")
+	next.Tooltip = "go to next match"
+	next.ActionSig.Connect(fb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_FindReplaceBar).(*FindReplaceBar).NextMatch()
+	})
+
+	rx := gi.AddNewCheckBox(fb, "regexp")
+	rx.SetText("Regexp")
+	rx.Tooltip = "interpret the find string as a regular expression"
+	rx.ButtonSig.Connect(fb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.ButtonToggled) {
+			fbb := recv.Embed(KiT_FindReplaceBar).(*FindReplaceBar)
+			fbb.Regexp = send.(*gi.CheckBox).IsChecked()
+			fbb.FindAction()
+		}
+	})
+
+	cs := gi.AddNewCheckBox(fb, "use-case")
+	cs.SetText("Case")
+	cs.Tooltip = "search is case-sensitive"
+	cs.ButtonSig.Connect(fb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.ButtonToggled) {
+			fbb := recv.Embed(KiT_FindReplaceBar).(*FindReplaceBar)
+			fbb.UseCase = send.(*gi.CheckBox).IsChecked()
+			fbb.FindAction()
+		}
+	})
+
+	ww := gi.AddNewCheckBox(fb, "whole-word")
+	ww.SetText("Word")
+	ww.Tooltip = "only match entire words"
+	ww.ButtonSig.Connect(fb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.ButtonToggled) {
+			fbb := recv.Embed(KiT_FindReplaceBar).(*FindReplaceBar)
+			fbb.WholeWord = send.(*gi.CheckBox).IsChecked()
+			fbb.FindAction()
+		}
+	})
+
+	rt := gi.AddNewTextField(fb, "repl-str")
+	rt.SetProp("width", units.NewCh(20))
+	rt.Tooltip = "string to replace the match with"
+
+	rp := gi.AddNewAction(fb, "replace")
+	rp.SetText("Replace")
+	rp.Tooltip = "replace the current match and go to the next one"
+	rp.ActionSig.Connect(fb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_FindReplaceBar).(*FindReplaceBar).ReplaceAction()
+	})
+
+	ra := gi.AddNewAction(fb, "replace-all")
+	ra.SetText("All")
+	ra.Tooltip = "replace all matches -- undo is grouped into a single action"
+	ra.ActionSig.Connect(fb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_FindReplaceBar).(*FindReplaceBar).ReplaceAllAction()
+	})
+
+	cl := gi.AddNewAction(fb, "close")
+	cl.SetIcon("close")
+	cl.Tooltip = "close the find / replace bar"
+	cl.ActionSig.Connect(fb.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		recv.Embed(KiT_FindReplaceBar).(*FindReplaceBar).Close()
+	})
+}
+
+func (fb *FindReplaceBar) Init2D() {
+	fb.Config()
+	fb.Layout.Init2D()
+}
+
+// Open shows the bar and grabs focus in the find field
+func (fb *FindReplaceBar) Open() {
+	fb.Config()
+	wupdt := fb.TopUpdateStart()
+	defer fb.TopUpdateEnd(wupdt)
+	fb.ClearInvisible()
+	ft := fb.ChildByName("find-str", 1)
+	if ft != nil {
+		ft.(*gi.TextField).GrabFocus()
+	}
+}
+
+// OpenReplace shows the bar and grabs focus in the replace field
+func (fb *FindReplaceBar) OpenReplace() {
+	fb.Open()
+	rt := fb.ChildByName("repl-str", 1)
+	if rt != nil {
+		rt.(*gi.TextField).GrabFocus()
+	}
+}
+
+// Close hides the bar and clears match highlighting in the view
+func (fb *FindReplaceBar) Close() {
+	wupdt := fb.TopUpdateStart()
+	defer fb.TopUpdateEnd(wupdt)
+	fb.Matches = nil
+	fb.SetInvisible()
+	if fb.View != nil {
+		fb.View.ClearHighlights()
+		fb.View.GrabFocus()
+	}
+}
+
+// FindRegexp returns the compiled regexp for the current find string,
+// incorporating the case-sensitivity option -- nil if invalid or not in
+// regexp mode
+func (fb *FindReplaceBar) FindRegexp() *regexp.Regexp {
+	ftxt := fb.FindText()
+	if !fb.UseCase {
+		ftxt = "(?i)" + ftxt
+	}
+	re, err := regexp.Compile(ftxt)
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// isWordRune returns true for runes that are part of a word, for whole-word
+// match filtering
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// WholeWordMatch returns true if given match region falls on word boundaries
+// in the buffer
+func (fb *FindReplaceBar) WholeWordMatch(reg TextRegion) bool {
+	ln := fb.View.Buf.Line(reg.Start.Ln)
+	if reg.Start.Ch > 0 && isWordRune(ln[reg.Start.Ch-1]) {
+		return false
+	}
+	if reg.End.Ch < len(ln) && isWordRune(ln[reg.End.Ch]) {
+		return false
+	}
+	return true
+}
+
+// FindAction (re)runs the search with the current find string and options,
+// highlighting all matches in the view and selecting the first one at or
+// after the cursor position
+func (fb *FindReplaceBar) FindAction() {
+	tv := fb.View
+	if tv == nil || tv.Buf == nil {
+		return
+	}
+	fb.Matches = nil
+	ftxt := fb.FindText()
+	if ftxt != "" {
+		if fb.Regexp {
+			re := fb.FindRegexp()
+			if re != nil {
+				_, fb.Matches = tv.Buf.SearchRegexp(re)
+			}
+		} else {
+			_, fb.Matches = tv.Buf.Search([]byte(ftxt), !fb.UseCase)
+			if fb.WholeWord {
+				wwm := make([]FileSearchMatch, 0, len(fb.Matches))
+				for _, m := range fb.Matches {
+					if fb.WholeWordMatch(m.Reg) {
+						wwm = append(wwm, m)
+					}
+				}
+				fb.Matches = wwm
+			}
+		}
+	}
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+	if len(fb.Matches) == 0 {
+		tv.Highlights = nil
+		tv.RenderAllLines()
+		return
+	}
+	hi := make([]TextRegion, 0, len(fb.Matches))
+	for i, m := range fb.Matches {
+		hi = append(hi, m.Reg)
+		if i > TextViewMaxFindHighlights {
+			break
+		}
+	}
+	tv.Highlights = hi
+	tv.RenderAllLines()
+	fb.CurIdx, _ = tv.MatchFromPos(fb.Matches, tv.CursorPos)
+	fb.SelectCurMatch()
+}
+
+// SelectCurMatch selects and scrolls to the match at CurIdx
+func (fb *FindReplaceBar) SelectCurMatch() {
+	tv := fb.View
+	nm := len(fb.Matches)
+	if tv == nil || nm == 0 {
+		return
+	}
+	if fb.CurIdx >= nm {
+		fb.CurIdx = nm - 1
+	}
+	m := fb.Matches[fb.CurIdx]
+	reg := tv.Buf.AdjustReg(m.Reg)
+	tv.SelectReg = reg
+	tv.SetCursor(reg.Start)
+	tv.SavePosHistory(tv.CursorPos)
+	tv.ScrollCursorToCenterIfHidden()
+	tv.RenderSelectLines()
+}
+
+// NextMatch selects the next match, wrapping around at the end
+func (fb *FindReplaceBar) NextMatch() {
+	nm := len(fb.Matches)
+	if nm == 0 {
+		fb.FindAction()
+		return
+	}
+	fb.CurIdx = (fb.CurIdx + 1) % nm
+	fb.SelectCurMatch()
+}
+
+// PrevMatch selects the previous match, wrapping around at the start
+func (fb *FindReplaceBar) PrevMatch() {
+	nm := len(fb.Matches)
+	if nm == 0 {
+		fb.FindAction()
+		return
+	}
+	fb.CurIdx = (fb.CurIdx + nm - 1) % nm
+	fb.SelectCurMatch()
+}
+
+// ReplaceAction replaces the currently-selected match with the replace
+// string, and goes to the next match
+func (fb *FindReplaceBar) ReplaceAction() {
+	tv := fb.View
+	if tv == nil || len(fb.Matches) == 0 {
+		return
+	}
+	m := fb.Matches[fb.CurIdx]
+	reg := tv.Buf.AdjustReg(m.Reg)
+	if reg == TextRegionNil {
+		fb.FindAction()
+		return
+	}
+	rtxt := fb.ReplaceText()
+	bufUpdt, winUpdt, autoSave := tv.Buf.BatchUpdateStart()
+	tv.Buf.DeleteText(reg.Start, reg.End, true, true)
+	if rtxt != "" {
+		tv.Buf.InsertText(reg.Start, []byte(rtxt), true, true)
+	}
+	tv.Buf.BatchUpdateEnd(bufUpdt, winUpdt, autoSave)
+	fb.FindAction()
+}
+
+// ReplaceAllAction replaces all matches with the replace string, as a single
+// batched (grouped undo) action
+func (fb *FindReplaceBar) ReplaceAllAction() {
+	tv := fb.View
+	if tv == nil {
+		return
+	}
+	fb.FindAction()
+	if len(fb.Matches) == 0 {
+		return
+	}
+	rtxt := fb.ReplaceText()
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+	bufUpdt, winUpdt, autoSave := tv.Buf.BatchUpdateStart()
+	for i := len(fb.Matches) - 1; i >= 0; i-- { // reverse order means no position adjusts needed
+		reg := fb.Matches[i].Reg
+		tv.Buf.DeleteText(reg.Start, reg.End, true, true)
+		if rtxt != "" {
+			tv.Buf.InsertText(reg.Start, []byte(rtxt), true, true)
+		}
+	}
+	tv.Buf.BatchUpdateEnd(bufUpdt, winUpdt, autoSave)
+	fb.Matches = nil
+	tv.Highlights = nil
+	tv.RenderAllLines()
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+)
+
+// GlobalHotkeysView opens a view of the global hotkey bindings table --
+// edited chords are re-activated with the oswin driver on change, and
+// conflicts are reported there (see gi.GlobalHotkeys)
+func GlobalHotkeysView(gh *gi.GlobalHotkeys) {
+	winm := "gogi-global-hotkeys"
+	width := 800
+	height := 600
+	win, recyc := gi.RecycleMainWindow(gh, winm, "Global Hotkeys", width, height)
+	if recyc {
+		return
+	}
+
+	vp := win.WinViewport2D()
+	updt := vp.UpdateStart()
+
+	mfr := win.SetMainFrame()
+	mfr.Lay = gi.LayoutVert
+
+	title := mfr.AddNewChild(gi.KiT_Label, "title").(*gi.Label)
+	title.SetText("Global Hotkeys: system-wide key chords that trigger app actions even when the app is not focused -- not all platforms support these")
+	title.SetProp("width", units.NewCh(30)) // need for wrap
+	title.SetStretchMaxWidth()
+	title.SetProp("white-space", gi.WhiteSpaceNormal) // wrap
+
+	tv := mfr.AddNewChild(KiT_TableView, "tv").(*TableView)
+	tv.Viewport = vp
+	tv.SetSlice(gh)
+	tv.SetStretchMax()
+
+	gi.AvailGlobalHotkeysChanged = false
+	tv.ViewSig.Connect(mfr.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		gi.AvailGlobalHotkeysChanged = true
+		gh.Activate() // re-register edited chords
+	})
+
+	mmen := win.MainMenu
+	MainMenuView(gh, win, mmen)
+
+	inClosePrompt := false
+	win.OSWin.SetCloseReqFunc(func(w oswin.Window) {
+		if !gi.AvailGlobalHotkeysChanged || gh != &gi.AvailGlobalHotkeys {
+			win.Close()
+			return
+		}
+		if inClosePrompt {
+			return
+		}
+		inClosePrompt = true
+		gi.ChoiceDialog(vp, gi.DlgOpts{Title: "Save Global Hotkeys Before Closing?",
+			Prompt: "Do you want to save any changes to the global hotkeys preferences file before closing, or Cancel the close and do a Save to a different file?"},
+			[]string{"Save and Close", "Discard and Close", "Cancel"},
+			win.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				switch sig {
+				case 0:
+					gh.SavePrefs()
+					fmt.Printf("Preferences Saved to %v\n", gi.PrefsGlobalHotkeysFileName)
+					win.Close()
+				case 1:
+					if gh == &gi.AvailGlobalHotkeys {
+						gh.OpenPrefs() // revert
+						gh.Activate()
+					}
+					win.Close()
+				case 2:
+					inClosePrompt = false
+					// default is to do nothing, i.e., cancel
+				}
+			})
+	})
+
+	win.MainMenuUpdated()
+
+	vp.UpdateEndNoSig(updt)
+	win.GoStartEventLoop()
+}
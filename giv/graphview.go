@@ -0,0 +1,723 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// This file provides a node-graph (dataflow) editor: a Graph model of nodes
+// with typed input / output ports connected by links, and a GraphView widget
+// that renders it on a zoomable, pannable canvas with draggable nodes,
+// bezier wires, interactive wire dragging between ports, and box selection.
+
+///////////////////////////////////////////////////////////////////////////////
+//    Graph model
+
+// GraphPort is one input or output port on a graph node.  Ports are typed
+// by a string type name: a link is only allowed between ports of the same
+// type, or if either has the empty type.
+type GraphPort struct {
+	Name string `desc:"name of the port, displayed next to it"`
+	Type string `desc:"type name of the port -- links connect matching types -- empty matches anything"`
+}
+
+// GraphNode is one node in a Graph, with input and output ports
+type GraphNode struct {
+	Name    string      `desc:"name displayed in the node header"`
+	Pos     mat32.Vec2  `desc:"position of the top-left of the node, in graph coordinates"`
+	Inputs  []GraphPort `desc:"input ports, on the left side"`
+	Outputs []GraphPort `desc:"output ports, on the right side"`
+	Data    interface{} `json:"-" xml:"-" desc:"optional user data associated with the node"`
+}
+
+// GraphLink is a connection from an output port on one node to an input
+// port on another, by index
+type GraphLink struct {
+	FromNode int `desc:"index of the source node in Nodes"`
+	FromPort int `desc:"index of the output port on the source node"`
+	ToNode   int `desc:"index of the destination node in Nodes"`
+	ToPort   int `desc:"index of the input port on the destination node"`
+}
+
+// Graph is the serializable model edited by a GraphView: a set of nodes
+// and the links connecting their ports
+type Graph struct {
+	Nodes []*GraphNode `desc:"the nodes in the graph"`
+	Links []GraphLink  `desc:"the links connecting node ports"`
+}
+
+// AddNode adds a new node with given name and position, returning it
+func (gr *Graph) AddNode(name string, pos mat32.Vec2) *GraphNode {
+	nd := &GraphNode{Name: name, Pos: pos}
+	gr.Nodes = append(gr.Nodes, nd)
+	return nd
+}
+
+// NodeIdx returns the index of given node in Nodes -- -1 if not found
+func (gr *Graph) NodeIdx(nd *GraphNode) int {
+	for i, n := range gr.Nodes {
+		if n == nd {
+			return i
+		}
+	}
+	return -1
+}
+
+// ConnectOK returns true if a link from given output port on from node to
+// given input port on to node is allowed: indexes valid, types compatible,
+// not a self-link, and not a duplicate
+func (gr *Graph) ConnectOK(fromNode, fromPort, toNode, toPort int) bool {
+	if fromNode == toNode {
+		return false
+	}
+	if fromNode < 0 || fromNode >= len(gr.Nodes) || toNode < 0 || toNode >= len(gr.Nodes) {
+		return false
+	}
+	fn, tn := gr.Nodes[fromNode], gr.Nodes[toNode]
+	if fromPort < 0 || fromPort >= len(fn.Outputs) || toPort < 0 || toPort >= len(tn.Inputs) {
+		return false
+	}
+	ft, tt := fn.Outputs[fromPort].Type, tn.Inputs[toPort].Type
+	if ft != "" && tt != "" && ft != tt {
+		return false
+	}
+	for _, lk := range gr.Links {
+		if lk.FromNode == fromNode && lk.FromPort == fromPort && lk.ToNode == toNode && lk.ToPort == toPort {
+			return false
+		}
+	}
+	return true
+}
+
+// Connect adds a link from given output port to given input port if
+// allowed, returning true if added
+func (gr *Graph) Connect(fromNode, fromPort, toNode, toPort int) bool {
+	if !gr.ConnectOK(fromNode, fromPort, toNode, toPort) {
+		return false
+	}
+	gr.Links = append(gr.Links, GraphLink{fromNode, fromPort, toNode, toPort})
+	return true
+}
+
+// DeleteLink deletes the link at given index in Links
+func (gr *Graph) DeleteLink(idx int) {
+	if idx < 0 || idx >= len(gr.Links) {
+		return
+	}
+	gr.Links = append(gr.Links[:idx], gr.Links[idx+1:]...)
+}
+
+// DeleteNode deletes the node at given index, along with all the links
+// attached to it, renumbering the remaining links
+func (gr *Graph) DeleteNode(idx int) {
+	if idx < 0 || idx >= len(gr.Nodes) {
+		return
+	}
+	gr.Nodes = append(gr.Nodes[:idx], gr.Nodes[idx+1:]...)
+	lks := gr.Links[:0]
+	for _, lk := range gr.Links {
+		if lk.FromNode == idx || lk.ToNode == idx {
+			continue
+		}
+		if lk.FromNode > idx {
+			lk.FromNode--
+		}
+		if lk.ToNode > idx {
+			lk.ToNode--
+		}
+		lks = append(lks, lk)
+	}
+	gr.Links = lks
+}
+
+// SaveJSON saves the graph to given file as JSON
+func (gr *Graph) SaveJSON(filename string) error {
+	b, err := json.MarshalIndent(gr, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, b, 0644)
+}
+
+// OpenJSON opens the graph from given JSON file
+func (gr *Graph) OpenJSON(filename string) error {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	*gr = Graph{}
+	return json.Unmarshal(b, gr)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    GraphView
+
+// GraphViewSignals are signals sent by a GraphView as the graph is edited
+type GraphViewSignals int64
+
+const (
+	// GraphViewNodeSelected is sent when the set of selected nodes changes
+	// -- data is the index of the last node selected, or -1 for none
+	GraphViewNodeSelected GraphViewSignals = iota
+
+	// GraphViewNodeMoved is sent when nodes have been dragged to a new
+	// position -- data is the index of the node under the pointer
+	GraphViewNodeMoved
+
+	// GraphViewLinkAdded is sent when a wire has been connected -- data is
+	// the index of the new link in Links
+	GraphViewLinkAdded
+
+	// GraphViewLinkDeleted is sent after a link has been deleted -- data
+	// is the former index of the link
+	GraphViewLinkDeleted
+
+	// GraphViewDeleted is sent after selected nodes have been deleted
+	GraphViewDeleted
+
+	GraphViewSignalsN
+)
+
+//go:generate stringer -type=GraphViewSignals
+
+// graphDragModes are what an in-progress drag on the graph view is doing
+type graphDragMode int
+
+const (
+	graphDragNone graphDragMode = iota
+	graphDragPan
+	graphDragNode
+	graphDragWire
+	graphDragBox
+)
+
+// GraphView is an editor for a Graph: nodes are drawn as boxes with their
+// input ports on the left and output ports on the right, connected by
+// bezier wires.  Dragging the background pans, scrolling zooms, dragging a
+// node header moves the selected nodes, dragging from a port draws a new
+// wire (released over a compatible port to connect), and dragging a box on
+// the background selects the enclosed nodes.  The Delete key deletes the
+// selected nodes.  GraphSig sends GraphViewSignals as the graph is edited.
+type GraphView struct {
+	gi.WidgetBase
+	Graph     *Graph        `desc:"the graph being edited"`
+	Offset    mat32.Vec2    `desc:"graph coordinate at the top-left of the view (pan)"`
+	Scale     float32       `desc:"zoom factor from graph to window coordinates"`
+	SelNodes  map[int]bool  `json:"-" xml:"-" desc:"indexes of the currently-selected nodes"`
+	Snap      gi.SnapEngine `desc:"snap settings for node dragging -- targets are managed automatically"`
+	GraphSig  ki.Signal     `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for graph edits -- see GraphViewSignals"`
+	dragMode  graphDragMode
+	dragStart mat32.Vec2 // pointer position at start of drag, window coords
+	dragCur   mat32.Vec2 // current pointer position during drag, window coords
+	dragNode  int        // node index being dragged
+	wireNode  int        // node index the wire drag started from
+	wirePort  int        // port index the wire drag started from
+	wireOut   bool       // wire drag started from an output port
+}
+
+var KiT_GraphView = kit.Types.AddType(&GraphView{}, GraphViewProps)
+
+// AddNewGraphView adds a new graph view to given parent node, with given name.
+func AddNewGraphView(parent ki.Ki, name string) *GraphView {
+	gv := parent.AddNewChild(KiT_GraphView, name).(*GraphView)
+	gv.Scale = 1
+	gv.SelNodes = make(map[int]bool)
+	gv.Snap.Defaults()
+	return gv
+}
+
+var GraphViewProps = ki.Props{
+	"EnumType:Flag":    gi.KiT_NodeFlags,
+	"max-width":        -1,
+	"max-height":       -1,
+	"background-color": &gi.Prefs.Colors.Background,
+}
+
+func (gv *GraphView) Disconnect() {
+	gv.WidgetBase.Disconnect()
+	gv.GraphSig.DisconnectAll()
+}
+
+// SetGraph sets the graph to edit and updates the display
+func (gv *GraphView) SetGraph(gr *Graph) {
+	gv.Graph = gr
+	if gv.Scale == 0 {
+		gv.Scale = 1
+	}
+	if gv.SelNodes == nil {
+		gv.SelNodes = make(map[int]bool)
+	} else {
+		for k := range gv.SelNodes {
+			delete(gv.SelNodes, k)
+		}
+	}
+	gv.UpdateSig()
+}
+
+// GraphNodeWd is the width of a node box, in graph coordinates
+var GraphNodeWd = float32(120)
+
+// GraphNodeHdrHt is the height of a node header, in graph coordinates
+var GraphNodeHdrHt = float32(20)
+
+// GraphPortHt is the vertical spacing of ports, in graph coordinates
+var GraphPortHt = float32(16)
+
+// GraphPortRad is the radius of a port circle, in graph coordinates
+var GraphPortRad = float32(4)
+
+// GraphToPix returns the window pixel point for given graph coordinate
+func (gv *GraphView) GraphToPix(p mat32.Vec2) mat32.Vec2 {
+	pos := gv.LayData.AllocPos
+	return pos.Add(p.Sub(gv.Offset).MulScalar(gv.Scale))
+}
+
+// PixToGraph returns the graph coordinate for given window pixel point
+func (gv *GraphView) PixToGraph(p mat32.Vec2) mat32.Vec2 {
+	pos := gv.LayData.AllocPos
+	return p.Sub(pos).DivScalar(gv.Scale).Add(gv.Offset)
+}
+
+// NodeBox returns the bounding box of given node in graph coordinates
+func (gv *GraphView) NodeBox(nd *GraphNode) mat32.Box2 {
+	np := len(nd.Inputs)
+	if len(nd.Outputs) > np {
+		np = len(nd.Outputs)
+	}
+	ht := GraphNodeHdrHt + float32(np)*GraphPortHt + 4
+	return mat32.Box2{Min: nd.Pos, Max: nd.Pos.Add(mat32.Vec2{GraphNodeWd, ht})}
+}
+
+// PortPos returns the center of given port on given node, in graph
+// coordinates -- out selects the output vs. input side
+func (gv *GraphView) PortPos(nd *GraphNode, port int, out bool) mat32.Vec2 {
+	y := nd.Pos.Y + GraphNodeHdrHt + (float32(port)+.5)*GraphPortHt
+	if out {
+		return mat32.Vec2{nd.Pos.X + GraphNodeWd, y}
+	}
+	return mat32.Vec2{nd.Pos.X, y}
+}
+
+// NodeAtPix returns the index of the topmost node at given window pixel
+// point -- -1 if none
+func (gv *GraphView) NodeAtPix(pt mat32.Vec2) int {
+	if gv.Graph == nil {
+		return -1
+	}
+	gp := gv.PixToGraph(pt)
+	for i := len(gv.Graph.Nodes) - 1; i >= 0; i-- {
+		box := gv.NodeBox(gv.Graph.Nodes[i])
+		if box.ContainsPoint(gp) {
+			return i
+		}
+	}
+	return -1
+}
+
+// PortAtPix returns the node index, port index and output side of the port
+// at given window pixel point -- node is -1 if none
+func (gv *GraphView) PortAtPix(pt mat32.Vec2) (node, port int, out bool) {
+	node = -1
+	if gv.Graph == nil {
+		return
+	}
+	gp := gv.PixToGraph(pt)
+	prad := GraphPortRad + 3 // slop for easier targeting
+	for i, nd := range gv.Graph.Nodes {
+		for pi := range nd.Inputs {
+			pp := gv.PortPos(nd, pi, false)
+			if pp.DistTo(gp) <= prad {
+				return i, pi, false
+			}
+		}
+		for pi := range nd.Outputs {
+			pp := gv.PortPos(nd, pi, true)
+			if pp.DistTo(gp) <= prad {
+				return i, pi, true
+			}
+		}
+	}
+	return
+}
+
+// SelectNode adds node at given index to the selection -- if exclusive,
+// the prior selection is cleared first
+func (gv *GraphView) SelectNode(idx int, exclusive bool) {
+	if exclusive {
+		for k := range gv.SelNodes {
+			delete(gv.SelNodes, k)
+		}
+	}
+	if idx >= 0 {
+		gv.SelNodes[idx] = true
+	}
+	gv.GraphSig.Emit(gv.This(), int64(GraphViewNodeSelected), idx)
+	gv.UpdateSig()
+}
+
+// DeleteSelected deletes the selected nodes and their links
+func (gv *GraphView) DeleteSelected() {
+	if gv.Graph == nil || len(gv.SelNodes) == 0 {
+		return
+	}
+	for i := len(gv.Graph.Nodes) - 1; i >= 0; i-- {
+		if gv.SelNodes[i] {
+			gv.Graph.DeleteNode(i)
+		}
+	}
+	for k := range gv.SelNodes {
+		delete(gv.SelNodes, k)
+	}
+	gv.GraphSig.Emit(gv.This(), int64(GraphViewDeleted), -1)
+	gv.UpdateSig()
+}
+
+func (gv *GraphView) MouseEvent() {
+	gv.ConnectEvent(oswin.MouseEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		gvv := recv.Embed(KiT_GraphView).(*GraphView)
+		if gvv.Graph == nil || me.Button != mouse.Left {
+			return
+		}
+		me.SetProcessed()
+		pt := mat32.NewVec2FmPoint(me.Pos())
+		switch me.Action {
+		case mouse.Press:
+			gvv.GrabFocus()
+			gvv.dragStart = pt
+			gvv.dragCur = pt
+			if nd, port, out := gvv.PortAtPix(pt); nd >= 0 {
+				gvv.dragMode = graphDragWire
+				gvv.wireNode = nd
+				gvv.wirePort = port
+				gvv.wireOut = out
+				return
+			}
+			if idx := gvv.NodeAtPix(pt); idx >= 0 {
+				if !gvv.SelNodes[idx] {
+					gvv.SelectNode(idx, !me.HasAnyModifier(key.Shift))
+				}
+				gvv.dragMode = graphDragNode
+				gvv.dragNode = idx
+				return
+			}
+			if me.HasAnyModifier(key.Shift) {
+				gvv.dragMode = graphDragBox
+			} else {
+				gvv.dragMode = graphDragPan
+				if len(gvv.SelNodes) > 0 {
+					gvv.SelectNode(-1, true)
+				}
+			}
+		case mouse.Release:
+			switch gvv.dragMode {
+			case graphDragWire:
+				gvv.WireRelease(pt)
+			case graphDragNode:
+				if pt != gvv.dragStart {
+					gvv.GraphSig.Emit(gvv.This(), int64(GraphViewNodeMoved), gvv.dragNode)
+				}
+			case graphDragBox:
+				gvv.BoxSelect()
+			}
+			gvv.dragMode = graphDragNone
+			gvv.UpdateSig()
+		}
+	})
+}
+
+// WireRelease completes a wire drag at given window pixel point, adding a
+// link if released over a compatible port
+func (gv *GraphView) WireRelease(pt mat32.Vec2) {
+	nd, port, out := gv.PortAtPix(pt)
+	if nd < 0 || out == gv.wireOut {
+		return
+	}
+	fn, fp, tn, tp := gv.wireNode, gv.wirePort, nd, port
+	if !gv.wireOut {
+		fn, fp, tn, tp = nd, port, gv.wireNode, gv.wirePort
+	}
+	if gv.Graph.Connect(fn, fp, tn, tp) {
+		gv.GraphSig.Emit(gv.This(), int64(GraphViewLinkAdded), len(gv.Graph.Links)-1)
+	}
+}
+
+// BoxSelect selects the nodes enclosed by the current drag box
+func (gv *GraphView) BoxSelect() {
+	g0 := gv.PixToGraph(gv.dragStart)
+	g1 := gv.PixToGraph(gv.dragCur)
+	box := mat32.Box2{Min: g0.Min(g1), Max: g0.Max(g1)}
+	for i, nd := range gv.Graph.Nodes {
+		nb := gv.NodeBox(nd)
+		if nb.Min.X >= box.Min.X && nb.Max.X <= box.Max.X && nb.Min.Y >= box.Min.Y && nb.Max.Y <= box.Max.Y {
+			gv.SelNodes[i] = true
+		}
+	}
+	gv.GraphSig.Emit(gv.This(), int64(GraphViewNodeSelected), -1)
+}
+
+func (gv *GraphView) MouseDragEvent() {
+	gv.ConnectEvent(oswin.MouseDragEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		me.SetProcessed()
+		gvv := recv.Embed(KiT_GraphView).(*GraphView)
+		pt := mat32.NewVec2FmPoint(me.Pos())
+		del := pt.Sub(gvv.dragCur)
+		gvv.dragCur = pt
+		switch gvv.dragMode {
+		case graphDragPan:
+			gvv.Offset = gvv.Offset.Sub(del.DivScalar(gvv.Scale))
+			gvv.UpdateSig()
+		case graphDragNode:
+			gdel := del.DivScalar(gvv.Scale)
+			for i := range gvv.SelNodes {
+				gvv.Graph.Nodes[i].Pos = gvv.Graph.Nodes[i].Pos.Add(gdel)
+			}
+			gvv.SnapDragNode()
+			gvv.UpdateSig()
+		case graphDragWire, graphDragBox:
+			gvv.UpdateSig()
+		}
+	})
+}
+
+// SnapDragNode snaps the node being dragged against the other (unselected)
+// nodes and the grid, moving all the selected nodes by the snap offset --
+// see the Snap field for the settings
+func (gv *GraphView) SnapDragNode() {
+	if gv.dragNode < 0 || gv.dragNode >= len(gv.Graph.Nodes) {
+		return
+	}
+	targs := make([]mat32.Box2, 0, len(gv.Graph.Nodes))
+	for i, nd := range gv.Graph.Nodes {
+		if gv.SelNodes[i] {
+			continue
+		}
+		targs = append(targs, gv.NodeBox(nd))
+	}
+	gv.Snap.SetTargets(targs)
+	box := gv.NodeBox(gv.Graph.Nodes[gv.dragNode])
+	off := gv.Snap.SnapBox(box).Min.Sub(box.Min)
+	if off == (mat32.Vec2{}) {
+		return
+	}
+	for i := range gv.SelNodes {
+		gv.Graph.Nodes[i].Pos = gv.Graph.Nodes[i].Pos.Add(off)
+	}
+}
+
+func (gv *GraphView) MouseScrollEvent() {
+	gv.ConnectEvent(oswin.MouseScrollEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.ScrollEvent)
+		me.SetProcessed()
+		gvv := recv.Embed(KiT_GraphView).(*GraphView)
+		del := me.NonZeroDelta(false)
+		factor := float32(1.1)
+		if del < 0 {
+			factor = 1 / 1.1
+		}
+		fix := gvv.PixToGraph(mat32.NewVec2FmPoint(me.Pos()))
+		gvv.Scale = mat32.Clamp(gvv.Scale*factor, .1, 5)
+		// keep the graph point under the pointer fixed
+		npix := gvv.GraphToPix(fix)
+		gvv.Offset = gvv.Offset.Add(npix.Sub(mat32.NewVec2FmPoint(me.Pos())).DivScalar(gvv.Scale))
+		gvv.UpdateSig()
+	})
+}
+
+func (gv *GraphView) KeyChordEvent() {
+	gv.ConnectEvent(oswin.KeyChordEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		ke := d.(*key.ChordEvent)
+		gvv := recv.Embed(KiT_GraphView).(*GraphView)
+		kf := gi.KeyFun(ke.Chord())
+		switch kf {
+		case gi.KeyFunDelete, gi.KeyFunBackspace:
+			ke.SetProcessed()
+			gvv.DeleteSelected()
+		}
+	})
+}
+
+func (gv *GraphView) ConnectEvents2D() {
+	gv.MouseEvent()
+	gv.MouseDragEvent()
+	gv.MouseScrollEvent()
+	gv.KeyChordEvent()
+	gv.HoverTooltipEvent()
+}
+
+// RenderText renders given string at given window pixel position
+func (gv *GraphView) RenderText(s string, pos mat32.Vec2) {
+	rs := &gv.Viewport.Render
+	sty := &gv.Sty
+	fst := sty.Font
+	fst.BgColor.SetColor(nil)
+	var tr gi.TextRender
+	tr.SetString(s, &fst, &sty.UnContext, &sty.Text, true, 0, 0)
+	tr.Render(rs, pos)
+}
+
+// RenderWire renders a bezier wire between given window pixel points
+func (gv *GraphView) RenderWire(p0, p1 mat32.Vec2) {
+	rs := &gv.Viewport.Render
+	pc := &rs.Paint
+	cd := math32.Abs(p1.X-p0.X) * .5
+	if cd < 20 {
+		cd = 20
+	}
+	pc.MoveTo(rs, p0.X, p0.Y)
+	pc.CubicTo(rs, p0.X+cd, p0.Y, p1.X-cd, p1.Y, p1.X, p1.Y)
+	pc.Stroke(rs)
+}
+
+// RenderGraph does the full rendering of the graph
+func (gv *GraphView) RenderGraph() {
+	if gv.Graph == nil {
+		return
+	}
+	rs := &gv.Viewport.Render
+	pc := &rs.Paint
+	sty := &gv.Sty
+
+	// wires
+	rs.Lock()
+	pc.StrokeStyle.SetColor(sty.Font.Color.Highlight(20))
+	pc.StrokeStyle.Width.Dots = 2
+	for _, lk := range gv.Graph.Links {
+		if lk.FromNode >= len(gv.Graph.Nodes) || lk.ToNode >= len(gv.Graph.Nodes) {
+			continue
+		}
+		p0 := gv.GraphToPix(gv.PortPos(gv.Graph.Nodes[lk.FromNode], lk.FromPort, true))
+		p1 := gv.GraphToPix(gv.PortPos(gv.Graph.Nodes[lk.ToNode], lk.ToPort, false))
+		gv.RenderWire(p0, p1)
+	}
+	// wire being dragged
+	if gv.dragMode == graphDragWire {
+		nd := gv.Graph.Nodes[gv.wireNode]
+		p0 := gv.GraphToPix(gv.PortPos(nd, gv.wirePort, gv.wireOut))
+		pc.StrokeStyle.SetColor(&gi.Prefs.Colors.Select)
+		if gv.wireOut {
+			gv.RenderWire(p0, gv.dragCur)
+		} else {
+			gv.RenderWire(gv.dragCur, p0)
+		}
+	}
+	rs.Unlock()
+
+	// nodes
+	for i, nd := range gv.Graph.Nodes {
+		box := gv.NodeBox(nd)
+		p0 := gv.GraphToPix(box.Min)
+		psz := box.Size().MulScalar(gv.Scale)
+		rs.Lock()
+		pc.FillStyle.SetColor(gi.Prefs.Colors.Background.Highlight(10))
+		pc.StrokeStyle.Width.Dots = 1
+		if gv.SelNodes[i] {
+			pc.StrokeStyle.SetColor(&gi.Prefs.Colors.Select)
+			pc.StrokeStyle.Width.Dots = 2
+		} else {
+			pc.StrokeStyle.SetColor(sty.Font.Color)
+		}
+		pc.DrawRoundedRectangle(rs, p0.X, p0.Y, psz.X, psz.Y, 4*gv.Scale)
+		pc.FillStrokeClear(rs)
+		// header separator
+		hy := p0.Y + GraphNodeHdrHt*gv.Scale
+		pc.StrokeStyle.SetColor(sty.Font.Color.Highlight(30))
+		pc.StrokeStyle.Width.Dots = 1
+		pc.DrawLine(rs, p0.X, hy, p0.X+psz.X, hy)
+		pc.Stroke(rs)
+		// ports
+		pc.FillStyle.SetColor(sty.Font.Color)
+		for pi := range nd.Inputs {
+			pp := gv.GraphToPix(gv.PortPos(nd, pi, false))
+			pc.DrawCircle(rs, pp.X, pp.Y, GraphPortRad*gv.Scale)
+			pc.Fill(rs)
+		}
+		for pi := range nd.Outputs {
+			pp := gv.GraphToPix(gv.PortPos(nd, pi, true))
+			pc.DrawCircle(rs, pp.X, pp.Y, GraphPortRad*gv.Scale)
+			pc.Fill(rs)
+		}
+		rs.Unlock()
+		// labels -- skipped when zoomed far out
+		if gv.Scale < .5 {
+			continue
+		}
+		gv.RenderText(nd.Name, mat32.Vec2{p0.X + 4, p0.Y + 2})
+		for pi, prt := range nd.Inputs {
+			pp := gv.GraphToPix(gv.PortPos(nd, pi, false))
+			gv.RenderText(prt.Name, mat32.Vec2{pp.X + 6, pp.Y - 7})
+		}
+		for pi, prt := range nd.Outputs {
+			pp := gv.GraphToPix(gv.PortPos(nd, pi, true))
+			wd := float32(len(prt.Name)) * .6 * sty.Font.Face.Metrics.Height // approx
+			gv.RenderText(prt.Name, mat32.Vec2{pp.X - 6 - wd, pp.Y - 7})
+		}
+	}
+
+	// snap alignment guides during a node drag
+	if gv.dragMode == graphDragNode && len(gv.Snap.Guides) > 0 {
+		rs.Lock()
+		pc.StrokeStyle.SetColor(&gi.Prefs.Colors.Select)
+		pc.StrokeStyle.Width.Dots = 1
+		for _, g := range gv.Snap.Guides {
+			var p0, p1 mat32.Vec2
+			if g.Dim == mat32.X {
+				p0 = gv.GraphToPix(mat32.Vec2{g.Pos, g.Min})
+				p1 = gv.GraphToPix(mat32.Vec2{g.Pos, g.Max})
+			} else {
+				p0 = gv.GraphToPix(mat32.Vec2{g.Min, g.Pos})
+				p1 = gv.GraphToPix(mat32.Vec2{g.Max, g.Pos})
+			}
+			pc.DrawLine(rs, p0.X, p0.Y, p1.X, p1.Y)
+			pc.Stroke(rs)
+		}
+		rs.Unlock()
+	}
+
+	// selection box
+	if gv.dragMode == graphDragBox {
+		rs.Lock()
+		pc.StrokeStyle.SetColor(&gi.Prefs.Colors.Select)
+		pc.StrokeStyle.Width.Dots = 1
+		mn := gv.dragStart.Min(gv.dragCur)
+		sz := gv.dragStart.Max(gv.dragCur).Sub(mn)
+		pc.DrawRectangle(rs, mn.X, mn.Y, sz.X, sz.Y)
+		pc.Stroke(rs)
+		rs.Unlock()
+	}
+
+	// zoom readout
+	if gv.Scale != 1 {
+		pos := gv.LayData.AllocPos
+		gv.RenderText(fmt.Sprintf("%d%%", int(gv.Scale*100)), mat32.Vec2{pos.X + 4, pos.Y + 2})
+	}
+}
+
+func (gv *GraphView) Render2D() {
+	if gv.FullReRenderIfNeeded() {
+		return
+	}
+	if gv.PushBounds() {
+		gv.This().(gi.Node2D).ConnectEvents2D()
+		gv.RenderGraph()
+		gv.Render2DChildren()
+		gv.PopBounds()
+	} else {
+		gv.DisconnectAllEvents(gi.RegPri)
+	}
+}
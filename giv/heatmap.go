@@ -0,0 +1,287 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"golang.org/x/image/draw"
+)
+
+// HeatMapView renders a 2D float32 matrix as a heatmap using a ColorMap
+// (e.g., Viridis, Magma, or a custom map -- see AvailColorMaps), with a
+// colorbar legend and a hover readout of the cell value under the pointer.
+// The cell colors are cached in an image that is only recomputed for the
+// cells that changed (see SetBlock), so large matrices with localized
+// updates re-render efficiently.  Note that this is not a ValueView widget.
+type HeatMapView struct {
+	gi.WidgetBase
+	Mat       [][]float32 `desc:"the matrix of values, as rows of columns"`
+	MinVal    float32     `desc:"value mapped to the bottom of the colormap"`
+	MaxVal    float32     `desc:"value mapped to the top of the colormap"`
+	AutoScale bool        `desc:"set Min / MaxVal automatically from the data"`
+	Map       *ColorMap   `desc:"the colormap used to render the values"`
+	ShowBar   bool        `desc:"show the colorbar legend at the right"`
+	cellImg   *image.RGBA // cached cell colors, one pixel per cell
+	sclImg    *image.RGBA // cell image scaled to the current display size
+	sclDirty  bool        // sclImg needs to be recomputed
+	hoverCell image.Point // cell under the pointer
+	hasHover  bool        // pointer is over a cell
+}
+
+var KiT_HeatMapView = kit.Types.AddType(&HeatMapView{}, HeatMapViewProps)
+
+// AddNewHeatMapView adds a new heatmap view to given parent node, with given name.
+func AddNewHeatMapView(parent ki.Ki, name string) *HeatMapView {
+	hm := parent.AddNewChild(KiT_HeatMapView, name).(*HeatMapView)
+	hm.AutoScale = true
+	hm.ShowBar = true
+	return hm
+}
+
+var HeatMapViewProps = ki.Props{
+	"EnumType:Flag":    gi.KiT_NodeFlags,
+	"max-width":        -1,
+	"max-height":       -1,
+	"background-color": &gi.Prefs.Colors.Background,
+}
+
+// NRows returns the number of rows in the matrix
+func (hm *HeatMapView) NRows() int {
+	return len(hm.Mat)
+}
+
+// NCols returns the number of columns in the matrix
+func (hm *HeatMapView) NCols() int {
+	if len(hm.Mat) == 0 {
+		return 0
+	}
+	return len(hm.Mat[0])
+}
+
+// SetMat sets the full matrix of values and re-renders all the cells
+func (hm *HeatMapView) SetMat(mat [][]float32) {
+	hm.Mat = mat
+	if hm.Map == nil {
+		hm.Map = AvailColorMaps["Viridis"]
+	}
+	if hm.AutoScale {
+		hm.RangeFromData()
+	}
+	nr, nc := hm.NRows(), hm.NCols()
+	hm.cellImg = image.NewRGBA(image.Rect(0, 0, nc, nr))
+	hm.RenderCells(0, 0, nr, nc)
+	hm.UpdateSig()
+}
+
+// SetColorMap sets the colormap used to render the values, and re-renders
+func (hm *HeatMapView) SetColorMap(cmap *ColorMap) {
+	hm.Map = cmap
+	hm.RenderCells(0, 0, hm.NRows(), hm.NCols())
+	hm.UpdateSig()
+}
+
+// SetBlock updates the sub-block of the matrix starting at given row, col
+// with given values, only re-rendering the affected cells
+func (hm *HeatMapView) SetBlock(row, col int, block [][]float32) {
+	nr, nc := hm.NRows(), hm.NCols()
+	mxr, mxc := row, col
+	for ri, brow := range block {
+		r := row + ri
+		if r < 0 || r >= nr {
+			continue
+		}
+		for ci, v := range brow {
+			c := col + ci
+			if c < 0 || c >= nc {
+				continue
+			}
+			hm.Mat[r][c] = v
+			mxr = ints.MaxInt(mxr, r+1)
+			mxc = ints.MaxInt(mxc, c+1)
+		}
+	}
+	hm.RenderCells(row, col, mxr, mxc)
+	hm.UpdateSig()
+}
+
+// RangeFromData sets MinVal, MaxVal from the data range
+func (hm *HeatMapView) RangeFromData() {
+	got := false
+	for _, row := range hm.Mat {
+		for _, v := range row {
+			if !got {
+				hm.MinVal, hm.MaxVal = v, v
+				got = true
+				continue
+			}
+			hm.MinVal = math32.Min(hm.MinVal, v)
+			hm.MaxVal = math32.Max(hm.MaxVal, v)
+		}
+	}
+	if !got {
+		hm.MinVal, hm.MaxVal = 0, 1
+	}
+	if hm.MaxVal == hm.MinVal {
+		hm.MaxVal = hm.MinVal + 1
+	}
+}
+
+// RenderCells updates the cached cell colors for cells in rows [r0, r1)
+// and columns [c0, c1)
+func (hm *HeatMapView) RenderCells(r0, c0, r1, c1 int) {
+	if hm.cellImg == nil || hm.Map == nil {
+		return
+	}
+	rng := hm.MaxVal - hm.MinVal
+	for r := r0; r < r1 && r < hm.NRows(); r++ {
+		for c := c0; c < c1 && c < hm.NCols(); c++ {
+			nv := (hm.Mat[r][c] - hm.MinVal) / rng
+			clr := hm.Map.Map(float64(nv))
+			hm.cellImg.SetRGBA(c, r, color.RGBA{clr.R, clr.G, clr.B, clr.A})
+		}
+	}
+	hm.sclDirty = true
+}
+
+// HeatMapBarWd is the width of the colorbar legend, in pixels
+var HeatMapBarWd = float32(20)
+
+// CellBox returns the area in window pixels where the cells are drawn,
+// excluding the colorbar
+func (hm *HeatMapView) CellBox() mat32.Box2 {
+	pos := hm.LayData.AllocPos
+	sz := hm.LayData.AllocSize
+	max := pos.Add(sz)
+	if hm.ShowBar {
+		max.X -= HeatMapBarWd + 46 // bar and value labels
+	}
+	if max.X < pos.X+1 {
+		max.X = pos.X + 1
+	}
+	if max.Y < pos.Y+1 {
+		max.Y = pos.Y + 1
+	}
+	return mat32.Box2{Min: pos, Max: max}
+}
+
+// PixToCell returns the matrix cell at given window pixel point -- false if
+// outside the cells
+func (hm *HeatMapView) PixToCell(pt mat32.Vec2) (image.Point, bool) {
+	box := hm.CellBox()
+	nr, nc := hm.NRows(), hm.NCols()
+	if nr == 0 || nc == 0 || !box.ContainsPoint(pt) {
+		return image.Point{}, false
+	}
+	c := int((pt.X - box.Min.X) / (box.Max.X - box.Min.X) * float32(nc))
+	r := int((pt.Y - box.Min.Y) / (box.Max.Y - box.Min.Y) * float32(nr))
+	if c >= nc || r >= nr {
+		return image.Point{}, false
+	}
+	return image.Point{c, r}, true
+}
+
+func (hm *HeatMapView) MouseMoveEvent() {
+	hm.ConnectEvent(oswin.MouseMoveEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.MoveEvent)
+		me.SetProcessed()
+		hmm := recv.Embed(KiT_HeatMapView).(*HeatMapView)
+		cell, has := hmm.PixToCell(mat32.NewVec2FmPoint(me.Pos()))
+		if has != hmm.hasHover || cell != hmm.hoverCell {
+			hmm.hoverCell = cell
+			hmm.hasHover = has
+			hmm.UpdateSig()
+		}
+	})
+}
+
+func (hm *HeatMapView) ConnectEvents2D() {
+	hm.MouseMoveEvent()
+	hm.HoverTooltipEvent()
+}
+
+// RenderText renders given string at given window pixel position
+func (hm *HeatMapView) RenderText(s string, pos mat32.Vec2) {
+	rs := &hm.Viewport.Render
+	sty := &hm.Sty
+	fst := sty.Font
+	fst.BgColor.SetColor(nil)
+	var tr gi.TextRender
+	tr.SetString(s, &fst, &sty.UnContext, &sty.Text, true, 0, 0)
+	tr.Render(rs, pos)
+}
+
+// RenderHeatMap does the full rendering of the heatmap
+func (hm *HeatMapView) RenderHeatMap() {
+	if hm.cellImg == nil || hm.NRows() == 0 {
+		return
+	}
+	rs := &hm.Viewport.Render
+	rs.Lock()
+	pc := &rs.Paint
+	box := hm.CellBox()
+	bsz := image.Point{int(box.Max.X - box.Min.X), int(box.Max.Y - box.Min.Y)}
+	if hm.sclImg == nil || hm.sclImg.Bounds().Size() != bsz {
+		hm.sclImg = image.NewRGBA(image.Rectangle{Max: bsz})
+		hm.sclDirty = true
+	}
+	if hm.sclDirty {
+		draw.NearestNeighbor.Scale(hm.sclImg, hm.sclImg.Bounds(), hm.cellImg, hm.cellImg.Bounds(), draw.Src, nil)
+		hm.sclDirty = false
+	}
+	pc.DrawImage(rs, hm.sclImg, int(box.Min.X), int(box.Min.Y))
+
+	if hm.ShowBar && hm.Map != nil {
+		bx := box.Max.X + 4
+		bht := box.Max.Y - box.Min.Y
+		inc := math32.Ceil(bht / 100)
+		if inc < 2 {
+			inc = 2
+		}
+		for p := float32(0); p < bht; p += inc {
+			val := 1 - p/(bht-1)
+			clr := hm.Map.Map(float64(val))
+			pr := mat32.Vec2{bx, box.Min.Y + p}
+			sr := mat32.Vec2{HeatMapBarWd, inc}
+			pc.FillBoxColor(rs, pr, sr, clr)
+		}
+	}
+	rs.Unlock()
+
+	if hm.ShowBar {
+		lx := box.Max.X + HeatMapBarWd + 8
+		hm.RenderText(fmt.Sprintf("%.4g", hm.MaxVal), mat32.Vec2{lx, box.Min.Y})
+		hm.RenderText(fmt.Sprintf("%.4g", hm.MinVal), mat32.Vec2{lx, box.Max.Y - hm.Sty.Font.Face.Metrics.Height})
+	}
+	if hm.hasHover {
+		cell := hm.hoverCell
+		txt := fmt.Sprintf("[%d, %d] = %.4g", cell.Y, cell.X, hm.Mat[cell.Y][cell.X])
+		hm.RenderText(txt, mat32.Vec2{box.Min.X + 4, box.Min.Y})
+	}
+}
+
+func (hm *HeatMapView) Render2D() {
+	if hm.FullReRenderIfNeeded() {
+		return
+	}
+	if hm.PushBounds() {
+		hm.This().(gi.Node2D).ConnectEvents2D()
+		hm.RenderHeatMap()
+		hm.Render2DChildren()
+		hm.PopBounds()
+	} else {
+		hm.DisconnectAllEvents(gi.RegPri)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd,!solaris
+
+package giv
+
+import (
+	"io/ioutil"
+)
+
+// MmapSupported is true if memory-mapped file loading is available on this
+// platform -- otherwise MmapFile falls back on reading the whole file
+const MmapSupported = false
+
+// MmapFile returns the contents of given file -- memory mapping is not
+// supported on this platform so the file is read fully
+func MmapFile(fname string) ([]byte, error) {
+	return ioutil.ReadFile(fname)
+}
+
+// MunmapFile releases a mapping returned by MmapFile -- no-op on this
+// platform
+func MunmapFile(b []byte) error {
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build linux darwin dragonfly freebsd netbsd openbsd solaris
+
+package giv
+
+import (
+	"os"
+	"syscall"
+)
+
+// MmapSupported is true if memory-mapped file loading is available on this
+// platform -- otherwise MmapFile falls back on reading the whole file
+const MmapSupported = true
+
+// MmapFile memory-maps the given file read-only, returning its contents as
+// a byte slice that is paged in lazily by the OS -- MunmapFile must be
+// called to release it, and the bytes must not be modified
+func MmapFile(fname string) ([]byte, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	sz := int(fi.Size())
+	if sz == 0 {
+		return []byte{}, nil
+	}
+	return syscall.Mmap(int(f.Fd()), 0, sz, syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// MunmapFile releases a mapping returned by MmapFile
+func MunmapFile(b []byte) error {
+	if cap(b) == 0 {
+		return nil
+	}
+	return syscall.Munmap(b)
+}
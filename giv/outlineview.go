@@ -0,0 +1,206 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+	"github.com/goki/pi/syms"
+)
+
+// OutlineItem is one symbol in a document outline -- a flattened view of
+// the symbol hierarchy, with Depth giving the nesting level
+type OutlineItem struct {
+	Name   string     `desc:"display name of the symbol, indented by nesting depth"`
+	Kind   string     `desc:"kind of symbol (function, type, field, etc)"`
+	Detail string     `desc:"additional detail, e.g., a function signature"`
+	Reg    TextRegion `view:"-" desc:"region of the symbol in the buffer"`
+	Depth  int        `view:"-" desc:"nesting depth in the symbol hierarchy"`
+}
+
+// OutlineFunc is a function that returns the outline symbols for given
+// buffer -- can be set on TextBuf.OutlineFunc to provide symbols from an
+// external source such as a language server, instead of the default pi
+// parser symbols
+type OutlineFunc func(tb *TextBuf) []OutlineItem
+
+// Outline returns the document outline symbols for this buffer, from the
+// OutlineFunc if set, and otherwise from the pi parser symbols, ordered by
+// position in the file
+func (tb *TextBuf) Outline() []OutlineItem {
+	if tb.OutlineFunc != nil {
+		return tb.OutlineFunc(tb)
+	}
+	var its []OutlineItem
+	tb.PiState.SymsMu.RLock()
+	its = outlineSyms(its, tb.PiState.Syms, 0)
+	tb.PiState.SymsMu.RUnlock()
+	return its
+}
+
+// outlineSyms appends the symbols in given map to the outline items,
+// recursively including children, at given depth
+func outlineSyms(its []OutlineItem, sm syms.SymMap, depth int) []OutlineItem {
+	var sl []*syms.Symbol
+	for _, sy := range sm {
+		sl = append(sl, sy)
+	}
+	sort.Slice(sl, func(i, j int) bool {
+		if sl[i].Region.St.Ln != sl[j].Region.St.Ln {
+			return sl[i].Region.St.Ln < sl[j].Region.St.Ln
+		}
+		return sl[i].Region.St.Ch < sl[j].Region.St.Ch
+	})
+	for _, sy := range sl {
+		reg := sy.Region
+		it := OutlineItem{
+			Name:   strings.Repeat("  ", depth) + sy.Name,
+			Kind:   sy.Kind.String(),
+			Detail: sy.Detail,
+			Reg:    NewTextRegion(reg.St.Ln, reg.St.Ch, reg.Ed.Ln, reg.Ed.Ch),
+			Depth:  depth,
+		}
+		its = append(its, it)
+		if len(sy.Children) > 0 {
+			its = outlineSyms(its, sy.Children, depth+1)
+		}
+	}
+	return its
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    OutlineView
+
+// OutlineView is a document structure panel showing the outline symbols of
+// a TextBuf -- clicking an item jumps the editor to the symbol, and for
+// views connected via ConnectView, the item containing the cursor is
+// selected as the cursor moves
+type OutlineView struct {
+	gi.Frame
+	Buf    *TextBuf      `json:"-" xml:"-" desc:"the text buffer whose outline is shown"`
+	Items  []OutlineItem `json:"-" xml:"-" desc:"the current outline items"`
+	CurIdx int           `json:"-" xml:"-" desc:"index of the item containing the cursor"`
+}
+
+var KiT_OutlineView = kit.Types.AddType(&OutlineView{}, OutlineViewProps)
+
+// AddNewOutlineView adds a new outline view to given parent node, with given name.
+func AddNewOutlineView(parent ki.Ki, name string) *OutlineView {
+	return parent.AddNewChild(KiT_OutlineView, name).(*OutlineView)
+}
+
+var OutlineViewProps = ki.Props{
+	"EnumType:Flag":    gi.KiT_NodeFlags,
+	"max-width":        -1,
+	"max-height":       -1,
+	"background-color": &gi.Prefs.Colors.Background,
+}
+
+// SetBuf sets the buffer whose outline is shown, and updates the display
+func (ov *OutlineView) SetBuf(tb *TextBuf) {
+	if ov.Buf != nil && ov.Buf != tb {
+		ov.Buf.TextBufSig.Disconnect(ov.This())
+	}
+	ov.Buf = tb
+	if tb != nil {
+		tb.TextBufSig.Connect(ov.This(), OutlineViewBufSigRecv)
+	}
+	ov.Config()
+	ov.UpdateOutline()
+}
+
+// ConnectView connects to cursor movement in given text view, so the
+// outline selection tracks the symbol containing the cursor
+func (ov *OutlineView) ConnectView(tv *TextView) {
+	tv.TextViewSig.Connect(ov.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(TextViewCursorMoved) {
+			ovv := recv.Embed(KiT_OutlineView).(*OutlineView)
+			ovv.CursorMoved(data.(TextPos))
+		}
+	})
+}
+
+// Config configures the table view
+func (ov *OutlineView) Config() {
+	ov.Lay = gi.LayoutVert
+	ov.SetStretchMax()
+	config := kit.TypeAndNameList{}
+	config.Add(KiT_TableView, "outline")
+	mods, updt := ov.ConfigChildren(config, true)
+	if mods {
+		tview := ov.TableView()
+		tview.SetStretchMax()
+		tview.SetInactive()
+		tview.SliceViewSig.Connect(ov.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			if sig == int64(SliceViewDoubleClicked) {
+				ovv := recv.Embed(KiT_OutlineView).(*OutlineView)
+				ovv.ShowItem(data.(int))
+			}
+		})
+	}
+	ov.UpdateEnd(updt)
+}
+
+// TableView returns the table view listing the outline items
+func (ov *OutlineView) TableView() *TableView {
+	return ov.ChildByName("outline", 0).(*TableView)
+}
+
+// UpdateOutline updates the item list from the buffer's current symbols
+func (ov *OutlineView) UpdateOutline() {
+	if ov.Buf == nil {
+		return
+	}
+	ov.Items = ov.Buf.Outline()
+	ov.CurIdx = -1
+	ov.TableView().SetSlice(&ov.Items)
+}
+
+// ShowItem shows the location of outline item at given index in the
+// buffer's first view
+func (ov *OutlineView) ShowItem(idx int) {
+	if ov.Buf == nil || idx < 0 || idx >= len(ov.Items) {
+		return
+	}
+	if len(ov.Buf.Views) == 0 {
+		return
+	}
+	vw := ov.Buf.Views[0]
+	vw.SetCursorShow(ov.Items[idx].Reg.Start)
+	vw.GrabFocus()
+}
+
+// CursorMoved updates the selected item to the innermost symbol containing
+// given cursor position
+func (ov *OutlineView) CursorMoved(pos TextPos) {
+	idx := -1
+	for i, it := range ov.Items { // items are in position order -- last match is innermost
+		if pos.Ln >= it.Reg.Start.Ln && pos.Ln <= it.Reg.End.Ln {
+			idx = i
+		}
+	}
+	if idx < 0 || idx == ov.CurIdx {
+		return
+	}
+	ov.CurIdx = idx
+	tview := ov.TableView()
+	tview.UnselectAllIdxs()
+	tview.SelectIdxAction(idx, mouse.SelectOne)
+}
+
+// OutlineViewBufSigRecv receives a signal from the text buffer and updates
+// the outline when the symbols may have changed
+func OutlineViewBufSigRecv(rvwki, sbufki ki.Ki, sig int64, data interface{}) {
+	ov := rvwki.Embed(KiT_OutlineView).(*OutlineView)
+	switch TextBufSignals(sig) {
+	case TextBufNew, TextBufMarkUpdt:
+		ov.UpdateOutline()
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sort"
+)
+
+// PieceTable is a piece-table text storage engine for editing very large
+// files.  The original text is never modified (and can be a memory-mapped
+// file -- see MmapFile), with all inserted text going into a separate
+// append-only add buffer, and the current text described as a sequence of
+// pieces referring to spans of the two buffers.  Insert and delete only
+// split or remove pieces, so edits are cheap regardless of file size, and
+// the line index is built lazily and spliced incrementally after each edit.
+// This is the backing store for big-file editing, where the regular
+// line-slice representation in TextBuf is too expensive.
+type PieceTable struct {
+	Orig     []byte  `json:"-" xml:"-" desc:"original text -- never modified -- can be a memory-mapped file"`
+	Add      []byte  `json:"-" xml:"-" desc:"append-only buffer holding all inserted text"`
+	Pieces   []Piece `json:"-" xml:"-" desc:"the sequence of pieces constituting the current text"`
+	Total    int     `desc:"total length of current text in bytes"`
+	Mmapped  bool    `desc:"Orig is a memory-mapped file -- Close must be called to release it"`
+	LineOffs []int   `json:"-" xml:"-" desc:"lazily-built byte offsets of the start of each line -- nil until built -- see BuildLines"`
+}
+
+// Piece is one contiguous span of text in a PieceTable, referring to either
+// the original buffer or the add buffer
+type Piece struct {
+	InAdd bool `desc:"piece is in the add buffer, else the original"`
+	Off   int  `desc:"byte offset of start of piece in its buffer"`
+	Len   int  `desc:"length of piece in bytes"`
+}
+
+// NewPieceTable returns a new piece table on given original text
+func NewPieceTable(orig []byte) *PieceTable {
+	pt := &PieceTable{Orig: orig, Total: len(orig)}
+	if len(orig) > 0 {
+		pt.Pieces = []Piece{{Off: 0, Len: len(orig)}}
+	}
+	return pt
+}
+
+// OpenPieceTable opens given file as the original text of a piece table,
+// memory-mapping it when supported so opening is instant and pages are
+// loaded on demand, and otherwise reading it fully
+func OpenPieceTable(fname string) (*PieceTable, error) {
+	b, err := MmapFile(fname)
+	if err == nil {
+		pt := NewPieceTable(b)
+		pt.Mmapped = MmapSupported
+		return pt, nil
+	}
+	b, err = ioutil.ReadFile(fname)
+	if err != nil {
+		return nil, err
+	}
+	return NewPieceTable(b), nil
+}
+
+// Close releases the memory-mapped original file if there is one -- the
+// piece table must not be used after this
+func (pt *PieceTable) Close() error {
+	if !pt.Mmapped {
+		return nil
+	}
+	pt.Mmapped = false
+	orig := pt.Orig
+	pt.Orig = nil
+	pt.Pieces = nil
+	pt.LineOffs = nil
+	pt.Total = 0
+	return MunmapFile(orig)
+}
+
+// Len returns the total length of the current text in bytes
+func (pt *PieceTable) Len() int {
+	return pt.Total
+}
+
+// pieceBytes returns the bytes of given piece
+func (pt *PieceTable) pieceBytes(p Piece) []byte {
+	if p.InAdd {
+		return pt.Add[p.Off : p.Off+p.Len]
+	}
+	return pt.Orig[p.Off : p.Off+p.Len]
+}
+
+// findPiece returns the index of the piece containing given byte offset,
+// and the offset within that piece.  An offset equal to the total length
+// returns len(Pieces), 0.  Linear in the number of pieces, which stays
+// small relative to file size under interactive editing.
+func (pt *PieceTable) findPiece(off int) (int, int) {
+	for i, p := range pt.Pieces {
+		if off < p.Len {
+			return i, off
+		}
+		off -= p.Len
+	}
+	return len(pt.Pieces), 0
+}
+
+// Insert inserts given bytes at given byte offset
+func (pt *PieceTable) Insert(off int, b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	np := Piece{InAdd: true, Off: len(pt.Add), Len: len(b)}
+	pt.Add = append(pt.Add, b...)
+	pi, po := pt.findPiece(off)
+	switch {
+	case pi >= len(pt.Pieces): // at end
+		pt.Pieces = append(pt.Pieces, np)
+	case po == 0: // at piece boundary
+		pt.Pieces = append(pt.Pieces, Piece{})
+		copy(pt.Pieces[pi+1:], pt.Pieces[pi:])
+		pt.Pieces[pi] = np
+	default: // split piece
+		p := pt.Pieces[pi]
+		head := Piece{InAdd: p.InAdd, Off: p.Off, Len: po}
+		tail := Piece{InAdd: p.InAdd, Off: p.Off + po, Len: p.Len - po}
+		pt.Pieces = append(pt.Pieces, Piece{}, Piece{})
+		copy(pt.Pieces[pi+3:], pt.Pieces[pi+1:])
+		pt.Pieces[pi] = head
+		pt.Pieces[pi+1] = np
+		pt.Pieces[pi+2] = tail
+	}
+	pt.Total += len(b)
+	pt.linesInsert(off, b)
+}
+
+// Delete deletes n bytes starting at given byte offset
+func (pt *PieceTable) Delete(off, n int) {
+	if n <= 0 || off >= pt.Total {
+		return
+	}
+	if off+n > pt.Total {
+		n = pt.Total - off
+	}
+	spi, spo := pt.findPiece(off)
+	epi, epo := pt.findPiece(off + n)
+	var nps []Piece
+	nps = append(nps, pt.Pieces[:spi]...)
+	if spo > 0 {
+		p := pt.Pieces[spi]
+		nps = append(nps, Piece{InAdd: p.InAdd, Off: p.Off, Len: spo})
+	}
+	if epi < len(pt.Pieces) && epo > 0 {
+		p := pt.Pieces[epi]
+		nps = append(nps, Piece{InAdd: p.InAdd, Off: p.Off + epo, Len: p.Len - epo})
+		epi++
+	} else if epi < len(pt.Pieces) && epo == 0 {
+		// end at piece boundary -- keep from epi
+	}
+	nps = append(nps, pt.Pieces[epi:]...)
+	pt.Pieces = nps
+	pt.Total -= n
+	pt.linesDelete(off, n)
+}
+
+// Slice returns a copy of the text in byte range [st, ed)
+func (pt *PieceTable) Slice(st, ed int) []byte {
+	if ed > pt.Total {
+		ed = pt.Total
+	}
+	if st < 0 || st >= ed {
+		return nil
+	}
+	b := make([]byte, 0, ed-st)
+	off := 0
+	for _, p := range pt.Pieces {
+		pst := off
+		ped := off + p.Len
+		off = ped
+		if ped <= st {
+			continue
+		}
+		if pst >= ed {
+			break
+		}
+		pb := pt.pieceBytes(p)
+		cst := 0
+		if st > pst {
+			cst = st - pst
+		}
+		ced := p.Len
+		if ed < ped {
+			ced = ed - pst
+		}
+		b = append(b, pb[cst:ced]...)
+	}
+	return b
+}
+
+// Bytes returns a copy of the entire current text
+func (pt *PieceTable) Bytes() []byte {
+	return pt.Slice(0, pt.Total)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    Line index
+
+// BuildLines builds the line index if it is not already valid -- called
+// automatically by the line accessors.  The index holds the byte offset of
+// the start of each line.
+func (pt *PieceTable) BuildLines() {
+	if pt.LineOffs != nil {
+		return
+	}
+	offs := make([]int, 1, 64) // line 0 at offset 0
+	off := 0
+	for _, p := range pt.Pieces {
+		pb := pt.pieceBytes(p)
+		for {
+			i := bytes.IndexByte(pb, '\n')
+			if i < 0 {
+				break
+			}
+			offs = append(offs, off+i+1)
+			off += i + 1
+			pb = pb[i+1:]
+		}
+		off += len(pb)
+	}
+	pt.LineOffs = offs
+}
+
+// NumLines returns the number of lines in the current text
+func (pt *PieceTable) NumLines() int {
+	pt.BuildLines()
+	return len(pt.LineOffs)
+}
+
+// LineStart returns the byte offset of the start of given line
+func (pt *PieceTable) LineStart(ln int) int {
+	pt.BuildLines()
+	if ln < 0 || ln >= len(pt.LineOffs) {
+		return pt.Total
+	}
+	return pt.LineOffs[ln]
+}
+
+// LineEnd returns the byte offset just past the content of given line,
+// excluding the newline
+func (pt *PieceTable) LineEnd(ln int) int {
+	pt.BuildLines()
+	if ln < 0 || ln >= len(pt.LineOffs) {
+		return pt.Total
+	}
+	if ln == len(pt.LineOffs)-1 {
+		return pt.Total
+	}
+	return pt.LineOffs[ln+1] - 1
+}
+
+// Line returns a copy of the content of given line, without the newline
+func (pt *PieceTable) Line(ln int) []byte {
+	return pt.Slice(pt.LineStart(ln), pt.LineEnd(ln))
+}
+
+// OffToLine returns the line containing given byte offset
+func (pt *PieceTable) OffToLine(off int) int {
+	pt.BuildLines()
+	ln := sort.Search(len(pt.LineOffs), func(i int) bool {
+		return pt.LineOffs[i] > off
+	})
+	return ln - 1
+}
+
+// linesInsert splices the line index for an insert of given bytes at given
+// offset -- no-op if the index has not been built
+func (pt *PieceTable) linesInsert(off int, b []byte) {
+	if pt.LineOffs == nil {
+		return
+	}
+	si := sort.Search(len(pt.LineOffs), func(i int) bool {
+		return pt.LineOffs[i] >= off && pt.LineOffs[i] > 0
+	})
+	for i := si; i < len(pt.LineOffs); i++ {
+		pt.LineOffs[i] += len(b)
+	}
+	var nl []int
+	for i, c := range b {
+		if c == '\n' {
+			nl = append(nl, off+i+1)
+		}
+	}
+	if len(nl) == 0 {
+		return
+	}
+	offs := make([]int, 0, len(pt.LineOffs)+len(nl))
+	offs = append(offs, pt.LineOffs[:si]...)
+	offs = append(offs, nl...)
+	offs = append(offs, pt.LineOffs[si:]...)
+	pt.LineOffs = offs
+}
+
+// linesDelete splices the line index for a delete of n bytes at given
+// offset -- no-op if the index has not been built
+func (pt *PieceTable) linesDelete(off, n int) {
+	if pt.LineOffs == nil {
+		return
+	}
+	offs := pt.LineOffs[:0]
+	for _, lo := range pt.LineOffs {
+		switch {
+		case lo <= off || lo == 0:
+			offs = append(offs, lo)
+		case lo <= off+n: // its newline was deleted
+		default:
+			offs = append(offs, lo-n)
+		}
+	}
+	pt.LineOffs = offs
+}
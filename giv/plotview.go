@@ -0,0 +1,413 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// PlotData is one named line / point series in a PlotView
+type PlotData struct {
+	Name  string    `desc:"name of the series, shown in tooltips"`
+	X     []float32 `desc:"x values"`
+	Y     []float32 `desc:"y values -- same length as X"`
+	Color gi.Color  `desc:"line and point color"`
+}
+
+// PlotRange is the plotted range of data values along both axes
+type PlotRange struct {
+	XMin, XMax float32
+	YMin, YMax float32
+}
+
+// PlotView is an interactive XY plot of one or more data series, for data
+// exploration: hovering shows a crosshair cursor with axis readouts and the
+// nearest data point value, clicking a point emits PlotPointSelected, and
+// dragging a box zooms into that region, with double-click resetting the
+// zoom.  Note that this is not a ValueView widget.
+type PlotView struct {
+	gi.WidgetBase
+	Data      []PlotData  `desc:"the data series being plotted"`
+	Range     PlotRange   `desc:"current plotted data range -- set automatically from the data, and by box zoom"`
+	AutoRange bool        `desc:"maintain Range from the data -- turned off while zoomed, restored by ResetZoom"`
+	SelData   int         `desc:"index of data series of currently selected point, or -1"`
+	SelIdx    int         `desc:"index of currently selected point within its series, or -1"`
+	PlotSig   ki.Signal   `json:"-" xml:"-" view:"-" desc:"signal for plot events -- see PlotSignals"`
+	hoverPix  mat32.Vec2  // current mouse position in window pixels, for crosshair
+	hasHover  bool        // crosshair / readout is active
+	dragStart image.Point // box zoom drag start, in window pixels
+	dragEnd   image.Point // box zoom drag end
+	dragging  bool        // in a box-zoom drag
+}
+
+var KiT_PlotView = kit.Types.AddType(&PlotView{}, PlotViewProps)
+
+// AddNewPlotView adds a new plotview to given parent node, with given name.
+func AddNewPlotView(parent ki.Ki, name string) *PlotView {
+	pv := parent.AddNewChild(KiT_PlotView, name).(*PlotView)
+	pv.AutoRange = true
+	pv.SelData = -1
+	pv.SelIdx = -1
+	return pv
+}
+
+var PlotViewProps = ki.Props{
+	"EnumType:Flag":    gi.KiT_NodeFlags,
+	"max-width":        -1,
+	"max-height":       -1,
+	"background-color": &gi.Prefs.Colors.Background,
+}
+
+func (pv *PlotView) Disconnect() {
+	pv.WidgetBase.Disconnect()
+	pv.PlotSig.DisconnectAll()
+}
+
+// PlotSignals are signals that PlotView sends for interactive events
+type PlotSignals int64
+
+const (
+	// PlotPointSelected means a data point was clicked -- data is a
+	// PlotPoint with the series and point indexes and values
+	PlotPointSelected PlotSignals = iota
+
+	// PlotRangeChanged means the plotted range changed from box zoom or
+	// reset -- data is the current PlotRange
+	PlotRangeChanged
+
+	PlotSignalsN
+)
+
+//go:generate stringer -type=PlotSignals
+
+// PlotPoint identifies one data point in a plot, sent with PlotPointSelected
+type PlotPoint struct {
+	Data int     `desc:"index of the data series"`
+	Idx  int     `desc:"index of the point within the series"`
+	X    float32 `desc:"x value of the point"`
+	Y    float32 `desc:"y value of the point"`
+}
+
+// SetData sets the data series to plot, and updates the display
+func (pv *PlotView) SetData(data []PlotData) {
+	pv.Data = data
+	pv.SelData = -1
+	pv.SelIdx = -1
+	if pv.AutoRange {
+		pv.DataRange()
+	}
+	pv.UpdateSig()
+}
+
+// DataRange sets Range from the min / max of all the data
+func (pv *PlotView) DataRange() {
+	got := false
+	var rng PlotRange
+	for _, dt := range pv.Data {
+		for i := range dt.X {
+			x, y := dt.X[i], dt.Y[i]
+			if !got {
+				rng = PlotRange{x, x, y, y}
+				got = true
+				continue
+			}
+			rng.XMin = math32.Min(rng.XMin, x)
+			rng.XMax = math32.Max(rng.XMax, x)
+			rng.YMin = math32.Min(rng.YMin, y)
+			rng.YMax = math32.Max(rng.YMax, y)
+		}
+	}
+	if !got {
+		rng = PlotRange{0, 1, 0, 1}
+	}
+	if rng.XMax == rng.XMin {
+		rng.XMax = rng.XMin + 1
+	}
+	if rng.YMax == rng.YMin {
+		rng.YMax = rng.YMin + 1
+	}
+	pv.Range = rng
+}
+
+// ResetZoom restores the automatic data-driven range
+func (pv *PlotView) ResetZoom() {
+	pv.AutoRange = true
+	pv.DataRange()
+	pv.PlotSig.Emit(pv.This(), int64(PlotRangeChanged), pv.Range)
+	pv.UpdateSig()
+}
+
+// PlotMargins are the pixel margins around the plotting area for the axis
+// lines and tick readouts: left, bottom, top-right
+var PlotMargins = image.Point{56, 26}
+
+// PlotBox returns the plotting area in window pixels
+func (pv *PlotView) PlotBox() mat32.Box2 {
+	pos := pv.LayData.AllocPos
+	sz := pv.LayData.AllocSize
+	min := mat32.Vec2{pos.X + float32(PlotMargins.X), pos.Y + 8}
+	max := mat32.Vec2{pos.X + sz.X - 8, pos.Y + sz.Y - float32(PlotMargins.Y)}
+	if max.X < min.X+1 {
+		max.X = min.X + 1
+	}
+	if max.Y < min.Y+1 {
+		max.Y = min.Y + 1
+	}
+	return mat32.Box2{Min: min, Max: max}
+}
+
+// DataToPix returns the window pixel point for given data values
+func (pv *PlotView) DataToPix(x, y float32) mat32.Vec2 {
+	box := pv.PlotBox()
+	px := box.Min.X + (x-pv.Range.XMin)/(pv.Range.XMax-pv.Range.XMin)*(box.Max.X-box.Min.X)
+	py := box.Max.Y - (y-pv.Range.YMin)/(pv.Range.YMax-pv.Range.YMin)*(box.Max.Y-box.Min.Y)
+	return mat32.Vec2{px, py}
+}
+
+// PixToData returns the data values for given window pixel point
+func (pv *PlotView) PixToData(pt mat32.Vec2) (float32, float32) {
+	box := pv.PlotBox()
+	x := pv.Range.XMin + (pt.X-box.Min.X)/(box.Max.X-box.Min.X)*(pv.Range.XMax-pv.Range.XMin)
+	y := pv.Range.YMin + (box.Max.Y-pt.Y)/(box.Max.Y-box.Min.Y)*(pv.Range.YMax-pv.Range.YMin)
+	return x, y
+}
+
+// NearestPoint returns the data series and point index of the data point
+// nearest to given window pixel position, and its pixel distance -- false
+// if there is no data
+func (pv *PlotView) NearestPoint(pix mat32.Vec2) (int, int, float32, bool) {
+	mind := float32(0)
+	mdi, mpi := -1, -1
+	for di, dt := range pv.Data {
+		for i := range dt.X {
+			pp := pv.DataToPix(dt.X[i], dt.Y[i])
+			d := pp.DistTo(pix)
+			if mdi < 0 || d < mind {
+				mind = d
+				mdi, mpi = di, i
+			}
+		}
+	}
+	return mdi, mpi, mind, mdi >= 0
+}
+
+// ZoomToBox zooms the plotted range to given box in window pixels
+func (pv *PlotView) ZoomToBox(st, ed image.Point) {
+	x0, y0 := pv.PixToData(mat32.Vec2{float32(st.X), float32(st.Y)})
+	x1, y1 := pv.PixToData(mat32.Vec2{float32(ed.X), float32(ed.Y)})
+	rng := PlotRange{math32.Min(x0, x1), math32.Max(x0, x1), math32.Min(y0, y1), math32.Max(y0, y1)}
+	if rng.XMax-rng.XMin <= 0 || rng.YMax-rng.YMin <= 0 {
+		return
+	}
+	pv.Range = rng
+	pv.AutoRange = false
+	pv.PlotSig.Emit(pv.This(), int64(PlotRangeChanged), pv.Range)
+	pv.UpdateSig()
+}
+
+// SelectPoint selects the data point nearest to given window pixel
+// position, if within a reasonable distance, and emits PlotPointSelected
+func (pv *PlotView) SelectPoint(pix mat32.Vec2) {
+	di, ix, dist, got := pv.NearestPoint(pix)
+	if !got || dist > 20 {
+		pv.SelData = -1
+		pv.SelIdx = -1
+		pv.UpdateSig()
+		return
+	}
+	pv.SelData = di
+	pv.SelIdx = ix
+	dt := &pv.Data[di]
+	pv.PlotSig.Emit(pv.This(), int64(PlotPointSelected), PlotPoint{Data: di, Idx: ix, X: dt.X[ix], Y: dt.Y[ix]})
+	pv.UpdateSig()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    Events
+
+func (pv *PlotView) MouseEvent() {
+	pv.ConnectEvent(oswin.MouseEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		pvv := recv.Embed(KiT_PlotView).(*PlotView)
+		if me.Button != mouse.Left {
+			return
+		}
+		switch me.Action {
+		case mouse.DoubleClick:
+			me.SetProcessed()
+			pvv.ResetZoom()
+		case mouse.Press:
+			me.SetProcessed()
+			pvv.dragStart = me.Pos()
+			pvv.dragEnd = me.Pos()
+		case mouse.Release:
+			me.SetProcessed()
+			if pvv.dragging {
+				pvv.dragging = false
+				pvv.ZoomToBox(pvv.dragStart, me.Pos())
+			} else {
+				pvv.SelectPoint(mat32.NewVec2FmPoint(me.Pos()))
+			}
+		}
+	})
+}
+
+func (pv *PlotView) MouseDragEvent() {
+	pv.ConnectEvent(oswin.MouseDragEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		me.SetProcessed()
+		pvv := recv.Embed(KiT_PlotView).(*PlotView)
+		pvv.dragEnd = me.Pos()
+		ds := pvv.dragEnd.Sub(pvv.dragStart)
+		if !pvv.dragging && (ints.AbsInt(ds.X) > 4 || ints.AbsInt(ds.Y) > 4) {
+			pvv.dragging = true
+		}
+		if pvv.dragging {
+			pvv.UpdateSig()
+		}
+	})
+}
+
+func (pv *PlotView) MouseMoveEvent() {
+	pv.ConnectEvent(oswin.MouseMoveEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.MoveEvent)
+		me.SetProcessed()
+		pvv := recv.Embed(KiT_PlotView).(*PlotView)
+		pvv.hoverPix = mat32.NewVec2FmPoint(me.Pos())
+		box := pvv.PlotBox()
+		pvv.hasHover = box.ContainsPoint(pvv.hoverPix)
+		pvv.UpdateSig()
+	})
+}
+
+func (pv *PlotView) ConnectEvents2D() {
+	pv.MouseEvent()
+	pv.MouseDragEvent()
+	pv.MouseMoveEvent()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    Rendering
+
+// RenderText renders given string at given window pixel position
+func (pv *PlotView) RenderText(s string, pos mat32.Vec2) {
+	rs := &pv.Viewport.Render
+	sty := &pv.Sty
+	fst := sty.Font
+	fst.BgColor.SetColor(nil)
+	var tr gi.TextRender
+	tr.SetString(s, &fst, &sty.UnContext, &sty.Text, true, 0, 0)
+	tr.Render(rs, pos)
+}
+
+// RenderPlot does the full rendering of the plot
+func (pv *PlotView) RenderPlot() {
+	rs := &pv.Viewport.Render
+	rs.Lock()
+	pc := &rs.Paint
+	sty := &pv.Sty
+	box := pv.PlotBox()
+
+	fg := sty.Font.Color
+
+	// axes
+	pc.StrokeStyle.SetColor(fg)
+	pc.StrokeStyle.Width.Dots = 1
+	pc.DrawLine(rs, box.Min.X, box.Min.Y, box.Min.X, box.Max.Y)
+	pc.DrawLine(rs, box.Min.X, box.Max.Y, box.Max.X, box.Max.Y)
+	pc.Stroke(rs)
+
+	// data series
+	for di := range pv.Data {
+		dt := &pv.Data[di]
+		if len(dt.X) == 0 {
+			continue
+		}
+		pc.StrokeStyle.SetColor(dt.Color)
+		pc.StrokeStyle.Width.Dots = 1.5
+		pp := pv.DataToPix(dt.X[0], dt.Y[0])
+		pc.MoveTo(rs, pp.X, pp.Y)
+		for i := 1; i < len(dt.X); i++ {
+			pp = pv.DataToPix(dt.X[i], dt.Y[i])
+			pc.LineTo(rs, pp.X, pp.Y)
+		}
+		pc.Stroke(rs)
+		pc.FillStyle.SetColor(dt.Color)
+		for i := range dt.X {
+			pp = pv.DataToPix(dt.X[i], dt.Y[i])
+			r := float32(2.5)
+			if di == pv.SelData && i == pv.SelIdx {
+				r = 5
+			}
+			pc.DrawCircle(rs, pp.X, pp.Y, r)
+			pc.Fill(rs)
+		}
+	}
+
+	// box zoom rubber band
+	if pv.dragging {
+		pc.StrokeStyle.SetColor(fg)
+		pc.StrokeStyle.Width.Dots = 1
+		st, ed := pv.dragStart, pv.dragEnd
+		pc.DrawRectangle(rs, float32(st.X), float32(st.Y), float32(ed.X-st.X), float32(ed.Y-st.Y))
+		pc.Stroke(rs)
+	}
+
+	// crosshair with readouts
+	hasHover := pv.hasHover && !pv.dragging
+	var hx, hy float32
+	var ndi, nix int
+	nearOk := false
+	if hasHover {
+		pc.StrokeStyle.SetColor(fg)
+		pc.StrokeStyle.Width.Dots = 1
+		pc.DrawLine(rs, box.Min.X, pv.hoverPix.Y, box.Max.X, pv.hoverPix.Y)
+		pc.DrawLine(rs, pv.hoverPix.X, box.Min.Y, pv.hoverPix.X, box.Max.Y)
+		pc.Stroke(rs)
+		hx, hy = pv.PixToData(pv.hoverPix)
+		var dist float32
+		ndi, nix, dist, nearOk = pv.NearestPoint(pv.hoverPix)
+		nearOk = nearOk && dist <= 30
+	}
+	rs.Unlock()
+
+	// axis tick readouts -- text rendering does its own locking
+	pv.RenderText(fmt.Sprintf("%.4g", pv.Range.YMax), mat32.Vec2{pv.LayData.AllocPos.X + 2, box.Min.Y})
+	pv.RenderText(fmt.Sprintf("%.4g", pv.Range.YMin), mat32.Vec2{pv.LayData.AllocPos.X + 2, box.Max.Y - pv.Sty.Font.Face.Metrics.Height})
+	pv.RenderText(fmt.Sprintf("%.4g", pv.Range.XMin), mat32.Vec2{box.Min.X, box.Max.Y + 2})
+	pv.RenderText(fmt.Sprintf("%.4g", pv.Range.XMax), mat32.Vec2{box.Max.X - 40, box.Max.Y + 2})
+	if hasHover {
+		pv.RenderText(fmt.Sprintf("x: %.4g  y: %.4g", hx, hy), mat32.Vec2{box.Min.X + 4, box.Min.Y})
+		if nearOk {
+			dt := &pv.Data[ndi]
+			txt := fmt.Sprintf("%v: (%.4g, %.4g)", dt.Name, dt.X[nix], dt.Y[nix])
+			pv.RenderText(txt, pv.hoverPix.Add(mat32.Vec2{8, -16}))
+		}
+	}
+}
+
+func (pv *PlotView) Render2D() {
+	if pv.FullReRenderIfNeeded() {
+		return
+	}
+	if pv.PushBounds() {
+		pv.This().(gi.Node2D).ConnectEvents2D()
+		pv.RenderPlot()
+		pv.Render2DChildren()
+		pv.PopBounds()
+	} else {
+		pv.DisconnectAllEvents(gi.RegPri)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"sort"
+
+	"github.com/goki/ki/kit"
+)
+
+// Schema is a parsed JSON Schema document (the subset relevant for form
+// generation: object / string / number / integer / boolean types, enum,
+// numeric and string bounds, pattern, required, default) -- drives the
+// SchemaView editable form widget.  Instance data is the natural
+// encoding/json representation: map[string]interface{} with float64
+// numbers, so instances round-trip cleanly through json.Marshal /
+// Unmarshal.
+type Schema struct {
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Type        string             `json:"type"`
+	Properties  map[string]*Schema `json:"properties"`
+	Required    []string           `json:"required"`
+	Enum        []interface{}      `json:"enum"`
+	Default     interface{}        `json:"default"`
+	Minimum     *float64           `json:"minimum"`
+	Maximum     *float64           `json:"maximum"`
+	MinLength   *int               `json:"minLength"`
+	MaxLength   *int               `json:"maxLength"`
+	Pattern     string             `json:"pattern"`
+}
+
+// ParseSchema parses a JSON Schema document from given bytes
+func ParseSchema(b []byte) (*Schema, error) {
+	sc := &Schema{}
+	err := json.Unmarshal(b, sc)
+	if err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// OpenSchema parses a JSON Schema document from given file
+func OpenSchema(filename string) (*Schema, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSchema(b)
+}
+
+// OrderedProps returns the property names of an object schema in stable
+// (sorted) order -- JSON object keys have no inherent order
+func (sc *Schema) OrderedProps() []string {
+	nms := make([]string, 0, len(sc.Properties))
+	for nm := range sc.Properties {
+		nms = append(nms, nm)
+	}
+	sort.Strings(nms)
+	return nms
+}
+
+// IsRequired returns whether given property name is in the Required list
+func (sc *Schema) IsRequired(name string) bool {
+	for _, rq := range sc.Required {
+		if rq == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Label returns the display label for this schema: Title if set, else
+// the given property name
+func (sc *Schema) Label(name string) string {
+	if sc.Title != "" {
+		return sc.Title
+	}
+	return name
+}
+
+// ApplyDefaults fills in any missing properties of given instance map
+// that have a default value in this (object) schema, recursively for
+// nested objects
+func (sc *Schema) ApplyDefaults(m map[string]interface{}) {
+	for nm, psc := range sc.Properties {
+		if psc.Type == "object" {
+			sm, ok := m[nm].(map[string]interface{})
+			if !ok {
+				sm = map[string]interface{}{}
+				m[nm] = sm
+			}
+			psc.ApplyDefaults(sm)
+			continue
+		}
+		if _, has := m[nm]; !has && psc.Default != nil {
+			m[nm] = psc.Default
+		}
+	}
+}
+
+// ValidateValue validates given instance value against this (leaf)
+// schema, returning a descriptive error if any constraint is violated
+func (sc *Schema) ValidateValue(val interface{}) error {
+	switch sc.Type {
+	case "boolean":
+		if _, ok := val.(bool); !ok {
+			return fmt.Errorf("must be a boolean")
+		}
+	case "integer":
+		fv, ok := kit.ToFloat(val)
+		if !ok || fv != math.Trunc(fv) {
+			return fmt.Errorf("must be an integer")
+		}
+		return sc.validateNumber(fv)
+	case "number":
+		fv, ok := kit.ToFloat(val)
+		if !ok {
+			return fmt.Errorf("must be a number")
+		}
+		return sc.validateNumber(fv)
+	case "string":
+		sv, ok := val.(string)
+		if !ok {
+			return fmt.Errorf("must be a string")
+		}
+		if sc.MinLength != nil && len([]rune(sv)) < *sc.MinLength {
+			return fmt.Errorf("must be at least %v characters", *sc.MinLength)
+		}
+		if sc.MaxLength != nil && len([]rune(sv)) > *sc.MaxLength {
+			return fmt.Errorf("must be at most %v characters", *sc.MaxLength)
+		}
+		if sc.Pattern != "" {
+			re, err := regexp.Compile(sc.Pattern)
+			if err == nil && !re.MatchString(sv) {
+				return fmt.Errorf("must match pattern %v", sc.Pattern)
+			}
+		}
+	}
+	if len(sc.Enum) > 0 {
+		for _, ev := range sc.Enum {
+			if val == ev {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of the allowed values")
+	}
+	return nil
+}
+
+// validateNumber checks the numeric bounds
+func (sc *Schema) validateNumber(fv float64) error {
+	if sc.Minimum != nil && fv < *sc.Minimum {
+		return fmt.Errorf("must be >= %v", *sc.Minimum)
+	}
+	if sc.Maximum != nil && fv > *sc.Maximum {
+		return fmt.Errorf("must be <= %v", *sc.Maximum)
+	}
+	return nil
+}
+
+// ValidateMap validates given instance map against this (object) schema,
+// returning a map of property name to error for all violations,
+// including missing required properties -- nil if everything is valid.
+// Nested object errors are keyed as parent.child.
+func (sc *Schema) ValidateMap(m map[string]interface{}) map[string]error {
+	var errs map[string]error
+	adderr := func(nm string, err error) {
+		if errs == nil {
+			errs = map[string]error{}
+		}
+		errs[nm] = err
+	}
+	for _, nm := range sc.OrderedProps() {
+		psc := sc.Properties[nm]
+		val, has := m[nm]
+		if !has {
+			if sc.IsRequired(nm) {
+				adderr(nm, fmt.Errorf("required"))
+			}
+			continue
+		}
+		if psc.Type == "object" {
+			sm, ok := val.(map[string]interface{})
+			if !ok {
+				adderr(nm, fmt.Errorf("must be an object"))
+				continue
+			}
+			for snm, serr := range psc.ValidateMap(sm) {
+				adderr(nm+"."+snm, serr)
+			}
+			continue
+		}
+		if err := psc.ValidateValue(val); err != nil {
+			adderr(nm, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// SchemaView generates an editable form from a JSON Schema document
+// (equivalent to StructView for Go structs), editing a
+// map[string]interface{} instance with validation from the schema -- for
+// apps that consume dynamic configuration schemas.  Property widgets are
+// chosen from the schema types: TextField for strings, SpinBox for
+// numbers and integers (with schema bounds), CheckBox for booleans,
+// ComboBox for enums, and a nested SchemaView for objects.  Invalid
+// values are still written to the map, but are flagged on the widget and
+// reported by Validate.
+type SchemaView struct {
+	gi.Frame
+	Schema  *Schema                `desc:"the object schema that defines the form"`
+	Map     map[string]interface{} `desc:"the instance data that we are a view onto"`
+	Changed bool                   `desc:"has the value of any property changed?  updated by the widget signals"`
+	Errs    map[string]error       `json:"-" xml:"-" desc:"current validation errors, by property name (parent.child for nested objects)"`
+	ViewSig ki.Signal              `copy:"-" json:"-" xml:"-" view:"-" desc:"signal emitted when a property has been edited -- data is the property name"`
+}
+
+var KiT_SchemaView = kit.Types.AddType(&SchemaView{}, SchemaViewProps)
+
+// AddNewSchemaView adds a new schemaview to given parent node, with given name.
+func AddNewSchemaView(parent ki.Ki, name string) *SchemaView {
+	return parent.AddNewChild(KiT_SchemaView, name).(*SchemaView)
+}
+
+func (sv *SchemaView) Disconnect() {
+	sv.Frame.Disconnect()
+	sv.ViewSig.DisconnectAll()
+}
+
+var SchemaViewProps = ki.Props{
+	"EnumType:Flag":    gi.KiT_NodeFlags,
+	"background-color": &gi.Prefs.Colors.Background,
+	"color":            &gi.Prefs.Colors.Font,
+	"max-width":        -1,
+	"max-height":       -1,
+}
+
+// SetSchema sets the schema and instance map being viewed, applies
+// schema defaults for missing properties, validates, and (re)builds the
+// form -- the map must be non-nil
+func (sv *SchemaView) SetSchema(sc *Schema, m map[string]interface{}) {
+	updt := false
+	if sv.Schema != sc || sv.Map == nil {
+		updt = sv.UpdateStart()
+		sv.Schema = sc
+		sv.Map = m
+		if sc != nil && m != nil {
+			sc.ApplyDefaults(m)
+		}
+	}
+	sv.Revalidate()
+	sv.Config()
+	sv.UpdateEnd(updt)
+}
+
+// IsValid returns whether the current instance map satisfies all the
+// schema constraints
+func (sv *SchemaView) IsValid() bool {
+	return len(sv.Errs) == 0
+}
+
+// Validate re-validates the instance map against the schema and returns
+// an error summarizing all violations, nil if valid
+func (sv *SchemaView) Validate() error {
+	sv.Revalidate()
+	if len(sv.Errs) == 0 {
+		return nil
+	}
+	nms := make([]string, 0, len(sv.Errs))
+	for nm := range sv.Errs {
+		nms = append(nms, nm)
+	}
+	sort.Strings(nms)
+	es := ""
+	for i, nm := range nms {
+		if i > 0 {
+			es += "; "
+		}
+		es += fmt.Sprintf("%v: %v", nm, sv.Errs[nm])
+	}
+	return fmt.Errorf("%v", es)
+}
+
+// Revalidate updates Errs from the current map contents
+func (sv *SchemaView) Revalidate() {
+	if sv.Schema == nil || sv.Map == nil {
+		sv.Errs = nil
+		return
+	}
+	sv.Errs = sv.Schema.ValidateMap(sv.Map)
+}
+
+// Config configures the view
+func (sv *SchemaView) Config() {
+	sv.Lay = gi.LayoutVert
+	sv.SetProp("spacing", gi.StdDialogVSpaceUnits)
+	config := kit.TypeAndNameList{}
+	if sv.Schema != nil && sv.Schema.Title != "" {
+		config.Add(gi.KiT_Label, "title-lbl")
+	}
+	if sv.Schema != nil && sv.Schema.Description != "" {
+		config.Add(gi.KiT_Label, "desc-lbl")
+	}
+	config.Add(gi.KiT_Frame, "schema-grid")
+	mods, updt := sv.ConfigChildren(config, true)
+	if sv.Schema != nil {
+		if sv.Schema.Title != "" {
+			tl := sv.ChildByName("title-lbl", 0).(*gi.Label)
+			tl.SetProp("font-weight", gi.WeightBold)
+			tl.SetText(sv.Schema.Title)
+		}
+		if sv.Schema.Description != "" {
+			dl := sv.ChildByName("desc-lbl", 0).(*gi.Label)
+			dl.SetProp("color", "darker-20")
+			dl.SetText(sv.Schema.Description)
+		}
+	}
+	sv.ConfigSchemaGrid()
+	if mods {
+		sv.UpdateEnd(updt)
+	}
+}
+
+// SchemaGrid returns the grid layout widget, which contains all the
+// property labels and value widgets
+func (sv *SchemaView) SchemaGrid() *gi.Frame {
+	return sv.ChildByName("schema-grid", 0).(*gi.Frame)
+}
+
+// ConfigSchemaGrid configures the SchemaGrid for the current schema
+func (sv *SchemaView) ConfigSchemaGrid() {
+	if sv.Schema == nil || sv.Map == nil {
+		return
+	}
+	sg := sv.SchemaGrid()
+	sg.Lay = gi.LayoutGrid
+	sg.Stripes = gi.RowStripes
+	// setting a pref here is key for giving it a scrollbar in larger context
+	sg.SetMinPrefHeight(units.NewEm(1.5))
+	sg.SetMinPrefWidth(units.NewEm(10))
+	sg.SetStretchMax() // for this to work, ALL layers above need it too
+	sg.SetProp("columns", 2)
+	nms := sv.Schema.OrderedProps()
+	config := kit.TypeAndNameList{}
+	for _, nm := range nms {
+		psc := sv.Schema.Properties[nm]
+		config.Add(gi.KiT_Label, fmt.Sprintf("label-%v", nm))
+		config.Add(sv.PropWidgetType(psc), fmt.Sprintf("value-%v", nm))
+	}
+	mods, updt := sg.ConfigChildren(config, false)
+	if mods {
+		sg.SetFullReRender()
+	} else {
+		updt = sg.UpdateStart()
+	}
+	for i, nm := range nms {
+		psc := sv.Schema.Properties[nm]
+		lbl := sg.Child(i * 2).(*gi.Label)
+		lbl.Redrawable = true
+		ltxt := psc.Label(nm)
+		if sv.Schema.IsRequired(nm) {
+			ltxt += " *"
+		}
+		lbl.SetText(ltxt)
+		lbl.Tooltip = psc.Description
+		widg := sg.Child(i*2 + 1).(gi.Node2D)
+		widg.SetProp("horizontal-align", gi.AlignLeft)
+		sv.ConfigPropWidget(nm, psc, widg)
+	}
+	sg.UpdateEnd(updt)
+}
+
+// PropWidgetType returns the widget type to use for given property schema
+func (sv *SchemaView) PropWidgetType(psc *Schema) reflect.Type {
+	switch {
+	case len(psc.Enum) > 0:
+		return gi.KiT_ComboBox
+	case psc.Type == "object":
+		return KiT_SchemaView
+	case psc.Type == "boolean":
+		return gi.KiT_CheckBox
+	case psc.Type == "integer" || psc.Type == "number":
+		return gi.KiT_SpinBox
+	}
+	return gi.KiT_TextField
+}
+
+// ConfigPropWidget configures the value widget for given property from
+// the schema and current map value, connecting its edit signal to
+// SetMapValue
+func (sv *SchemaView) ConfigPropWidget(nm string, psc *Schema, widg gi.Node2D) {
+	pnm := nm
+	val := sv.Map[nm]
+	switch wd := widg.(type) {
+	case *gi.ComboBox:
+		its := make([]string, len(psc.Enum))
+		for i, ev := range psc.Enum {
+			its[i] = kit.ToString(ev)
+		}
+		wd.ItemsFromStringList(its, false, 0)
+		if val != nil {
+			wd.SetCurVal(kit.ToString(val))
+		}
+		wd.ComboSig.ConnectOnly(sv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			svv := recv.Embed(KiT_SchemaView).(*SchemaView)
+			svv.SetMapValue(pnm, svv.Schema.Properties[pnm].Enum[sig])
+		})
+	case *SchemaView:
+		sm, ok := val.(map[string]interface{})
+		if !ok {
+			sm = map[string]interface{}{}
+			sv.Map[nm] = sm
+		}
+		wd.SetSchema(psc, sm)
+		wd.ViewSig.ConnectOnly(sv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			svv := recv.Embed(KiT_SchemaView).(*SchemaView)
+			svv.Changed = true
+			svv.Revalidate()
+			svv.ViewSig.Emit(svv.This(), 0, pnm+"."+data.(string))
+		})
+	case *gi.CheckBox:
+		bv, _ := val.(bool)
+		wd.SetChecked(bv)
+		wd.ButtonSig.ConnectOnly(sv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			if sig != int64(gi.ButtonToggled) {
+				return
+			}
+			svv := recv.Embed(KiT_SchemaView).(*SchemaView)
+			svv.SetMapValue(pnm, send.(*gi.CheckBox).IsChecked())
+		})
+	case *gi.SpinBox:
+		if psc.Type == "integer" {
+			wd.Step = 1
+			wd.PageStep = 10
+		}
+		wd.SetMinMax(psc.Minimum != nil, minMaxVal(psc.Minimum), psc.Maximum != nil, minMaxVal(psc.Maximum))
+		if fv, ok := kit.ToFloat(val); ok {
+			wd.Value = float32(fv)
+		}
+		wd.SpinBoxSig.ConnectOnly(sv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			svv := recv.Embed(KiT_SchemaView).(*SchemaView)
+			fv, _ := kit.ToFloat(data)
+			if svv.Schema.Properties[pnm].Type == "integer" {
+				fv = math.Round(fv)
+			}
+			svv.SetMapValue(pnm, fv)
+		})
+	case *gi.TextField:
+		strv, _ := val.(string)
+		wd.SetText(strv)
+		wd.TextFieldSig.ConnectOnly(sv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			if sig != int64(gi.TextFieldDone) && sig != int64(gi.TextFieldDeFocused) {
+				return
+			}
+			svv := recv.Embed(KiT_SchemaView).(*SchemaView)
+			svv.SetMapValue(pnm, data.(string))
+		})
+	}
+	sv.MarkPropErr(nm, widg)
+}
+
+// minMaxVal dereferences an optional schema bound, 0 if not set
+func minMaxVal(bd *float64) float32 {
+	if bd == nil {
+		return 0
+	}
+	return float32(*bd)
+}
+
+// SetMapValue sets given property in the instance map from a widget
+// edit, re-validates, updates the widget error flag, and emits ViewSig
+// with the property name
+func (sv *SchemaView) SetMapValue(nm string, val interface{}) {
+	sv.Map[nm] = val
+	sv.Changed = true
+	sv.Revalidate()
+	sg := sv.SchemaGrid()
+	if widg := sg.ChildByName("value-"+nm, 0); widg != nil {
+		sv.MarkPropErr(nm, widg.(gi.Node2D))
+	}
+	sv.ViewSig.Emit(sv.This(), 0, nm)
+}
+
+// MarkPropErr flags (or clears) the validation error for given property
+// on its value widget, via background color and tooltip
+func (sv *SchemaView) MarkPropErr(nm string, widg gi.Node2D) {
+	wb := widg.AsWidget()
+	if wb == nil {
+		return
+	}
+	err := sv.Errs[nm]
+	if err != nil {
+		widg.SetProp("background-color", "#FFDDDD")
+		wb.Tooltip = err.Error()
+	} else {
+		widg.DeleteProp("background-color")
+		psc := sv.Schema.Properties[nm]
+		if psc != nil {
+			wb.Tooltip = psc.Description
+		}
+	}
+	widg.AsNode2D().SetFullReRender()
+	wb.UpdateSig()
+}
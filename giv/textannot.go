@@ -0,0 +1,305 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"sort"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// This file implements the annotation (diagnostics) layer for text editing:
+// external tools (compilers, linters, language servers) set ranges with a
+// severity and message on a TextBuf, which views render as underlines and
+// gutter markers, with the message shown in a tooltip on hover.  The
+// AnnotationsView panel shows all the annotations as a problems list,
+// synchronized with the buffer via the TextBufAnnotsUpdt signal.
+
+// AnnotationSeverity is the severity level of a text annotation
+type AnnotationSeverity int32
+
+const (
+	// AnnotationHint is for unobtrusive suggestions
+	AnnotationHint AnnotationSeverity = iota
+
+	// AnnotationInfo is for informational messages
+	AnnotationInfo
+
+	// AnnotationWarning is for warnings
+	AnnotationWarning
+
+	// AnnotationError is for errors
+	AnnotationError
+
+	AnnotationSeverityN
+)
+
+//go:generate stringer -type=AnnotationSeverity
+
+var KiT_AnnotationSeverity = kit.Enums.AddEnumAltLower(AnnotationSeverityN, kit.NotBitFlag, nil, "Annotation")
+
+// AnnotationColors are the color names used to render each severity of
+// annotation -- can be customized to match the color scheme
+var AnnotationColors = map[AnnotationSeverity]string{
+	AnnotationHint:    "grey",
+	AnnotationInfo:    "blue",
+	AnnotationWarning: "orange",
+	AnnotationError:   "red",
+}
+
+// AnnotationColor returns the render color for given severity
+func AnnotationColor(sev AnnotationSeverity) gi.Color {
+	clr, _ := gi.ColorFromName(AnnotationColors[sev])
+	return clr
+}
+
+// TextAnnotation is one annotation on a text buffer, from an external tool
+// such as a compiler, linter or language server
+type TextAnnotation struct {
+	Reg      TextRegion         `desc:"region of the buffer the annotation applies to"`
+	Severity AnnotationSeverity `desc:"severity of the annotation, determining its render color"`
+	Msg      string             `desc:"the message, shown in the hover tooltip and problems list"`
+	Source   string             `desc:"name of the tool that produced the annotation"`
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    TextBuf annotations
+
+// SetAnnotations sets the full list of annotations on the buffer, replacing
+// any existing ones, and signals views and problem lists to update.  The
+// regions are time-stamped so they track subsequent edits until the source
+// tool provides a fresh set.
+func (tb *TextBuf) SetAnnotations(annots []TextAnnotation) {
+	for i := range annots {
+		annots[i].Reg.TimeNow()
+	}
+	sort.Slice(annots, func(i, j int) bool {
+		return annots[i].Reg.Start.IsLess(annots[j].Reg.Start)
+	})
+	tb.Annotations = annots
+	tb.TextBufSig.Emit(tb.This(), int64(TextBufAnnotsUpdt), tb.Annotations)
+}
+
+// ClearAnnotations removes all the annotations from the buffer
+func (tb *TextBuf) ClearAnnotations() {
+	if len(tb.Annotations) == 0 {
+		return
+	}
+	tb.Annotations = nil
+	tb.TextBufSig.Emit(tb.This(), int64(TextBufAnnotsUpdt), tb.Annotations)
+}
+
+// AnnotationAtPos returns the first annotation whose region contains given
+// position, adjusted for any edits since it was set -- false if none
+func (tb *TextBuf) AnnotationAtPos(pos TextPos) (TextAnnotation, bool) {
+	for _, an := range tb.Annotations {
+		reg := tb.AdjustReg(an.Reg)
+		if reg.IsNil() {
+			continue
+		}
+		if !pos.IsLess(reg.Start) && pos.IsLess(reg.End) {
+			return an, true
+		}
+	}
+	return TextAnnotation{}, false
+}
+
+// AnnotationSevAtLine returns the maximum severity of annotations touching
+// given line, adjusted for any edits -- false if there are none
+func (tb *TextBuf) AnnotationSevAtLine(ln int) (AnnotationSeverity, bool) {
+	sev := AnnotationHint
+	got := false
+	for _, an := range tb.Annotations {
+		reg := tb.AdjustReg(an.Reg)
+		if reg.IsNil() || ln < reg.Start.Ln || ln > reg.End.Ln {
+			continue
+		}
+		if !got || an.Severity > sev {
+			sev = an.Severity
+		}
+		got = true
+	}
+	return sev, got
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    TextView rendering
+
+// RenderAnnotations renders the annotation regions as colored underlines --
+// always called within context of outer RenderLines or RenderAllLines
+func (tv *TextView) RenderAnnotations(stln, edln int) {
+	if tv.Buf == nil || len(tv.Buf.Annotations) == 0 {
+		return
+	}
+	for _, an := range tv.Buf.Annotations {
+		reg := tv.Buf.AdjustReg(an.Reg)
+		if reg.IsNil() || (stln >= 0 && (reg.Start.Ln > edln || reg.End.Ln < stln)) {
+			continue
+		}
+		tv.RenderAnnotUnderline(reg, AnnotationColor(an.Severity))
+	}
+}
+
+// RenderAnnotUnderline renders an underline in given color under each line
+// segment of given region
+func (tv *TextView) RenderAnnotUnderline(reg TextRegion, clr gi.Color) {
+	rs := &tv.Viewport.Render
+	pc := &rs.Paint
+	for ln := reg.Start.Ln; ln <= reg.End.Ln && ln < tv.NLines; ln++ {
+		if tv.LineIsFolded(ln) {
+			continue
+		}
+		st := TextPos{Ln: ln}
+		if ln == reg.Start.Ln {
+			st.Ch = reg.Start.Ch
+		}
+		ed := TextPos{Ln: ln, Ch: tv.Buf.LineLen(ln)}
+		if ln == reg.End.Ln && reg.End.Ch < ed.Ch {
+			ed.Ch = reg.End.Ch
+		}
+		if ed.Ch <= st.Ch { // zero-width (e.g., end of line) -- show one char
+			ed.Ch = st.Ch + 1
+		}
+		spos := tv.CharStartPos(st)
+		epos := tv.CharStartPos(ed)
+		spos.Y += tv.LineHeight - 2
+		if int(math32.Ceil(spos.Y)) < tv.VpBBox.Min.Y || int(math32.Floor(spos.Y)) > tv.VpBBox.Max.Y {
+			continue
+		}
+		sz := epos.Sub(spos)
+		sz.Y = 2
+		pc.FillBoxColor(rs, spos, sz, clr)
+	}
+}
+
+// HoverAnnotEvent connects to the hover event, showing the message of any
+// annotation under the pointer in a tooltip, otherwise falling back on the
+// standard widget tooltip
+func (tv *TextView) HoverAnnotEvent() {
+	tv.ConnectEvent(oswin.MouseHoverEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.HoverEvent)
+		tvv := recv.Embed(KiT_TextView).(*TextView)
+		if tvv.Buf != nil {
+			pos := tvv.PixelToCursor(me.Pos())
+			if an, has := tvv.Buf.AnnotationAtPos(pos); has {
+				me.SetProcessed()
+				gi.PopupTooltip(an.Msg, me.Pos().X, me.Pos().Y, tvv.Viewport, tvv.Nm)
+				return
+			}
+		}
+		if tvv.Tooltip != "" {
+			me.SetProcessed()
+			pos := tvv.WinBBox.Max
+			pos.X -= 20
+			gi.PopupTooltip(tvv.Tooltip, pos.X, pos.Y, tvv.Viewport, tvv.Nm)
+		}
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    AnnotationsView
+
+// AnnotationsView is a problems-list panel showing the annotations on a
+// TextBuf in a table, kept in sync with the buffer via its TextBufAnnotsUpdt
+// signal -- double-clicking an item shows that location in the buffer's
+// first view
+type AnnotationsView struct {
+	gi.Frame
+	Buf *TextBuf `json:"-" xml:"-" desc:"the text buffer whose annotations are shown"`
+}
+
+var KiT_AnnotationsView = kit.Types.AddType(&AnnotationsView{}, AnnotationsViewProps)
+
+// AddNewAnnotationsView adds a new annotations view to given parent node, with given name.
+func AddNewAnnotationsView(parent ki.Ki, name string) *AnnotationsView {
+	return parent.AddNewChild(KiT_AnnotationsView, name).(*AnnotationsView)
+}
+
+var AnnotationsViewProps = ki.Props{
+	"EnumType:Flag":    gi.KiT_NodeFlags,
+	"max-width":        -1,
+	"max-height":       -1,
+	"background-color": &gi.Prefs.Colors.Background,
+}
+
+// SetBuf sets the buffer whose annotations are shown, and updates the display
+func (av *AnnotationsView) SetBuf(tb *TextBuf) {
+	if av.Buf != nil && av.Buf != tb {
+		av.Buf.TextBufSig.Disconnect(av.This())
+	}
+	av.Buf = tb
+	if tb != nil {
+		tb.TextBufSig.Connect(av.This(), AnnotationsViewBufSigRecv)
+	}
+	av.Config()
+	av.UpdateAnnots()
+}
+
+// Config configures the table view
+func (av *AnnotationsView) Config() {
+	av.Lay = gi.LayoutVert
+	av.SetStretchMax()
+	config := kit.TypeAndNameList{}
+	config.Add(KiT_TableView, "annots")
+	mods, updt := av.ConfigChildren(config, true)
+	if mods {
+		tview := av.TableView()
+		tview.SetStretchMax()
+		tview.SetInactive()
+		tview.SliceViewSig.Connect(av.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			if sig == int64(SliceViewDoubleClicked) {
+				avv := recv.Embed(KiT_AnnotationsView).(*AnnotationsView)
+				avv.ShowAnnot(data.(int))
+			}
+		})
+	}
+	av.UpdateEnd(updt)
+}
+
+// TableView returns the table view listing the annotations
+func (av *AnnotationsView) TableView() *TableView {
+	return av.ChildByName("annots", 0).(*TableView)
+}
+
+// UpdateAnnots updates the table from the buffer's current annotations
+func (av *AnnotationsView) UpdateAnnots() {
+	if av.Buf == nil {
+		return
+	}
+	av.TableView().SetSlice(&av.Buf.Annotations)
+}
+
+// ShowAnnot shows the location of annotation at given index in the buffer's
+// first view
+func (av *AnnotationsView) ShowAnnot(idx int) {
+	if av.Buf == nil || idx < 0 || idx >= len(av.Buf.Annotations) {
+		return
+	}
+	an := av.Buf.Annotations[idx]
+	if len(av.Buf.Views) == 0 {
+		return
+	}
+	vw := av.Buf.Views[0]
+	reg := av.Buf.AdjustReg(an.Reg)
+	if reg.IsNil() {
+		return
+	}
+	vw.SetCursorShow(reg.Start)
+	vw.GrabFocus()
+}
+
+// AnnotationsViewBufSigRecv receives a signal from the text buffer and
+// updates the table when the annotations change
+func AnnotationsViewBufSigRecv(rvwki, sbufki ki.Ki, sig int64, data interface{}) {
+	av := rvwki.Embed(KiT_AnnotationsView).(*AnnotationsView)
+	if TextBufSignals(sig) == TextBufAnnotsUpdt {
+		av.UpdateAnnots()
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -139,6 +140,10 @@ type TextBuf struct {
 	PosHistory   []TextPos        `json:"-" xml:"-" desc:"history of cursor positions -- can move back through them"`
 	Complete     *gi.Complete     `json:"-" xml:"-" desc:"functions and data for text completion"`
 	SpellCorrect *gi.SpellCorrect `json:"-" xml:"-" desc:"functions and data for spelling correction"`
+	FoldsFunc    FoldsFunc        `json:"-" xml:"-" view:"-" desc:"optional function for providing language-derived code folding regions -- if nil, folding regions are computed from indentation -- see FoldRegions"`
+	OutlineFunc  OutlineFunc      `json:"-" xml:"-" view:"-" desc:"optional function for providing document outline symbols (e.g., from a language server) -- if nil, the pi parser symbols are used -- see Outline"`
+	Annotations  []TextAnnotation `json:"-" xml:"-" desc:"annotations (diagnostics) from external tools, rendered as underlines and gutter markers in views -- set via SetAnnotations"`
+	PT           *PieceTable      `json:"-" xml:"-" desc:"piece-table storage engine, used as the authoritative store for very large files (see TextBufMmapSize) -- in that case Lines / LineBytes / Markup are materialized lazily per line via EnsureLines -- nil for normal files"`
 	CurView      *TextView        `json:"-" xml:"-" desc:"current textview -- e.g., the one that initiated Complete or Correct process -- update cursor position in this view -- is reset to nil after usage always"`
 }
 
@@ -147,6 +152,7 @@ var KiT_TextBuf = kit.Types.AddType(&TextBuf{}, TextBufProps)
 func (tb *TextBuf) Disconnect() {
 	tb.Node.Disconnect()
 	tb.TextBufSig.DisconnectAll()
+	tb.CloseMmap()
 }
 
 var TextBufProps = ki.Props{
@@ -189,6 +195,10 @@ const (
 	// with a mutex
 	TextBufMarkUpdt
 
+	// TextBufAnnotsUpdt signals that the Annotations (diagnostics etc) have
+	// been updated -- views and problem lists refresh their display
+	TextBufAnnotsUpdt
+
 	TextBufSignalsN
 )
 
@@ -238,6 +248,7 @@ func (tb *TextBuf) ClearChanged() {
 // SetText sets the text to given bytes
 func (tb *TextBuf) SetText(txt []byte) {
 	tb.Defaults()
+	tb.CloseMmap()
 	tb.Txt = txt
 	tb.BytesToLines()
 	tb.Refresh()
@@ -282,6 +293,7 @@ func (tb *TextBuf) IsValidLine(ln int) bool {
 
 // Line is the concurrent-safe accessor to specific Line of Lines runes
 func (tb *TextBuf) Line(ln int) []rune {
+	tb.EnsureLines(ln, ln)
 	tb.LinesMu.RLock()
 	defer tb.LinesMu.RUnlock()
 	if ln >= tb.NLines || ln < 0 {
@@ -292,6 +304,7 @@ func (tb *TextBuf) Line(ln int) []rune {
 
 // LineLen is the concurrent-safe accessor to length of specific Line of Lines runes
 func (tb *TextBuf) LineLen(ln int) int {
+	tb.EnsureLines(ln, ln)
 	tb.LinesMu.RLock()
 	defer tb.LinesMu.RUnlock()
 	if ln >= tb.NLines || ln < 0 {
@@ -302,6 +315,7 @@ func (tb *TextBuf) LineLen(ln int) int {
 
 // BytesLine is the concurrent-safe accessor to specific Line of LineBytes
 func (tb *TextBuf) BytesLine(ln int) []byte {
+	tb.EnsureLines(ln, ln)
 	tb.LinesMu.RLock()
 	defer tb.LinesMu.RUnlock()
 	if ln >= tb.NLines || ln < 0 {
@@ -452,9 +466,28 @@ func (tb *TextBuf) Open(filename gi.FileName) error {
 	return nil
 }
 
+// TextBufMmapSize is the file size above which files are loaded through the
+// PieceTable storage engine, memory-mapped where supported (see MmapFile),
+// with syntax highlighting turned off and line contents materialized lazily
+// (see EnsureLines), so very large logs and data files open quickly
+var TextBufMmapSize = int64(10 * 1024 * 1024)
+
 // OpenFile just loads a file into the buffer -- doesn't do any markup or
 // notification -- for temp bufs
 func (tb *TextBuf) OpenFile(filename gi.FileName) error {
+	tb.CloseMmap()
+	fst, err := os.Stat(string(filename))
+	if err == nil && fst.Size() > TextBufMmapSize {
+		pt, merr := OpenPieceTable(string(filename))
+		if merr == nil {
+			tb.Txt = pt.Orig
+			tb.Hi.Off = true // markup too expensive at this size
+			tb.Filename = filename
+			tb.Stat()
+			tb.PTToLines(pt)
+			return nil
+		}
+	}
 	fp, err := os.Open(string(filename))
 	if err != nil {
 		return err
@@ -467,6 +500,21 @@ func (tb *TextBuf) OpenFile(filename gi.FileName) error {
 	return nil
 }
 
+// CloseMmap releases the piece-table storage engine and any memory-mapped
+// file text from a prior OpenFile -- called whenever the buffer gets new
+// text (OpenFile, SetText) and when it is retired (Close, Disconnect), so
+// that dropped big-file buffers do not leak their mappings
+func (tb *TextBuf) CloseMmap() {
+	if tb.PT == nil {
+		return
+	}
+	if tb.PT.Mmapped {
+		tb.Txt = nil // aliases the mapping -- stale once it is released
+	}
+	tb.PT.Close()
+	tb.PT = nil
+}
+
 // Revert re-opens text from current file, if filename set -- returns false if
 // not -- uses an optimized diff-based update to preserve existing formatting
 // -- very fast if not very different
@@ -631,6 +679,7 @@ func (tb *TextBuf) Close(afterFun func(canceled bool)) bool {
 	for _, tve := range tb.Views {
 		tve.SetBuf(nil) // automatically disconnects signals, views
 	}
+	tb.CloseMmap()
 	tb.New(1)
 	tb.Filename = ""
 	tb.ClearChanged()
@@ -704,6 +753,8 @@ func (tb *TextBuf) AutoSaveCheck() bool {
 
 // EndPos returns the ending position at end of buffer
 func (tb *TextBuf) EndPos() TextPos {
+	nln := tb.NumLines()
+	tb.EnsureLines(nln-1, nln-1)
 	tb.LinesMu.RLock()
 	defer tb.LinesMu.RUnlock()
 
@@ -933,6 +984,10 @@ func (tb *TextBuf) LinesToBytesCopy() []byte {
 	tb.LinesMu.RLock()
 	defer tb.LinesMu.RUnlock()
 
+	if tb.PT != nil { // piece table is authoritative for big files --
+		// unmaterialized lines have no LineBytes
+		return tb.PT.Bytes()
+	}
 	txt := bytes.Join(tb.LineBytes, []byte("\n"))
 	txt = append(txt, '\n')
 	return txt
@@ -968,6 +1023,84 @@ func (tb *TextBuf) BytesToLines() {
 	tb.LinesMu.Unlock()
 }
 
+// PTToLines initializes the line representation from given piece table --
+// the lazy, big-file equivalent of BytesToLines: the line index comes from
+// the piece table, but the rune / byte / markup content of each line is only
+// materialized on demand, via EnsureLines, as views and edits touch it, so
+// opening a very large file does not copy the entire text into per-line
+// slices
+func (tb *TextBuf) PTToLines(pt *PieceTable) {
+	if pt.Len() == 0 {
+		pt.Close()
+		tb.New(1)
+		return
+	}
+	nlines := pt.NumLines()
+	if nlines > 1 && pt.LineStart(nlines-1) == pt.Len() { // lines have lf at end typically
+		nlines--
+	}
+	tb.PT = pt
+	tb.New(nlines)
+	tb.LinesMu.Lock()
+	if nlines == 1 { // New materializes a single blank line -- keep it lazy
+		tb.Lines[0] = nil
+		tb.LineBytes[0] = nil
+		tb.Markup[0] = nil
+	}
+	for ln := 0; ln < nlines; ln++ {
+		tb.ByteOffs[ln] = pt.LineStart(ln)
+	}
+	tb.TotalBytes = pt.Len()
+	tb.LinesMu.Unlock()
+}
+
+// EnsureLines materializes the rune / byte / markup content of lines st..ed
+// (inclusive) from the piece table, for any not yet materialized (indicated
+// by a nil LineBytes) -- no-op unless the buffer is backed by a piece table
+// (big files -- see OpenFile).  Views and edits call this for the lines they
+// touch, so content is only converted as needed.
+func (tb *TextBuf) EnsureLines(st, ed int) {
+	if tb.PT == nil {
+		return
+	}
+	tb.LinesMu.RLock()
+	st = ints.MaxInt(st, 0)
+	ed = ints.MinInt(ed, tb.NLines-1)
+	need := false
+	for ln := st; ln <= ed; ln++ {
+		if tb.LineBytes[ln] == nil {
+			need = true
+			break
+		}
+	}
+	tb.LinesMu.RUnlock()
+	if !need {
+		return
+	}
+	tb.LinesMu.Lock()
+	tb.MarkupMu.Lock()
+	for ln := st; ln <= ed; ln++ {
+		if tb.LineBytes[ln] != nil {
+			continue
+		}
+		lb := tb.PT.Line(ln)
+		if lb == nil {
+			lb = []byte{}
+		}
+		tb.LineBytes[ln] = lb
+		tb.Lines[ln] = bytes.Runes(lb)
+		tb.Markup[ln] = HTMLEscapeBytes(lb)
+	}
+	tb.MarkupMu.Unlock()
+	tb.LinesMu.Unlock()
+}
+
+// ptByteOff returns the byte offset in the piece table of given position,
+// which must be on a materialized line -- caller must hold LinesMu
+func (tb *TextBuf) ptByteOff(pos TextPos) int {
+	return tb.PT.LineStart(pos.Ln) + len(string(tb.Lines[pos.Ln][:pos.Ch]))
+}
+
 /////////////////////////////////////////////////////////////////////////////
 //   Search
 
@@ -1018,6 +1151,7 @@ func (tb *TextBuf) Search(find []byte, ignoreCase bool) (int, []FileSearchMatch)
 	if fsz == 0 {
 		return 0, nil
 	}
+	tb.EnsureLines(0, tb.NumLines()-1) // big files: search needs full content
 	tb.LinesMu.RLock()
 	defer tb.LinesMu.RUnlock()
 	cnt := 0
@@ -1045,6 +1179,39 @@ func (tb *TextBuf) Search(find []byte, ignoreCase bool) (int, []FileSearchMatch)
 	return cnt, matches
 }
 
+// SearchRegexp looks for a string (given as compiled regular expression) within
+// buffer, with given case-sensitivity (encoded in the regexp itself), returning
+// number of occurrences and specific match position list -- column positions
+// are in runes
+func (tb *TextBuf) SearchRegexp(re *regexp.Regexp) (int, []FileSearchMatch) {
+	if re == nil {
+		return 0, nil
+	}
+	tb.EnsureLines(0, tb.NumLines()-1) // big files: search needs full content
+	tb.LinesMu.RLock()
+	defer tb.LinesMu.RUnlock()
+	cnt := 0
+	var matches []FileSearchMatch
+	for ln, rn := range tb.Lines {
+		str := string(rn)
+		fidx := re.FindAllStringIndex(str, -1)
+		if fidx == nil {
+			continue
+		}
+		for _, fi := range fidx {
+			if fi[1] == fi[0] { // exclude empty matches
+				continue
+			}
+			st := len([]rune(str[:fi[0]])) // byte to rune index
+			ed := len([]rune(str[:fi[1]]))
+			mat := NewFileSearchMatch(rn, st, ed, ln)
+			matches = append(matches, mat)
+			cnt++
+		}
+	}
+	return cnt, matches
+}
+
 /////////////////////////////////////////////////////////////////////////////
 //   TextPos, TextRegion, TextBufEdit
 
@@ -1432,6 +1599,7 @@ func (tb *TextBuf) ValidPos(pos TextPos) TextPos {
 // views after text lines have been updated.  Sets the timestamp on resulting TextBufEdit
 // to now
 func (tb *TextBuf) DeleteText(st, ed TextPos, saveUndo, signal bool) *TextBufEdit {
+	tb.EnsureLines(st.Ln, ed.Ln)
 	st = tb.ValidPos(st)
 	ed = tb.ValidPos(ed)
 	if st == ed {
@@ -1446,6 +1614,11 @@ func (tb *TextBuf) DeleteText(st, ed TextPos, saveUndo, signal bool) *TextBufEdi
 	tb.SetChanged()
 	tb.LinesMu.Lock()
 	tbe.Delete = true
+	if tb.PT != nil { // mirror into storage engine, from pre-edit offsets
+		stoff := tb.ptByteOff(st)
+		edoff := tb.ptByteOff(ed)
+		tb.PT.Delete(stoff, edoff-stoff)
+	}
 	if ed.Ln == st.Ln {
 		tb.Lines[st.Ln] = append(tb.Lines[st.Ln][:st.Ch], tb.Lines[st.Ln][ed.Ch:]...)
 		tb.LinesMu.Unlock()
@@ -1494,10 +1667,14 @@ func (tb *TextBuf) InsertText(st TextPos, text []byte, saveUndo, signal bool) *T
 	if len(tb.Lines) == 0 {
 		tb.New(1)
 	}
+	tb.EnsureLines(st.Ln, st.Ln)
 	st = tb.ValidPos(st)
 	tb.FileModCheck()
 	tb.LinesMu.Lock()
 	tb.SetChanged()
+	if tb.PT != nil { // mirror into storage engine, from pre-edit offset
+		tb.PT.Insert(tb.ptByteOff(st), text)
+	}
 	lns := bytes.Split(text, []byte("\n"))
 	sz := len(lns)
 	rs := bytes.Runes(lns[0])
@@ -1561,6 +1738,9 @@ func (tb *TextBuf) InsertText(st TextPos, text []byte, saveUndo, signal bool) *T
 
 // Region returns a TextBufEdit representation of text between start and end positions
 // returns nil if not a valid region.  sets the timestamp on the TextBufEdit to now
+// note: Region does not call EnsureLines, because it is called from within
+// the edit methods at a point where Lines has been spliced but LineBytes has
+// not yet -- edit callers materialize the affected lines up front instead
 func (tb *TextBuf) Region(st, ed TextPos) *TextBufEdit {
 	st = tb.ValidPos(st)
 	ed = tb.ValidPos(ed)
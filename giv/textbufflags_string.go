@@ -13,11 +13,11 @@ func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the stringer command to generate them again.
 	var x [1]struct{}
-	_ = x[TextBufAutoSaving-29]
-	_ = x[TextBufMarkingUp-30]
-	_ = x[TextBufChanged-31]
-	_ = x[TextBufFileModOk-32]
-	_ = x[TextBufFlagsN-33]
+	_ = x[TextBufAutoSaving-30]
+	_ = x[TextBufMarkingUp-31]
+	_ = x[TextBufChanged-32]
+	_ = x[TextBufFileModOk-33]
+	_ = x[TextBufFlagsN-34]
 }
 
 const _TextBufFlags_name = "TextBufAutoSavingTextBufMarkingUpTextBufChangedTextBufFileModOkTextBufFlagsN"
@@ -25,9 +25,9 @@ const _TextBufFlags_name = "TextBufAutoSavingTextBufMarkingUpTextBufChangedTextB
 var _TextBufFlags_index = [...]uint8{0, 17, 33, 47, 63, 76}
 
 func (i TextBufFlags) String() string {
-	i -= 29
+	i -= 30
 	if i < 0 || i >= TextBufFlags(len(_TextBufFlags_index)-1) {
-		return "TextBufFlags(" + strconv.FormatInt(int64(i+29), 10) + ")"
+		return "TextBufFlags(" + strconv.FormatInt(int64(i+30), 10) + ")"
 	}
 	return _TextBufFlags_name[_TextBufFlags_index[i]:_TextBufFlags_index[i+1]]
 }
@@ -35,7 +35,7 @@ func (i TextBufFlags) String() string {
 func StringToTextBufFlags(s string) (TextBufFlags, error) {
 	for i := 0; i < len(_TextBufFlags_index)-1; i++ {
 		if s == _TextBufFlags_name[_TextBufFlags_index[i]:_TextBufFlags_index[i+1]] {
-			return TextBufFlags(i + 29), nil
+			return TextBufFlags(i + 30), nil
 		}
 	}
 	return 0, errors.New("String: " + s + " is not a valid option for type: TextBufFlags")
@@ -0,0 +1,255 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"sort"
+)
+
+// This file implements code folding for TextView.  Foldable regions are
+// provided by the TextBuf, either from indentation structure (the default)
+// or from a language-provided FoldsFunc.  Folded regions hide all lines
+// after the first line of the region, with a + marker in the line number
+// gutter, and are driven by KeyFunFoldToggle / KeyFunFoldAll /
+// KeyFunUnfoldAll.
+
+// FoldsFunc is a function that returns the foldable regions for given
+// buffer -- can be set on TextBuf.FoldsFunc to provide language-derived
+// folding ranges instead of the default indentation-based ones
+type FoldsFunc func(tb *TextBuf) []TextRegion
+
+///////////////////////////////////////////////////////////////////////////////
+//    TextBuf folding regions
+
+// FoldLineIndent returns the effective indentation depth of given line, in
+// char widths, with tabs counting as the tab-size option -- second return
+// value is false if the line is entirely blank (whitespace only)
+func (tb *TextBuf) FoldLineIndent(ln int) (int, bool) {
+	rn := tb.Line(ln)
+	ts := tb.Opts.TabSize
+	if ts <= 0 {
+		ts = 4
+	}
+	ind := 0
+	for _, r := range rn {
+		switch r {
+		case ' ':
+			ind++
+		case '\t':
+			ind += ts
+		default:
+			return ind, true
+		}
+	}
+	return ind, false
+}
+
+// FoldRegionIndent returns the indentation-based foldable region starting at
+// given line: all subsequent lines with greater indentation (blank lines are
+// included if followed by further-indented ones) -- false if line starts no
+// region
+func (tb *TextBuf) FoldRegionIndent(ln int) (TextRegion, bool) {
+	nln := tb.NumLines()
+	if ln < 0 || ln >= nln-1 {
+		return TextRegionNil, false
+	}
+	ind, ok := tb.FoldLineIndent(ln)
+	if !ok {
+		return TextRegionNil, false
+	}
+	ed := ln
+	for li := ln + 1; li < nln; li++ {
+		lind, lok := tb.FoldLineIndent(li)
+		if !lok { // blank -- include only if a deeper line follows
+			continue
+		}
+		if lind <= ind {
+			break
+		}
+		ed = li
+	}
+	if ed == ln {
+		return TextRegionNil, false
+	}
+	reg := NewTextRegion(ln, 0, ed, tb.LineLen(ed))
+	return reg, true
+}
+
+// FoldRegions returns all the foldable regions for this buffer, using the
+// FoldsFunc if set (e.g., from language parsing), and otherwise the
+// indentation structure via FoldRegionIndent -- regions are sorted by start
+// line
+func (tb *TextBuf) FoldRegions() []TextRegion {
+	if tb.FoldsFunc != nil {
+		return tb.FoldsFunc(tb)
+	}
+	var regs []TextRegion
+	nln := tb.NumLines()
+	for ln := 0; ln < nln-1; ln++ {
+		if reg, ok := tb.FoldRegionIndent(ln); ok {
+			regs = append(regs, reg)
+		}
+	}
+	return regs
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    TextView folding
+
+// FoldedAtLine returns true if there is a fold starting at given line (i.e.,
+// the line shows the gutter fold marker and hides the rest of its region)
+func (tv *TextView) FoldedAtLine(ln int) bool {
+	for _, f := range tv.Folds {
+		if f.Start.Ln == ln {
+			return true
+		}
+	}
+	return false
+}
+
+// LineIsFolded returns true if given line is hidden within a folded region
+// (i.e., after the first line of a fold)
+func (tv *TextView) LineIsFolded(ln int) bool {
+	for _, f := range tv.Folds {
+		if ln > f.Start.Ln && ln <= f.End.Ln {
+			return true
+		}
+	}
+	return false
+}
+
+// FoldSkipPos returns a cursor position that does not fall on a hidden
+// folded line, moving in the direction indicated by the previous position:
+// forward past the end of the fold when moving down, and back up to the fold
+// start line when moving up
+func (tv *TextView) FoldSkipPos(pos, prev TextPos) TextPos {
+	if len(tv.Folds) == 0 {
+		return pos
+	}
+	for itr := 0; itr < len(tv.Folds)+1; itr++ { // each pass can land in another fold
+		fnd := false
+		for _, f := range tv.Folds {
+			if pos.Ln <= f.Start.Ln || pos.Ln > f.End.Ln {
+				continue
+			}
+			fnd = true
+			if pos.Ln >= prev.Ln && f.End.Ln+1 < tv.NLines { // moving down
+				pos.Ln = f.End.Ln + 1
+			} else {
+				pos.Ln = f.Start.Ln
+			}
+			if pos.Ch > tv.Buf.LineLen(pos.Ln) {
+				pos.Ch = tv.Buf.LineLen(pos.Ln)
+			}
+		}
+		if !fnd {
+			break
+		}
+	}
+	return pos
+}
+
+// FoldsUpdate adjusts the fold regions for any buffer edits since they were
+// created, removing any that were deleted, and keeps them sorted by start
+// line
+func (tv *TextView) FoldsUpdate() {
+	if len(tv.Folds) == 0 || tv.Buf == nil {
+		return
+	}
+	fds := tv.Folds[:0]
+	for _, f := range tv.Folds {
+		f = tv.Buf.AdjustReg(f)
+		if f != TextRegionNil && f.Start.Ln < f.End.Ln {
+			fds = append(fds, f)
+		}
+	}
+	tv.Folds = fds
+	sort.Slice(tv.Folds, func(i, j int) bool {
+		return tv.Folds[i].Start.Ln < tv.Folds[j].Start.Ln
+	})
+}
+
+// Fold folds the foldable region starting at given line -- returns true if
+// a region was folded
+func (tv *TextView) Fold(ln int) bool {
+	if tv.Buf == nil || tv.FoldedAtLine(ln) {
+		return false
+	}
+	reg, ok := tv.Buf.FoldRegionIndent(ln)
+	if tv.Buf.FoldsFunc != nil {
+		ok = false
+		for _, fr := range tv.Buf.FoldRegions() {
+			if fr.Start.Ln == ln {
+				reg = fr
+				ok = true
+				break
+			}
+		}
+	}
+	if !ok {
+		return false
+	}
+	reg.TimeNow()
+	tv.Folds = append(tv.Folds, reg)
+	tv.FoldsUpdate()
+	if tv.CursorPos.Ln > reg.Start.Ln && tv.CursorPos.Ln <= reg.End.Ln {
+		tv.SetCursor(TextPos{Ln: reg.Start.Ln})
+	}
+	tv.Refresh()
+	return true
+}
+
+// Unfold removes the fold starting at given line -- returns true if there
+// was one
+func (tv *TextView) Unfold(ln int) bool {
+	for i, f := range tv.Folds {
+		if f.Start.Ln == ln {
+			tv.Folds = append(tv.Folds[:i], tv.Folds[i+1:]...)
+			tv.Refresh()
+			return true
+		}
+	}
+	return false
+}
+
+// FoldToggle folds or unfolds the region at the current cursor position
+func (tv *TextView) FoldToggle() {
+	ln := tv.CursorPos.Ln
+	if tv.FoldedAtLine(ln) {
+		tv.Unfold(ln)
+		return
+	}
+	tv.Fold(ln)
+}
+
+// FoldAll folds all the foldable regions in the buffer (ignoring regions
+// nested within an existing fold)
+func (tv *TextView) FoldAll() {
+	if tv.Buf == nil {
+		return
+	}
+	tv.Folds = nil
+	for _, reg := range tv.Buf.FoldRegions() {
+		if tv.LineIsFolded(reg.Start.Ln) { // nested inside existing fold
+			continue
+		}
+		reg.TimeNow()
+		tv.Folds = append(tv.Folds, reg)
+	}
+	tv.FoldsUpdate()
+	if tv.LineIsFolded(tv.CursorPos.Ln) {
+		tv.SetCursor(tv.FoldSkipPos(tv.CursorPos, TextPos{Ln: tv.NLines}))
+	}
+	tv.Refresh()
+}
+
+// UnfoldAll removes all the folds
+func (tv *TextView) UnfoldAll() {
+	if len(tv.Folds) == 0 {
+		return
+	}
+	tv.Folds = nil
+	tv.Refresh()
+}
@@ -0,0 +1,360 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/goki/ki/ints"
+)
+
+// This file implements multi-cursor editing and rectangular block selection
+// for TextView.  Extra cursors are added with Control+Click or the
+// KeyFunAddCursorAbove / KeyFunAddCursorBelow key functions, and cleared
+// with Escape.  Key input edits (rune insert, backspace, delete) are applied
+// at all cursors.  Block selection is started with Alt+Click and renders /
+// copies / deletes one region per line between the start and end columns.
+
+// TextViewClipBlock indicates that the last clipboard copy / cut from a
+// TextView was a rectangular block selection -- in that case Paste does
+// PasteBlock instead of a regular insert.
+var TextViewClipBlock bool
+
+///////////////////////////////////////////////////////////////////////////////
+//    Extra cursors
+
+// HasExtraCursors returns true if there are any extra multi-editing cursors
+// beyond the main CursorPos
+func (tv *TextView) HasExtraCursors() bool {
+	return len(tv.ExtraCursors) > 0
+}
+
+// ClearExtraCursors removes all the extra multi-editing cursors
+func (tv *TextView) ClearExtraCursors() {
+	if !tv.HasExtraCursors() {
+		return
+	}
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+	tv.ExtraCursors = nil
+	tv.RenderAllLines()
+}
+
+// AddExtraCursor adds an extra multi-editing cursor at given position, if
+// not already there (and not at the main cursor)
+func (tv *TextView) AddExtraCursor(pos TextPos) {
+	if pos == tv.CursorPos {
+		return
+	}
+	for _, ec := range tv.ExtraCursors {
+		if ec == pos {
+			return
+		}
+	}
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+	tv.ExtraCursors = append(tv.ExtraCursors, pos)
+	tv.RenderLines(pos.Ln, pos.Ln)
+}
+
+// ToggleExtraCursor adds an extra multi-editing cursor at given position,
+// or removes it if one is already there -- this is the Control+Click action
+func (tv *TextView) ToggleExtraCursor(pos TextPos) {
+	for i, ec := range tv.ExtraCursors {
+		if ec == pos {
+			wupdt := tv.TopUpdateStart()
+			defer tv.TopUpdateEnd(wupdt)
+			tv.ExtraCursors = append(tv.ExtraCursors[:i], tv.ExtraCursors[i+1:]...)
+			tv.RenderLines(pos.Ln, pos.Ln)
+			return
+		}
+	}
+	tv.AddExtraCursor(pos)
+}
+
+// AllCursors returns all the cursors (main CursorPos plus ExtraCursors),
+// sorted in ascending order, along with the index of the main cursor therein
+func (tv *TextView) AllCursors() ([]TextPos, int) {
+	np := make([]TextPos, 0, len(tv.ExtraCursors)+1)
+	np = append(np, tv.CursorPos)
+	np = append(np, tv.ExtraCursors...)
+	sort.Slice(np, func(i, j int) bool {
+		return np[i].IsLess(np[j])
+	})
+	midx := 0
+	for i, p := range np {
+		if p == tv.CursorPos {
+			midx = i
+			break
+		}
+	}
+	return np, midx
+}
+
+// SetAllCursors sets the cursors from given list, with main cursor at given
+// index -- inverse of AllCursors, used after multi-cursor edits
+func (tv *TextView) SetAllCursors(np []TextPos, midx int) {
+	tv.ExtraCursors = tv.ExtraCursors[:0]
+	for i, p := range np {
+		if i == midx {
+			continue
+		}
+		if p == np[midx] { // merged into main cursor
+			continue
+		}
+		dup := false
+		for _, ec := range tv.ExtraCursors {
+			if ec == p {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			tv.ExtraCursors = append(tv.ExtraCursors, p)
+		}
+	}
+	tv.SetCursorShow(np[midx])
+	tv.SetCursorCol(tv.CursorPos)
+}
+
+// AddCursorAbove adds an extra multi-editing cursor on the line above the
+// current topmost cursor, at the same column
+func (tv *TextView) AddCursorAbove() {
+	np, _ := tv.AllCursors()
+	tp := np[0]
+	if tp.Ln <= 0 {
+		return
+	}
+	tp.Ln--
+	tp.Ch = ints.MinInt(tp.Ch, tv.Buf.LineLen(tp.Ln))
+	tv.AddExtraCursor(tp)
+}
+
+// AddCursorBelow adds an extra multi-editing cursor on the line below the
+// current bottom-most cursor, at the same column
+func (tv *TextView) AddCursorBelow() {
+	np, _ := tv.AllCursors()
+	bp := np[len(np)-1]
+	if bp.Ln >= tv.Buf.NumLines()-1 {
+		return
+	}
+	bp.Ln++
+	bp.Ch = ints.MinInt(bp.Ch, tv.Buf.LineLen(bp.Ln))
+	tv.AddExtraCursor(bp)
+}
+
+// RenderExtraCursors renders the extra multi-editing cursors within given
+// line range, as selection-colored boxes at the cursor position -- always
+// called within context of outer RenderLines or RenderAllLines
+func (tv *TextView) RenderExtraCursors(stln, edln int) {
+	for _, ec := range tv.ExtraCursors {
+		if stln >= 0 && (ec.Ln > edln || ec.Ln < stln) {
+			continue
+		}
+		tv.RenderRegionBox(NewTextRegion(ec.Ln, ec.Ch, ec.Ln, ec.Ch+1), TextViewSel)
+	}
+}
+
+// InsertAtCursors inserts given text at the main cursor and all extra
+// cursors -- if there are no extra cursors, this is just InsertAtCursor
+func (tv *TextView) InsertAtCursors(txt []byte) {
+	if !tv.HasExtraCursors() {
+		tv.InsertAtCursor(txt)
+		return
+	}
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+	bufUpdt, winUpdt, autoSave := tv.Buf.BatchUpdateStart()
+	defer tv.Buf.BatchUpdateEnd(bufUpdt, winUpdt, autoSave)
+	np, midx := tv.AllCursors()
+	for i := 0; i < len(np); i++ {
+		tbe := tv.Buf.InsertText(np[i], txt, true, true)
+		if tbe == nil {
+			continue
+		}
+		np[i] = tbe.Reg.End
+		for j := i + 1; j < len(np); j++ {
+			np[j] = tbe.AdjustPos(np[j], AdjustPosDelStart)
+		}
+	}
+	tv.SetAllCursors(np, midx)
+}
+
+// BackspaceAtCursors deletes character(s) immediately before the main cursor
+// and all extra cursors
+func (tv *TextView) BackspaceAtCursors(steps int) {
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+	bufUpdt, winUpdt, autoSave := tv.Buf.BatchUpdateStart()
+	defer tv.Buf.BatchUpdateEnd(bufUpdt, winUpdt, autoSave)
+	np, midx := tv.AllCursors()
+	for i := 0; i < len(np); i++ {
+		st := np[i]
+		for k := 0; k < steps; k++ {
+			if st.Ch > 0 {
+				st.Ch--
+			} else if st.Ln > 0 {
+				st.Ln--
+				st.Ch = tv.Buf.LineLen(st.Ln)
+			}
+		}
+		if st == np[i] {
+			continue
+		}
+		tbe := tv.Buf.DeleteText(st, np[i], true, true)
+		if tbe == nil {
+			continue
+		}
+		np[i] = st
+		for j := i + 1; j < len(np); j++ {
+			np[j] = tbe.AdjustPos(np[j], AdjustPosDelStart)
+		}
+	}
+	tv.SetAllCursors(np, midx)
+}
+
+// DeleteAtCursors deletes character(s) immediately after the main cursor
+// and all extra cursors
+func (tv *TextView) DeleteAtCursors(steps int) {
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+	bufUpdt, winUpdt, autoSave := tv.Buf.BatchUpdateStart()
+	defer tv.Buf.BatchUpdateEnd(bufUpdt, winUpdt, autoSave)
+	np, midx := tv.AllCursors()
+	for i := 0; i < len(np); i++ {
+		ed := np[i]
+		for k := 0; k < steps; k++ {
+			if ed.Ch < tv.Buf.LineLen(ed.Ln) {
+				ed.Ch++
+			} else if ed.Ln < tv.Buf.NumLines()-1 {
+				ed.Ln++
+				ed.Ch = 0
+			}
+		}
+		if ed == np[i] {
+			continue
+		}
+		tbe := tv.Buf.DeleteText(np[i], ed, true, true)
+		if tbe == nil {
+			continue
+		}
+		for j := i + 1; j < len(np); j++ {
+			np[j] = tbe.AdjustPos(np[j], AdjustPosDelStart)
+		}
+	}
+	tv.SetAllCursors(np, midx)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//    Block (rectangular) selection
+
+// BlockSelectStart switches selection into rectangular block mode -- the
+// subsequent selection region is interpreted per-line between the start and
+// end columns -- this is the Alt+Click action
+func (tv *TextView) BlockSelectStart() {
+	tv.BlockSelect = true
+}
+
+// BlockRegions returns the per-line regions of the current block selection,
+// one per line between the start and end columns of SelectReg, clamped to
+// the length of each line
+func (tv *TextView) BlockRegions() []TextRegion {
+	reg := tv.SelectReg
+	stCh := ints.MinInt(reg.Start.Ch, reg.End.Ch)
+	edCh := ints.MaxInt(reg.Start.Ch, reg.End.Ch)
+	regs := make([]TextRegion, 0, reg.End.Ln-reg.Start.Ln+1)
+	for ln := reg.Start.Ln; ln <= reg.End.Ln; ln++ {
+		ll := tv.Buf.LineLen(ln)
+		sc := ints.MinInt(stCh, ll)
+		ec := ints.MinInt(edCh, ll)
+		regs = append(regs, NewTextRegion(ln, sc, ln, ec))
+	}
+	return regs
+}
+
+// RenderBlockSelect renders the block selection as one selected background
+// box per line -- always called within context of outer RenderLines or
+// RenderAllLines
+func (tv *TextView) RenderBlockSelect() {
+	for _, reg := range tv.BlockRegions() {
+		tv.RenderRegionBox(reg, TextViewSel)
+	}
+}
+
+// BlockSelection returns the currently block-selected text as a TextBufEdit
+// with one line of text per line of the block -- nil if no selection
+func (tv *TextView) BlockSelection() *TextBufEdit {
+	if !tv.HasSelection() {
+		return nil
+	}
+	tbe := &TextBufEdit{Reg: tv.SelectReg}
+	for _, reg := range tv.BlockRegions() {
+		seg := tv.Buf.Region(reg.Start, reg.End)
+		if seg != nil && len(seg.Text) > 0 {
+			tbe.Text = append(tbe.Text, seg.Text[0])
+		} else {
+			tbe.Text = append(tbe.Text, []rune{})
+		}
+	}
+	return tbe
+}
+
+// BlockDeleteSelection deletes the block-selected text, one per-line region
+// at a time, without adding to clipboard -- returns text deleted as
+// TextBufEdit with one line of text per line of the block (nil if none)
+func (tv *TextView) BlockDeleteSelection() *TextBufEdit {
+	if !tv.HasSelection() {
+		return nil
+	}
+	bufUpdt, winUpdt, autoSave := tv.Buf.BatchUpdateStart()
+	defer tv.Buf.BatchUpdateEnd(bufUpdt, winUpdt, autoSave)
+	tbe := &TextBufEdit{Reg: tv.SelectReg}
+	for _, reg := range tv.BlockRegions() { // within-line deletes don't shift other lines
+		del := tv.Buf.DeleteText(reg.Start, reg.End, true, true)
+		if del != nil && len(del.Text) > 0 {
+			tbe.Text = append(tbe.Text, del.Text[0])
+		} else {
+			tbe.Text = append(tbe.Text, []rune{})
+		}
+	}
+	tv.SelectReset()
+	return tbe
+}
+
+// PasteBlock inserts given text as a rectangular block at the current cursor
+// position: line i of the text is inserted at cursor line + i, at the cursor
+// column, padding short lines with spaces as needed -- this is how block
+// selections are pasted back
+func (tv *TextView) PasteBlock(txt []byte) {
+	lns := bytes.Split(txt, []byte("\n"))
+	for len(lns) > 0 && len(lns[len(lns)-1]) == 0 {
+		lns = lns[:len(lns)-1]
+	}
+	if len(lns) == 0 {
+		return
+	}
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+	bufUpdt, winUpdt, autoSave := tv.Buf.BatchUpdateStart()
+	defer tv.Buf.BatchUpdateEnd(bufUpdt, winUpdt, autoSave)
+	cpos := tv.CursorPos
+	for i, lt := range lns {
+		ln := cpos.Ln + i
+		if ln >= tv.Buf.NumLines() {
+			tv.Buf.InsertText(tv.Buf.EndPos(), []byte("\n"), true, true)
+		}
+		ch := ints.MinInt(cpos.Ch, tv.Buf.LineLen(ln))
+		ll := tv.Buf.LineLen(ln)
+		if cpos.Ch > ll {
+			pad := bytes.Repeat([]byte(" "), cpos.Ch-ll)
+			tv.Buf.InsertText(TextPos{Ln: ln, Ch: ll}, pad, true, true)
+			ch = cpos.Ch
+		}
+		tv.Buf.InsertText(TextPos{Ln: ln, Ch: ch}, lt, true, true)
+	}
+	tv.SetCursorShow(TextPos{Ln: cpos.Ln, Ch: cpos.Ch + len(bytes.Runes(lns[0]))})
+	tv.SetCursorCol(tv.CursorPos)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// TextViewSplits presents the same TextBuf in multiple split TextViews.
+// The TextBuf model / TextView separation already keeps edits synchronized
+// across all the views of a buffer, while each view has its own independent
+// cursor position, selection and scrolling -- this widget manages the
+// bookkeeping of creating and removing the views in a SplitView.
+type TextViewSplits struct {
+	gi.SplitView
+	Buf *TextBuf `json:"-" xml:"-" desc:"the text buffer shown in all the views"`
+}
+
+var KiT_TextViewSplits = kit.Types.AddType(&TextViewSplits{}, TextViewSplitsProps)
+
+// AddNewTextViewSplits adds a new text view splits to given parent node, with given name.
+func AddNewTextViewSplits(parent ki.Ki, name string) *TextViewSplits {
+	return parent.AddNewChild(KiT_TextViewSplits, name).(*TextViewSplits)
+}
+
+var TextViewSplitsProps = ki.Props{
+	"EnumType:Flag": gi.KiT_NodeFlags,
+	"max-width":     -1,
+	"max-height":    -1,
+}
+
+// SetBuf sets the buffer that all the views show, configuring a single view
+// if none exist yet
+func (ts *TextViewSplits) SetBuf(tb *TextBuf) {
+	ts.Buf = tb
+	if ts.NViews() == 0 {
+		ts.SetNViews(1)
+		return
+	}
+	for i := 0; i < ts.NViews(); i++ {
+		ts.TextView(i).SetBuf(tb)
+	}
+}
+
+// NViews returns the current number of split views
+func (ts *TextViewSplits) NViews() int {
+	return len(ts.Kids)
+}
+
+// TextView returns the text view for given split index
+func (ts *TextViewSplits) TextView(idx int) *TextView {
+	return ts.Child(idx).(*gi.Layout).Child(0).(*TextView)
+}
+
+// SetNViews sets the number of split views of the buffer, adding or removing
+// views as needed, with even splits
+func (ts *TextViewSplits) SetNViews(n int) {
+	if n < 1 {
+		n = 1
+	}
+	cur := ts.NViews()
+	if n == cur {
+		return
+	}
+	updt := ts.UpdateStart()
+	ts.SetFullReRender()
+	for i := cur; i < n; i++ {
+		ly := gi.AddNewLayout(ts, fmt.Sprintf("text-lay-%d", i), gi.LayoutVert)
+		ly.SetStretchMax()
+		ly.SetMinPrefWidth(units.NewCh(20))
+		ly.SetMinPrefHeight(units.NewEm(10))
+		tv := AddNewTextView(ly, fmt.Sprintf("text-view-%d", i))
+		tv.Viewport = ts.Viewport
+		if ts.Buf != nil {
+			tv.SetBuf(ts.Buf)
+		}
+	}
+	for i := cur - 1; i >= n; i-- {
+		ts.DeleteChildAtIndex(i, true)
+	}
+	ts.EvenSplits()
+	ts.UpdateEnd(updt)
+}
+
+// SplitAction adds another split view of the buffer, copying the cursor
+// position of the last view so the new view starts in the same place
+func (ts *TextViewSplits) SplitAction() {
+	n := ts.NViews()
+	ts.SetNViews(n + 1)
+	if n > 0 {
+		ts.TextView(n).SetCursorShow(ts.TextView(n - 1).CursorPos)
+	}
+}
+
+// UnsplitAction removes the last split view of the buffer
+func (ts *TextViewSplits) UnsplitAction() {
+	ts.SetNViews(ts.NViews() - 1)
+}
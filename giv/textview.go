@@ -7,11 +7,9 @@ package giv
 import (
 	"fmt"
 	"image"
-	"image/draw"
 	"log"
 	"strings"
 	"sync"
-	"time"
 	"unicode"
 
 	"github.com/goki/gi/mat32"
@@ -61,9 +59,13 @@ type TextView struct {
 	PrevSelectReg  TextRegion                `json:"-" xml:"-" desc:"previous selection region, that was actually rendered -- needed to update render"`
 	Highlights     []TextRegion              `json:"-" xml:"-" desc:"highlighted regions, e.g., for search results"`
 	Scopelights    []TextRegion              `json:"-" xml:"-" desc:"highlighted regions, specific to scope markers"`
+	ExtraCursors   []TextPos                 `json:"-" xml:"-" desc:"extra cursors for multi-cursor editing, in addition to main CursorPos -- key input edits are applied at all cursors -- see AddExtraCursor, AddCursorAbove, AddCursorBelow"`
+	Folds          []TextRegion              `json:"-" xml:"-" desc:"folded (collapsed) regions of text -- lines after the first line of each fold are hidden -- see Fold, Unfold, FoldAll, UnfoldAll"`
+	BlockSelect    bool                      `json:"-" xml:"-" desc:"if true, SelectReg is interpreted as a rectangular block of text between the start and end columns, on each of the lines in the region"`
 	SelectMode     bool                      `json:"-" xml:"-" desc:"if true, select text as cursor moves"`
 	ForceComplete  bool                      `json:"-" xml:"-" desc:"if true, complete regardless of any disqualifying reasons"`
 	ISearch        ISearch                   `json:"-" xml:"-" desc:"interactive search data"`
+	FindBar        *FindReplaceBar           `json:"-" xml:"-" view:"-" desc:"find / replace bar attached to this view, if any -- activated by KeyFunFind / KeyFunSearch / KeyFunReplace instead of the interactive search / query-replace prompts -- see FindReplaceBar.SetTextView"`
 	QReplace       QReplace                  `json:"-" xml:"-" desc:"query replace data"`
 	TextViewSig    ki.Signal                 `json:"-" xml:"-" view:"-" desc:"signal for text view -- see TextViewSignals for the types"`
 	LinkSig        ki.Signal                 `json:"-" xml:"-" view:"-" desc:"signal for clicking on a link -- data is a string of the URL -- if nobody receiving this signal, calls TextLinkHandler then URLHandler"`
@@ -392,6 +394,8 @@ func TextViewBufSigRecv(rvwki, sbufki ki.Ki, sig int64, data interface{}) {
 	}
 	switch TextBufSignals(sig) {
 	case TextBufDone:
+	case TextBufAnnotsUpdt:
+		tv.RenderAllLines()
 	case TextBufNew:
 		tv.ResetState()
 		tv.Refresh()
@@ -518,6 +522,7 @@ func (tv *TextView) LayoutAllLines(inLayout bool) bool {
 	// fmt.Printf("layout all: %v\n", tv.Nm)
 
 	tv.NLines = tv.Buf.NumLines()
+	tv.FoldsUpdate()
 	nln := tv.NLines
 	if cap(tv.Renders) >= nln {
 		tv.Renders = tv.Renders[:nln]
@@ -533,6 +538,8 @@ func (tv *TextView) LayoutAllLines(inLayout bool) bool {
 	tv.VisSizes()
 	sz := tv.RenderSz
 
+	tv.Buf.EnsureLines(0, nln-1) // materialize any lazy big-file lines before markup lock
+
 	// fmt.Printf("rendersize: %v\n", sz)
 	sty := &tv.Sty
 	fst := sty.Font
@@ -543,13 +550,15 @@ func (tv *TextView) LayoutAllLines(inLayout bool) bool {
 	tv.Buf.MarkupMu.RLock()
 	tv.HasLinks = false
 	for ln := 0; ln < nln; ln++ {
-		tv.Renders[ln].SetHTMLPre(tv.Buf.Markup[ln], &fst, &sty.Text, &sty.UnContext, tv.CSS)
-		tv.Renders[ln].LayoutStdLR(&sty.Text, &sty.Font, &sty.UnContext, sz)
+		tv.LayoutLineRender(ln, sz, &fst)
 		if !tv.HasLinks && len(tv.Renders[ln].Links) > 0 {
 			tv.HasLinks = true
 		}
 		tv.Offs[ln] = off
 		lsz := mat32.Max(tv.Renders[ln].Size.Y, tv.LineHeight)
+		if tv.LineIsFolded(ln) {
+			lsz = 0
+		}
 		off += lsz
 		mxwd = mat32.Max(mxwd, tv.Renders[ln].Size.X)
 	}
@@ -565,6 +574,53 @@ func (tv *TextView) LayoutAllLines(inLayout bool) bool {
 	return tv.ResizeIfNeeded(nwSz)
 }
 
+// TextViewMaxLineLen is the maximum length of a line (in runes) that is laid
+// out with full markup shaping -- longer lines (e.g., in minified files) are
+// lazily rendered as truncated plain text, which keeps the UI responsive --
+// set to 0 to disable the limit
+var TextViewMaxLineLen = 4096
+
+// LayoutLineRender performs the text render setup and layout for given line,
+// using the marked-up version from the buffer, with lazy truncated shaping of
+// extremely long lines beyond TextViewMaxLineLen
+func (tv *TextView) LayoutLineRender(ln int, sz mat32.Vec2, fst *gi.FontStyle) {
+	sty := &tv.Sty
+	if TextViewMaxLineLen > 0 && tv.Buf.LineLen(ln) > TextViewMaxLineLen {
+		txt := string(tv.Buf.Line(ln)[:TextViewMaxLineLen]) + "…"
+		tv.Renders[ln].SetString(txt, fst, &sty.UnContext, &sty.Text, true, 0, 0)
+	} else {
+		tv.Renders[ln].SetHTMLPre(tv.Buf.Markup[ln], fst, &sty.Text, &sty.UnContext, tv.CSS)
+	}
+	tv.Renders[ln].LayoutStdLR(&sty.Text, &sty.Font, &sty.UnContext, sz)
+}
+
+// SoftWrap returns whether long lines are currently soft-wrapped to the view
+// width (vs. extending off to the right with horizontal scrolling)
+func (tv *TextView) SoftWrap() bool {
+	return tv.Sty.Text.HasWordWrap()
+}
+
+// SetSoftWrap sets whether long lines are soft-wrapped to the view width --
+// if off, lines extend to the right and the enclosing layout scrolls
+// horizontally -- triggers a full re-layout
+func (tv *TextView) SetSoftWrap(wrap bool) {
+	if wrap {
+		tv.SetProp("white-space", gi.WhiteSpacePreWrap)
+	} else {
+		tv.SetProp("white-space", gi.WhiteSpacePre)
+	}
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+	tv.StyleTextView()
+	tv.LayoutAllLines(false)
+	tv.RenderAllLines()
+}
+
+// SoftWrapToggle toggles between soft-wrapped and horizontally-scrolling modes
+func (tv *TextView) SoftWrapToggle() {
+	tv.SetSoftWrap(!tv.SoftWrap())
+}
+
 // SetSize updates our size only if larger than our allocation
 func (tv *TextView) SetSize() bool {
 	sty := &tv.Sty
@@ -632,11 +688,11 @@ func (tv *TextView) LayoutLines(st, ed int, isDel bool) bool {
 	mxwd := float32(tv.LinesSize.X)
 	rerend := false
 
+	tv.Buf.EnsureLines(st, ed) // materialize any lazy big-file lines before markup lock
 	tv.Buf.MarkupMu.RLock()
 	for ln := st; ln <= ed; ln++ {
 		curspans := len(tv.Renders[ln].Spans)
-		tv.Renders[ln].SetHTMLPre(tv.Buf.Markup[ln], &fst, &sty.Text, &sty.UnContext, tv.CSS)
-		tv.Renders[ln].LayoutStdLR(&sty.Text, &sty.Font, &sty.UnContext, tv.RenderSz)
+		tv.LayoutLineRender(ln, tv.RenderSz, &fst)
 		if !tv.HasLinks && len(tv.Renders[ln].Links) > 0 {
 			tv.HasLinks = true
 		}
@@ -658,6 +714,9 @@ func (tv *TextView) LayoutLines(st, ed int, isDel bool) bool {
 		for ln := ofst; ln < tv.NLines; ln++ {
 			tv.Offs[ln] = off
 			lsz := mat32.Max(tv.Renders[ln].Size.Y, tv.LineHeight)
+			if tv.LineIsFolded(ln) {
+				lsz = 0
+			}
 			off += lsz
 		}
 		extraHalf := tv.LineHeight * 0.5 * float32(tv.VisSize.Y)
@@ -713,7 +772,7 @@ func (tv *TextView) SetCursor(pos TextPos) {
 		return
 	}
 	tv.ClearScopelights()
-	tv.CursorPos = tv.Buf.ValidPos(pos)
+	tv.CursorPos = tv.FoldSkipPos(tv.Buf.ValidPos(pos), tv.CursorPos)
 	tv.CursorMovedSig()
 	txt := tv.Buf.Line(tv.CursorPos.Ln)
 	ch := tv.CursorPos.Ch
@@ -1239,6 +1298,10 @@ func (tv *TextView) CursorEndDoc() {
 
 // CursorBackspace deletes character(s) immediately before cursor
 func (tv *TextView) CursorBackspace(steps int) {
+	if tv.HasExtraCursors() {
+		tv.BackspaceAtCursors(steps)
+		return
+	}
 	wupdt := tv.TopUpdateStart()
 	defer tv.TopUpdateEnd(wupdt)
 	tv.ValidateCursor()
@@ -1258,6 +1321,10 @@ func (tv *TextView) CursorBackspace(steps int) {
 
 // CursorDelete deletes character(s) immediately after the cursor
 func (tv *TextView) CursorDelete(steps int) {
+	if tv.HasExtraCursors() {
+		tv.DeleteAtCursors(steps)
+		return
+	}
 	wupdt := tv.TopUpdateStart()
 	defer tv.TopUpdateEnd(wupdt)
 	tv.ValidateCursor()
@@ -1962,6 +2029,8 @@ func (tv *TextView) EscPressed() {
 	case tv.QReplace.On:
 		tv.QReplaceCancel()
 		tv.SetCursorShow(tv.ISearch.StartPos)
+	case tv.HasExtraCursors():
+		tv.ClearExtraCursors()
 	case tv.HasSelection():
 		tv.SelectReset()
 	default:
@@ -1991,6 +2060,9 @@ func (tv *TextView) HasSelection() bool {
 // captures start, end, and full lines in between -- nil if no selection
 func (tv *TextView) Selection() *TextBufEdit {
 	if tv.HasSelection() {
+		if tv.BlockSelect {
+			return tv.BlockSelection()
+		}
 		return tv.Buf.Region(tv.SelectReg.Start, tv.SelectReg.End)
 	}
 	return nil
@@ -2220,6 +2292,7 @@ func (tv *TextView) WordAt() (region TextRegion) {
 // SelectReset resets the selection
 func (tv *TextView) SelectReset() {
 	tv.SelectMode = false
+	tv.BlockSelect = false
 	if !tv.HasSelection() {
 		return
 	}
@@ -2253,6 +2326,7 @@ var TextViewClipHistMax = 100
 
 // TextViewClipHistAdd adds the given clipboard bytes to top of history stack
 func TextViewClipHistAdd(clip []byte) {
+	gi.TheClipHist.Add(string(clip)) // also feed the global clipboard history
 	max := TextViewClipHistMax
 	if TextViewClipHistory == nil {
 		TextViewClipHistory = make([][]byte, 0, max)
@@ -2321,6 +2395,7 @@ func (tv *TextView) Cut() *TextBufEdit {
 	wupdt := tv.TopUpdateStart()
 	defer tv.TopUpdateEnd(wupdt)
 	org := tv.SelectReg.Start
+	TextViewClipBlock = tv.BlockSelect
 	cut := tv.DeleteSelection()
 	if cut != nil {
 		cb := cut.ToBytes()
@@ -2335,6 +2410,9 @@ func (tv *TextView) Cut() *TextBufEdit {
 // DeleteSelection deletes any selected text, without adding to clipboard --
 // returns text deleted as TextBufEdit (nil if none)
 func (tv *TextView) DeleteSelection() *TextBufEdit {
+	if tv.BlockSelect {
+		return tv.BlockDeleteSelection()
+	}
 	tbe := tv.Buf.DeleteText(tv.SelectReg.Start, tv.SelectReg.End, true, true)
 	tv.SelectReset()
 	return tbe
@@ -2343,6 +2421,7 @@ func (tv *TextView) DeleteSelection() *TextBufEdit {
 // Copy copies any selected text to the clipboard, and returns that text,
 // optionally resetting the current selection
 func (tv *TextView) Copy(reset bool) *TextBufEdit {
+	TextViewClipBlock = tv.BlockSelect
 	tbe := tv.Selection()
 	if tbe == nil {
 		return nil
@@ -2365,7 +2444,11 @@ func (tv *TextView) Paste() {
 	defer tv.TopUpdateEnd(wupdt)
 	data := oswin.TheApp.ClipBoard(tv.Viewport.Win.OSWin).Read([]string{filecat.TextPlain})
 	if data != nil {
-		tv.InsertAtCursor(data.TypeData(filecat.TextPlain))
+		if TextViewClipBlock {
+			tv.PasteBlock(data.TypeData(filecat.TextPlain))
+		} else {
+			tv.InsertAtCursor(data.TypeData(filecat.TextPlain))
+		}
 		tv.SavePosHistory(tv.CursorPos)
 	}
 }
@@ -2439,6 +2522,15 @@ func (tv *TextView) MakeContextMenu(m *gi.Menu) {
 				txf.Clear()
 			})
 	}
+	wrlb := "Wrap Lines"
+	if tv.SoftWrap() {
+		wrlb = "Don't Wrap Lines"
+	}
+	m.AddAction(gi.ActOpts{Label: wrlb},
+		tv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			txf := recv.Embed(KiT_TextView).(*TextView)
+			txf.SoftWrapToggle()
+		})
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -2850,58 +2942,14 @@ func (tv *TextView) CharEndPos(pos TextPos) mat32.Vec2 {
 	return spos
 }
 
-// TextViewBlinkMu is mutex protecting TextViewBlink updating and access
-var TextViewBlinkMu sync.Mutex
-
-// TextViewBlinker is the time.Ticker for blinking cursors for text fields,
-// only one of which can be active at at a time
-var TextViewBlinker *time.Ticker
-
-// BlinkingTextView is the text field that is blinking
-var BlinkingTextView *TextView
-
 // TextViewSpriteName is the name of the window sprite used for the cursor
 var TextViewSpriteName = "giv.TextView.Cursor"
 
-// TextViewBlink is function that blinks text field cursor
-func TextViewBlink() {
-	for {
-		TextViewBlinkMu.Lock()
-		if TextViewBlinker == nil {
-			TextViewBlinkMu.Unlock()
-			return // shutdown..
-		}
-		TextViewBlinkMu.Unlock()
-		<-TextViewBlinker.C
-		TextViewBlinkMu.Lock()
-		if BlinkingTextView == nil || BlinkingTextView.This() == nil {
-			TextViewBlinkMu.Unlock()
-			continue
-		}
-		if BlinkingTextView.IsDestroyed() || BlinkingTextView.IsDeleted() {
-			BlinkingTextView = nil
-			TextViewBlinkMu.Unlock()
-			continue
-		}
-		tv := BlinkingTextView
-		if tv.Viewport == nil || !tv.HasFocus() || !tv.IsFocusActive() || !tv.This().(gi.Node2D).IsVisible() {
-			BlinkingTextView = nil
-			TextViewBlinkMu.Unlock()
-			continue
-		}
-		win := tv.ParentWindow()
-		if win == nil || win.IsResizing() || win.IsClosed() || !win.IsWindowInFocus() {
-			TextViewBlinkMu.Unlock()
-			continue
-		}
-		if win.IsUpdating() {
-			TextViewBlinkMu.Unlock()
-			continue
-		}
-		tv.BlinkOn = !tv.BlinkOn
-		tv.RenderCursor(tv.BlinkOn)
-		TextViewBlinkMu.Unlock()
-	}
+// CaretRender renders the caret on or off -- implements gi.CaretOwner for
+// the shared caret service (see gi.TheCaret)
+func (tv *TextView) CaretRender(on bool) {
+	tv.BlinkOn = on
+	tv.RenderCursor(on)
 }
 
 // StartCursor starts the cursor blinking and renders it
@@ -2909,27 +2957,8 @@ func (tv *TextView) StartCursor() {
 	if tv == nil || tv.This() == nil {
 		return
 	}
-	if !tv.This().(gi.Node2D).IsVisible() {
-		return
-	}
-	tv.BlinkOn = true
-	if gi.CursorBlinkMSec == 0 {
-		tv.RenderCursor(true)
-		return
-	}
-	TextViewBlinkMu.Lock()
-	if TextViewBlinker == nil {
-		TextViewBlinker = time.NewTicker(time.Duration(gi.CursorBlinkMSec) * time.Millisecond)
-		go TextViewBlink()
-	}
 	tv.BlinkOn = true
-	win := tv.ParentWindow()
-	if win != nil && !win.IsResizing() {
-		tv.RenderCursor(true)
-	}
-	//	fmt.Printf("set blink tv: %v\n", tv.PathUnique())
-	BlinkingTextView = tv
-	TextViewBlinkMu.Unlock()
+	gi.TheCaret.Start(tv)
 }
 
 // StopCursor stops the cursor from blinking
@@ -2937,14 +2966,7 @@ func (tv *TextView) StopCursor() {
 	if tv == nil || tv.This() == nil {
 		return
 	}
-	// if !tv.This().(gi.Node2D).IsVisible() {
-	// 	return
-	// }
-	TextViewBlinkMu.Lock()
-	if BlinkingTextView == tv {
-		BlinkingTextView = nil
-	}
-	TextViewBlinkMu.Unlock()
+	gi.TheCaret.Stop(tv)
 }
 
 // CursorBBox returns a bounding-box for a cursor at given position
@@ -2992,17 +3014,8 @@ func (tv *TextView) CursorSprite() *gi.Sprite {
 		return nil
 	}
 	sty := &tv.StateStyles[TextViewActive]
-	spnm := fmt.Sprintf("%v-%v", TextViewSpriteName, tv.FontHeight)
-	sp, ok := win.SpriteByName(spnm)
-	if !ok {
-		bbsz := image.Point{int(math32.Ceil(tv.CursorWidth.Dots)), int(math32.Ceil(tv.FontHeight))}
-		if bbsz.X < 2 { // at least 2
-			bbsz.X = 2
-		}
-		sp = win.AddNewSprite(spnm, bbsz, image.ZP)
-		draw.Draw(sp.Pixels, sp.Pixels.Bounds(), &image.Uniform{sty.Font.Color}, image.ZP, draw.Src)
-	}
-	return sp
+	spnm := fmt.Sprintf("%v-%v-%v", TextViewSpriteName, tv.FontHeight, gi.CaretShape)
+	return gi.MakeCaretSprite(win, spnm, tv.CursorWidth.Dots, tv.FontHeight, sty.Font.Face.Metrics.Ch, sty.Font.Color)
 }
 
 var TextViewDepthColors = []gi.Color{
@@ -3084,6 +3097,10 @@ func (tv *TextView) RenderSelect() {
 	if !tv.HasSelection() {
 		return
 	}
+	if tv.BlockSelect {
+		tv.RenderBlockSelect()
+		return
+	}
 	tv.RenderRegionBox(tv.SelectReg, TextViewSel)
 }
 
@@ -3316,6 +3333,9 @@ func (tv *TextView) RenderAllLinesInBounds() {
 		tv.RenderLineNosBoxAll()
 
 		for ln := stln; ln <= edln; ln++ {
+			if tv.LineIsFolded(ln) {
+				continue
+			}
 			tv.RenderLineNo(ln)
 		}
 	}
@@ -3324,6 +3344,8 @@ func (tv *TextView) RenderAllLinesInBounds() {
 	tv.RenderHighlights(stln, edln)
 	tv.RenderScopelights(stln, edln)
 	tv.RenderSelect()
+	tv.RenderExtraCursors(stln, edln)
+	tv.RenderAnnotations(stln, edln)
 	if tv.HasLineNos() {
 		tbb := tv.VpBBox
 		tbb.Min.X += int(tv.LineNoOff)
@@ -3332,6 +3354,9 @@ func (tv *TextView) RenderAllLinesInBounds() {
 		rs.Lock()
 	}
 	for ln := stln; ln <= edln; ln++ {
+		if tv.LineIsFolded(ln) {
+			continue
+		}
 		lst := pos.Y + tv.Offs[ln]
 		lp := pos
 		lp.Y = lst
@@ -3393,12 +3418,26 @@ func (tv *TextView) RenderLineNo(ln int) {
 	lfmt := fmt.Sprintf("%d", tv.LineNoDigs)
 	lfmt = "%" + lfmt + "d"
 	lnstr := fmt.Sprintf(lfmt, ln+1)
+	if tv.FoldedAtLine(ln) { // gutter marker for folded region
+		lnstr += "+"
+	}
 	tv.LineNoRender.SetString(lnstr, &fst, &sty.UnContext, &sty.Text, true, 0, 0)
 	pos := tv.RenderStartPos()
 	lst := tv.CharStartPos(TextPos{Ln: ln}).Y // note: charstart pos includes descent
 	pos.Y = lst + mat32.FromFixed(sty.Font.Face.Face.Metrics().Ascent) - +mat32.FromFixed(sty.Font.Face.Face.Metrics().Descent)
 	pos.X = float32(tv.VpBBox.Min.X) + spc
 	tv.LineNoRender.Render(rs, pos)
+	if tv.Buf != nil {
+		if sev, has := tv.Buf.AnnotationSevAtLine(ln); has {
+			pc := &rs.Paint
+			r := 0.2 * tv.LineHeight
+			cx := float32(tv.VpBBox.Min.X) + tv.LineNoOff - spc - r
+			cy := lst + 0.5*tv.LineHeight
+			pc.FillStyle.SetColor(AnnotationColor(sev))
+			pc.DrawCircle(rs, cx, cy, r)
+			pc.FillStrokeClear(rs)
+		}
+	}
 	// if ic, ok := tv.LineIcons[ln]; ok {
 	// 	// todo: render icon!
 	// }
@@ -3474,10 +3513,15 @@ func (tv *TextView) RenderLines(st, ed int) bool {
 		tv.RenderHighlights(visSt, visEd)
 		tv.RenderScopelights(visSt, visEd)
 		tv.RenderSelect()
+		tv.RenderExtraCursors(visSt, visEd)
+		tv.RenderAnnotations(visSt, visEd)
 		tv.RenderLineNosBox(visSt, visEd)
 
 		if tv.HasLineNos() {
 			for ln := visSt; ln <= visEd; ln++ {
+				if tv.LineIsFolded(ln) {
+					continue
+				}
 				tv.RenderLineNo(ln)
 			}
 			tbb := tv.VpBBox
@@ -3487,6 +3531,9 @@ func (tv *TextView) RenderLines(st, ed int) bool {
 			rs.Lock()
 		}
 		for ln := visSt; ln <= visEd; ln++ {
+			if tv.LineIsFolded(ln) {
+				continue
+			}
 			lst := pos.Y + tv.Offs[ln]
 			lp := pos
 			lp.Y = lst
@@ -3914,12 +3961,29 @@ func (tv *TextView) KeyInput(kt *key.ChordEvent) {
 		kt.SetProcessed()
 		tv.QReplaceCancel()
 		tv.CancelComplete()
-		tv.ISearchStart()
+		if tv.FindBar != nil {
+			tv.FindBar.Open()
+		} else {
+			tv.ISearchStart()
+		}
+	case gi.KeyFunFind:
+		kt.SetProcessed()
+		tv.QReplaceCancel()
+		tv.CancelComplete()
+		if tv.FindBar != nil {
+			tv.FindBar.Open()
+		} else {
+			tv.ISearchStart()
+		}
 	case gi.KeyFunReplace:
 		kt.SetProcessed()
 		tv.CancelComplete()
 		tv.ISearchCancel()
-		tv.QReplacePrompt()
+		if tv.FindBar != nil {
+			tv.FindBar.OpenReplace()
+		} else {
+			tv.QReplacePrompt()
+		}
 	case gi.KeyFunAbort:
 		kt.SetProcessed()
 		tv.CancelComplete()
@@ -3936,6 +4000,26 @@ func (tv *TextView) KeyInput(kt *key.ChordEvent) {
 		cancelAll()
 		kt.SetProcessed()
 		tv.CursorToHistNext()
+	case gi.KeyFunAddCursorAbove:
+		cancelAll()
+		kt.SetProcessed()
+		tv.AddCursorAbove()
+	case gi.KeyFunAddCursorBelow:
+		cancelAll()
+		kt.SetProcessed()
+		tv.AddCursorBelow()
+	case gi.KeyFunFoldToggle:
+		cancelAll()
+		kt.SetProcessed()
+		tv.FoldToggle()
+	case gi.KeyFunFoldAll:
+		cancelAll()
+		kt.SetProcessed()
+		tv.FoldAll()
+	case gi.KeyFunUnfoldAll:
+		cancelAll()
+		kt.SetProcessed()
+		tv.UnfoldAll()
 	}
 	if tv.IsInactive() {
 		switch {
@@ -4117,7 +4201,7 @@ func (tv *TextView) KeyInputInsertRune(kt *key.ChordEvent) {
 			tv.lastAutoInsert = 0
 		} else {
 			tv.lastAutoInsert = 0
-			tv.InsertAtCursor([]byte(string(kt.Rune)))
+			tv.InsertAtCursors([]byte(string(kt.Rune)))
 			if kt.Rune == ' ' {
 				tv.CancelComplete()
 			} else {
@@ -4217,8 +4301,13 @@ func (tv *TextView) MouseEvent(me *mouse.Event) {
 	case mouse.Left:
 		if me.Action == mouse.Press {
 			me.SetProcessed()
-			if _, got := tv.OpenLinkAt(newPos); got {
+			if me.HasAnyModifier(key.Control) {
+				tv.ToggleExtraCursor(newPos)
+			} else if _, got := tv.OpenLinkAt(newPos); got {
 			} else {
+				if me.HasAnyModifier(key.Alt) {
+					tv.BlockSelectStart()
+				}
 				tv.SetCursorFromMouse(pt, newPos, me.SelectMode())
 				tv.SavePosHistory(tv.CursorPos)
 			}
@@ -4297,7 +4386,7 @@ func (tv *TextView) MouseMoveEvent() {
 
 // TextViewEvents sets connections between mouse and key events and actions
 func (tv *TextView) TextViewEvents() {
-	tv.HoverTooltipEvent()
+	tv.HoverAnnotEvent()
 	tv.MouseMoveEvent()
 	tv.ConnectEvent(oswin.MouseDragEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
 		me := d.(*mouse.DragEvent)
@@ -13,14 +13,14 @@ func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the stringer command to generate them again.
 	var x [1]struct{}
-	_ = x[TextViewNeedsRefresh-29]
-	_ = x[TextViewInReLayout-30]
-	_ = x[TextViewRenderScrolls-31]
-	_ = x[TextViewFocusActive-32]
-	_ = x[TextViewHasLineNos-33]
-	_ = x[TextViewLastWasTabAI-34]
-	_ = x[TextViewLastWasUndo-35]
-	_ = x[TextViewFlagsN-36]
+	_ = x[TextViewNeedsRefresh-30]
+	_ = x[TextViewInReLayout-31]
+	_ = x[TextViewRenderScrolls-32]
+	_ = x[TextViewFocusActive-33]
+	_ = x[TextViewHasLineNos-34]
+	_ = x[TextViewLastWasTabAI-35]
+	_ = x[TextViewLastWasUndo-36]
+	_ = x[TextViewFlagsN-37]
 }
 
 const _TextViewFlags_name = "TextViewNeedsRefreshTextViewInReLayoutTextViewRenderScrollsTextViewFocusActiveTextViewHasLineNosTextViewLastWasTabAITextViewLastWasUndoTextViewFlagsN"
@@ -28,9 +28,9 @@ const _TextViewFlags_name = "TextViewNeedsRefreshTextViewInReLayoutTextViewRende
 var _TextViewFlags_index = [...]uint8{0, 20, 38, 59, 78, 96, 116, 135, 149}
 
 func (i TextViewFlags) String() string {
-	i -= 29
+	i -= 30
 	if i < 0 || i >= TextViewFlags(len(_TextViewFlags_index)-1) {
-		return "TextViewFlags(" + strconv.FormatInt(int64(i+29), 10) + ")"
+		return "TextViewFlags(" + strconv.FormatInt(int64(i+30), 10) + ")"
 	}
 	return _TextViewFlags_name[_TextViewFlags_index[i]:_TextViewFlags_index[i+1]]
 }
@@ -38,7 +38,7 @@ func (i TextViewFlags) String() string {
 func StringToTextViewFlags(s string) (TextViewFlags, error) {
 	for i := 0; i < len(_TextViewFlags_index)-1; i++ {
 		if s == _TextViewFlags_name[_TextViewFlags_index[i]:_TextViewFlags_index[i+1]] {
-			return TextViewFlags(i + 29), nil
+			return TextViewFlags(i + 30), nil
 		}
 	}
 	return 0, errors.New("String: " + s + " is not a valid option for type: TextViewFlags")
@@ -0,0 +1,602 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/mouse"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// TimelineItem is one bar on a TimelineView: an interval of time within a
+// lane.  A zero-length interval (End == Start) is drawn as a point event
+// (diamond) instead of a bar.
+type TimelineItem struct {
+	Name  string       `desc:"label displayed on the bar"`
+	Lane  int          `desc:"index of the lane (row) the item is in"`
+	Start time.Time    `desc:"start time of the item"`
+	End   time.Time    `desc:"end time of the item -- equal to Start for a point event"`
+	Color gi.ColorName `desc:"optional color for the bar -- uses the default if empty"`
+	Data  interface{}  `json:"-" xml:"-" desc:"optional user data associated with the item"`
+}
+
+// IsEvent returns true if this is a zero-length point event
+func (ti *TimelineItem) IsEvent() bool {
+	return !ti.End.After(ti.Start)
+}
+
+// Duration returns the duration of the item
+func (ti *TimelineItem) Duration() time.Duration {
+	return ti.End.Sub(ti.Start)
+}
+
+// TimelineSignals are signals sent by a TimelineView
+type TimelineSignals int64
+
+const (
+	// TimelineItemSelected is sent when an item is clicked -- data is the
+	// index of the item in Items
+	TimelineItemSelected TimelineSignals = iota
+
+	// TimelineItemMoved is sent when an item has been dragged to a new
+	// start time or lane -- data is the index of the item
+	TimelineItemMoved
+
+	// TimelineItemResized is sent when an item's start or end has been
+	// dragged -- data is the index of the item
+	TimelineItemResized
+
+	// TimelineRangeChanged is sent when the visible time range changes
+	// from zooming or panning
+	TimelineRangeChanged
+
+	TimelineSignalsN
+)
+
+//go:generate stringer -type=TimelineSignals
+
+// timelineDragModes are what an in-progress drag on the timeline is doing
+type timelineDragMode int
+
+const (
+	timelineDragNone timelineDragMode = iota
+	timelineDragPan
+	timelineDragMove
+	timelineDragStart
+	timelineDragEnd
+)
+
+// TimelineView displays items as bars and events in lanes on a zoomable,
+// pannable time axis -- the basis for schedulers, Gantt charts and media
+// editing tracks.  Scrolling zooms around the pointer, dragging the
+// background pans, dragging a bar moves it (vertically across lanes,
+// horizontally in time), and dragging near a bar's edge resizes it.  Times
+// snap to the Snap interval if set.  TimelineSig sends selection and edit
+// signals with the index of the affected item.
+type TimelineView struct {
+	gi.WidgetBase
+	Items       []*TimelineItem `desc:"the items displayed on the timeline"`
+	Lanes       []string        `desc:"names of the lanes (rows), displayed at the left"`
+	ViewStart   time.Time       `desc:"start of the visible time range"`
+	ViewEnd     time.Time       `desc:"end of the visible time range"`
+	Snap        time.Duration   `desc:"interval that item times snap to when dragging -- 0 = no snapping"`
+	SelIdx      int             `desc:"index of the currently-selected item -- -1 if none"`
+	Editable    bool            `desc:"can items be moved and resized by dragging?"`
+	TimelineSig ki.Signal       `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for timeline events -- see TimelineSignals for the types -- data is the item index"`
+	dragMode    timelineDragMode
+	dragStart   mat32.Vec2 // pointer position at start of drag
+	dragTime    time.Time  // ViewStart or item Start at start of drag
+	dragEndTm   time.Time  // item End at start of drag
+	dragLane    int        // item Lane at start of drag
+}
+
+var KiT_TimelineView = kit.Types.AddType(&TimelineView{}, TimelineViewProps)
+
+// AddNewTimelineView adds a new timeline view to given parent node, with given name.
+func AddNewTimelineView(parent ki.Ki, name string) *TimelineView {
+	tl := parent.AddNewChild(KiT_TimelineView, name).(*TimelineView)
+	tl.SelIdx = -1
+	tl.Editable = true
+	return tl
+}
+
+var TimelineViewProps = ki.Props{
+	"EnumType:Flag":    gi.KiT_NodeFlags,
+	"max-width":        -1,
+	"max-height":       -1,
+	"background-color": &gi.Prefs.Colors.Background,
+}
+
+func (tl *TimelineView) Disconnect() {
+	tl.WidgetBase.Disconnect()
+	tl.TimelineSig.DisconnectAll()
+}
+
+// TimelineLaneHt is the height of each lane, in pixels
+var TimelineLaneHt = float32(24)
+
+// TimelineAxisHt is the height of the time axis at the top, in pixels
+var TimelineAxisHt = float32(20)
+
+// TimelineLaneWd is the width of the lane labels at the left, in pixels
+var TimelineLaneWd = float32(80)
+
+// TimelineEdgePix is the distance in pixels from a bar edge within which a
+// drag resizes instead of moves
+var TimelineEdgePix = float32(5)
+
+// SetItems sets the items to display, sets the visible range to span them
+// if not already set, and updates the display
+func (tl *TimelineView) SetItems(items []*TimelineItem) {
+	tl.Items = items
+	tl.SelIdx = -1
+	if tl.ViewStart.IsZero() || !tl.ViewEnd.After(tl.ViewStart) {
+		tl.RangeFromItems()
+	}
+	tl.UpdateSig()
+}
+
+// NLanes returns the number of lanes -- from Lanes if set, else from the
+// maximum lane index of the items
+func (tl *TimelineView) NLanes() int {
+	nl := len(tl.Lanes)
+	for _, it := range tl.Items {
+		nl = ints.MaxInt(nl, it.Lane+1)
+	}
+	return nl
+}
+
+// RangeFromItems sets the visible range to span all the items, with a
+// small margin on each side
+func (tl *TimelineView) RangeFromItems() {
+	if len(tl.Items) == 0 {
+		tl.ViewStart = time.Now()
+		tl.ViewEnd = tl.ViewStart.Add(time.Hour)
+		return
+	}
+	st, ed := tl.Items[0].Start, tl.Items[0].End
+	for _, it := range tl.Items {
+		if it.Start.Before(st) {
+			st = it.Start
+		}
+		if it.End.After(ed) {
+			ed = it.End
+		}
+	}
+	mrg := ed.Sub(st) / 20
+	if mrg == 0 {
+		mrg = time.Minute
+	}
+	tl.ViewStart = st.Add(-mrg)
+	tl.ViewEnd = ed.Add(mrg)
+}
+
+// SnapTime returns given time snapped to the Snap interval, if set
+func (tl *TimelineView) SnapTime(t time.Time) time.Time {
+	if tl.Snap <= 0 {
+		return t
+	}
+	return t.Round(tl.Snap)
+}
+
+// BarBox returns the area in window pixels where the bars are drawn,
+// excluding the lane labels and time axis
+func (tl *TimelineView) BarBox() mat32.Box2 {
+	pos := tl.LayData.AllocPos
+	sz := tl.LayData.AllocSize
+	min := mat32.Vec2{pos.X + TimelineLaneWd, pos.Y + TimelineAxisHt}
+	max := pos.Add(sz)
+	if max.X < min.X+1 {
+		max.X = min.X + 1
+	}
+	if max.Y < min.Y+1 {
+		max.Y = min.Y + 1
+	}
+	return mat32.Box2{Min: min, Max: max}
+}
+
+// TimeToPix returns the window x pixel for given time
+func (tl *TimelineView) TimeToPix(t time.Time) float32 {
+	box := tl.BarBox()
+	rng := tl.ViewEnd.Sub(tl.ViewStart)
+	if rng <= 0 {
+		return box.Min.X
+	}
+	fr := float32(t.Sub(tl.ViewStart)) / float32(rng)
+	return box.Min.X + fr*(box.Max.X-box.Min.X)
+}
+
+// PixToTime returns the time for given window x pixel
+func (tl *TimelineView) PixToTime(x float32) time.Time {
+	box := tl.BarBox()
+	fr := (x - box.Min.X) / (box.Max.X - box.Min.X)
+	rng := tl.ViewEnd.Sub(tl.ViewStart)
+	return tl.ViewStart.Add(time.Duration(fr * float32(rng)))
+}
+
+// LaneAtPix returns the lane index at given window y pixel -- -1 if outside
+func (tl *TimelineView) LaneAtPix(y float32) int {
+	box := tl.BarBox()
+	if y < box.Min.Y || y >= box.Max.Y {
+		return -1
+	}
+	ln := int((y - box.Min.Y) / TimelineLaneHt)
+	if ln >= tl.NLanes() {
+		return -1
+	}
+	return ln
+}
+
+// ItemBox returns the bar rectangle in window pixels for given item
+func (tl *TimelineView) ItemBox(it *TimelineItem) mat32.Box2 {
+	box := tl.BarBox()
+	x0 := tl.TimeToPix(it.Start)
+	x1 := tl.TimeToPix(it.End)
+	if it.IsEvent() {
+		x0 -= 4
+		x1 = x0 + 8
+	} else if x1 < x0+2 {
+		x1 = x0 + 2
+	}
+	y0 := box.Min.Y + float32(it.Lane)*TimelineLaneHt + 3
+	return mat32.Box2{Min: mat32.Vec2{x0, y0}, Max: mat32.Vec2{x1, y0 + TimelineLaneHt - 6}}
+}
+
+// ItemAtPix returns the index of the item at given window pixel point, and
+// whether the point is near its start or end edge -- -1 if none
+func (tl *TimelineView) ItemAtPix(pt mat32.Vec2) (idx int, atStart, atEnd bool) {
+	idx = -1
+	for i, it := range tl.Items {
+		ib := tl.ItemBox(it)
+		if pt.Y < ib.Min.Y || pt.Y > ib.Max.Y {
+			continue
+		}
+		if pt.X < ib.Min.X-TimelineEdgePix || pt.X > ib.Max.X+TimelineEdgePix {
+			continue
+		}
+		idx = i
+		if !it.IsEvent() {
+			atStart = math32.Abs(pt.X-ib.Min.X) <= TimelineEdgePix
+			atEnd = math32.Abs(pt.X-ib.Max.X) <= TimelineEdgePix
+		}
+		return
+	}
+	return
+}
+
+// SelectItem selects item at given index (-1 = none) and emits
+// TimelineItemSelected
+func (tl *TimelineView) SelectItem(idx int) {
+	tl.SelIdx = idx
+	tl.TimelineSig.Emit(tl.This(), int64(TimelineItemSelected), idx)
+	tl.UpdateSig()
+}
+
+// ZoomAround zooms the visible range by given factor (> 1 = zoom out),
+// keeping the time at given window x pixel fixed
+func (tl *TimelineView) ZoomAround(x float32, factor float32) {
+	fix := tl.PixToTime(x)
+	rng := time.Duration(float32(tl.ViewEnd.Sub(tl.ViewStart)) * factor)
+	if rng < time.Millisecond {
+		rng = time.Millisecond
+	}
+	box := tl.BarBox()
+	fr := (x - box.Min.X) / (box.Max.X - box.Min.X)
+	tl.ViewStart = fix.Add(-time.Duration(fr * float32(rng)))
+	tl.ViewEnd = tl.ViewStart.Add(rng)
+	tl.TimelineSig.Emit(tl.This(), int64(TimelineRangeChanged), -1)
+	tl.UpdateSig()
+}
+
+// Pan shifts the visible range by given number of window pixels
+func (tl *TimelineView) Pan(dx float32) {
+	box := tl.BarBox()
+	rng := tl.ViewEnd.Sub(tl.ViewStart)
+	del := time.Duration(dx / (box.Max.X - box.Min.X) * float32(rng))
+	tl.ViewStart = tl.ViewStart.Add(del)
+	tl.ViewEnd = tl.ViewEnd.Add(del)
+	tl.TimelineSig.Emit(tl.This(), int64(TimelineRangeChanged), -1)
+	tl.UpdateSig()
+}
+
+func (tl *TimelineView) MouseEvent() {
+	tl.ConnectEvent(oswin.MouseEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.Event)
+		tlv := recv.Embed(KiT_TimelineView).(*TimelineView)
+		if me.Button != mouse.Left {
+			return
+		}
+		me.SetProcessed()
+		pt := mat32.NewVec2FmPoint(me.Pos())
+		switch me.Action {
+		case mouse.Press:
+			idx, atSt, atEd := tlv.ItemAtPix(pt)
+			tlv.dragStart = pt
+			if idx >= 0 {
+				it := tlv.Items[idx]
+				tlv.dragTime = it.Start
+				tlv.dragEndTm = it.End
+				tlv.dragLane = it.Lane
+				if tlv.SelIdx != idx {
+					tlv.SelectItem(idx)
+				}
+				if !tlv.Editable {
+					tlv.dragMode = timelineDragNone
+				} else if atSt {
+					tlv.dragMode = timelineDragStart
+				} else if atEd {
+					tlv.dragMode = timelineDragEnd
+				} else {
+					tlv.dragMode = timelineDragMove
+				}
+			} else {
+				tlv.dragMode = timelineDragPan
+				tlv.dragTime = tlv.ViewStart
+				if tlv.SelIdx >= 0 {
+					tlv.SelectItem(-1)
+				}
+			}
+		case mouse.Release:
+			switch tlv.dragMode {
+			case timelineDragMove:
+				if tlv.SelIdx >= 0 {
+					it := tlv.Items[tlv.SelIdx]
+					if it.Start != tlv.dragTime || it.Lane != tlv.dragLane {
+						tlv.TimelineSig.Emit(tlv.This(), int64(TimelineItemMoved), tlv.SelIdx)
+					}
+				}
+			case timelineDragStart, timelineDragEnd:
+				if tlv.SelIdx >= 0 {
+					it := tlv.Items[tlv.SelIdx]
+					if it.Start != tlv.dragTime || it.End != tlv.dragEndTm {
+						tlv.TimelineSig.Emit(tlv.This(), int64(TimelineItemResized), tlv.SelIdx)
+					}
+				}
+			}
+			tlv.dragMode = timelineDragNone
+		}
+	})
+}
+
+func (tl *TimelineView) MouseDragEvent() {
+	tl.ConnectEvent(oswin.MouseDragEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.DragEvent)
+		me.SetProcessed()
+		tlv := recv.Embed(KiT_TimelineView).(*TimelineView)
+		pt := mat32.NewVec2FmPoint(me.Pos())
+		dx := pt.X - tlv.dragStart.X
+		switch tlv.dragMode {
+		case timelineDragPan:
+			tlv.Pan(tlv.dragStart.X - pt.X)
+			tlv.dragStart = pt
+		case timelineDragMove:
+			if tlv.SelIdx < 0 {
+				return
+			}
+			it := tlv.Items[tlv.SelIdx]
+			dur := tlv.dragEndTm.Sub(tlv.dragTime)
+			nst := tlv.SnapTime(tlv.PixToTime(tlv.TimeToPix(tlv.dragTime) + dx))
+			it.Start = nst
+			it.End = nst.Add(dur)
+			if ln := tlv.LaneAtPix(pt.Y); ln >= 0 {
+				it.Lane = ln
+			}
+			tlv.UpdateSig()
+		case timelineDragStart:
+			if tlv.SelIdx < 0 {
+				return
+			}
+			it := tlv.Items[tlv.SelIdx]
+			nst := tlv.SnapTime(tlv.PixToTime(tlv.TimeToPix(tlv.dragTime) + dx))
+			if nst.Before(it.End) {
+				it.Start = nst
+			}
+			tlv.UpdateSig()
+		case timelineDragEnd:
+			if tlv.SelIdx < 0 {
+				return
+			}
+			it := tlv.Items[tlv.SelIdx]
+			ned := tlv.SnapTime(tlv.PixToTime(tlv.TimeToPix(tlv.dragEndTm) + dx))
+			if ned.After(it.Start) {
+				it.End = ned
+			}
+			tlv.UpdateSig()
+		}
+	})
+}
+
+func (tl *TimelineView) MouseScrollEvent() {
+	tl.ConnectEvent(oswin.MouseScrollEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		me := d.(*mouse.ScrollEvent)
+		me.SetProcessed()
+		tlv := recv.Embed(KiT_TimelineView).(*TimelineView)
+		del := me.NonZeroDelta(false)
+		factor := float32(1.1)
+		if del > 0 {
+			factor = 1 / 1.1
+		}
+		tlv.ZoomAround(float32(me.Pos().X), factor)
+	})
+}
+
+func (tl *TimelineView) ConnectEvents2D() {
+	tl.MouseEvent()
+	tl.MouseDragEvent()
+	tl.MouseScrollEvent()
+	tl.HoverTooltipEvent()
+}
+
+// timelineTickIncs are the "nice" tick intervals for the time axis
+var timelineTickIncs = []time.Duration{
+	time.Millisecond, 10 * time.Millisecond, 100 * time.Millisecond,
+	time.Second, 5 * time.Second, 15 * time.Second, time.Minute,
+	5 * time.Minute, 15 * time.Minute, time.Hour, 6 * time.Hour,
+	24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour,
+}
+
+// tickInc returns a tick interval giving roughly n ticks over the visible range
+func (tl *TimelineView) tickInc(n int) time.Duration {
+	rng := tl.ViewEnd.Sub(tl.ViewStart)
+	tgt := rng / time.Duration(n)
+	i := sort.Search(len(timelineTickIncs), func(i int) bool {
+		return timelineTickIncs[i] >= tgt
+	})
+	if i >= len(timelineTickIncs) {
+		i = len(timelineTickIncs) - 1
+	}
+	return timelineTickIncs[i]
+}
+
+// tickLabel formats given time for the axis, at given tick resolution
+func timelineTickLabel(t time.Time, inc time.Duration) string {
+	switch {
+	case inc >= 24*time.Hour:
+		return t.Format("01-02")
+	case inc >= time.Minute:
+		return t.Format("15:04")
+	case inc >= time.Second:
+		return t.Format("15:04:05")
+	default:
+		return t.Format("04:05.000")
+	}
+}
+
+// RenderText renders given string at given window pixel position
+func (tl *TimelineView) RenderText(s string, pos mat32.Vec2) {
+	rs := &tl.Viewport.Render
+	sty := &tl.Sty
+	fst := sty.Font
+	fst.BgColor.SetColor(nil)
+	var tr gi.TextRender
+	tr.SetString(s, &fst, &sty.UnContext, &sty.Text, true, 0, 0)
+	tr.Render(rs, pos)
+}
+
+// RenderTimeline does the full rendering of the timeline
+func (tl *TimelineView) RenderTimeline() {
+	rs := &tl.Viewport.Render
+	pc := &rs.Paint
+	sty := &tl.Sty
+	box := tl.BarBox()
+	pos := tl.LayData.AllocPos
+	nl := tl.NLanes()
+
+	rs.Lock()
+	// lane backgrounds and separators
+	pc.StrokeStyle.Width.Dots = 1
+	pc.StrokeStyle.SetColor(sty.Font.Color.Highlight(40))
+	for ln := 0; ln <= nl; ln++ {
+		ly := box.Min.Y + float32(ln)*TimelineLaneHt
+		if ly > box.Max.Y {
+			break
+		}
+		pc.DrawLine(rs, pos.X, ly, box.Max.X, ly)
+		pc.Stroke(rs)
+	}
+	// axis ticks
+	inc := tl.tickInc(8)
+	tick := tl.ViewStart.Truncate(inc)
+	laneBot := math32.Min(box.Min.Y+float32(nl)*TimelineLaneHt, box.Max.Y)
+	for ; !tick.After(tl.ViewEnd); tick = tick.Add(inc) {
+		if tick.Before(tl.ViewStart) {
+			continue
+		}
+		tx := tl.TimeToPix(tick)
+		pc.StrokeStyle.SetColor(sty.Font.Color.Highlight(30))
+		pc.DrawLine(rs, tx, box.Min.Y, tx, laneBot)
+		pc.Stroke(rs)
+	}
+	rs.Unlock()
+
+	// tick labels (text rendering does its own locking)
+	tick = tl.ViewStart.Truncate(inc)
+	for ; !tick.After(tl.ViewEnd); tick = tick.Add(inc) {
+		if tick.Before(tl.ViewStart) {
+			continue
+		}
+		tx := tl.TimeToPix(tick)
+		tl.RenderText(timelineTickLabel(tick, inc), mat32.Vec2{tx + 2, pos.Y + 2})
+	}
+	// lane labels
+	for ln := 0; ln < len(tl.Lanes); ln++ {
+		ly := box.Min.Y + float32(ln)*TimelineLaneHt
+		if ly > box.Max.Y {
+			break
+		}
+		tl.RenderText(tl.Lanes[ln], mat32.Vec2{pos.X + 4, ly + 3})
+	}
+
+	// items
+	for i, it := range tl.Items {
+		ib := tl.ItemBox(it)
+		if ib.Max.X < box.Min.X || ib.Min.X > box.Max.X || ib.Max.Y > box.Max.Y {
+			continue
+		}
+		clr, err := gi.ColorFromName(string(it.Color))
+		if it.Color == "" || err != nil {
+			clr = gi.Prefs.Colors.Icon
+		}
+		rs.Lock()
+		pc.FillStyle.SetColor(clr)
+		if it.IsEvent() {
+			cx := .5 * (ib.Min.X + ib.Max.X)
+			cy := .5 * (ib.Min.Y + ib.Max.Y)
+			r := .5 * (ib.Max.Y - ib.Min.Y)
+			pc.MoveTo(rs, cx, ib.Min.Y)
+			pc.LineTo(rs, ib.Max.X, cy)
+			pc.LineTo(rs, cx, ib.Max.Y)
+			pc.LineTo(rs, cx-r, cy)
+			pc.ClosePath(rs)
+			pc.Fill(rs)
+		} else {
+			pc.DrawRectangle(rs, ib.Min.X, ib.Min.Y, ib.Max.X-ib.Min.X, ib.Max.Y-ib.Min.Y)
+			pc.Fill(rs)
+		}
+		if i == tl.SelIdx {
+			pc.StrokeStyle.SetColor(&gi.Prefs.Colors.Select)
+			pc.StrokeStyle.Width.Dots = 2
+			pc.DrawRectangle(rs, ib.Min.X-1, ib.Min.Y-1, ib.Max.X-ib.Min.X+2, ib.Max.Y-ib.Min.Y+2)
+			pc.Stroke(rs)
+		}
+		rs.Unlock()
+		if !it.IsEvent() && it.Name != "" && ib.Max.X-ib.Min.X > 20 {
+			tl.RenderText(it.Name, mat32.Vec2{ib.Min.X + 3, ib.Min.Y})
+		}
+	}
+
+	// readout for the selected item
+	if tl.SelIdx >= 0 && tl.SelIdx < len(tl.Items) {
+		it := tl.Items[tl.SelIdx]
+		txt := fmt.Sprintf("%s: %s", it.Name, it.Start.Format("2006-01-02 15:04:05"))
+		if !it.IsEvent() {
+			txt += fmt.Sprintf(" + %v", it.Duration())
+		}
+		tl.RenderText(txt, mat32.Vec2{box.Min.X + 4, box.Max.Y - tl.Sty.Font.Face.Metrics.Height})
+	}
+}
+
+func (tl *TimelineView) Render2D() {
+	if tl.FullReRenderIfNeeded() {
+		return
+	}
+	if tl.PushBounds() {
+		tl.This().(gi.Node2D).ConnectEvents2D()
+		tl.RenderTimeline()
+		tl.Render2DChildren()
+		tl.PopBounds()
+	} else {
+		tl.DisconnectAllEvents(gi.RegPri)
+	}
+}
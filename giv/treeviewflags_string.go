@@ -13,9 +13,9 @@ func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the stringer command to generate them again.
 	var x [1]struct{}
-	_ = x[TreeViewFlagClosed-29]
-	_ = x[TreeViewFlagChanged-30]
-	_ = x[TreeViewFlagsN-31]
+	_ = x[TreeViewFlagClosed-30]
+	_ = x[TreeViewFlagChanged-31]
+	_ = x[TreeViewFlagsN-32]
 }
 
 const _TreeViewFlags_name = "TreeViewFlagClosedTreeViewFlagChangedTreeViewFlagsN"
@@ -23,9 +23,9 @@ const _TreeViewFlags_name = "TreeViewFlagClosedTreeViewFlagChangedTreeViewFlagsN
 var _TreeViewFlags_index = [...]uint8{0, 18, 37, 51}
 
 func (i TreeViewFlags) String() string {
-	i -= 29
+	i -= 30
 	if i < 0 || i >= TreeViewFlags(len(_TreeViewFlags_index)-1) {
-		return "TreeViewFlags(" + strconv.FormatInt(int64(i+29), 10) + ")"
+		return "TreeViewFlags(" + strconv.FormatInt(int64(i+30), 10) + ")"
 	}
 	return _TreeViewFlags_name[_TreeViewFlags_index[i]:_TreeViewFlags_index[i+1]]
 }
@@ -33,7 +33,7 @@ func (i TreeViewFlags) String() string {
 func StringToTreeViewFlags(s string) (TreeViewFlags, error) {
 	for i := 0; i < len(_TreeViewFlags_index)-1; i++ {
 		if s == _TreeViewFlags_name[_TreeViewFlags_index[i]:_TreeViewFlags_index[i+1]] {
-			return TreeViewFlags(i + 29), nil
+			return TreeViewFlags(i + 30), nil
 		}
 	}
 	return 0, errors.New("String: " + s + " is not a valid option for type: TreeViewFlags")
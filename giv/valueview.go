@@ -137,6 +137,42 @@ func ValueViewMapAdd(typeNm string, fun ValueViewFunc) {
 	ValueViewMap[typeNm] = fun
 }
 
+// The ValueViewRegistry maps a type name plus a view struct tag value to a
+// custom ValueView constructor, consulted by FieldToValueView before any
+// builtin resolution, so apps can plug in custom editors for specific
+// fields without forking giv.  The key is the kit.LongTypeName of the
+// field's non-pointer type, ":", and the value of the view tag on the
+// field (e.g., `view:"my-editor"`) -- register with ValueViewRegistryAdd.
+var ValueViewRegistry map[string]ValueViewFunc
+
+// ValueViewRegistryAdd registers a custom ValueView constructor for given
+// type name and view struct tag value -- see ValueViewRegistry.
+// You must use kit.LongTypeName (full package name + "." + type name) for
+// the type name, as that is how it will be looked up.
+func ValueViewRegistryAdd(typeNm, viewTag string, fun ValueViewFunc) {
+	if ValueViewRegistry == nil {
+		ValueViewRegistry = make(map[string]ValueViewFunc)
+	}
+	ValueViewRegistry[typeNm+":"+viewTag] = fun
+}
+
+// valueViewFromRegistry returns a ValueView from the ValueViewRegistry for
+// given field value and field tags, or nil if none is registered
+func valueViewFromRegistry(fval interface{}, tags string) ValueView {
+	if ValueViewRegistry == nil || fval == nil || tags == "" {
+		return nil
+	}
+	vwtag, ok := reflect.StructTag(tags).Lookup("view")
+	if !ok {
+		return nil
+	}
+	nptyp := kit.NonPtrType(reflect.TypeOf(fval))
+	if vvf, has := ValueViewRegistry[kit.LongTypeName(nptyp)+":"+vwtag]; has {
+		return vvf()
+	}
+	return nil
+}
+
 // ToValueView returns the appropriate ValueView for given item, based only on
 // its type -- attempts to get the ValueViewer interface and failing that,
 // falls back on default Kind-based options.  tags are optional tags, e.g.,
@@ -196,6 +232,24 @@ func ToValueView(it interface{}, tags string) ValueView {
 				forceInline = true
 			case "no-inline":
 				forceNoInline = true
+			case "radio":
+				if kit.Enums.TypeRegistered(nptyp) && !kit.Enums.IsBitFlag(nptyp) {
+					vv := &RadioValueView{}
+					vv.Init(vv)
+					return vv
+				}
+			case "rating":
+				if (vk >= reflect.Int && vk <= reflect.Uint64) || (vk >= reflect.Float32 && vk <= reflect.Float64) {
+					vv := &RatingValueView{}
+					vv.Init(vv)
+					return vv
+				}
+			case "slider", "slider-spin":
+				if (vk >= reflect.Int && vk <= reflect.Uint64) || (vk >= reflect.Float32 && vk <= reflect.Float64) {
+					vv := &SliderValueView{}
+					vv.Init(vv)
+					return vv
+				}
 			}
 		}
 	}
@@ -352,6 +406,12 @@ func FieldToValueView(it interface{}, field string, fval interface{}) ValueView
 	typ := reflect.TypeOf(it)
 	nptyp := kit.NonPtrType(typ)
 
+	if ftyp, ok := nptyp.FieldByName(field); ok {
+		if vv := valueViewFromRegistry(fval, string(ftyp.Tag)); vv != nil {
+			return vv
+		}
+	}
+
 	if pv, has := kit.Types.Prop(nptyp, "EnumType:"+field); has {
 		et := pv.(reflect.Type)
 		if kit.Enums.IsBitFlag(et) {
@@ -495,20 +555,21 @@ type ValueView interface {
 // fallback for everything that doesn't provide a specific ValueViewer type.
 type ValueViewBase struct {
 	ki.Node
-	ViewSig   ki.Signal            `json:"-" xml:"-" desc:"signal for valueview -- only one signal sent when a value has been set -- all related value views interconnect with each other to update when others update -- data is the value that was set"`
-	Value     reflect.Value        `desc:"the reflect.Value representation of the value"`
-	OwnKind   reflect.Kind         `desc:"kind of owner that we have -- reflect.Struct, .Map, .Slice are supported"`
-	IsMapKey  bool                 `desc:"for OwnKind = Map, this value represents the Key -- otherwise the Value"`
-	ViewPath  string               `desc:"a record of parent View names that have led up to this view -- displayed as extra contextual information in view dialog windows"`
-	Owner     interface{}          `desc:"the object that owns this value, either a struct, slice, or map, if non-nil -- if a Ki Node, then SetField is used to set value, to provide proper updating"`
-	Field     *reflect.StructField `desc:"if Owner is a struct, this is the reflect.StructField associated with the value"`
-	Tags      map[string]string    `desc:"set of tags that can be set to customize interface for different types of values -- only source for non-structfield values"`
-	Key       interface{}          `desc:"if Owner is a map, and this is a value, this is the key for this value in the map"`
-	KeyView   ValueView            `desc:"if Owner is a map, and this is a value, this is the value view representing the key -- its value has the *current* value of the key, which can be edited"`
-	Idx       int                  `desc:"if Owner is a slice, this is the index for the value in the slice"`
-	WidgetTyp reflect.Type         `desc:"type of widget to create -- cached during WidgetType method -- chosen based on the ValueView type and reflect.Value type -- see ValueViewer interface"`
-	Widget    gi.Node2D            `desc:"the widget used to display and edit the value in the interface -- this is created for us externally and we cache it during ConfigWidget"`
-	TmpSave   ValueView            `desc:"value view that needs to have SaveTmp called on it whenever a change is made to one of the underlying values -- pass this down to any sub-views created from a parent"`
+	ViewSig    ki.Signal            `json:"-" xml:"-" desc:"signal for valueview -- only one signal sent when a value has been set -- all related value views interconnect with each other to update when others update -- data is the value that was set"`
+	Value      reflect.Value        `desc:"the reflect.Value representation of the value"`
+	OwnKind    reflect.Kind         `desc:"kind of owner that we have -- reflect.Struct, .Map, .Slice are supported"`
+	IsMapKey   bool                 `desc:"for OwnKind = Map, this value represents the Key -- otherwise the Value"`
+	ViewPath   string               `desc:"a record of parent View names that have led up to this view -- displayed as extra contextual information in view dialog windows"`
+	Owner      interface{}          `desc:"the object that owns this value, either a struct, slice, or map, if non-nil -- if a Ki Node, then SetField is used to set value, to provide proper updating"`
+	Field      *reflect.StructField `desc:"if Owner is a struct, this is the reflect.StructField associated with the value"`
+	Tags       map[string]string    `desc:"set of tags that can be set to customize interface for different types of values -- only source for non-structfield values"`
+	Key        interface{}          `desc:"if Owner is a map, and this is a value, this is the key for this value in the map"`
+	KeyView    ValueView            `desc:"if Owner is a map, and this is a value, this is the value view representing the key -- its value has the *current* value of the key, which can be edited"`
+	Idx        int                  `desc:"if Owner is a slice, this is the index for the value in the slice"`
+	WidgetTyp  reflect.Type         `desc:"type of widget to create -- cached during WidgetType method -- chosen based on the ValueView type and reflect.Value type -- see ValueViewer interface"`
+	Widget     gi.Node2D            `desc:"the widget used to display and edit the value in the interface -- this is created for us externally and we cache it during ConfigWidget"`
+	TmpSave    ValueView            `desc:"value view that needs to have SaveTmp called on it whenever a change is made to one of the underlying values -- pass this down to any sub-views created from a parent"`
+	TmpSavePar ValueView            `view:"-" desc:"previous TmpSave from our parent, retained when CreateTempIfNotPtr makes us our own TmpSave -- SaveTmp chains to this after saving our own temp value, so edits at any depth of nested containers propagate all the way back to the root"`
 }
 
 var KiT_ValueViewBase = kit.Types.AddType(&ValueViewBase{}, ValueViewBaseProps)
@@ -699,9 +760,10 @@ func (vv *ValueViewBase) SaveTmp() {
 		return
 	}
 	if vv.TmpSave == vv.This().(ValueView) {
-		// if we are a map value, of a struct value, we save our value
+		// if we are a map value, of a struct / container value, we save our value
 		if vv.Owner != nil && vv.OwnKind == reflect.Map && !vv.IsMapKey {
-			if kit.NonPtrValue(vv.Value).Kind() == reflect.Struct {
+			switch kit.NonPtrValue(vv.Value).Kind() {
+			case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
 				ov := kit.NonPtrValue(reflect.ValueOf(vv.Owner))
 				if vv.KeyView != nil {
 					ck := kit.NonPtrValue(vv.KeyView.Val())
@@ -712,6 +774,10 @@ func (vv *ValueViewBase) SaveTmp() {
 				}
 			}
 		}
+		if vv.TmpSavePar != nil {
+			// our owner may itself be a temp copy -- keep saving up the chain
+			vv.TmpSavePar.SaveTmp()
+		}
 	} else {
 		vv.TmpSave.SaveTmp()
 	}
@@ -719,6 +785,9 @@ func (vv *ValueViewBase) SaveTmp() {
 
 func (vv *ValueViewBase) CreateTempIfNotPtr() bool {
 	if vv.Value.Kind() != reflect.Ptr { // we create a temp variable -- SaveTmp will save it!
+		if vv.TmpSave != nil && vv.TmpSave != vv.This().(ValueView) {
+			vv.TmpSavePar = vv.TmpSave // keep the parent chain -- SaveTmp continues up it
+		}
 		vv.TmpSave = vv.This().(ValueView) // we are it!
 		vtyp := reflect.TypeOf(vv.Value.Interface())
 		vtp := reflect.New(vtyp)
@@ -951,6 +1020,10 @@ func (vi *ViewIFace) KeyMapsView(maps *gi.KeyMaps) {
 	KeyMapsView(maps)
 }
 
+func (vi *ViewIFace) GlobalHotkeysView(hk *gi.GlobalHotkeys) {
+	GlobalHotkeysView(hk)
+}
+
 func (vi *ViewIFace) PrefsDetView(prefs *gi.PrefsDetailed) {
 	PrefsDetView(prefs)
 }
@@ -28,6 +28,16 @@ type App interface {
 	// behavior in minor, simple ways
 	Platform() Platforms
 
+	// PrefersHighContrast returns whether the OS accessibility settings
+	// request a high-contrast theme -- always false on platforms that do
+	// not expose this setting
+	PrefersHighContrast() bool
+
+	// PrefersReducedMotion returns whether the OS accessibility settings
+	// request reduced motion (disabling non-essential animations) --
+	// always false on platforms that do not expose this setting
+	PrefersReducedMotion() bool
+
 	// Name is the overall name of the application -- used for specifying an
 	// application-specific preferences directory, etc
 	Name() string
@@ -176,9 +186,58 @@ const (
 	// Windows is a Microsoft Windows machine
 	Windows
 
+	// LinuxWayland is a Linux OS machine running a Wayland compositor
+	// natively (no XWayland)
+	LinuxWayland
+
 	PlatformsN
 )
 
 //go:generate stringer -type=Platforms
 
 var KiT_Platforms = kit.Enums.AddEnum(PlatformsN, kit.NotBitFlag, nil)
+
+// ScreenSaverInhibitor is an optional interface that an App implementation
+// can provide to prevent the system screensaver and sleep from activating,
+// e.g., for kiosk or media-playback applications.  Access it via a type
+// assertion on TheApp -- drivers that do not implement it simply cannot
+// inhibit, and callers should degrade gracefully.
+type ScreenSaverInhibitor interface {
+	// InhibitScreenSaver turns inhibition of the system screensaver and
+	// sleep on or off -- calls are not counted, so a single false call
+	// releases the inhibition regardless of how many times it was set.
+	InhibitScreenSaver(inhibit bool)
+}
+
+// TaskbarIndicator is an optional interface that the App on a given
+// platform may implement, to surface long-running operations at the OS
+// level: taskbar / dock progress, badge counts, and requesting user
+// attention (bouncing dock icon, flashing taskbar entry) -- use a type
+// assertion on oswin.TheApp to check for availability.
+type TaskbarIndicator interface {
+	// SetTaskbarProgress shows progress (0..1) on the app's taskbar / dock
+	// presence -- on = false removes the indicator
+	SetTaskbarProgress(pct float32, on bool)
+
+	// SetTaskbarBadge sets a numeric badge on the app's taskbar / dock
+	// icon -- 0 clears it
+	SetTaskbarBadge(count int)
+
+	// RequestUserAttention asks the OS to draw the user's attention to the
+	// app (bouncing dock icon / flashing taskbar) -- critical requests are
+	// more insistent on platforms that distinguish
+	RequestUserAttention(critical bool)
+}
+
+// RecentDocumenter is an optional interface that the App on a given
+// platform may implement, to mirror the app's recent-documents list into
+// the OS-level recents UI: the dock menu on macOS, the taskbar jump list
+// on Windows.  When the user activates an entry there, the path is
+// delivered back to the running app via the open-files mechanism -- see
+// SetOpenFilesFunc.  Use a type assertion on oswin.TheApp to check for
+// availability.
+type RecentDocumenter interface {
+	// SetRecentDocuments sets the OS-level recent documents list to given
+	// file paths, ordered most-recent first -- an empty list clears it
+	SetRecentDocuments(paths []string)
+}
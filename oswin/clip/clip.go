@@ -41,3 +41,17 @@ type Board interface {
 	// Clear clears the clipboard
 	Clear()
 }
+
+// PrimaryBoarder is an optional interface that a clip.Board may implement
+// on platforms with a separate primary selection (X11): text selected in a
+// widget is written to primary and middle-click pastes from it -- use a
+// type assertion on the Board to check for availability
+type PrimaryBoarder interface {
+
+	// ReadPrimary attempts to read data of the given MIME type(s), in
+	// preference order, from the primary selection
+	ReadPrimary(types []string) mimedata.Mimes
+
+	// WritePrimary writes given mimedata to the primary selection
+	WritePrimary(data mimedata.Mimes) error
+}
@@ -26,3 +26,22 @@ import "github.com/goki/gi/oswin"
 func Main(f func(oswin.App)) {
 	driverMain(f)
 }
+
+// Init initializes the graphical application without taking over the main
+// loop: f is launched on its own goroutine as in Main, but control returns
+// to the caller, who must then drive the loop by calling MainIteration
+// repeatedly from the main thread.  Use instead of Main when embedding in
+// an application that already owns the main thread (a game engine, another
+// UI toolkit, etc).
+func Init(f func(oswin.App)) {
+	driverInit(f)
+}
+
+// MainIteration runs one non-blocking iteration of the main loop for an
+// application initialized with Init: pending main-thread work is run and
+// window events are polled.  Must be called from the main thread.  Returns
+// false once the application has finished, after which no further calls
+// should be made.
+func MainIteration() bool {
+	return driverMainIteration()
+}
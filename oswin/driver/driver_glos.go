@@ -17,3 +17,11 @@ import (
 func driverMain(f func(oswin.App)) {
 	glos.Main(f)
 }
+
+func driverInit(f func(oswin.App)) {
+	glos.Init(f)
+}
+
+func driverMainIteration() bool {
+	return glos.MainIteration()
+}
@@ -82,6 +82,7 @@ var mainCallback func(oswin.App)
 func Main(f func(oswin.App)) {
 	mainCallback = f
 	theApp.initGl()
+	osOpenFilesInit()
 	oswin.TheApp = theApp
 	go func() {
 		mainCallback(theApp)
@@ -90,6 +91,49 @@ func Main(f func(oswin.App)) {
 	theApp.mainLoop()
 }
 
+// Init initializes the app for an externally-driven main loop, instead of
+// the self-contained loop that Main runs: everything is set up as in Main
+// (including launching f on its own goroutine), but control returns to the
+// caller, who must then call MainIteration repeatedly from the main thread
+// -- for embedding gi in applications that already own the main thread
+// (game engines, other UI toolkits).
+func Init(f func(oswin.App)) {
+	mainCallback = f
+	theApp.initGl()
+	osOpenFilesInit()
+	oswin.TheApp = theApp
+	theApp.mainQueue = make(chan funcRun)
+	theApp.mainDone = make(chan struct{})
+	go func() {
+		mainCallback(theApp)
+		theApp.stopMain()
+	}()
+}
+
+// MainIteration runs one iteration of the main loop, for apps initialized
+// with Init: runs any queued main-thread functions, then polls for window
+// events without blocking, and returns -- must be called from the main
+// thread -- returns false once the app has finished (all windows closed or
+// Quit called), after which no further calls should be made.
+func MainIteration() bool {
+	app := theApp
+	for {
+		select {
+		case <-app.mainDone:
+			glfw.Terminate()
+			return false
+		case f := <-app.mainQueue:
+			f.f()
+			if f.done != nil {
+				f.done <- true
+			}
+		default:
+			glfw.PollEvents()
+			return true
+		}
+	}
+}
+
 type funcRun struct {
 	f    func()
 	done chan bool
@@ -108,6 +152,17 @@ func (app *appImpl) RunOnMain(f func()) {
 	}
 }
 
+// attnWindow returns the window to direct app-level attention requests at
+// -- the first (typically main) open window, or nil if none
+func (app *appImpl) attnWindow() *windowImpl {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if len(app.winlist) == 0 {
+		return nil
+	}
+	return app.winlist[0]
+}
+
 // GoRunOnMain runs given function on main thread and returns immediately
 func (app *appImpl) GoRunOnMain(f func()) {
 	go func() {
@@ -35,6 +35,11 @@ void doAddSeparator(uintptr_t menuID);
 uintptr_t doMenuItemByTitle(uintptr_t menuID, char* mnm);
 uintptr_t doMenuItemByTag(uintptr_t menuID, int tag);
 void doSetMenuItemActive(uintptr_t mitmID, bool active);
+void registerOpenFiles();
+void setDockBadge(const char* str);
+void requestUserAttention(int critical);
+void noteRecentDocument(const char* path);
+void clearRecentDocuments();
 */
 import "C"
 
@@ -62,6 +67,16 @@ func (app *appImpl) Platform() oswin.Platforms {
 	return oswin.MacOS
 }
 
+// todo: NSWorkspace accessibilityDisplayShouldIncreaseContrast /
+// ReduceMotion would need objc bridging in glos_darwin.m
+func (app *appImpl) PrefersHighContrast() bool {
+	return false
+}
+
+func (app *appImpl) PrefersReducedMotion() bool {
+	return false
+}
+
 func (app *appImpl) OpenURL(url string) {
 	cmd := exec.Command("open", url)
 	cmd.Run()
@@ -80,6 +95,56 @@ func (app *appImpl) PrefsDir() string {
 	return filepath.Join(usr.HomeDir, "Library")
 }
 
+// SetTaskbarProgress shows progress as a percentage badge on the dock
+// tile -- macOS has no native dock progress bar API, so the badge label is
+// used -- part of the oswin.TaskbarIndicator interface
+func (app *appImpl) SetTaskbarProgress(pct float32, on bool) {
+	if !on {
+		app.SetTaskbarBadge(0)
+		return
+	}
+	str := fmt.Sprintf("%d%%", int(pct*100))
+	cstr := C.CString(str)
+	defer C.free(unsafe.Pointer(cstr))
+	C.setDockBadge(cstr)
+}
+
+// SetTaskbarBadge sets a numeric badge on the dock tile -- 0 clears it --
+// part of the oswin.TaskbarIndicator interface
+func (app *appImpl) SetTaskbarBadge(count int) {
+	str := ""
+	if count > 0 {
+		str = fmt.Sprintf("%d", count)
+	}
+	cstr := C.CString(str)
+	defer C.free(unsafe.Pointer(cstr))
+	C.setDockBadge(cstr)
+}
+
+// RequestUserAttention bounces the dock icon -- critical bounces until the
+// app is activated, otherwise it bounces once -- part of the
+// oswin.TaskbarIndicator interface
+func (app *appImpl) RequestUserAttention(critical bool) {
+	crit := C.int(0)
+	if critical {
+		crit = 1
+	}
+	C.requestUserAttention(crit)
+}
+
+// SetRecentDocuments sets the dock menu / apple menu recent documents list
+// to given paths (most-recent first) -- activation comes back as an
+// open-document apple event, delivered via oswin.SendOpenFiles -- part of
+// the oswin.RecentDocumenter interface
+func (app *appImpl) SetRecentDocuments(paths []string) {
+	C.clearRecentDocuments()
+	for i := len(paths) - 1; i >= 0; i-- { // note each, oldest first
+		cstr := C.CString(paths[i])
+		C.noteRecentDocument(cstr)
+		C.free(unsafe.Pointer(cstr))
+	}
+}
+
 // this is the main call to create the main menu if not exist
 func (w *windowImpl) MainMenu() oswin.MainMenu {
 	if w.mainMenu == nil {
@@ -399,3 +464,18 @@ func menuFired(id uintptr, title *C.char, tilen C.int, tag C.int) {
 	}
 	go osmm.Triggered(w, tit, int(tag))
 }
+
+/////////////////////////////////////////////////////////////////
+// open-file / open-URL events
+
+// osOpenFilesInit registers the apple event handlers for open-document and
+// get-URL events, which are delivered to oswin.SendOpenFiles
+func osOpenFilesInit() {
+	C.registerOpenFiles()
+}
+
+//export appOpenFiles
+func appOpenFiles(data *C.char, dlen C.int) {
+	fstr := C.GoStringN(data, dlen)
+	go oswin.SendOpenFiles([]string{fstr})
+}
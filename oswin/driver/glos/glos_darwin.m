@@ -399,3 +399,81 @@ void doSetMenuItemActive(uintptr_t mitmID, bool active) {
 }
 
 
+
+///////////////////////////////////////////////////////////////////////
+//   open-file / open-URL apple events
+
+@interface GiOpenHandler : NSObject
+- (void)handleOpenDocs:(NSAppleEventDescriptor*)event withReplyEvent:(NSAppleEventDescriptor*)replyEvent;
+- (void)handleGetURL:(NSAppleEventDescriptor*)event withReplyEvent:(NSAppleEventDescriptor*)replyEvent;
+@end
+
+@implementation GiOpenHandler
+- (void)handleOpenDocs:(NSAppleEventDescriptor*)event withReplyEvent:(NSAppleEventDescriptor*)replyEvent {
+    NSAppleEventDescriptor* docs = [event paramDescriptorForKeyword:keyDirectObject];
+    NSInteger n = [docs numberOfItems];
+    for (NSInteger i = 1; i <= n; i++) {
+        NSString* urls = [[docs descriptorAtIndex:i] stringValue];
+        if (urls == nil) {
+            continue;
+        }
+        NSURL* url = [NSURL URLWithString:urls];
+        NSString* path = (url != nil && [url isFileURL]) ? [url path] : urls;
+        const char* cstr = [path UTF8String];
+        appOpenFiles((char*)cstr, (int)strlen(cstr));
+    }
+}
+
+- (void)handleGetURL:(NSAppleEventDescriptor*)event withReplyEvent:(NSAppleEventDescriptor*)replyEvent {
+    NSString* urls = [[event paramDescriptorForKeyword:keyDirectObject] stringValue];
+    if (urls == nil) {
+        return;
+    }
+    const char* cstr = [urls UTF8String];
+    appOpenFiles((char*)cstr, (int)strlen(cstr));
+}
+@end
+
+static GiOpenHandler* giOpenHandler = NULL;
+
+void registerOpenFiles() {
+    if (giOpenHandler != NULL) {
+        return;
+    }
+    giOpenHandler = [[GiOpenHandler alloc] init];
+    NSAppleEventManager* aem = [NSAppleEventManager sharedAppleEventManager];
+    [aem setEventHandler:giOpenHandler
+             andSelector:@selector(handleOpenDocs:withReplyEvent:)
+           forEventClass:kCoreEventClass
+              andEventID:kAEOpenDocuments];
+    [aem setEventHandler:giOpenHandler
+             andSelector:@selector(handleGetURL:withReplyEvent:)
+           forEventClass:kInternetEventClass
+              andEventID:kAEGetURL];
+}
+
+void setDockBadge(const char* str) {
+	NSString* s = [NSString stringWithUTF8String:str];
+	dispatch_async(dispatch_get_main_queue(), ^{
+		[[NSApp dockTile] setBadgeLabel:(s.length ? s : nil)];
+	});
+}
+
+void requestUserAttention(int critical) {
+	dispatch_async(dispatch_get_main_queue(), ^{
+		[NSApp requestUserAttention:(critical ? NSCriticalRequest : NSInformationalRequest)];
+	});
+}
+
+void noteRecentDocument(const char* path) {
+	NSString* p = [NSString stringWithUTF8String:path];
+	dispatch_async(dispatch_get_main_queue(), ^{
+		[[NSDocumentController sharedDocumentController] noteNewRecentDocumentURL:[NSURL fileURLWithPath:p]];
+	});
+}
+
+void clearRecentDocuments() {
+	dispatch_async(dispatch_get_main_queue(), ^{
+		[[NSDocumentController sharedDocumentController] clearRecentDocuments:nil];
+	});
+}
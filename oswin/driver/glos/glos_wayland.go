@@ -0,0 +1,325 @@
+// Copyright 2020 The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build linux,wayland,!android
+
+package glos
+
+// Native Wayland support: build with -tags wayland to compile glfw against
+// the Wayland backend (xdg-shell windows, client-side decorations, and
+// per-output scaling all come from glfw's wl_* platform code), instead of
+// X11 / XWayland.  The glfw clipboard works natively on Wayland; the
+// primary selection (middle-click paste) is provided via the wl-clipboard
+// command-line tools (wl-copy / wl-paste), which must be installed for it
+// to function.
+
+import (
+	"log"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/cursor"
+	"github.com/goki/gi/oswin/mimedata"
+)
+
+/////////////////////////////////////////////////////////////////
+// OS-specific methods
+
+func (app *appImpl) Platform() oswin.Platforms {
+	return oswin.LinuxWayland
+}
+
+// no standard wayland API for these accessibility settings -- desktop
+// environments each have their own -- user can set the gi.Prefs toggles
+func (app *appImpl) PrefersHighContrast() bool {
+	return false
+}
+
+func (app *appImpl) PrefersReducedMotion() bool {
+	return false
+}
+
+func (app *appImpl) OpenURL(url string) {
+	cmd := exec.Command("xdg-open", url)
+	cmd.Run()
+}
+
+func (app *appImpl) FontPaths() []string {
+	return []string{"/usr/share/fonts/truetype"}
+}
+
+func (app *appImpl) PrefsDir() string {
+	usr, err := user.Current()
+	if err != nil {
+		log.Print(err)
+		return "/tmp"
+	}
+	return filepath.Join(usr.HomeDir, ".config")
+}
+
+// this is the main call to create the main menu if not exist
+func (w *windowImpl) MainMenu() oswin.MainMenu {
+	return nil
+}
+
+func (w *windowImpl) OSHandle() uintptr {
+	return 0 // no X11 window under native Wayland
+}
+
+/////////////////////////////////////////////////////////////////
+//   Clipboard
+
+type clipImpl struct {
+	lastWrite mimedata.Mimes
+}
+
+var theClip = clipImpl{}
+
+func (ci *clipImpl) IsEmpty() bool {
+	str := glfw.GetClipboardString()
+	if len(str) == 0 {
+		return true
+	}
+	return false
+}
+
+func (ci *clipImpl) Read(types []string) mimedata.Mimes {
+	str := glfw.GetClipboardString()
+	if len(str) == 0 {
+		return nil
+	}
+	wantText := mimedata.IsText(types[0])
+	if wantText {
+		bstr := []byte(str)
+		isMulti, mediaType, boundary, body := mimedata.IsMultipart(bstr)
+		if isMulti {
+			return mimedata.FromMultipart(body, boundary)
+		} else {
+			if mediaType != "" { // found a mime type encoding
+				return mimedata.NewMime(mediaType, bstr)
+			} else {
+				// we can't really figure out type, so just assume..
+				return mimedata.NewMime(types[0], bstr)
+			}
+		}
+	} else {
+		// todo: deal with image formats etc
+	}
+	return nil
+}
+
+func (ci *clipImpl) Write(data mimedata.Mimes) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if len(data) > 1 { // multipart
+		mpd := data.ToMultipart()
+		glfw.SetClipboardString(string(mpd))
+	} else {
+		d := data[0]
+		if mimedata.IsText(d.Type) {
+			glfw.SetClipboardString(string(d.Data))
+		}
+	}
+	return nil
+}
+
+func (ci *clipImpl) Clear() {
+	// nop
+}
+
+// ReadPrimary reads the primary selection via wl-paste (wl-clipboard) --
+// returns nil if the tool is not installed or nothing is selected
+func (ci *clipImpl) ReadPrimary(types []string) mimedata.Mimes {
+	out, err := exec.Command("wl-paste", "--primary", "--no-newline").Output()
+	if err != nil || len(out) == 0 {
+		return nil
+	}
+	if mimedata.IsText(types[0]) {
+		return mimedata.NewMime(types[0], out)
+	}
+	return nil
+}
+
+// WritePrimary writes the primary selection via wl-copy (wl-clipboard)
+func (ci *clipImpl) WritePrimary(data mimedata.Mimes) error {
+	if len(data) == 0 {
+		return nil
+	}
+	d := data[0]
+	if !mimedata.IsText(d.Type) {
+		return nil
+	}
+	cmd := exec.Command("wl-copy", "--primary")
+	cmd.Stdin = nil
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+	in.Write(d.Data)
+	in.Close()
+	return cmd.Wait()
+}
+
+//////////////////////////////////////////////////////
+//  Cursor
+
+var cursorMap = map[cursor.Shapes]glfw.StandardCursor{
+	cursor.Arrow:        glfw.ArrowCursor,
+	cursor.Cross:        glfw.CrosshairCursor,
+	cursor.DragCopy:     glfw.HandCursor,
+	cursor.DragMove:     glfw.HandCursor,
+	cursor.DragLink:     glfw.HandCursor,
+	cursor.HandPointing: glfw.HandCursor,
+	cursor.HandOpen:     glfw.HandCursor,
+	cursor.HandClosed:   glfw.HandCursor,
+	cursor.Help:         glfw.HandCursor,
+	cursor.IBeam:        glfw.IBeamCursor,
+	cursor.Not:          glfw.HandCursor,
+	cursor.UpDown:       glfw.VResizeCursor,
+	cursor.LeftRight:    glfw.HResizeCursor,
+	cursor.UpRight:      glfw.HResizeCursor,
+	cursor.UpLeft:       glfw.HResizeCursor,
+	cursor.AllArrows:    glfw.VResizeCursor,
+	cursor.Wait:         glfw.VResizeCursor,
+}
+
+type cursorImpl struct {
+	cursor.CursorBase
+	cursors map[cursor.Shapes]*glfw.Cursor
+	mu      sync.Mutex
+}
+
+var theCursor = cursorImpl{CursorBase: cursor.CursorBase{Vis: true}}
+
+func (c *cursorImpl) createCursors() {
+	if c.cursors != nil {
+		return
+	}
+	c.cursors = make(map[cursor.Shapes]*glfw.Cursor)
+	for cs, sc := range cursorMap {
+		cur := glfw.CreateStandardCursor(sc)
+		c.cursors[cs] = cur
+	}
+}
+
+func (c *cursorImpl) setImpl(sh cursor.Shapes) {
+	c.createCursors()
+	cur, ok := c.cursors[sh]
+	if !ok || cur == nil {
+		return
+	}
+	w := theApp.ctxtwin
+	w.glw.SetCursor(cur)
+}
+
+func (c *cursorImpl) Set(sh cursor.Shapes) {
+	c.mu.Lock()
+	c.Cur = sh
+	c.mu.Unlock()
+	c.setImpl(sh)
+}
+
+func (c *cursorImpl) Push(sh cursor.Shapes) {
+	c.mu.Lock()
+	c.PushStack(sh)
+	c.mu.Unlock()
+	c.setImpl(sh)
+}
+
+func (c *cursorImpl) Pop() {
+	c.mu.Lock()
+	sh, _ := c.PopStack()
+	c.mu.Unlock()
+	c.setImpl(sh)
+}
+
+func (c *cursorImpl) Hide() {
+	c.mu.Lock()
+	if c.Vis == false {
+		c.mu.Unlock()
+		return
+	}
+	c.Vis = false
+	w := theApp.ctxtwin
+	w.glw.SetInputMode(glfw.CursorMode, glfw.CursorHidden)
+	c.mu.Unlock()
+}
+
+func (c *cursorImpl) Show() {
+	c.mu.Lock()
+	if c.Vis {
+		c.mu.Unlock()
+		return
+	}
+	c.Vis = true
+	w := theApp.ctxtwin
+	w.glw.SetInputMode(glfw.CursorMode, glfw.CursorNormal)
+	c.mu.Unlock()
+}
+
+func (c *cursorImpl) PushIfNot(sh cursor.Shapes) bool {
+	c.mu.Lock()
+	if c.Cur == sh {
+		c.mu.Unlock()
+		return false
+	}
+	c.mu.Unlock()
+	c.Push(sh)
+	return true
+}
+
+func (c *cursorImpl) PopIf(sh cursor.Shapes) bool {
+	c.mu.Lock()
+	if c.Cur == sh {
+		c.mu.Unlock()
+		c.Pop()
+		return true
+	}
+	c.mu.Unlock()
+	return false
+}
+
+// osOpenFilesInit is a no-op on Wayland -- open-file requests from file
+// associations arrive via command-line args (see gi.SingleInstMgr)
+func osOpenFilesInit() {
+}
+
+/////////////////////////////////////////////////////////////////
+//   TaskbarIndicator
+
+// SetTaskbarProgress is a no-op here -- there is no standard cross-desktop
+// taskbar progress protocol (the Unity LauncherEntry DBus API is
+// desktop-specific) -- part of the oswin.TaskbarIndicator interface
+func (app *appImpl) SetTaskbarProgress(pct float32, on bool) {
+}
+
+// SetTaskbarBadge is a no-op here -- see SetTaskbarProgress -- part of the
+// oswin.TaskbarIndicator interface
+func (app *appImpl) SetTaskbarBadge(count int) {
+}
+
+// RequestUserAttention asks the window manager to draw attention to the
+// main window (e.g., highlighting its taskbar entry) -- part of the
+// oswin.TaskbarIndicator interface
+func (app *appImpl) RequestUserAttention(critical bool) {
+	w := app.attnWindow()
+	if w == nil {
+		return
+	}
+	app.RunOnMain(func() { w.glw.RequestWindowAttention() })
+}
+
+// SetRecentDocuments is a no-op here -- there is no standard cross-desktop
+// taskbar / dock recents protocol -- part of the oswin.RecentDocumenter
+// interface
+func (app *appImpl) SetRecentDocuments(paths []string) {
+}
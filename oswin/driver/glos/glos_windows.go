@@ -7,16 +7,21 @@
 package glos
 
 import (
+	"fmt"
 	"log"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"runtime"
 	"sync"
+	"unicode"
+	"syscall"
 	"unsafe"
 
 	"github.com/go-gl/glfw/v3.3/glfw"
 	"github.com/goki/gi/oswin"
 	"github.com/goki/gi/oswin/cursor"
+	"github.com/goki/gi/oswin/key"
 	"github.com/goki/gi/oswin/mimedata"
 )
 
@@ -32,6 +37,44 @@ func (app *appImpl) OpenURL(url string) {
 	cmd.Run()
 }
 
+var (
+	user32               = syscall.NewLazyDLL("user32.dll")
+	systemParametersInfo = user32.NewProc("SystemParametersInfoW")
+)
+
+// SystemParametersInfo constants -- see winuser.h
+const (
+	spiGetHighContrast        = 0x0042
+	spiGetClientAreaAnimation = 0x1042
+	hcfHighContrastOn         = 0x0001
+)
+
+// highContrastW is the HIGHCONTRAST struct for SPI_GETHIGHCONTRAST
+type highContrastW struct {
+	cbSize        uint32
+	dwFlags       uint32
+	defaultScheme *uint16
+}
+
+func (app *appImpl) PrefersHighContrast() bool {
+	hc := highContrastW{}
+	hc.cbSize = uint32(unsafe.Sizeof(hc))
+	ret, _, _ := systemParametersInfo.Call(spiGetHighContrast, uintptr(hc.cbSize), uintptr(unsafe.Pointer(&hc)), 0)
+	if ret == 0 {
+		return false
+	}
+	return hc.dwFlags&hcfHighContrastOn != 0
+}
+
+func (app *appImpl) PrefersReducedMotion() bool {
+	anim := int32(1) // client area animation enabled
+	ret, _, _ := systemParametersInfo.Call(spiGetClientAreaAnimation, 0, uintptr(unsafe.Pointer(&anim)), 0)
+	if ret == 0 {
+		return false
+	}
+	return anim == 0
+}
+
 func (app *appImpl) FontPaths() []string {
 	return []string{"C:\\Windows\\Fonts"}
 }
@@ -243,3 +286,319 @@ func (c *cursorImpl) PopIf(sh cursor.Shapes) bool {
 	c.mu.Unlock()
 	return false
 }
+
+// osOpenFilesInit is a no-op on windows -- open-file requests from file
+// associations arrive via command-line args (see gi.SingleInstMgr)
+func osOpenFilesInit() {
+}
+
+/////////////////////////////////////////////////////////////////
+//   Window chrome (dark title bar, backdrop materials)
+
+var (
+	dwmapi                = syscall.NewLazyDLL("dwmapi.dll")
+	dwmSetWindowAttribute = dwmapi.NewProc("DwmSetWindowAttribute")
+)
+
+// dwm window attributes -- see dwmapi.h
+const (
+	dwmwaUseImmersiveDarkMode    = 20 // BOOL, Windows 10 1809+
+	dwmwaUseImmersiveDarkModeOld = 19 // pre-1903 builds used 19
+	dwmwaSystemBackdropType      = 38 // DWM_SYSTEMBACKDROP_TYPE, Windows 11 22H2+
+)
+
+// dwm backdrop types for dwmwaSystemBackdropType
+const (
+	dwmsbtAuto       = 0
+	dwmsbtNone       = 1
+	dwmsbtMainWindow = 2 // mica
+	dwmsbtTransient  = 3 // acrylic
+	dwmsbtTabbed     = 4 // mica-alt
+)
+
+func (w *windowImpl) dwmSetAttr(attr int, val uint32) {
+	hwnd := w.OSHandle()
+	if hwnd == 0 {
+		return
+	}
+	dwmSetWindowAttribute.Call(hwnd, uintptr(attr), uintptr(unsafe.Pointer(&val)), unsafe.Sizeof(val))
+}
+
+// SetDarkTitleBar sets whether the native title bar is drawn in dark mode,
+// to match a dark app theme -- see oswin.WindowChromer
+func (w *windowImpl) SetDarkTitleBar(dark bool) {
+	val := uint32(0)
+	if dark {
+		val = 1
+	}
+	w.dwmSetAttr(dwmwaUseImmersiveDarkMode, val)
+	w.dwmSetAttr(dwmwaUseImmersiveDarkModeOld, val) // older builds
+}
+
+// SetBackdrop sets the native backdrop material drawn behind the window
+// contents -- no-op before Windows 11 22H2 -- see oswin.WindowChromer
+func (w *windowImpl) SetBackdrop(bd oswin.WindowBackdrops) {
+	val := uint32(dwmsbtAuto)
+	switch bd {
+	case oswin.BackdropDefault:
+		val = dwmsbtNone
+	case oswin.BackdropMica:
+		val = dwmsbtMainWindow
+	case oswin.BackdropAcrylic:
+		val = dwmsbtTransient
+	case oswin.BackdropTabbed:
+		val = dwmsbtTabbed
+	}
+	w.dwmSetAttr(dwmwaSystemBackdropType, val)
+}
+
+/////////////////////////////////////////////////////////////////
+//   TaskbarIndicator
+
+// SetTaskbarProgress is a no-op on Windows -- taskbar progress requires the
+// ITaskbarList3 COM interface, which is not worth a COM dependency here --
+// part of the oswin.TaskbarIndicator interface
+func (app *appImpl) SetTaskbarProgress(pct float32, on bool) {
+}
+
+// SetTaskbarBadge is a no-op on Windows -- taskbar overlay icons require
+// the ITaskbarList3 COM interface -- part of the oswin.TaskbarIndicator
+// interface
+func (app *appImpl) SetTaskbarBadge(count int) {
+}
+
+// RequestUserAttention flashes the taskbar entry of the main window --
+// part of the oswin.TaskbarIndicator interface
+func (app *appImpl) RequestUserAttention(critical bool) {
+	w := app.attnWindow()
+	if w == nil {
+		return
+	}
+	app.RunOnMain(func() { w.glw.RequestWindowAttention() })
+}
+
+/////////////////////////////////////////////////////////////////
+//   RecentDocumenter (jump list recents)
+
+var (
+	shell32           = syscall.NewLazyDLL("shell32.dll")
+	shAddToRecentDocs = shell32.NewProc("SHAddToRecentDocs")
+)
+
+// shard flags for SHAddToRecentDocs -- see shlobj.h
+const (
+	shardPathW = 3 // unicode path string
+)
+
+// SetRecentDocuments sets the taskbar jump list recent documents to given
+// paths (most-recent first), via the shell recent-docs list -- the shell
+// only records paths whose file type is associated with this app, and
+// activating an entry opens the document through that association (see
+// gi.SingleInstMgr for routing to a running instance) -- part of the
+// oswin.RecentDocumenter interface
+func (app *appImpl) SetRecentDocuments(paths []string) {
+	if len(paths) == 0 {
+		shAddToRecentDocs.Call(uintptr(shardPathW), 0) // nil clears the list
+		return
+	}
+	for i := len(paths) - 1; i >= 0; i-- { // add each, oldest first
+		p, err := syscall.UTF16PtrFromString(paths[i])
+		if err != nil {
+			continue
+		}
+		shAddToRecentDocs.Call(uintptr(shardPathW), uintptr(unsafe.Pointer(p)))
+	}
+}
+
+/////////////////////////////////////////////////////////////////
+//   GlobalHotkeyer
+
+var (
+	registerHotKey     = user32.NewProc("RegisterHotKey")
+	unregisterHotKey   = user32.NewProc("UnregisterHotKey")
+	getMessageW        = user32.NewProc("GetMessageW")
+	postThreadMessageW = user32.NewProc("PostThreadMessageW")
+	getCurrentThreadId = syscall.NewLazyDLL("kernel32.dll").NewProc("GetCurrentThreadId")
+)
+
+// hotkey modifier flags and messages -- see winuser.h
+const (
+	modAlt      = 0x0001
+	modControl  = 0x0002
+	modShift    = 0x0004
+	modWin      = 0x0008
+	modNoRepeat = 0x4000
+
+	wmHotkey     = 0x0312
+	wmUserHotkey = 0x0400 + 1 // WM_USER + 1: process pending requests
+)
+
+// winMsg is the windows MSG structure, for the hotkey message loop
+type winMsg struct {
+	hwnd    uintptr
+	message uint32
+	wParam  uintptr
+	lParam  uintptr
+	time    uint32
+	pt      struct{ x, y int32 }
+}
+
+// hotkeyReq is a pending (un)registration, processed on the hotkey thread
+// since RegisterHotKey binds to the calling thread's message queue
+type hotkeyReq struct {
+	id   string
+	reg  bool
+	mods uintptr
+	vk   uintptr
+	err  chan error
+}
+
+// hotkeyMgr runs a dedicated locked OS thread with a message loop that owns
+// all global hotkey registrations for the app
+type hotkeyMgr struct {
+	mu     sync.Mutex
+	thread uintptr
+	reqs   []*hotkeyReq
+	ids    map[int32]string // os hotkey id -> registered id
+	byID   map[string]int32
+	nextID int32
+}
+
+var theHotkeys = hotkeyMgr{}
+
+// start launches the hotkey message loop thread if not already running
+func (hk *hotkeyMgr) start() {
+	if hk.thread != 0 {
+		return
+	}
+	hk.ids = make(map[int32]string)
+	hk.byID = make(map[string]int32)
+	started := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		tid, _, _ := getCurrentThreadId.Call()
+		hk.mu.Lock()
+		hk.thread = tid
+		hk.mu.Unlock()
+		close(started)
+		var msg winMsg
+		for {
+			rv, _, _ := getMessageW.Call(uintptr(unsafe.Pointer(&msg)), 0, 0, 0)
+			if int32(rv) <= 0 { // WM_QUIT or error
+				return
+			}
+			switch msg.message {
+			case wmHotkey:
+				hk.mu.Lock()
+				id, ok := hk.ids[int32(msg.wParam)]
+				hk.mu.Unlock()
+				if ok {
+					go oswin.SendGlobalHotkey(id)
+				}
+			case wmUserHotkey:
+				hk.process()
+			}
+		}
+	}()
+	<-started
+}
+
+// process handles pending requests, on the hotkey thread
+func (hk *hotkeyMgr) process() {
+	hk.mu.Lock()
+	reqs := hk.reqs
+	hk.reqs = nil
+	hk.mu.Unlock()
+	for _, rq := range reqs {
+		if rq.reg {
+			hk.mu.Lock()
+			hk.nextID++
+			osid := hk.nextID
+			hk.mu.Unlock()
+			rv, _, err := registerHotKey.Call(0, uintptr(osid), rq.mods, rq.vk)
+			if rv == 0 { // conflict with another registration, etc
+				rq.err <- fmt.Errorf("glos RegisterGlobalHotkey %v: %v", rq.id, err)
+				continue
+			}
+			hk.mu.Lock()
+			hk.ids[osid] = rq.id
+			hk.byID[rq.id] = osid
+			hk.mu.Unlock()
+			rq.err <- nil
+		} else {
+			hk.mu.Lock()
+			osid, ok := hk.byID[rq.id]
+			if ok {
+				delete(hk.byID, rq.id)
+				delete(hk.ids, osid)
+			}
+			hk.mu.Unlock()
+			if ok {
+				unregisterHotKey.Call(0, uintptr(osid))
+			}
+			rq.err <- nil
+		}
+	}
+}
+
+// post queues given request and wakes the hotkey thread to process it
+func (hk *hotkeyMgr) post(rq *hotkeyReq) error {
+	hk.start()
+	hk.mu.Lock()
+	hk.reqs = append(hk.reqs, rq)
+	thr := hk.thread
+	hk.mu.Unlock()
+	postThreadMessageW.Call(thr, wmUserHotkey, 0, 0)
+	return <-rq.err
+}
+
+// hotkeyChordToWin maps given key chord to windows hotkey modifier flags
+// and virtual key code -- only plain letter / digit / space keys are
+// supported as the non-modifier key
+func hotkeyChordToWin(chord key.Chord) (mods, vk uintptr, err error) {
+	r, cmods, err := chord.Decode()
+	if err != nil {
+		return 0, 0, err
+	}
+	if cmods&(1<<uint32(key.Shift)) != 0 {
+		mods |= modShift
+	}
+	if cmods&(1<<uint32(key.Control)) != 0 {
+		mods |= modControl
+	}
+	if cmods&(1<<uint32(key.Alt)) != 0 {
+		mods |= modAlt
+	}
+	if cmods&(1<<uint32(key.Meta)) != 0 {
+		mods |= modWin
+	}
+	mods |= modNoRepeat
+	r = unicode.ToUpper(r)
+	switch {
+	case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		vk = uintptr(r)
+	case r == ' ':
+		vk = 0x20 // VK_SPACE
+	default:
+		err = fmt.Errorf("glos hotkey: unsupported key %q in chord %v", r, chord)
+	}
+	return
+}
+
+// RegisterGlobalHotkey registers given chord as a system-wide hotkey via
+// RegisterHotKey, on a dedicated message-loop thread -- returns an error if
+// the chord is unsupported or already taken by another registration --
+// part of the oswin.GlobalHotkeyer interface
+func (app *appImpl) RegisterGlobalHotkey(id string, chord string) error {
+	mods, vk, err := hotkeyChordToWin(key.Chord(chord))
+	if err != nil {
+		return err
+	}
+	return theHotkeys.post(&hotkeyReq{id: id, reg: true, mods: mods, vk: vk, err: make(chan error, 1)})
+}
+
+// UnregisterGlobalHotkey unregisters the hotkey registered under given id
+// -- part of the oswin.GlobalHotkeyer interface
+func (app *appImpl) UnregisterGlobalHotkey(id string) {
+	theHotkeys.post(&hotkeyReq{id: id, err: make(chan error, 1)})
+}
@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// +build linux,!android dragonfly openbsd
+// +build linux,!android,!wayland dragonfly openbsd
 
 package glos
 
@@ -38,6 +38,16 @@ func (app *appImpl) Platform() oswin.Platforms {
 	return oswin.LinuxX11
 }
 
+// no standard X11 API for these accessibility settings -- desktop
+// environments each have their own -- user can set the gi.Prefs toggles
+func (app *appImpl) PrefersHighContrast() bool {
+	return false
+}
+
+func (app *appImpl) PrefersReducedMotion() bool {
+	return false
+}
+
 func (app *appImpl) OpenURL(url string) {
 	cmd := exec.Command("xdg-open", url)
 	cmd.Run()
@@ -128,6 +138,81 @@ func (ci *clipImpl) Clear() {
 	// nop
 }
 
+// xPrimaryTool returns the command-line tool used for primary selection
+// access (xclip, or xsel as a fallback), "" if neither is installed --
+// glfw has no API for the primary selection, so we go through the
+// standard tools like the Wayland driver does with wl-clipboard --
+// cached after the first call
+func xPrimaryTool() string {
+	xPrimaryOnce.Do(func() {
+		if _, err := exec.LookPath("xclip"); err == nil {
+			xPrimaryToolNm = "xclip"
+		} else if _, err := exec.LookPath("xsel"); err == nil {
+			xPrimaryToolNm = "xsel"
+		}
+	})
+	return xPrimaryToolNm
+}
+
+var (
+	xPrimaryToolNm string
+	xPrimaryOnce   sync.Once
+)
+
+// ReadPrimary reads the X11 primary selection via xclip or xsel --
+// returns nil if neither tool is installed or nothing is selected
+func (ci *clipImpl) ReadPrimary(types []string) mimedata.Mimes {
+	var cmd *exec.Cmd
+	switch xPrimaryTool() {
+	case "xclip":
+		cmd = exec.Command("xclip", "-selection", "primary", "-out")
+	case "xsel":
+		cmd = exec.Command("xsel", "--primary", "--output")
+	default:
+		return nil
+	}
+	out, err := cmd.Output()
+	if err != nil || len(out) == 0 {
+		return nil
+	}
+	if mimedata.IsText(types[0]) {
+		return mimedata.NewMime(types[0], out)
+	}
+	return nil
+}
+
+// WritePrimary writes the X11 primary selection via xclip or xsel --
+// both tools fork a background process that owns the selection until
+// another client takes it over
+func (ci *clipImpl) WritePrimary(data mimedata.Mimes) error {
+	if len(data) == 0 {
+		return nil
+	}
+	d := data[0]
+	if !mimedata.IsText(d.Type) {
+		return nil
+	}
+	var cmd *exec.Cmd
+	switch xPrimaryTool() {
+	case "xclip":
+		cmd = exec.Command("xclip", "-selection", "primary", "-in")
+	case "xsel":
+		cmd = exec.Command("xsel", "--primary", "--input")
+	default:
+		return nil
+	}
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+	in.Write(d.Data)
+	in.Close()
+	return cmd.Wait()
+}
+
 //////////////////////////////////////////////////////
 //  Cursor
 
@@ -249,3 +334,39 @@ func (c *cursorImpl) PopIf(sh cursor.Shapes) bool {
 	c.mu.Unlock()
 	return false
 }
+
+// osOpenFilesInit is a no-op on X11 -- open-file requests from file
+// associations arrive via command-line args (see gi.SingleInstMgr)
+func osOpenFilesInit() {
+}
+
+/////////////////////////////////////////////////////////////////
+//   TaskbarIndicator
+
+// SetTaskbarProgress is a no-op here -- there is no standard cross-desktop
+// taskbar progress protocol (the Unity LauncherEntry DBus API is
+// desktop-specific) -- part of the oswin.TaskbarIndicator interface
+func (app *appImpl) SetTaskbarProgress(pct float32, on bool) {
+}
+
+// SetTaskbarBadge is a no-op here -- see SetTaskbarProgress -- part of the
+// oswin.TaskbarIndicator interface
+func (app *appImpl) SetTaskbarBadge(count int) {
+}
+
+// RequestUserAttention asks the window manager to draw attention to the
+// main window (e.g., highlighting its taskbar entry) -- part of the
+// oswin.TaskbarIndicator interface
+func (app *appImpl) RequestUserAttention(critical bool) {
+	w := app.attnWindow()
+	if w == nil {
+		return
+	}
+	app.RunOnMain(func() { w.glw.RequestWindowAttention() })
+}
+
+// SetRecentDocuments is a no-op here -- there is no standard cross-desktop
+// taskbar / dock recents protocol -- part of the oswin.RecentDocumenter
+// interface
+func (app *appImpl) SetRecentDocuments(paths []string) {
+}
@@ -81,13 +81,12 @@ func (w *windowImpl) IsVisible() bool {
 // nothing further should happen.
 // Must call this on app main thread using oswin.TheApp.RunOnMain
 //
-// oswin.TheApp.RunOnMain(func() {
-//    if !win.Activate() {
-//        return
-//    }
-//    // do GPU calls here
-// })
-//
+//	oswin.TheApp.RunOnMain(func() {
+//	   if !win.Activate() {
+//	       return
+//	   }
+//	   // do GPU calls here
+//	})
 func (w *windowImpl) Activate() bool {
 	// note: activate is only run on main thread so we don't need to check for mutex
 	if w == nil || w.glw == nil {
@@ -127,6 +126,9 @@ func newGLWindow(opts *oswin.NewWindowOptions, sc *oswin.Screen) (*glfw.Window,
 	if fullscreen {
 		glfw.WindowHint(glfw.Maximized, glfw.True)
 	}
+	if bitflag.Has(opts.Flags, int(oswin.Transparent)) {
+		glfw.WindowHint(glfw.TransparentFramebuffer, glfw.True)
+	}
 	if tool {
 		glfw.WindowHint(glfw.Decorated, glfw.False)
 	} else {
@@ -630,8 +632,18 @@ func (w *windowImpl) getScreen() *oswin.Screen {
 func (w *windowImpl) moved(gw *glfw.Window, x, y int) {
 	w.mu.Lock()
 	w.Pos = image.Point{x, y}
+	cursc := w.scrnName
 	w.mu.Unlock()
-	w.getScreen()
+	sc := w.getScreen()
+	if cursc != sc.Name {
+		if monitorDebug {
+			log.Printf("glos window: %v moved() -- got new screen: %v (was: %v)\n", w.Nm, sc.Name, cursc)
+		}
+		w.mu.Lock()
+		w.LogDPI = sc.LogicalDPI
+		w.mu.Unlock()
+		w.sendWindowEvent(window.ScreenChanged)
+	}
 	w.sendWindowEvent(window.Move)
 }
 
@@ -675,6 +687,7 @@ func (w *windowImpl) updtGeom() {
 		if monitorDebug {
 			log.Printf("glos window: %v updtGeom() -- got new screen: %v (was: %v)\n", w.Nm, w.scrnName, cursc)
 		}
+		w.sendWindowEvent(window.ScreenChanged)
 	}
 	w.sendWindowEvent(window.Resize) // this will not get processed until the end
 }
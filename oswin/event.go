@@ -118,6 +118,11 @@ const (
 	// DNDFocusEvent is for Enter / Exit events of the DND into / out of a given widget
 	DNDFocusEvent
 
+	// SystemStatusEvent reports changes in system-wide status: battery
+	// level and charging state, power-save mode, and system theme / accent
+	// color -- see the power subpackage
+	SystemStatusEvent
+
 	// CustomEventType is a user-defined event with a data interface{} field
 	CustomEventType
 
@@ -0,0 +1,58 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package oswin
+
+import (
+	"sync"
+)
+
+// GlobalHotkeyer is an optional interface that the App on a given platform
+// may implement, for registering system-wide hotkeys that are delivered to
+// this app even when it is not focused -- not all platforms permit this
+// (e.g., Wayland has no global hotkey protocol).  Use a type assertion on
+// oswin.TheApp to check for availability.  Fired hotkeys are delivered via
+// SendGlobalHotkey to the handler set with SetGlobalHotkeyFunc.
+type GlobalHotkeyer interface {
+	// RegisterGlobalHotkey registers given key chord (in the standard
+	// key.Chord string format, e.g., "Control+Alt+K" -- passed as a plain
+	// string to avoid an import cycle with the key package) as a
+	// system-wide hotkey under given id, which is passed to the handler
+	// when the hotkey fires -- returns an error if the chord cannot be
+	// mapped to this platform's hotkey system or is already taken by
+	// another app
+	RegisterGlobalHotkey(id string, chord string) error
+
+	// UnregisterGlobalHotkey unregisters the hotkey previously registered
+	// under given id
+	UnregisterGlobalHotkey(id string)
+}
+
+// GlobalHotkeyFunc is the handler for global hotkey activations -- id is
+// the one passed to GlobalHotkeyer.RegisterGlobalHotkey
+type GlobalHotkeyFunc func(id string)
+
+var (
+	globalHotkeyMu   sync.Mutex
+	globalHotkeyFunc GlobalHotkeyFunc
+)
+
+// SetGlobalHotkeyFunc sets the handler called when a registered global
+// hotkey fires -- activations arriving while no handler is set are dropped.
+func SetGlobalHotkeyFunc(fun GlobalHotkeyFunc) {
+	globalHotkeyMu.Lock()
+	globalHotkeyFunc = fun
+	globalHotkeyMu.Unlock()
+}
+
+// SendGlobalHotkey is called by the platform drivers to deliver a global
+// hotkey activation for given registered id.
+func SendGlobalHotkey(id string) {
+	globalHotkeyMu.Lock()
+	fun := globalHotkeyFunc
+	globalHotkeyMu.Unlock()
+	if fun != nil {
+		fun(id)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package oswin
+
+import "sync"
+
+// OpenFilesFunc is the handler for OS-level requests to open files or URLs
+// in this app -- e.g., from double-clicking an associated document or
+// clicking a link with a custom URL scheme registered to the app.  URLs are
+// passed with their scheme intact; plain entries are file paths.
+type OpenFilesFunc func(files []string)
+
+var (
+	openFilesMu      sync.Mutex
+	openFilesFunc    OpenFilesFunc
+	pendingOpenFiles []string
+)
+
+// SetOpenFilesFunc sets the handler called when the OS requests that files
+// or URLs be opened in this app -- any requests that arrived before the
+// handler was set (e.g., the document that launched the app) are delivered
+// to it immediately.
+func SetOpenFilesFunc(fun OpenFilesFunc) {
+	openFilesMu.Lock()
+	openFilesFunc = fun
+	pend := pendingOpenFiles
+	pendingOpenFiles = nil
+	openFilesMu.Unlock()
+	if fun != nil && len(pend) > 0 {
+		fun(pend)
+	}
+}
+
+// SendOpenFiles is called by the platform drivers to deliver OS open-file
+// and open-URL requests.  Requests arriving before a handler is set are
+// buffered and delivered when one is.
+func SendOpenFiles(files []string) {
+	if len(files) == 0 {
+		return
+	}
+	openFilesMu.Lock()
+	fun := openFilesFunc
+	if fun == nil {
+		pendingOpenFiles = append(pendingOpenFiles, files...)
+	}
+	openFilesMu.Unlock()
+	if fun != nil {
+		fun(files)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2020, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package power defines events and queries for system-wide status: battery
+// level and charging state, power-save mode, and the system theme / accent
+// color.  Apps can use these to reduce animation and polling when on
+// battery, and to match OS colors.  Drivers that can provide this
+// information implement the Statuser interface on their App and send
+// power.Event when any of it changes.
+package power
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/goki/gi/oswin"
+	"github.com/goki/ki/kit"
+)
+
+// Status is the current power status of the system
+type Status struct {
+	HasBattery bool    `desc:"whether the system has a battery at all -- if not, other battery fields are not meaningful"`
+	OnBattery  bool    `desc:"whether the system is currently running on battery power"`
+	BatteryPct float32 `desc:"battery charge level in percent (0..100)"`
+	Charging   bool    `desc:"whether the battery is currently charging"`
+	PowerSave  bool    `desc:"whether the OS power-save / low-power mode is enabled"`
+}
+
+// Themes is the overall light-vs-dark mode of the system theme
+type Themes int32
+
+const (
+	// ThemeLight is the standard light theme
+	ThemeLight Themes = iota
+
+	// ThemeDark is the dark theme
+	ThemeDark
+
+	ThemesN
+)
+
+//go:generate stringer -type=Themes
+
+var KiT_Themes = kit.Enums.AddEnum(ThemesN, kit.NotBitFlag, nil)
+
+// Theme is the current system theme settings
+type Theme struct {
+	Mode   Themes     `desc:"light vs. dark mode"`
+	Accent color.RGBA `desc:"system accent / highlight color"`
+}
+
+// Statuser is an optional interface that an oswin App implementation can
+// provide to report current power status and system theme -- access it via
+// a type assertion on oswin.TheApp.  Drivers that implement it should also
+// send a power.Event whenever any of the information changes.
+type Statuser interface {
+	// PowerStatus returns the current power / battery status
+	PowerStatus() Status
+
+	// SystemTheme returns the current system theme and accent color
+	SystemTheme() Theme
+}
+
+/////////////////////////////
+// power.Event
+
+// power.Event is sent when any of the system power or theme status changes
+// -- it carries the full new status
+type Event struct {
+	oswin.EventBase
+
+	// Status is the new power status
+	Status Status
+
+	// Theme is the new system theme
+	Theme Theme
+}
+
+/////////////////////////////
+// oswin.Event interface
+
+func (ev Event) Type() oswin.EventType {
+	return oswin.SystemStatusEvent
+}
+
+func (ev Event) HasPos() bool {
+	return false
+}
+
+func (ev Event) Pos() image.Point {
+	return image.ZP
+}
+
+func (ev Event) OnFocus() bool {
+	return false
+}
+
+func (ev Event) String() string {
+	return fmt.Sprintf("Type: %v Status: %+v Time: %v", ev.Type(), ev.Status, ev.Time())
+}
@@ -123,3 +123,13 @@ func LogicalFmPhysicalDPI(logScale, pdpi float32) float32 {
 // screen LogicalDPI values based on user preferences etc.  Called just before
 // first window is opened.
 var InitScreenLogicalDPIFunc func()
+
+// ScreenColorProfiler is an optional interface that the App on a given
+// platform may implement, to provide access to the ICC color profile
+// associated with each screen (monitor) -- use a type assertion on
+// oswin.TheApp to check for availability.
+type ScreenColorProfiler interface {
+	// ScreenColorProfile returns the raw ICC color profile data for the
+	// screen at given index -- returns an error if no profile is available
+	ScreenColorProfile(scrN int) ([]byte, error)
+}
@@ -39,7 +39,6 @@ import (
 //
 // IMPORTANT: ALL GPU (e.g., OPENGL) CALLS MUST USE oswin.TheApp.RunOnMain()
 // to execute on the main thread!!
-//
 type Window interface {
 
 	// Name returns the name of the window -- name is used strictly for
@@ -146,6 +145,10 @@ type Window interface {
 	// IsFullscreen returns true if this is a fullscreen window.
 	IsFullscreen() bool
 
+	// IsTransparent returns true if this window was created with a per-pixel
+	// alpha framebuffer (the Transparent flag) -- see WindowFlags.
+	IsTransparent() bool
+
 	// IsMinimized returns true if this window is minimized.  See also IsVisible()
 	IsMinimized() bool
 
@@ -325,6 +328,10 @@ func (w *WindowBase) IsFullscreen() bool {
 	return bitflag.HasAtomic(&w.Flag, int(Fullscreen))
 }
 
+func (w *WindowBase) IsTransparent() bool {
+	return bitflag.HasAtomic(&w.Flag, int(Transparent))
+}
+
 func (w *WindowBase) IsMinimized() bool {
 	return bitflag.HasAtomic(&w.Flag, int(Minimized))
 }
@@ -357,6 +364,13 @@ const (
 	// Fullscreen indicates a window that occupies the entire screen.
 	Fullscreen
 
+	// Transparent indicates a window with a per-pixel alpha framebuffer, so
+	// that pixels rendered with alpha < 1 are composited over whatever is
+	// behind the window -- for splash screens and custom-shaped popups --
+	// requires compositing support from the OS / window manager, and falls
+	// back to an opaque window where unavailable.
+	Transparent
+
 	// Minimized indicates a window reduced to an icon, or otherwise no longer
 	// visible or active.  Otherwise, the window should be assumed to be
 	// visible.
@@ -411,6 +425,10 @@ func (o *NewWindowOptions) SetFullscreen() {
 	bitflag.Set(&o.Flags, int(Fullscreen))
 }
 
+func (o *NewWindowOptions) SetTransparent() {
+	bitflag.Set(&o.Flags, int(Transparent))
+}
+
 func WindowFlagsToBool(flags int64) (dialog, modal, tool, fullscreen bool) {
 	dialog = bitflag.Has(flags, int(Dialog))
 	modal = bitflag.Has(flags, int(Modal))
@@ -506,3 +524,40 @@ func (o *NewWindowOptions) Fixup() {
 		o.Pos.Y = scsz.Y - o.Size.Y
 	}
 }
+
+// WindowBackdrops are native window backdrop materials available on some
+// platforms (currently Windows 11) -- see WindowChromer
+type WindowBackdrops int32
+
+const (
+	// BackdropDefault is the standard opaque window background
+	BackdropDefault WindowBackdrops = iota
+
+	// BackdropMica is the Windows 11 mica material: a subtle desktop-tinted
+	// backdrop for long-lived windows
+	BackdropMica
+
+	// BackdropAcrylic is a translucent blurred backdrop, for transient
+	// surfaces
+	BackdropAcrylic
+
+	// BackdropTabbed is the mica-alt material used behind tabbed title bars
+	BackdropTabbed
+)
+
+// WindowChromer is an optional interface that a Window may implement on
+// platforms with configurable native window chrome (currently the Windows
+// driver): immersive dark title bars matching the app theme, and
+// acrylic / mica backdrop materials behind transparent viewports.  Use a
+// type assertion on the Window to check for availability.  Snap layouts
+// work with the native decorations that GoGi windows use by default, so no
+// separate integration is needed there.
+type WindowChromer interface {
+	// SetDarkTitleBar sets whether the native title bar is drawn in dark
+	// mode, to match a dark app theme
+	SetDarkTitleBar(dark bool)
+
+	// SetBackdrop sets the native backdrop material drawn behind the
+	// window contents -- ignored on OS versions without backdrop support
+	SetBackdrop(bd WindowBackdrops)
+}
@@ -9,9 +9,9 @@ import (
 
 var _ = errors.New("dummy error")
 
-const _Actions_name = "CloseMinimizeResizeMoveFocusDeFocusPaintShowScreenUpdateActionsN"
+const _Actions_name = "CloseMinimizeResizeMoveFocusDeFocusPaintShowScreenUpdateScreenChangedActionsN"
 
-var _Actions_index = [...]uint8{0, 5, 13, 19, 23, 28, 35, 40, 44, 56, 64}
+var _Actions_index = [...]uint8{0, 5, 13, 19, 23, 28, 35, 40, 44, 56, 69, 77}
 
 func (i Actions) String() string {
 	if i < 0 || i >= Actions(len(_Actions_index)-1) {
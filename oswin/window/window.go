@@ -73,6 +73,11 @@ const (
 	// and it should then perform any necessary updating
 	ScreenUpdate
 
+	// ScreenChanged occurs when this window has moved to a different
+	// screen, with a possibly different DPI -- the window should re-style
+	// and re-layout using the new screen's LogicalDPI
+	ScreenChanged
+
 	ActionsN
 )
 
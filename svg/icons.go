@@ -186,6 +186,75 @@ func (im *IconMgr) IconList(alphaSort bool) []gi.IconName {
 	return CurIconSet.IconList(alphaSort)
 }
 
+// InterpIcons sets the rendered paths of ic to an interpolation pct (0 = all
+// from, 1 = all to) between the two named icons, for animated two-state icon
+// transitions (play/pause etc) -- the icons must have the same tree structure
+// with matching path commands, as in icon pairs designed as morph states of
+// each other -- returns error if the structures do not match
+func (im *IconMgr) InterpIcons(ic *gi.Icon, fromName, toName string, pct float32) error {
+	fic, err := im.IconByName(fromName)
+	if err != nil {
+		return err
+	}
+	tic, err := im.IconByName(toName)
+	if err != nil {
+		return err
+	}
+	ic.SetNChildren(1, KiT_Icon, "icon")
+	nic := ic.Child(0).(*Icon)
+	nic.CopyFromIcon(tic) // structure and final state from target
+	ic.Filename = tic.Filename
+	return interpIconNode(fic.This(), nic.This(), pct)
+}
+
+// interpIconNode recursively interpolates path data in nk (a copy of the
+// "to" icon) between matching nodes of fk (the "from" icon) -- returns error
+// on any structural mismatch
+func interpIconNode(fk, nk ki.Ki, pct float32) error {
+	if fk.NumChildren() != nk.NumChildren() {
+		return fmt.Errorf("svg.IconMgr.InterpIcons: icon structures differ at %v", nk.PathUnique())
+	}
+	if np, ok := nk.(*Path); ok {
+		fp, ok := fk.(*Path)
+		if !ok {
+			return fmt.Errorf("svg.IconMgr.InterpIcons: node type mismatch at %v", nk.PathUnique())
+		}
+		nd, err := interpPathData(fp.Data, np.Data, pct)
+		if err != nil {
+			return err
+		}
+		np.Data = nd
+	}
+	for i, nkk := range *nk.Children() {
+		if err := interpIconNode(fk.Child(i), nkk, pct); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// interpPathData returns path data interpolated pct of the way from fd to td
+// -- the two paths must have identical commands, differing only in coords
+func interpPathData(fd, td []PathData, pct float32) ([]PathData, error) {
+	if len(fd) != len(td) {
+		return nil, fmt.Errorf("svg.IconMgr.InterpIcons: path data lengths differ: %v vs %v", len(fd), len(td))
+	}
+	nd := make([]PathData, len(td))
+	for i := 0; i < len(td); {
+		tc, n := td[i].Cmd()
+		fc, fn := fd[i].Cmd()
+		if tc != fc || n != fn {
+			return nil, fmt.Errorf("svg.IconMgr.InterpIcons: path commands differ at index %v", i)
+		}
+		nd[i] = td[i]
+		for j := 1; j <= n && i+j < len(td); j++ {
+			nd[i+j] = fd[i+j] + PathData(pct)*(td[i+j]-fd[i+j])
+		}
+		i += n + 1
+	}
+	return nd, nil
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 // IconSet is a list of icons
 
@@ -375,6 +444,67 @@ func MakeDefaultIcons() *IconSet {
 			// bx.Radius.Set(0.02, 0.02) // not rendering well at small sizes
 			iset[ic.Nm] = ic
 		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "indeterminate-box")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			bx := AddNewRect(ic, "bx", 0.05, 0.05, 0.9, 0.9)
+			bx.SetProp("stroke-width", units.NewPct(5))
+			p := AddNewPath(ic, "p", "M 0.25 0.5 .75 0.5")
+			p.SetProp("stroke-width", units.NewPct(20))
+			p.SetProp("fill", "none")
+			iset[ic.Nm] = ic
+		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "switch-on")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			tr := AddNewRect(ic, "track", 0.05, 0.3, 0.9, 0.4)
+			tr.SetProp("stroke-width", units.NewPct(5))
+			kn := AddNewCircle(ic, "knob", 0.75, 0.5, 0.22)
+			kn.SetProp("fill", "none")
+			kn.SetProp("stroke-width", units.NewPct(10))
+			iset[ic.Nm] = ic
+		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "switch-off")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			tr := AddNewRect(ic, "track", 0.05, 0.3, 0.9, 0.4)
+			tr.SetProp("fill", "none")
+			tr.SetProp("stroke-width", units.NewPct(5))
+			kn := AddNewCircle(ic, "knob", 0.25, 0.5, 0.22)
+			kn.SetProp("stroke-width", units.NewPct(10))
+			iset[ic.Nm] = ic
+		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "star-on")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			p := AddNewPath(ic, "p", "M 0.5 0.05 L 0.618 0.338 L 0.928 0.361 L 0.69 0.562 L 0.765 0.864 L 0.5 0.7 L 0.235 0.864 L 0.31 0.562 L 0.072 0.361 L 0.382 0.338 Z")
+			p.SetProp("stroke-width", units.NewPct(2))
+			iset[ic.Nm] = ic
+		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "star-off")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			p := AddNewPath(ic, "p", "M 0.5 0.05 L 0.618 0.338 L 0.928 0.361 L 0.69 0.562 L 0.765 0.864 L 0.5 0.7 L 0.235 0.864 L 0.31 0.562 L 0.072 0.361 L 0.382 0.338 Z")
+			p.SetProp("fill", "none")
+			p.SetProp("stroke-width", units.NewPct(5))
+			iset[ic.Nm] = ic
+		}
+		{
+			ic := &Icon{}
+			ic.InitName(ic, "star-half")
+			ic.ViewBox.Size = mat32.Vec2{1, 1}
+			hp := AddNewPath(ic, "hp", "M 0.5 0.05 L 0.382 0.338 L 0.072 0.361 L 0.31 0.562 L 0.235 0.864 L 0.5 0.7 Z")
+			hp.SetProp("stroke-width", units.NewPct(2))
+			p := AddNewPath(ic, "p", "M 0.5 0.05 L 0.618 0.338 L 0.928 0.361 L 0.69 0.562 L 0.765 0.864 L 0.5 0.7 L 0.235 0.864 L 0.31 0.562 L 0.072 0.361 L 0.382 0.338 Z")
+			p.SetProp("fill", "none")
+			p.SetProp("stroke-width", units.NewPct(5))
+			iset[ic.Nm] = ic
+		}
 		{
 			ic := &Icon{}
 			ic.InitName(ic, "circlebutton-on")